@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sensors-generator/internal/apikey"
+	"sensors-generator/pkg/client/postgresql"
+)
+
+const apiKeyUsage = `Usage:
+  apikey create -name NAME -role reader|operator|admin
+  apikey list [-revoked]
+  apikey revoke -id ID`
+
+// runAPIKeyCommand manages api keys without starting the server,
+// so the first admin key can be created before any key exists.
+func runAPIKeyCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no apikey command\n%s", apiKeyUsage)
+	}
+
+	fs := flag.NewFlagSet("apikey "+args[0], flag.ExitOnError)
+	cf := newConfigFlags(fs)
+
+	var name, role *string
+	var revoked *bool
+	var id *int
+
+	switch args[0] {
+	case "create":
+		name = fs.String("name", "", "name of the key owner")
+		role = fs.String("role", string(apikey.RoleReader), "reader, operator or admin")
+	case "list":
+		revoked = fs.Bool("revoked", false, "include revoked keys")
+	case "revoke":
+		id = fs.Int("id", 0, "id of the key")
+	default:
+		return fmt.Errorf("unknown apikey command: %s\n%s", args[0], apiKeyUsage)
+	}
+	fs.Parse(args[1:])
+
+	cfg, logger, err := cf.load()
+	if err != nil {
+		return err
+	}
+
+	dbClient, err := postgresql.NewClient(cfg.PgConfig)
+	if err != nil {
+		return err
+	}
+	defer dbClient.Close()
+
+	apiKeyService := apikey.NewService(apikey.NewPostgresqlRepository(dbClient, logger, cfg), logger, cfg)
+	ctx := context.Background()
+
+	switch args[0] {
+	case "create":
+		key, apiKey, err := apiKeyService.Create(ctx, apikey.CreateAPIKeyDTO{Name: *name, Role: apikey.Role(*role)})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Created key %d (%s, %s). Store it now, it will not be shown again:\n%s\n",
+			apiKey.ID, apiKey.Name, apiKey.Role, key)
+
+	case "list":
+		apiKeys, err := apiKeyService.GetAll(ctx, apikey.APIKeyFilters{IncludeRevoked: *revoked})
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(apiKeys)
+
+	case "revoke":
+		if err := apiKeyService.Revoke(ctx, *id); err != nil {
+			return err
+		}
+
+		fmt.Printf("Key %d was revoked.\n", *id)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os/signal"
+	"sensors-generator/config"
+	"sensors-generator/internal/event"
+	"sensors-generator/internal/generator"
+	"sensors-generator/internal/importer"
+	"sensors-generator/internal/retention"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/pkg/client/postgresql"
+	"sensors-generator/pkg/clock"
+	"syscall"
+	"time"
+)
+
+const backfillUsage = `Usage:
+  backfill [-from RFC3339] [-till RFC3339] [-since 24h] [-group alpha] [-codename 'alpha 1'] [-batch-size N]`
+
+// runBackfillCommand generates readings of existing sensors for the past range.
+// Without -from the range starts -since before -till, which is now by default.
+func runBackfillCommand(args []string) error {
+	fs := flag.NewFlagSet("backfill", flag.ExitOnError)
+	cf := newConfigFlags(fs)
+	from := timeFlag(fs, "from", "generate readings since")
+	till := timeFlag(fs, "till", "generate readings till, now by default")
+	since := fs.Duration("since", 24*time.Hour, "range length, used without -from")
+	group := fs.String("group", "", "only sensors of the group")
+	codeName := fs.String("codename", "", "only the sensor, e.g. 'alpha 1'")
+	cf.Int(fs, "batch-size", "readings per transaction", func(cfg *config.Config, v int) { cfg.ImportConfig.BatchSize = v })
+	fs.Parse(args)
+
+	cfg, logger, err := cf.load()
+	if err != nil {
+		return err
+	}
+
+	if till.IsZero() {
+		*till = time.Now()
+	}
+	if from.IsZero() {
+		*from = till.Add(-*since)
+	}
+	if !from.Before(*till) {
+		return fmt.Errorf("from should be before till\n%s", backfillUsage)
+	}
+
+	var cdn sensor.Codename
+	if *codeName != "" {
+		if cdn, err = sensor.NewCodenameFromString(*codeName); err != nil {
+			return err
+		}
+	}
+
+	dbClient, err := postgresql.NewClient(cfg.PgConfig)
+	if err != nil {
+		return err
+	}
+	defer dbClient.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	services := newServices(dbClient, noCache{}, clock.Real{}, logger, cfg)
+
+	sensors, err := services.SensorService.GetAll(ctx, sensor.SensorFilters{})
+	if err != nil {
+		return err
+	}
+
+	selected := make([]sensor.Sensor, 0, len(sensors))
+	for _, sens := range sensors {
+		if (*group == "" || sens.CodeName.GroupName == *group) &&
+			(cdn.IsEmpty() || sens.CodeName == cdn) {
+			selected = append(selected, sens)
+		}
+	}
+
+	if len(selected) == 0 {
+		return fmt.Errorf("no sensors selected\n%s", backfillUsage)
+	}
+
+	randomGen := generator.NewRandomGenerator(cfg.EnvironmentConfig)
+	eventService := event.NewService(event.NewPostgresqlRepository(dbClient, clock.Real{}, logger, cfg), randomGen, logger, cfg)
+	if err := eventService.Apply(ctx); err != nil {
+		return err
+	}
+
+	backfiller := generator.NewBackfiller(services, randomGen,
+		importer.NewPostgresqlRepository(dbClient, clock.Real{}, logger, cfg),
+		retention.NewService(retention.NewPostgresqlRepository(dbClient, logger, cfg), logger, cfg),
+		cfg.ImportConfig.BatchSize, logger)
+	backfiller.SetCurrents(cfg.EnvironmentConfig.Currents)
+
+	written, err := backfiller.Backfill(ctx, selected, *from, *till)
+	if err != nil {
+		return fmt.Errorf("backfill stopped after %d readings: %w", written, err)
+	}
+
+	logger.Infof("Backfilled %d readings of %d sensors from %s till %s.",
+		written, len(selected), from.Format(time.RFC3339), till.Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sensors-generator/config"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+const configUsage = `Usage:
+  config validate [-config FILE] [flags]
+  config env`
+
+// runConfigCommand prints the effective config with secrets redacted, or env variables of the config.
+// It does not connect anywhere, so it can check deployment configs.
+func runConfigCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no config command\n%s", configUsage)
+	}
+
+	switch args[0] {
+	case "validate":
+		fs := flag.NewFlagSet("config validate", flag.ExitOnError)
+		cf := newConfigFlags(fs)
+		fs.Parse(args[1:])
+
+		cfg, err := cf.config()
+		if err != nil {
+			return err
+		}
+
+		if path := config.Path(cf.path); path != "" {
+			fmt.Fprintf(os.Stderr, "Config file: %s\n", path)
+		} else {
+			fmt.Fprintln(os.Stderr, "Config file: none, defaults and env variables are used")
+		}
+
+		out, err := yaml.Marshal(cfg.Redacted())
+		if err != nil {
+			return err
+		}
+		os.Stdout.Write(out)
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config:\n%w", err)
+		}
+
+		fmt.Fprintln(os.Stderr, "Config is valid.")
+		return nil
+
+	case "env":
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tDEFAULT\tDESCRIPTION")
+		for _, v := range config.EnvVars() {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", v.Name, v.Default, v.Description)
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unknown config command: %s\n%s", args[0], configUsage)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sensors-generator/internal/export"
+	"sensors-generator/internal/sensor"
+	sensordata "sensors-generator/internal/sensorData"
+	"sensors-generator/pkg/client/postgresql"
+	"sensors-generator/pkg/clock"
+)
+
+const exportUsage = `Usage:
+  export -o FILE|- [-format csv|ndjson|parquet] [-gzip] [-codename 'alpha 1'] [-group alpha]
+         [-from RFC3339] [-till RFC3339] [-limit N] [-raw]`
+
+// runExportCommand writes readings to the file. With '-o -' readings are written to stdout,
+// so logs should go to stderr or file.
+func runExportCommand(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	cf := newConfigFlags(fs)
+	output := fs.String("o", "", "output file, - for stdout")
+	formatName := fs.String("format", string(export.FormatCSV), "csv, ndjson or parquet")
+	gzipped := fs.Bool("gzip", false, "compress with gzip")
+	codeName := fs.String("codename", "", "codename of the sensor, e.g. 'alpha 1'")
+	group := fs.String("group", "", "name of the group")
+	from := timeFlag(fs, "from", "export readings created since")
+	till := timeFlag(fs, "till", "export readings created till")
+	limit := fs.Int("limit", 0, "max number of readings")
+	raw := fs.Bool("raw", false, "export values before calibration")
+	fs.Parse(args)
+
+	if *output == "" {
+		return fmt.Errorf("output is required\n%s", exportUsage)
+	}
+
+	cfg, logger, err := cf.load()
+	if err != nil {
+		return err
+	}
+
+	format, err := export.NewFormatFromString(*formatName)
+	if err != nil {
+		return err
+	}
+
+	filters := sensordata.SensorDataFilters{GroupName: *group, FromDate: *from, TillDate: *till, Limit: *limit, Raw: *raw}
+
+	if *codeName != "" {
+		if filters.CodeName, err = sensor.NewCodenameFromString(*codeName); err != nil {
+			return err
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	dbClient, err := postgresql.NewClient(cfg.PgConfig)
+	if err != nil {
+		return err
+	}
+	defer dbClient.Close()
+
+	sensorDataService := sensordata.NewService(sensordata.NewPostgresqlRepository(dbClient, clock.Real{}, logger, cfg), logger, cfg)
+	exportService := export.NewService(sensorDataService, logger, cfg)
+
+	count, err := exportService.Export(context.Background(), w, filters, export.Options{Format: format, Gzip: *gzipped})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Exported %d readings to %s.", count, *output)
+	return nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sensors-generator/config"
+	"sensors-generator/pkg/logging"
+	"strconv"
+	"time"
+)
+
+// configFlags are registered by every command. Values of set flags override the config file.
+type configFlags struct {
+	path      string
+	overrides []func(cfg *config.Config)
+}
+
+func newConfigFlags(fs *flag.FlagSet) *configFlags {
+	f := &configFlags{}
+
+	fs.StringVar(&f.path, "config", "", "path to the config file, "+config.EnvConfigPath+" or "+config.DefaultPath+" by default")
+	f.String(fs, "log-level", "log level: trace, debug, info, warn or error", func(cfg *config.Config, v string) {
+		cfg.AppConfig.LogLevel = v
+	})
+	f.String(fs, "pg-host", "postgres host", func(cfg *config.Config, v string) { cfg.PgConfig.Host = v })
+	f.String(fs, "pg-port", "postgres port", func(cfg *config.Config, v string) { cfg.PgConfig.Port = v })
+	f.String(fs, "pg-database", "postgres database", func(cfg *config.Config, v string) { cfg.PgConfig.Database = v })
+	f.String(fs, "pg-username", "postgres user", func(cfg *config.Config, v string) { cfg.PgConfig.Username = v })
+	f.String(fs, "redis-host", "redis host", func(cfg *config.Config, v string) { cfg.RedisConfig.Host = v })
+	f.String(fs, "redis-port", "redis port", func(cfg *config.Config, v string) { cfg.RedisConfig.Port = v })
+
+	return f
+}
+
+// String registers a flag which sets string value of the config.
+func (f *configFlags) String(fs *flag.FlagSet, name, usage string, apply func(cfg *config.Config, value string)) {
+	fs.Func(name, usage, func(value string) error {
+		f.overrides = append(f.overrides, func(cfg *config.Config) { apply(cfg, value) })
+		return nil
+	})
+}
+
+// Bool registers a flag which sets bool value of the config, '-name' is the same as '-name=true'.
+func (f *configFlags) Bool(fs *flag.FlagSet, name, usage string, apply func(cfg *config.Config, value bool)) {
+	fs.BoolFunc(name, usage, func(value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+
+		f.overrides = append(f.overrides, func(cfg *config.Config) { apply(cfg, b) })
+		return nil
+	})
+}
+
+// Int registers a flag which sets int value of the config.
+func (f *configFlags) Int(fs *flag.FlagSet, name, usage string, apply func(cfg *config.Config, value int)) {
+	fs.Func(name, usage, func(value string) error {
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+
+		f.overrides = append(f.overrides, func(cfg *config.Config) { apply(cfg, i) })
+		return nil
+	})
+}
+
+// config builds config from defaults, the file and env variables, then applies flags in the command line order.
+func (f *configFlags) config() (*config.Config, error) {
+	cfg, err := config.Load(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load config: %w", err)
+	}
+
+	for _, override := range f.overrides {
+		override(cfg)
+	}
+
+	return cfg, nil
+}
+
+// load returns valid config and inits the logger with it.
+func (f *configFlags) load() (*config.Config, *logging.Logger, error) {
+	cfg, err := f.config()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid config:\n%w", err)
+	}
+
+	if err := logging.InitWithConfig(cfg.AppConfig.LogLevel, cfg.LogConfig); err != nil {
+		return nil, nil, fmt.Errorf("cannot init logger: %w", err)
+	}
+
+	return cfg, logging.GetLogger(), nil
+}
+
+// timeFlag registers RFC3339 time flag, zero time means the flag is not set.
+func timeFlag(fs *flag.FlagSet, name, usage string) *time.Time {
+	t := new(time.Time)
+
+	fs.Func(name, usage+", RFC3339", func(value string) error {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return err
+		}
+		*t = parsed
+		return nil
+	})
+
+	return t
+}
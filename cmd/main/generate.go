@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os/signal"
+	"sensors-generator/internal/event"
+	"sensors-generator/internal/generator"
+	"sensors-generator/internal/population"
+	"sensors-generator/pkg/client/postgresql"
+	"sensors-generator/pkg/clock"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// runGenerateCommand writes readings of existing sensors till interrupted or till -duration passes.
+func runGenerateCommand(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	cf := newConfigFlags(fs)
+	duration := fs.Duration("duration", 0, "stop after the duration, 0 runs till interrupted")
+	flushTimeout := fs.Duration("flush-timeout", 30*time.Second, "how long queued readings are written after stop, the rest spill or are dropped")
+	fs.Parse(args)
+
+	cfg, logger, err := cf.load()
+	if err != nil {
+		return err
+	}
+
+	dbClient, err := postgresql.NewClient(cfg.PgConfig)
+	if err != nil {
+		return err
+	}
+	defer dbClient.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if *duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *duration)
+		defer cancel()
+	}
+
+	// Nothing advances a stepped clock without the api of the server.
+	if cfg.ClockConfig.Mode == clock.ModeStepped {
+		return fmt.Errorf("stepped clock is advanced with the api, run serve instead")
+	}
+	clk, err := clock.New(cfg.ClockConfig)
+	if err != nil {
+		return err
+	}
+
+	services := newServices(dbClient, noCache{}, clk, logger, cfg)
+	meGen := generator.NewMainEntitiesGenerator(generator.MainEntities{}, services)
+	if !meGen.IsGenerated() {
+		return fmt.Errorf("there are no sensor groups, run seed or import sensors first")
+	}
+
+	var populationRunning sync.WaitGroup
+	if cfg.PopulationConfig.Enabled {
+		populationService := population.NewService(population.NewPostgresqlRepository(dbClient, logger, cfg),
+			services.SpieceService, services.SensorService, clk, logger, cfg)
+		services.PopulationService = populationService
+
+		populationRunning.Add(1)
+		go func() {
+			defer populationRunning.Done()
+			populationService.Run(ctx)
+		}()
+	}
+
+	randomGen := generator.NewRandomGenerator(cfg.EnvironmentConfig)
+	eventService := event.NewService(event.NewPostgresqlRepository(dbClient, clk, logger, cfg), randomGen, logger, cfg)
+	if err := eventService.Apply(ctx); err != nil {
+		return err
+	}
+
+	dataGen := generator.NewDataGenerator(services, randomGen, clk, cfg)
+	dataGen.SetFaultConfig(cfg.FaultConfig)
+	if err := dataGen.Generate(); err != nil {
+		return err
+	}
+	logger.Info("Data generator is started.")
+
+	<-ctx.Done()
+	dataGen.Stop()
+	dataGen.Wait()
+
+	flushCtx, cancel := context.WithTimeout(context.Background(), *flushTimeout)
+	defer cancel()
+	dataGen.Close(flushCtx)
+	// The simulation saves its last checkpoint when it is stopped.
+	populationRunning.Wait()
+	logger.Info("Data generator is stopped.")
+	return nil
+}
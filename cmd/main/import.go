@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sensors-generator/internal/importer"
+	"sensors-generator/internal/retention"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/client/postgresql"
+	"sensors-generator/pkg/clock"
+)
+
+const importUsage = `Usage:
+  import readings -f FILE|- [-format csv|ndjson] [-dry-run]
+  import sensors -f FILE|- [-dry-run]`
+
+// runImportCommand prints the import report as JSON to stdout.
+// Report with row errors fails the command, unless it is a dry run.
+func runImportCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no import command\n%s", importUsage)
+	}
+
+	fs := flag.NewFlagSet("import "+args[0], flag.ExitOnError)
+	cf := newConfigFlags(fs)
+	file := fs.String("f", "", "input file, - for stdin")
+	formatName := fs.String("format", string(importer.FormatCSV), "csv or ndjson, only for readings")
+	dryRun := fs.Bool("dry-run", false, "only validate the file")
+	fs.Parse(args[1:])
+
+	if *file == "" {
+		return fmt.Errorf("input file is required\n%s", importUsage)
+	}
+
+	cfg, logger, err := cf.load()
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	dbClient, err := postgresql.NewClient(cfg.PgConfig)
+	if err != nil {
+		return err
+	}
+	defer dbClient.Close()
+
+	sensorService := sensor.NewService(sensor.NewPostgresqlRepository(dbClient, logger, cfg), logger, cfg)
+	spieceService := spiece.NewService(spiece.NewPostgresqlRepository(dbClient, logger, cfg), logger, cfg)
+	retentionService := retention.NewService(retention.NewPostgresqlRepository(dbClient, logger, cfg), logger, cfg)
+	importService := importer.NewService(importer.NewPostgresqlRepository(dbClient, clock.Real{}, logger, cfg),
+		sensorService, spieceService, retentionService, logger, cfg)
+
+	ctx := context.Background()
+	opts := importer.Options{DryRun: *dryRun}
+	var report importer.Report
+
+	switch args[0] {
+	case "readings":
+		format, err := importer.NewFormatFromString(*formatName)
+		if err != nil {
+			return err
+		}
+
+		report, err = importService.ImportReadings(ctx, r, format, opts)
+		if err != nil {
+			return err
+		}
+
+	case "sensors":
+		report, err = importService.ImportSensors(ctx, r, opts)
+		if err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unknown import command: %s\n%s", args[0], importUsage)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	if report.HasErrors() && !report.DryRun {
+		return fmt.Errorf("file is rejected, %d of %d rows are valid", report.Valid, report.Rows)
+	}
+
+	return nil
+}
@@ -1,29 +1,69 @@
 package main
 
 import (
-	"context"
+	"fmt"
 	"log"
-	"sensors-generator/config"
+	"os"
 	_ "sensors-generator/docs"
-	"sensors-generator/internal/app"
-	"sensors-generator/pkg/logging"
+	"strings"
 )
 
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{"serve", "start the http server, seed mock entities and the generator", runServeCommand},
+	{"generate", "run the data generator without the http server", runGenerateCommand},
+	{"seed", "create mock groups, sensors and spieces", runSeedCommand},
+	{"migrate", "apply, revert or show migrations", runMigrateCommand},
+	{"backfill", "generate readings for the past time range", runBackfillCommand},
+	{"export", "export readings to csv, ndjson or parquet", runExportCommand},
+	{"import", "import readings or GeoJSON sensors layout", runImportCommand},
+	{"query", "run analytics queries and print JSON", runQueryCommand},
+	{"apikey", "create, list or revoke api keys", runAPIKeyCommand},
+	{"config", "validate and print the effective config, list env variables", runConfigCommand},
+}
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
 func main() {
-	log.Print("Create configs")
-	cfg := config.GetConfig()
-
-	// I use this logger, because it is better for me.
-	log.Print("Create logger.")
-	logging.Init(cfg.AppConfig.LogLevel, false)
-	logger := logging.GetLogger()
-
-	logger.Info("Create app.")
-	app, err := app.NewApp(context.Background(), cfg, logger)
-	if err != nil {
-		logger.Fatalf("Application failed, due to error: %v", err)
+	name, args := "serve", os.Args[1:]
+	// Without command the server is started, flags may go right after the binary.
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+
+	if name == "help" {
+		fmt.Fprint(os.Stderr, usage())
+		return
 	}
 
-	logger.Info("Start application.")
-	app.Run()
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+
+		if err := cmd.run(args); err != nil {
+			log.Fatalf("%s command failed, due to error: %v", name, err)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command: %s\n%s", name, usage())
+	os.Exit(2)
+}
+
+func usage() string {
+	var b strings.Builder
+	b.WriteString("Usage:\n  sensors-generator [command] [flags]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(&b, "  %-9s %s\n", cmd.name, cmd.summary)
+	}
+	b.WriteString("\nConfig is built from defaults, the file from -config or SENSOR_GEN_CONFIG (config.yml by default),\n" +
+		"SENSOR_GEN_* env variables and command flags, every next layer overrides the previous one. See 'command -h'.\n")
+	return b.String()
 }
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sensors-generator/migrations"
+	"sensors-generator/pkg/client/postgresql"
+	"sensors-generator/pkg/migrate"
+)
+
+const migrateUsage = `Usage:
+  migrate up
+  migrate down [-steps N]
+  migrate goto -version N
+  migrate status`
+
+func runMigrateCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no migrate command\n%s", migrateUsage)
+	}
+
+	fs := flag.NewFlagSet("migrate "+args[0], flag.ExitOnError)
+	cf := newConfigFlags(fs)
+
+	var steps, version *int
+
+	switch args[0] {
+	case "up", "status":
+	case "down":
+		steps = fs.Int("steps", 1, "number of migrations to revert")
+	case "goto":
+		version = fs.Int("version", -1, "target version, 0 reverts everything")
+	default:
+		return fmt.Errorf("unknown migrate command: %s\n%s", args[0], migrateUsage)
+	}
+	fs.Parse(args[1:])
+
+	if version != nil && *version < 0 {
+		return fmt.Errorf("version is required\n%s", migrateUsage)
+	}
+
+	cfg, logger, err := cf.load()
+	if err != nil {
+		return err
+	}
+
+	dbClient, err := postgresql.NewClient(cfg.PgConfig)
+	if err != nil {
+		return err
+	}
+	defer dbClient.Close()
+
+	migrator, err := migrate.New(dbClient, migrations.FS, logger)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+
+	case "down":
+		return migrator.Down(ctx, *steps)
+
+	case "goto":
+		return migrator.Goto(ctx, *version)
+
+	default:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(statuses)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sensors-generator/internal/group"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/pkg/client/postgresql"
+	"sensors-generator/pkg/clock"
+	"sensors-generator/pkg/measurement"
+	"sort"
+	"strings"
+)
+
+const queryUsage = `Usage:
+  query avg-temperature -codename 'alpha 1' [-metric salinity] [-from RFC3339] [-till RFC3339]
+  query region-temperature -min-coords x,y,z -max-coords x,y,z [-metric salinity] [-lowest]
+  query group-spieces -group alpha [-top N] [-from RFC3339] [-till RFC3339]
+  query group-transparency -group alpha
+  query group-temperature -group alpha`
+
+// runQueryCommand prints the same JSON as the http api. Group averages are not cached.
+func runQueryCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no query\n%s", queryUsage)
+	}
+
+	switch args[0] {
+	case "avg-temperature", "region-temperature", "group-spieces", "group-transparency", "group-temperature":
+	default:
+		return fmt.Errorf("unknown query: %s\n%s", args[0], queryUsage)
+	}
+
+	fs := flag.NewFlagSet("query "+args[0], flag.ExitOnError)
+	cf := newConfigFlags(fs)
+	codeName := fs.String("codename", "", "codename of the sensor, e.g. 'alpha 1'")
+	groupName := fs.String("group", "", "name of the group")
+	minCoords := fs.String("min-coords", "", "minimum coordinates of the region, x,y,z")
+	maxCoords := fs.String("max-coords", "", "maximum coordinates of the region, x,y,z")
+	metric := fs.String("metric", measurement.Temperature, "measurement type of avg-temperature and region-temperature")
+	lowest := fs.Bool("lowest", false, "minimum of the region instead of maximum")
+	top := fs.Int("top", 0, "only N most detected spieces")
+	from := timeFlag(fs, "from", "readings created since")
+	till := timeFlag(fs, "till", "readings created till")
+	fs.Parse(args[1:])
+
+	cfg, logger, err := cf.load()
+	if err != nil {
+		return err
+	}
+
+	dbClient, err := postgresql.NewClient(cfg.PgConfig)
+	if err != nil {
+		return err
+	}
+	defer dbClient.Close()
+
+	services := newServices(dbClient, noCache{}, clock.Real{}, logger, cfg)
+	ctx := context.Background()
+	groupFilters := group.SensorGroupFilters{TopLimit: *top, FromDate: *from, TillDate: *till}
+
+	var result map[string]interface{}
+
+	switch args[0] {
+	case "avg-temperature":
+		cdn, err := sensor.NewCodenameFromString(*codeName)
+		if err != nil {
+			return fmt.Errorf("wrong codename %q\n%s", *codeName, queryUsage)
+		}
+
+		avg, err := services.SensorService.GetAvgForSensor(ctx, *metric,
+			sensor.SensorFilters{CodeName: cdn, FromDate: *from, TillDate: *till})
+		if err != nil {
+			return err
+		}
+		result = map[string]interface{}{"avg_" + *metric: avg}
+
+	case "region-temperature":
+		minC, err := parseCoords(*minCoords)
+		if err != nil {
+			return fmt.Errorf("wrong min coords: %w\n%s", err, queryUsage)
+		}
+
+		maxC, err := parseCoords(*maxCoords)
+		if err != nil {
+			return fmt.Errorf("wrong max coords: %w\n%s", err, queryUsage)
+		}
+
+		temperature, err := services.SensorService.GetExtremumForRegion(ctx, *metric, minC, maxC, *lowest)
+		if err != nil {
+			return err
+		}
+
+		key := "max_" + *metric
+		if *lowest {
+			key = "min_" + *metric
+		}
+		result = map[string]interface{}{key: temperature}
+
+	case "group-spieces":
+		spieces, err := services.SensorGroupService.GetSpiecesInGroup(ctx, *groupName, groupFilters)
+		if err != nil {
+			return err
+		}
+
+		spiecesJSON := make([]map[string]interface{}, 0, len(spieces))
+		for sp, count := range spieces {
+			spiecesJSON = append(spiecesJSON, map[string]interface{}{"name": sp.Name, "count": count})
+		}
+		// Map order is random, the most detected spieces go first.
+		sort.SliceStable(spiecesJSON, func(i, j int) bool {
+			return spiecesJSON[i]["count"].(int) > spiecesJSON[j]["count"].(int)
+		})
+		result = map[string]interface{}{"spieces": spiecesJSON}
+
+	case "group-transparency":
+		avg, err := services.SensorGroupService.GetAvgTrasparencyInGroup(ctx, *groupName, groupFilters)
+		if err != nil {
+			return err
+		}
+		result = map[string]interface{}{"average_transparency": avg}
+
+	case "group-temperature":
+		avg, err := services.SensorGroupService.GetAvgTemperatureInGroup(ctx, *groupName, groupFilters)
+		if err != nil {
+			return err
+		}
+		result = map[string]interface{}{"average_temperature": avg}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+func parseCoords(coords string) (sensor.Coordinates, error) {
+	parts := strings.Split(coords, ",")
+	if len(parts) != 3 {
+		return sensor.Coordinates{}, fmt.Errorf("expected x,y,z, got %q", coords)
+	}
+
+	return sensor.NewCoordsFromString(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), strings.TrimSpace(parts[2]))
+}
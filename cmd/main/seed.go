@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"sensors-generator/internal/generator"
+	"sensors-generator/internal/mocks"
+	"sensors-generator/pkg/client/postgresql"
+	"sensors-generator/pkg/clock"
+)
+
+// runSeedCommand creates mock entities once, it does nothing when groups already exist.
+func runSeedCommand(args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ExitOnError)
+	cf := newConfigFlags(fs)
+	fs.Parse(args)
+
+	cfg, logger, err := cf.load()
+	if err != nil {
+		return err
+	}
+
+	dbClient, err := postgresql.NewClient(cfg.PgConfig)
+	if err != nil {
+		return err
+	}
+	defer dbClient.Close()
+
+	meGen := generator.NewMainEntitiesGenerator(generator.MainEntities{
+		Groups:  mocks.CreateSensorGroups,
+		Sensors: mocks.CreateSensors,
+		Spieces: mocks.CreateSpieces,
+	}, newServices(dbClient, noCache{}, clock.Real{}, logger, cfg))
+
+	if meGen.IsGenerated() {
+		logger.Info("Main entities are already created.")
+		return nil
+	}
+
+	if err := meGen.Generate(); err != nil {
+		return err
+	}
+
+	logger.Infof("Created %d groups, %d sensors and %d spieces.",
+		len(mocks.CreateSensorGroups), len(mocks.CreateSensors), len(mocks.CreateSpieces))
+	return nil
+}
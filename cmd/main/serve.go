@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os/signal"
+	"sensors-generator/config"
+	"sensors-generator/internal/app"
+	"syscall"
+	"time"
+)
+
+func runServeCommand(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	cf := newConfigFlags(fs)
+	cf.String(fs, "bind-ip", "ip to listen", func(cfg *config.Config, v string) { cfg.Listen.BindIP = v })
+	cf.String(fs, "port", "port to listen", func(cfg *config.Config, v string) { cfg.Listen.Port = v })
+	cf.Bool(fs, "grpc", "start the grpc server", func(cfg *config.Config, v bool) { cfg.GRPC.Enabled = v })
+	cf.String(fs, "grpc-port", "grpc port to listen", func(cfg *config.Config, v string) { cfg.GRPC.Port = v })
+	cf.Bool(fs, "auto-migrate", "apply pending migrations on startup", func(cfg *config.Config, v bool) {
+		cfg.AppConfig.AutoMigrate = v
+	})
+	cf.Bool(fs, "seed", "create mock entities on startup", func(cfg *config.Config, v bool) { cfg.AppConfig.Seed = v })
+	cf.Bool(fs, "generate", "start the data generator on startup", func(cfg *config.Config, v bool) {
+		cfg.AppConfig.Generate = v
+	})
+	cf.Bool(fs, "auth", "require api keys", func(cfg *config.Config, v bool) { cfg.AuthConfig.Enabled = v })
+	flushTimeout := fs.Duration("flush-timeout", 30*time.Second, "how long queued readings are written after stop, the rest spill or are dropped")
+	fs.Parse(args)
+
+	cfg, logger, err := cf.load()
+	if err != nil {
+		return err
+	}
+
+	// Reloads read the same file, env variables and flags as the startup.
+	watcher := config.NewWatcher(cfg, config.Path(cf.path), func() (*config.Config, error) {
+		cfg, err := cf.config()
+		if err != nil {
+			return nil, err
+		}
+		if err := cfg.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid config:\n%w", err)
+		}
+		return cfg, nil
+	}, logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info("Create app.")
+	app, err := app.NewApp(ctx, watcher, logger)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Start application.")
+	app.Run(ctx, *flushTimeout)
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"sensors-generator/config"
+	"sensors-generator/internal/generator"
+	"sensors-generator/internal/groundtruth"
+	"sensors-generator/internal/group"
+	"sensors-generator/internal/retention"
+	"sensors-generator/internal/sensor"
+	sensordata "sensors-generator/internal/sensorData"
+	"sensors-generator/internal/spiece"
+	clients "sensors-generator/pkg/client/interfaces"
+	"sensors-generator/pkg/clock"
+	"sensors-generator/pkg/logging"
+	"time"
+)
+
+// newServices builds services for commands which run without the http server.
+// Readings are stamped with time of clk.
+func newServices(dbClient *sql.DB, cache clients.Cache, clk clock.Clock,
+	logger *logging.Logger, cfg *config.Config) generator.Services {
+	services := generator.Services{
+		SensorService: sensor.NewService(sensor.NewPostgresqlRepository(dbClient, logger, cfg), logger, cfg),
+		SensorGroupService: group.NewService(group.NewPostgresqlRepository(dbClient, logger, cfg),
+			cache, logger, cfg),
+		SpieceService:     spiece.NewService(spiece.NewPostgresqlRepository(dbClient, logger, cfg), logger, cfg),
+		SensorDataService: sensordata.NewService(sensordata.NewPostgresqlRepository(dbClient, clk, logger, cfg), logger, cfg),
+		InjectedFaultService: groundtruth.NewService(groundtruth.NewPostgresqlRepository(dbClient, logger, cfg),
+			logger, cfg),
+	}
+	retentionService := retention.NewService(retention.NewPostgresqlRepository(dbClient, logger, cfg), logger, cfg)
+	if cfg.PartitionConfig.Enabled {
+		services.PartitionService = retentionService
+	}
+	if cfg.RetentionConfig.Enabled {
+		services.RollupService = retentionService
+	}
+
+	return services
+}
+
+// noCache makes group service read averages from the database every time,
+// so commands do not need redis and never print stale values.
+type noCache struct{}
+
+func (noCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return nil
+}
+
+func (noCache) Get(ctx context.Context, key string) (string, error) {
+	return "", nil
+}
@@ -1,56 +1,133 @@
 package config
 
 import (
-	"log"
 	"sensors-generator/pkg/client/postgresql"
 	"sensors-generator/pkg/client/redis"
-	"sync"
-
-	"github.com/ilyakaznacheev/cleanenv"
+	"sensors-generator/pkg/clock"
+	"sensors-generator/pkg/environment"
+	"sensors-generator/pkg/fault"
+	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/ratelimit"
+	"time"
 )
 
+// Config is filled by Load. Env names are joined from EnvPrefix, env-prefix of parent structs and env tag,
+// e.g. SENSOR_GEN_PG_HOST. Fields tagged with secret are redacted by Redacted.
 type Config struct {
-	IsDebug      bool `yaml:"is_debug" env-default:"false"`
-	IsProduction bool `yaml:"is_production" env-default:"true"`
+	IsDebug      bool `yaml:"is_debug" env:"IS_DEBUG" env-default:"false"`
+	IsProduction bool `yaml:"is_production" env:"IS_PRODUCTION" env-default:"true"`
 
 	Listen struct {
-		Type       string `yaml:"type" env-default:"port" env-description:"port or sock"`
-		BindIP     string `yaml:"bind_ip" env-default:"127.0.0.1"`
-		Port       string `yaml:"port" env-default:"8080"`
-		SocketFile string `yaml:"socket_file" env-default:"app.sock"`
-	} `yaml:"listen"`
+		Type       string `yaml:"type" env:"TYPE" env-default:"port" env-description:"port or sock"`
+		BindIP     string `yaml:"bind_ip" env:"BIND_IP" env-default:"127.0.0.1"`
+		Port       string `yaml:"port" env:"PORT" env-default:"8080"`
+		SocketFile string `yaml:"socket_file" env:"SOCKET_FILE" env-default:"app.sock"`
+		// TrustedProxies may set the client IP with X-Forwarded-For, it is ignored from other addresses.
+		TrustedProxies []string `yaml:"trusted_proxies" env:"TRUSTED_PROXIES" env-description:"IPs or CIDRs of proxies, empty trusts none"`
+	} `yaml:"listen" env-prefix:"LISTEN_"`
+
+	GRPC struct {
+		Enabled    bool   `yaml:"enabled" env:"ENABLED" env-default:"true"`
+		Type       string `yaml:"type" env:"TYPE" env-default:"port" env-description:"port or sock"`
+		BindIP     string `yaml:"bind_ip" env:"BIND_IP" env-default:"127.0.0.1"`
+		Port       string `yaml:"port" env:"PORT" env-default:"9090"`
+		SocketFile string `yaml:"socket_file" env:"SOCKET_FILE" env-default:"grpc.sock"`
+	} `yaml:"grpc" env-prefix:"GRPC_"`
 
 	AppConfig struct {
-		LogLevel string `yaml:"log_level" env-default:"trace"`
-	} `yaml:"app_config"`
+		LogLevel    string `yaml:"log_level" env:"LOG_LEVEL" env-default:"trace"`
+		AutoMigrate bool   `yaml:"auto_migrate" env:"AUTO_MIGRATE" env-default:"false" env-description:"apply pending migrations on startup"`
+		Seed        bool   `yaml:"seed" env:"SEED" env-default:"true" env-description:"create mock groups, sensors and spieces on startup"`
+		Generate    bool   `yaml:"generate" env:"GENERATE" env-default:"true" env-description:"start the data generator on startup"`
+		// WatchInterval is how often the config file is checked for changes, SIGHUP reloads it anyway.
+		WatchInterval time.Duration `yaml:"watch_interval" env:"WATCH_INTERVAL" env-default:"5s" env-description:"0 disables watching the config file"`
+	} `yaml:"app_config" env-prefix:"APP_"`
 
-	CorsConfig struct {
-		AllowedMethods     []string `yaml:"allowed_methods"`
-		AllowedOrigins     []string `yaml:"allowed_origins"`
-		AllowedHeaders     []string `yaml:"allowed_headers"`
-		ExposedHeaders     []string `yaml:"exposed_headers"`
-		AllowCredentials   bool     `yaml:"allow_credentials"`
-		OptionsPassthrough bool     `yaml:"options_passthrough"`
-	} `yaml:"cors_config"`
-
-	PgConfig    postgresql.PgConfig `yaml:"pg_config"`
-	RedisConfig redis.RedisConfig   `yaml:"redis_config"`
-}
+	LogConfig logging.LogConfig `yaml:"log_config"`
+
+	QueryConfig struct {
+		DefaultTimeout time.Duration            `yaml:"default_timeout" env:"DEFAULT_TIMEOUT" env-default:"10s"`
+		RouteTimeouts  map[string]time.Duration `yaml:"route_timeouts" env:"ROUTE_TIMEOUTS" env-description:"route=timeout pairs separated by comma"`
+	} `yaml:"query_config" env-prefix:"QUERY_"`
+
+	AuthConfig struct {
+		Enabled bool `yaml:"enabled" env:"ENABLED" env-default:"true"`
+	} `yaml:"auth_config" env-prefix:"AUTH_"`
+
+	RateLimitConfig struct {
+		Enabled bool            `yaml:"enabled" env:"ENABLED" env-default:"true"`
+		Store   string          `yaml:"store" env:"STORE" env-default:"memory" env-description:"memory or redis"`
+		IP      ratelimit.Limit `yaml:"ip" env-prefix:"IP_"`
+		Rules   ratelimit.Rules `yaml:",inline"`
+	} `yaml:"rate_limit_config" env-prefix:"RATE_LIMIT_"`
+
+	RetentionConfig struct {
+		Enabled     bool          `yaml:"enabled" env:"ENABLED" env-default:"false"`
+		Interval    time.Duration `yaml:"interval" env:"INTERVAL" env-default:"10m"`
+		RollupDelay time.Duration `yaml:"rollup_delay" env:"ROLLUP_DELAY" env-default:"5m" env-description:"wait for late readings before an hour is rolled up"`
+		RawDays     int           `yaml:"raw_days" env:"RAW_DAYS" env-default:"30" env-description:"0 keeps raw readings forever"`
+		HourlyDays  int           `yaml:"hourly_days" env:"HOURLY_DAYS" env-default:"365" env-description:"0 keeps hourly rollups forever"`
+		BatchSize   int           `yaml:"batch_size" env:"BATCH_SIZE" env-default:"5000"`
+	} `yaml:"retention_config" env-prefix:"RETENTION_"`
 
-var instance *Config
-var once sync.Once
+	PartitionConfig struct {
+		Enabled  bool   `yaml:"enabled" env:"ENABLED" env-default:"true" env-description:"without the job writes fail when premade partitions end"`
+		Interval string `yaml:"interval" env:"INTERVAL" env-default:"month" env-description:"month or day"`
+		Premake  int    `yaml:"premake" env:"PREMAKE" env-default:"3" env-description:"how many future partitions are created in advance"`
+	} `yaml:"partition_config" env-prefix:"PARTITION_"`
 
-func GetConfig() *Config {
-	once.Do(func() {
-		instance = &Config{}
+	ImportConfig struct {
+		BatchSize int `yaml:"batch_size" env:"BATCH_SIZE" env-default:"1000"`
+		MaxRows   int `yaml:"max_rows" env:"MAX_ROWS" env-default:"1000000" env-description:"0 disables the limit"`
+		MaxErrors int `yaml:"max_errors" env:"MAX_ERRORS" env-default:"100" env-description:"max row errors in the report, 0 reports all"`
+	} `yaml:"import_config" env-prefix:"IMPORT_"`
 
-		if err := cleanenv.ReadConfig("config.yml", instance); err != nil {
-			helpText := "sensor-generator project"
-			help, _ := cleanenv.GetDescription(instance, &helpText)
-			log.Print(help)
-			log.Fatal(err)
-		}
-	})
+	// FaultConfig injects faults into generated readings, injected faults are stored in injected_faults.
+	FaultConfig fault.Config `yaml:"fault_config" env-prefix:"FAULT_"`
+
+	// EnvironmentConfig is the shared field of temperature and transparency which sensors sample.
+	EnvironmentConfig environment.Config `yaml:"environment_config" env-prefix:"ENVIRONMENT_"`
+
+	// GeneratorConfig sizes the engine of the data generator, it is applied when the generator starts.
+	GeneratorConfig struct {
+		Workers     int   `yaml:"workers" env:"WORKERS" env-default:"16" env-description:"goroutines which write readings of due sensors"`
+		StartJitter bool  `yaml:"start_jitter" env:"START_JITTER" env-default:"true" env-description:"spread first readings of sensors over their output rate"`
+		Seed        int64 `yaml:"seed" env:"SEED" env-default:"0" env-description:"makes detected spieces and start jitter repeatable, 0 seeds with the current time"`
+	} `yaml:"generator_config" env-prefix:"GENERATOR_"`
+
+	// WriteQueueConfig buffers readings between the generator and Postgres, so outages do not lose them.
+	WriteQueueConfig struct {
+		Size      int           `yaml:"size" env:"SIZE" env-default:"10000" env-description:"readings held in memory before when_full applies"`
+		Writers   int           `yaml:"writers" env:"WRITERS" env-default:"4" env-description:"goroutines which write queued readings"`
+		WhenFull  string        `yaml:"when_full" env:"WHEN_FULL" env-default:"block" env-description:"block, drop_oldest or drop_newest"`
+		SpillFile string        `yaml:"spill_file" env:"SPILL_FILE" env-description:"file readings spill to when the queue is full, empty disables spilling"`
+		RetryMin  time.Duration `yaml:"retry_min" env:"RETRY_MIN" env-default:"100ms" env-description:"first delay of a failed write, it doubles on every retry"`
+		RetryMax  time.Duration `yaml:"retry_max" env:"RETRY_MAX" env-default:"30s"`
+	} `yaml:"write_queue_config" env-prefix:"WRITE_QUEUE_"`
+
+	// ClockConfig is the time of the data generator: real, accelerated or stepped with the api.
+	ClockConfig clock.Config `yaml:"clock_config" env-prefix:"CLOCK_"`
+
+	// PopulationConfig moves schools of fish through the sensor field, sensors detect schools near them.
+	PopulationConfig struct {
+		Enabled          bool          `yaml:"enabled" env:"ENABLED" env-default:"false"`
+		Step             time.Duration `yaml:"step" env:"STEP" env-default:"5s"`
+		CheckpointEvery  time.Duration `yaml:"checkpoint_every" env:"CHECKPOINT_EVERY" env-default:"1m" env-description:"how often schools are saved to resume after a restart"`
+		SchoolsPerSpiece int           `yaml:"schools_per_spiece" env:"SCHOOLS_PER_SPIECE" env-default:"3"`
+		Speed            float64       `yaml:"speed" env:"SPEED" env-default:"0.2" env-description:"mean speed of schools, meters per second"`
+		DetectionRadius  float64       `yaml:"detection_radius" env:"DETECTION_RADIUS" env-default:"10" env-description:"distance a sensor sees a school from, meters"`
+		Seed             int64         `yaml:"seed" env:"SEED" env-default:"0" env-description:"0 seeds with the current time"`
+	} `yaml:"population_config" env-prefix:"POPULATION_"`
+
+	CorsConfig struct {
+		AllowedMethods     []string `yaml:"allowed_methods" env:"ALLOWED_METHODS"`
+		AllowedOrigins     []string `yaml:"allowed_origins" env:"ALLOWED_ORIGINS"`
+		AllowedHeaders     []string `yaml:"allowed_headers" env:"ALLOWED_HEADERS"`
+		ExposedHeaders     []string `yaml:"exposed_headers" env:"EXPOSED_HEADERS"`
+		AllowCredentials   bool     `yaml:"allow_credentials" env:"ALLOW_CREDENTIALS"`
+		OptionsPassthrough bool     `yaml:"options_passthrough" env:"OPTIONS_PASSTHROUGH"`
+	} `yaml:"cors_config" env-prefix:"CORS_"`
 
-	return instance
+	PgConfig    postgresql.PgConfig `yaml:"pg_config" env-prefix:"PG_"`
+	RedisConfig redis.RedisConfig   `yaml:"redis_config" env-prefix:"REDIS_"`
 }
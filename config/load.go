@@ -0,0 +1,284 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+)
+
+const (
+	// EnvPrefix starts names of all config env variables.
+	EnvPrefix = "SENSOR_GEN_"
+	// EnvConfigPath is used when the config file is not set by flag.
+	EnvConfigPath = EnvPrefix + "CONFIG"
+	// DefaultPath is read only if it exists, so the app can be configured by env variables only.
+	DefaultPath = "config.yml"
+)
+
+// Load builds config from layers, every next one overrides the previous:
+// env-default tags, the config file, env variables with EnvPrefix.
+// Empty path means SENSOR_GEN_CONFIG, then config.yml if it exists.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+
+	if err := walk(cfg, func(f field) error {
+		if f.def == nil {
+			return nil
+		}
+		return setValue(f.value, *f.def)
+	}); err != nil {
+		return nil, fmt.Errorf("defaults: %w", err)
+	}
+
+	path, err := resolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if path != "" {
+		if err := readFile(path, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := walk(cfg, func(f field) error {
+		if f.env == "" {
+			return nil
+		}
+
+		value, ok := os.LookupEnv(f.env)
+		if !ok {
+			return nil
+		}
+
+		if err := setValue(f.value, value); err != nil {
+			return fmt.Errorf("%s: %w", f.env, err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("env: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Path returns the config file which Load reads for the path, empty if there is no file.
+func Path(path string) string {
+	path, _ = resolvePath(path)
+	return path
+}
+
+func resolvePath(path string) (string, error) {
+	if path == "" {
+		path = os.Getenv(EnvConfigPath)
+	}
+
+	if path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("config file: %w", err)
+		}
+		return path, nil
+	}
+
+	if _, err := os.Stat(DefaultPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("config file: %w", err)
+	}
+
+	return DefaultPath, nil
+}
+
+// readFile decodes the file over defaults, values missing in the file are kept.
+func readFile(path string, cfg *Config) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+	defer f.Close()
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yml", ".yaml":
+		err = cleanenv.ParseYAML(f, cfg)
+	case ".json":
+		err = cleanenv.ParseJSON(f, cfg)
+	default:
+		return fmt.Errorf("config file: %s format is not supported", ext)
+	}
+
+	if err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// EnvVar describes env variable of a config field.
+type EnvVar struct {
+	Name        string
+	Default     string
+	Description string
+}
+
+// EnvVars lists env variables of all config fields in the order of the struct.
+func EnvVars() []EnvVar {
+	vars := make([]EnvVar, 0)
+
+	walk(&Config{}, func(f field) error {
+		if f.env == "" {
+			return nil
+		}
+
+		v := EnvVar{Name: f.env, Description: f.tag.Get("env-description")}
+		if f.def != nil {
+			v.Default = *f.def
+		}
+		vars = append(vars, v)
+		return nil
+	})
+
+	return vars
+}
+
+// field is a leaf of the config struct. Path is joined from yaml keys, e.g. "pg_config.host".
+type field struct {
+	value reflect.Value
+	tag   reflect.StructTag
+	path  string
+	env   string
+	def   *string
+}
+
+// walk calls fn for leaves of the struct, nested structs add their env-prefix to env names.
+func walk(cfg interface{}, fn func(f field) error) error {
+	return walkStruct(reflect.ValueOf(cfg).Elem(), "", EnvPrefix, fn)
+}
+
+func walkStruct(s reflect.Value, path, prefix string, fn func(f field) error) error {
+	for i := 0; i < s.NumField(); i++ {
+		fType := s.Type().Field(i)
+		if !fType.IsExported() {
+			continue
+		}
+
+		fPath := path
+		if key, _, _ := strings.Cut(fType.Tag.Get("yaml"), ","); key != "" {
+			fPath = strings.TrimPrefix(path+"."+key, ".")
+		}
+
+		if fType.Type.Kind() == reflect.Struct {
+			if err := walkStruct(s.Field(i), fPath, prefix+fType.Tag.Get("env-prefix"), fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		f := field{value: s.Field(i), tag: fType.Tag, path: fPath}
+		if env, ok := fType.Tag.Lookup("env"); ok && env != "" {
+			f.env = prefix + env
+		}
+		if def, ok := fType.Tag.Lookup("env-default"); ok {
+			f.def = &def
+		}
+
+		if err := fn(f); err != nil {
+			return fmt.Errorf("%s: %w", fType.Name, err)
+		}
+	}
+
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setValue parses raw value of env variable or default. Slices are separated by comma,
+// maps are key=value pairs separated by comma.
+func setValue(v reflect.Value, raw string) error {
+	switch {
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+
+	case v.Kind() == reflect.Slice:
+		slice := reflect.MakeSlice(v.Type(), 0, 0)
+		if raw != "" {
+			for _, item := range strings.Split(raw, ",") {
+				elem := reflect.New(v.Type().Elem()).Elem()
+				if err := setValue(elem, strings.TrimSpace(item)); err != nil {
+					return err
+				}
+				slice = reflect.Append(slice, elem)
+			}
+		}
+		v.Set(slice)
+		return nil
+
+	case v.Kind() == reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		if raw != "" {
+			for _, pair := range strings.Split(raw, ",") {
+				key, value, ok := strings.Cut(pair, "=")
+				if !ok {
+					return fmt.Errorf("expected key=value, got %q", pair)
+				}
+
+				k := reflect.New(v.Type().Key()).Elem()
+				if err := setValue(k, strings.TrimSpace(key)); err != nil {
+					return err
+				}
+				elem := reflect.New(v.Type().Elem()).Elem()
+				if err := setValue(elem, strings.TrimSpace(value)); err != nil {
+					return err
+				}
+				m.SetMapIndex(k, elem)
+			}
+		}
+		v.Set(m)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("%s fields are not supported", v.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sensors-generator/config"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func Test_Load_Defaults(t *testing.T) {
+	cfg, err := config.Load("")
+
+	require.NoError(t, err)
+	assert.Equal(t, "8080", cfg.Listen.Port)
+	assert.True(t, cfg.AuthConfig.Enabled)
+	assert.Equal(t, 10*time.Second, cfg.QueryConfig.DefaultTimeout)
+	assert.Equal(t, []string{"stdout", "file"}, cfg.LogConfig.Outputs)
+	assert.Equal(t, "localhost", cfg.PgConfig.Host)
+}
+
+func Test_Load_FileOverridesDefaults(t *testing.T) {
+	path := writeConfig(t, `
+auth_config:
+  enabled: false
+listen:
+  port: "9090"
+query_config:
+  route_timeouts:
+    /api/v1/export/readings: 30m
+`)
+
+	cfg, err := config.Load(path)
+
+	require.NoError(t, err)
+	// False in the file is not replaced by the default.
+	assert.False(t, cfg.AuthConfig.Enabled)
+	assert.Equal(t, "9090", cfg.Listen.Port)
+	// Values missing in the file keep defaults.
+	assert.Equal(t, "127.0.0.1", cfg.Listen.BindIP)
+	assert.Equal(t, 30*time.Minute, cfg.QueryConfig.RouteTimeouts["/api/v1/export/readings"])
+}
+
+func Test_Load_EnvOverridesFile(t *testing.T) {
+	path := writeConfig(t, `
+listen:
+  port: "9090"
+pg_config:
+  host: db
+`)
+	t.Setenv(config.EnvConfigPath, path)
+	t.Setenv("SENSOR_GEN_LISTEN_PORT", "7070")
+	t.Setenv("SENSOR_GEN_REDIS_HOST", "cache")
+	t.Setenv("SENSOR_GEN_CORS_ALLOWED_ORIGINS", "http://a, http://b")
+	t.Setenv("SENSOR_GEN_QUERY_ROUTE_TIMEOUTS", "/api/v1/export/readings=1h")
+	t.Setenv("SENSOR_GEN_RATE_LIMIT_DEFAULT_RATE", "2.5")
+	// Env variables without the prefix are ignored.
+	t.Setenv("HOST", "ignored")
+
+	cfg, err := config.Load("")
+
+	require.NoError(t, err)
+	assert.Equal(t, "7070", cfg.Listen.Port)
+	assert.Equal(t, "db", cfg.PgConfig.Host)
+	assert.Equal(t, "cache", cfg.RedisConfig.Host)
+	assert.Equal(t, []string{"http://a", "http://b"}, cfg.CorsConfig.AllowedOrigins)
+	assert.Equal(t, map[string]time.Duration{"/api/v1/export/readings": time.Hour}, cfg.QueryConfig.RouteTimeouts)
+	assert.Equal(t, 2.5, cfg.RateLimitConfig.Rules.Default.Rate)
+}
+
+func Test_Load_Errors(t *testing.T) {
+	_, err := config.Load(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.Error(t, err)
+
+	t.Setenv("SENSOR_GEN_IMPORT_BATCH_SIZE", "many")
+	_, err = config.Load("")
+	assert.ErrorContains(t, err, "SENSOR_GEN_IMPORT_BATCH_SIZE")
+}
+
+func Test_Config_ValidateAndRedacted(t *testing.T) {
+	cfg, err := config.Load("")
+	require.NoError(t, err)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.PgConfig.Password = "postgres"
+	cfg.Listen.Type = "pipe"
+	cfg.PartitionConfig.Interval = "week"
+	cfg.Listen.TrustedProxies = []string{"10.0.0.0/8", "192.168.1.1", "proxy"}
+
+	err = cfg.Validate()
+	assert.ErrorContains(t, err, "listen.type")
+	assert.ErrorContains(t, err, `listen.trusted_proxies should be IPs or CIDRs, got "proxy"`)
+	assert.NotContains(t, err.Error(), "10.0.0.0/8")
+	assert.ErrorContains(t, err, "partition_config.interval")
+
+	redacted := cfg.Redacted()
+	assert.Equal(t, "REDACTED", redacted.PgConfig.Password)
+	assert.Equal(t, "", redacted.RedisConfig.Password)
+	assert.Equal(t, "postgres", cfg.PgConfig.Password)
+}
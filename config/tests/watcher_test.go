@@ -0,0 +1,104 @@
+package config
+
+import (
+	"errors"
+	"sensors-generator/config"
+	"sensors-generator/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Diff(t *testing.T) {
+	a, err := config.Load("")
+	require.NoError(t, err)
+	b, err := config.Load("")
+	require.NoError(t, err)
+
+	b.AppConfig.LogLevel = "info"
+	b.CorsConfig.AllowedOrigins = []string{"http://localhost:3000"}
+	b.RateLimitConfig.Rules.Default.Burst = 100
+	b.CorsConfig.ExposedHeaders = []string{}
+
+	assert.Equal(t, []string{
+		"app_config.log_level",
+		"cors_config.allowed_origins",
+		"rate_limit_config.default.burst",
+	}, config.Diff(a, b))
+}
+
+func Test_Watcher_Reload(t *testing.T) {
+	logging.Init("trace", true)
+
+	initial, err := config.Load("")
+	require.NoError(t, err)
+
+	next, err := config.Load("")
+	require.NoError(t, err)
+	next.AppConfig.LogLevel = "info"
+	next.RedisConfig.TTL = time.Minute
+	next.Listen.Port = "9090"
+
+	watcher := config.NewWatcher(initial, "", func() (*config.Config, error) { return next, nil }, logging.GetLogger())
+
+	var level string
+	watcher.Subscribe("logger", func(cfg *config.Config) error {
+		level = cfg.AppConfig.LogLevel
+		return nil
+	}, "app_config.log_level")
+	watcher.Subscribe("cache", func(cfg *config.Config) error {
+		return errors.New("cache is down")
+	}, "redis_config")
+	refreshed := 0
+	watcher.Subscribe("generator", func(cfg *config.Config) error {
+		refreshed++
+		return nil
+	})
+
+	report, err := watcher.Reload()
+
+	require.NoError(t, err)
+	assert.Equal(t, "info", level)
+	assert.Equal(t, 1, refreshed)
+	assert.Same(t, next, watcher.Config())
+	assert.Equal(t, []string{"app_config.log_level", "listen.port", "redis_config.ttl"}, report.Changed)
+	assert.Equal(t, []string{"app_config.log_level"}, report.Applied)
+	assert.Equal(t, []string{"listen.port", "redis_config.ttl"}, report.NeedRestart)
+	assert.Equal(t, []string{"listen.port", "redis_config.ttl"}, watcher.Status().PendingRestart)
+
+	// Port is changed back, so it does not wait for restart anymore. Subscribers without keys run on every reload.
+	next.Listen.Port = initial.Listen.Port
+	report, err = watcher.Reload()
+
+	require.NoError(t, err)
+	assert.Empty(t, report.Changed)
+	assert.Equal(t, 2, refreshed)
+	assert.Equal(t, []string{"redis_config.ttl"}, watcher.Status().PendingRestart)
+	assert.Equal(t, 2, watcher.Status().Reloads)
+}
+
+func Test_Watcher_Reload_InvalidConfigIsNotApplied(t *testing.T) {
+	logging.Init("trace", true)
+
+	initial, err := config.Load("")
+	require.NoError(t, err)
+
+	watcher := config.NewWatcher(initial, "", func() (*config.Config, error) {
+		return nil, errors.New("invalid config")
+	}, logging.GetLogger())
+
+	called := false
+	watcher.Subscribe("generator", func(cfg *config.Config) error {
+		called = true
+		return nil
+	})
+
+	_, err = watcher.Reload()
+
+	assert.Error(t, err)
+	assert.False(t, called)
+	assert.Same(t, initial, watcher.Config())
+	assert.Equal(t, "invalid config", watcher.Status().LastError)
+}
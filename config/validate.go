@@ -0,0 +1,127 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"sensors-generator/pkg/ratelimit"
+
+	"github.com/sirupsen/logrus"
+)
+
+const redacted = "REDACTED"
+
+// Validate checks values which otherwise fail only when the related part of the app starts.
+// All problems are returned at once.
+func (cfg *Config) Validate() error {
+	errs := make([]error, 0)
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(cfg.Listen.Type == "port" || cfg.Listen.Type == "sock", "listen.type should be port or sock, got %q", cfg.Listen.Type)
+	check(cfg.Listen.Type != "port" || cfg.Listen.Port != "", "listen.port is required")
+	for _, proxy := range cfg.Listen.TrustedProxies {
+		_, _, err := net.ParseCIDR(proxy)
+		check(err == nil || net.ParseIP(proxy) != nil, "listen.trusted_proxies should be IPs or CIDRs, got %q", proxy)
+	}
+
+	if cfg.GRPC.Enabled {
+		check(cfg.GRPC.Type == "port" || cfg.GRPC.Type == "sock", "grpc.type should be port or sock, got %q", cfg.GRPC.Type)
+		check(cfg.GRPC.Type != "port" || cfg.GRPC.Port != "", "grpc.port is required")
+		check(cfg.GRPC.Type != "port" || cfg.Listen.Type != "port" || cfg.GRPC.Port != cfg.Listen.Port,
+			"grpc.port should differ from listen.port")
+		check(cfg.GRPC.Type != "sock" || cfg.Listen.Type != "sock" || cfg.GRPC.SocketFile != cfg.Listen.SocketFile,
+			"grpc.socket_file should differ from listen.socket_file")
+	}
+
+	_, err := logrus.ParseLevel(cfg.AppConfig.LogLevel)
+	check(err == nil, "app_config.log_level: %v", err)
+	check(cfg.AppConfig.WatchInterval >= 0, "app_config.watch_interval should not be negative")
+
+	check(cfg.LogConfig.Format == "text" || cfg.LogConfig.Format == "json",
+		"log_config.format should be text or json, got %q", cfg.LogConfig.Format)
+	for _, output := range cfg.LogConfig.Outputs {
+		check(output == "stdout" || output == "stderr" || output == "file",
+			"log_config.outputs should be stdout, stderr or file, got %q", output)
+	}
+
+	check(cfg.QueryConfig.DefaultTimeout > 0, "query_config.default_timeout should be positive")
+	for route, timeout := range cfg.QueryConfig.RouteTimeouts {
+		check(timeout > 0, "query_config.route_timeouts of %s should be positive", route)
+	}
+
+	check(cfg.RateLimitConfig.Store == ratelimit.StoreMemory || cfg.RateLimitConfig.Store == ratelimit.StoreRedis,
+		"rate_limit_config.store should be memory or redis, got %q", cfg.RateLimitConfig.Store)
+
+	check(cfg.RetentionConfig.Interval > 0, "retention_config.interval should be positive")
+	check(cfg.RetentionConfig.RollupDelay >= 0, "retention_config.rollup_delay should not be negative")
+	check(cfg.RetentionConfig.RawDays >= 0, "retention_config.raw_days should not be negative")
+	check(cfg.RetentionConfig.HourlyDays >= 0, "retention_config.hourly_days should not be negative")
+	check(cfg.RetentionConfig.HourlyDays == 0 || cfg.RetentionConfig.RawDays <= cfg.RetentionConfig.HourlyDays,
+		"retention_config.raw_days should not be greater than hourly_days")
+	check(cfg.RetentionConfig.BatchSize > 0, "retention_config.batch_size should be positive")
+
+	check(cfg.PartitionConfig.Interval == "month" || cfg.PartitionConfig.Interval == "day",
+		"partition_config.interval should be month or day, got %q", cfg.PartitionConfig.Interval)
+	check(cfg.PartitionConfig.Premake >= 0, "partition_config.premake should not be negative")
+
+	check(cfg.ImportConfig.BatchSize > 0, "import_config.batch_size should be positive")
+	check(cfg.ImportConfig.MaxRows >= 0, "import_config.max_rows should not be negative")
+	check(cfg.ImportConfig.MaxErrors >= 0, "import_config.max_errors should not be negative")
+
+	for i, profile := range cfg.FaultConfig.Profiles {
+		for _, problem := range profile.Validate() {
+			check(false, "fault_config.profiles[%d] %s: %s", i, profile.Name, problem)
+		}
+	}
+
+	for _, problem := range cfg.EnvironmentConfig.Validate() {
+		check(false, "environment_config.%s", problem)
+	}
+
+	check(cfg.GeneratorConfig.Workers > 0, "generator_config.workers should be positive")
+	check(cfg.WriteQueueConfig.Size > 0, "write_queue_config.size should be positive")
+	check(cfg.WriteQueueConfig.Writers > 0, "write_queue_config.writers should be positive")
+	check(cfg.WriteQueueConfig.WhenFull == "block" || cfg.WriteQueueConfig.WhenFull == "drop_oldest" ||
+		cfg.WriteQueueConfig.WhenFull == "drop_newest",
+		"write_queue_config.when_full should be block, drop_oldest or drop_newest, got %q", cfg.WriteQueueConfig.WhenFull)
+	check(cfg.WriteQueueConfig.RetryMin > 0 && cfg.WriteQueueConfig.RetryMax >= cfg.WriteQueueConfig.RetryMin,
+		"write_queue_config.retry_min should be positive and not above retry_max")
+
+	for _, problem := range cfg.ClockConfig.Validate() {
+		check(false, "clock_config.%s", problem)
+	}
+
+	if cfg.PopulationConfig.Enabled {
+		check(cfg.PopulationConfig.Step > 0, "population_config.step should be positive")
+		check(cfg.PopulationConfig.CheckpointEvery > 0, "population_config.checkpoint_every should be positive")
+		check(cfg.PopulationConfig.SchoolsPerSpiece >= 0, "population_config.schools_per_spiece should not be negative")
+		check(cfg.PopulationConfig.Speed > 0, "population_config.speed should be positive")
+		check(cfg.PopulationConfig.DetectionRadius > 0, "population_config.detection_radius should be positive")
+	}
+
+	check(cfg.PgConfig.Host != "", "pg_config.host is required")
+	check(cfg.PgConfig.Database != "", "pg_config.database is required")
+
+	check(cfg.RedisConfig.TTL > 0, "redis_config.ttl should be positive")
+
+	return errors.Join(errs...)
+}
+
+// Redacted returns a copy of config with values of secret fields replaced, so it can be printed.
+func (cfg *Config) Redacted() *Config {
+	c := *cfg
+
+	walk(&c, func(f field) error {
+		if f.tag.Get("secret") == "true" && f.value.Kind() == reflect.String && f.value.String() != "" {
+			f.value.SetString(redacted)
+		}
+		return nil
+	})
+
+	return &c
+}
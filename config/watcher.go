@@ -0,0 +1,279 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"sensors-generator/pkg/logging"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Subscriber applies reloaded config to a running part of the app.
+// Keys of a failed subscriber are reported as pending restart.
+type Subscriber func(cfg *Config) error
+
+type subscription struct {
+	name  string
+	keys  []string
+	apply Subscriber
+}
+
+// matches returns changed keys which are equal to or nested in keys of the subscription.
+func (s subscription) matches(changed []string) []string {
+	matched := make([]string, 0)
+	for _, key := range changed {
+		if coveredBy(key, s.keys) {
+			matched = append(matched, key)
+		}
+	}
+	return matched
+}
+
+func coveredBy(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if key == prefix || strings.HasPrefix(key, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// ReloadReport describes one reload. Keys are yaml paths, e.g. "cors_config.allowed_origins".
+type ReloadReport struct {
+	Changed     []string  `json:"changed"`
+	Applied     []string  `json:"applied"`
+	NeedRestart []string  `json:"need_restart"`
+	ReloadedAt  time.Time `json:"reloaded_at"`
+}
+
+// WatchStatus tells which config is running and which of its changes wait for a restart.
+type WatchStatus struct {
+	Path           string    `json:"path"`
+	StartedAt      time.Time `json:"started_at"`
+	ReloadedAt     time.Time `json:"reloaded_at,omitempty"`
+	Reloads        int       `json:"reloads"`
+	LastError      string    `json:"last_error,omitempty"`
+	PendingRestart []string  `json:"pending_restart"`
+}
+
+// Watcher reloads config on SIGHUP, when the file changes or on demand,
+// and passes it to subscribers of changed keys. Config values are never mutated,
+// every reload swaps the whole config, so Config can be called from any goroutine.
+type Watcher struct {
+	path   string
+	load   func() (*Config, error)
+	logger *logging.Logger
+
+	current atomic.Pointer[Config]
+	initial *Config
+
+	mu      sync.Mutex
+	subs    []subscription
+	failed  map[string]bool
+	status  WatchStatus
+	modTime time.Time
+	size    int64
+}
+
+// NewWatcher watches config loaded from path. Load should build config the same way as on startup
+// (file, env and flags) and validate it, invalid config is not applied.
+func NewWatcher(cfg *Config, path string, load func() (*Config, error), logger *logging.Logger) *Watcher {
+	w := &Watcher{
+		path:    path,
+		load:    load,
+		logger:  logger,
+		initial: cfg,
+		failed:  make(map[string]bool),
+		status:  WatchStatus{Path: path, StartedAt: time.Now(), PendingRestart: []string{}},
+	}
+	w.current.Store(cfg)
+	w.modTime, w.size = w.stat()
+
+	return w
+}
+
+// Config returns the last applied config.
+func (w *Watcher) Config() *Config {
+	return w.current.Load()
+}
+
+// Subscribe calls apply after reloads which change any of keys or keys nested in them.
+// Without keys apply is called after every reload, e.g. to re-read state kept outside of the config.
+// Changes of keys without subscribers need a restart.
+func (w *Watcher) Subscribe(name string, apply Subscriber, keys ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subs = append(w.subs, subscription{name: name, keys: keys, apply: apply})
+}
+
+// Reload loads config and applies it. When it cannot be loaded the running config is kept.
+func (w *Watcher) Reload() (ReloadReport, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	cfg, err := w.load()
+	if err != nil {
+		w.status.LastError = err.Error()
+		w.logger.Errorf("Config is not reloaded, due to error: %v", err)
+		return ReloadReport{}, err
+	}
+
+	report := ReloadReport{
+		Changed:     Diff(w.current.Load(), cfg),
+		Applied:     []string{},
+		NeedRestart: []string{},
+		ReloadedAt:  time.Now(),
+	}
+	w.current.Store(cfg)
+
+	applied := make(map[string]bool)
+	for _, sub := range w.subs {
+		keys := sub.matches(report.Changed)
+		if len(keys) == 0 && len(sub.keys) > 0 {
+			continue
+		}
+
+		if err := sub.apply(cfg); err != nil {
+			w.logger.Errorf("Config subscriber %s failed, due to error: %v", sub.name, err)
+			for _, key := range keys {
+				w.failed[key] = true
+			}
+			continue
+		}
+
+		for _, key := range keys {
+			applied[key] = true
+			delete(w.failed, key)
+		}
+	}
+
+	for _, key := range report.Changed {
+		if applied[key] {
+			report.Applied = append(report.Applied, key)
+		} else {
+			report.NeedRestart = append(report.NeedRestart, key)
+		}
+	}
+
+	w.status.ReloadedAt = report.ReloadedAt
+	w.status.Reloads++
+	w.status.LastError = ""
+	w.status.PendingRestart = w.pendingRestart(cfg)
+
+	w.logger.Infof("Config reloaded, applied: %v, need restart: %v", report.Applied, report.NeedRestart)
+	if len(w.status.PendingRestart) > 0 {
+		w.logger.Warnf("Config changes wait for restart: %v", w.status.PendingRestart)
+	}
+
+	return report, nil
+}
+
+// pendingRestart lists keys which differ from the startup config and were not applied live.
+// A key changed back to the startup value is not pending anymore.
+func (w *Watcher) pendingRestart(cfg *Config) []string {
+	live := make([]string, 0)
+	for _, sub := range w.subs {
+		live = append(live, sub.keys...)
+	}
+
+	pending := make([]string, 0)
+	for _, key := range Diff(w.initial, cfg) {
+		if w.failed[key] || !coveredBy(key, live) {
+			pending = append(pending, key)
+		}
+	}
+	return pending
+}
+
+// Status returns the state of the last reload.
+func (w *Watcher) Status() WatchStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	status := w.status
+	status.PendingRestart = append([]string{}, w.status.PendingRestart...)
+	return status
+}
+
+// Run reloads config on SIGHUP and, when app_config.watch_interval is positive,
+// when modification time or size of the file changes. It returns when ctx is done.
+func (w *Watcher) Run(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var poll <-chan time.Time
+	if interval := w.initial.AppConfig.WatchInterval; w.path != "" && interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			w.logger.Info("SIGHUP received, reload config.")
+			w.Reload()
+		case <-poll:
+			modTime, size := w.stat()
+			if modTime.Equal(w.modTime) && size == w.size {
+				continue
+			}
+			w.modTime, w.size = modTime, size
+
+			w.logger.Infof("Config file %s changed, reload config.", w.path)
+			w.Reload()
+		}
+	}
+}
+
+func (w *Watcher) stat() (time.Time, int64) {
+	if w.path == "" {
+		return time.Time{}, 0
+	}
+
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return time.Time{}, 0
+	}
+	return info.ModTime(), info.Size()
+}
+
+// Diff returns sorted yaml paths of fields which differ. Empty and nil slices and maps are equal.
+func Diff(a, b *Config) []string {
+	values := make(map[string]reflect.Value)
+	walk(a, func(f field) error {
+		values[f.path] = f.value
+		return nil
+	})
+
+	changed := make([]string, 0)
+	walk(b, func(f field) error {
+		if !equalValues(values[f.path], f.value) {
+			changed = append(changed, f.path)
+		}
+		return nil
+	})
+
+	sort.Strings(changed)
+	return changed
+}
+
+func equalValues(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Slice, reflect.Map:
+		if a.Len() == 0 && b.Len() == 0 {
+			return true
+		}
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
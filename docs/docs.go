@@ -32,30 +32,75 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/group/{groupName}/spieces": {
+        "/api/v1/admin/config": {
             "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Config file, last reload and settings which were changed but need a restart to take effect.",
                 "tags": [
-                    "Groups"
+                    "Admin"
                 ],
-                "summary": "Spieces in group",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Name of the group",
-                        "name": "groupName",
-                        "in": "path",
-                        "required": true
+                "summary": "Config status",
+                "responses": {
+                    "200": {
+                        "description": "OK"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/config/reload": {
+            "post": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "from",
-                        "name": "from",
-                        "in": "query"
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Reads the config again, the same as SIGHUP. Log level and format, cors, cache ttl\nand sensors of the generator are applied live, other changes are listed in need_restart.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Reload config",
+                "responses": {
+                    "200": {
+                        "description": "OK"
                     },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/keys": {
+            "get": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "till",
-                        "name": "till",
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Api keys",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include revoked keys",
+                        "name": "revoked",
                         "in": "query"
                     }
                 ],
@@ -63,45 +108,125 @@ const docTemplate = `{
                     "200": {
                         "description": "OK"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The key is returned only once, only its hash is stored.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create api key",
+                "parameters": [
+                    {
+                        "description": "Name and role (reader, operator or admin)",
+                        "name": "key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apikey.CreateAPIKeyDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
                     "400": {
                         "description": "Bad Request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/api/v1/group/{groupName}/spieces/top/{N}": {
-            "get": {
+        "/api/v1/admin/keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "tags": [
-                    "Groups"
+                    "Admin"
                 ],
-                "summary": "Top N spieces in group",
+                "summary": "Revoke api key",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Name of the group",
-                        "name": "groupName",
+                        "type": "integer",
+                        "description": "Api key ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
-                    {
-                        "type": "string",
-                        "description": "Top N",
-                        "name": "N",
-                        "in": "path",
-                        "required": true
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
                     },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Storms, upwellings and algal blooms of the config and of the api, ordered by start.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Environmental events",
+                "parameters": [
                     {
                         "type": "integer",
-                        "description": "from",
+                        "description": "Keep events which end after the timestamp",
                         "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "till",
+                        "description": "Keep events which start before the timestamp",
                         "name": "till",
                         "in": "query"
                     }
@@ -113,116 +238,166 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
-            }
-        },
-        "/api/v1/group/{groupName}/temperature/average": {
-            "get": {
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The event is applied to readings at once. Kind is storm, upwelling or algal_bloom,\nshape of ramp up, decay and edges is linear or smooth.",
                 "tags": [
-                    "Groups"
+                    "Events"
                 ],
-                "summary": "Average temperature in group",
+                "summary": "Create environmental event",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Name of the group",
-                        "name": "groupName",
-                        "in": "path",
-                        "required": true
+                        "description": "Event",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/event.CreateEventDTO"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "201": {
+                        "description": "Created"
                     },
                     "400": {
                         "description": "Bad Request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/api/v1/group/{groupName}/transparency/average": {
-            "get": {
+        "/api/v1/events/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Only events created with the api can be deleted.",
                 "tags": [
-                    "Groups"
+                    "Events"
                 ],
-                "summary": "Average transparency in group",
+                "summary": "Delete environmental event",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Name of the group",
-                        "name": "groupName",
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/api/v1/region/temperature/max": {
+        "/api/v1/export/readings": {
             "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams readings with codename, coordinates and detected spieces. Ordered by creation time.\nValues are calibrated unless raw is set, readings taken during maintenance are flagged.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/octet-stream"
+                ],
                 "tags": [
-                    "Sensors"
+                    "Export"
                 ],
-                "summary": "Max Temperature",
+                "summary": "Export readings",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Minimum value for x coordinate",
-                        "name": "xMin",
-                        "in": "query",
-                        "required": true
+                        "description": "csv (default), ndjson or parquet",
+                        "name": "format",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Minimum value for y coordinate",
-                        "name": "yMin",
-                        "in": "query",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Compress with gzip",
+                        "name": "gzip",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Minimum value for z coordinate",
-                        "name": "zMin",
-                        "in": "query",
-                        "required": true
+                        "description": "Codename of the sensor, e.g. 'alpha 1'",
+                        "name": "codeName",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Maximum value for x coordinate",
-                        "name": "xMax",
-                        "in": "query",
-                        "required": true
+                        "description": "Name of the group",
+                        "name": "group",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Maximum value for y coordinate",
-                        "name": "yMax",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "from",
+                        "name": "from",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Maximum value for z coordinate",
-                        "name": "zMax",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "till",
+                        "name": "till",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max number of readings",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Values before calibration",
+                        "name": "raw",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -232,60 +407,69 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/api/v1/region/temperature/min": {
+        "/api/v1/faults": {
             "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Ground truth of faults which the generator injected into readings, ordered by start time.\nOutage, stuck and drift cover all readings of the sensor from started_at till ended_at,\nother faults reference one reading by sensor_data_id.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Sensors"
+                    "Faults"
                 ],
-                "summary": "Min Temperature",
+                "summary": "Injected faults",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Minimum value for x coordinate",
-                        "name": "xMin",
-                        "in": "query",
-                        "required": true
+                        "description": "Codename of the sensor, e.g. 'alpha 1'",
+                        "name": "codeName",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Minimum value for y coordinate",
-                        "name": "yMin",
-                        "in": "query",
-                        "required": true
+                        "description": "Name of the group",
+                        "name": "group",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Minimum value for z coordinate",
-                        "name": "zMin",
-                        "in": "query",
-                        "required": true
+                        "description": "outage, stuck, drift, spike, duplicate, out_of_order or delay",
+                        "name": "kind",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Maximum value for x coordinate",
-                        "name": "xMax",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "from",
+                        "name": "from",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Maximum value for y coordinate",
-                        "name": "yMax",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "till",
+                        "name": "till",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Maximum value for z coordinate",
-                        "name": "zMax",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "Max number of faults",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -295,26 +479,954 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/api/v1/sensor/{codeName}/temperature/average": {
+        "/api/v1/generator/clock": {
             "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mode (real, accelerated or stepped), current time of the generator and speed of accelerated time.",
                 "tags": [
-                    "Sensors"
+                    "Generator"
                 ],
-                "summary": "Average Temperature",
-                "parameters": [
-                    {
+                "summary": "Generator clock",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    }
+                }
+            }
+        },
+        "/api/v1/generator/clock/advance": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Moves the stepped clock forward, readings of the passed time are written at once.",
+                "tags": [
+                    "Generator"
+                ],
+                "summary": "Advance stepped clock",
+                "parameters": [
+                    {
+                        "description": "Duration, e.g. 1h",
+                        "name": "advance",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/generator.AdvanceClockDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    }
+                }
+            }
+        },
+        "/api/v1/generator/start": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Generator"
+                ],
+                "summary": "Start generator",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/generator/stats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sensors, queued sensors, busy workers, written readings and scheduling lag: how late readings start\nafter their scheduled time. Lag buckets count readings by lag since the app started.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Generator"
+                ],
+                "summary": "Generator stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/generator.Stats"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    }
+                }
+            }
+        },
+        "/api/v1/generator/status": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Generator"
+                ],
+                "summary": "Generator status",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    }
+                }
+            }
+        },
+        "/api/v1/generator/stop": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Generator"
+                ],
+                "summary": "Stop generator",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    }
+                }
+            }
+        },
+        "/api/v1/group/{groupName}/spieces": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Spieces in group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of the group",
+                        "name": "groupName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "from",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "till",
+                        "name": "till",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/group/{groupName}/spieces/top/{N}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Top N spieces in group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of the group",
+                        "name": "groupName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Top N",
+                        "name": "N",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "from",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "till",
+                        "name": "till",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/group/{groupName}/{metric}/average": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Average of a metric in group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of the group",
+                        "name": "groupName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Measurement type, e.g. temperature or salinity",
+                        "name": "metric",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/import/readings": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Imports readings keyed by sensor codename. CSV needs codename, temperature, transparency,\ncreated_at (RFC3339) and optional spieces (separated by ';') columns, NDJSON has the same fields.\nNothing is written if any row is invalid.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import"
+                ],
+                "summary": "Import readings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (default) or ndjson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the file",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/importer.Report"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/importer.Report"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/import/sensors": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Imports GeoJSON FeatureCollection of points with group, index, depth and data_output_rate (seconds) properties.\nExisting sensors are moved, new sensors and groups are created. Nothing is written if any feature is invalid.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import"
+                ],
+                "summary": "Import sensors layout",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the file",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/importer.Report"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/importer.Report"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/measurements": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Types which sensors can measure, with units and valid ranges. Names are metrics of aggregations.",
+                "tags": [
+                    "Sensors"
+                ],
+                "summary": "Measurement types",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    }
+                }
+            }
+        },
+        "/api/v1/population/schools": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Ground truth of simulated schools: position, velocity and size, ordered by id.\nWhen the simulation runs in another process, schools of its last checkpoint are returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Population"
+                ],
+                "summary": "Schools",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/region/{metric}/max": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Sensors"
+                ],
+                "summary": "Max of a metric in region",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Measurement type, e.g. temperature or salinity",
+                        "name": "metric",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum value for x coordinate",
+                        "name": "xMin",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum value for y coordinate",
+                        "name": "yMin",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum value for z coordinate",
+                        "name": "zMin",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum value for x coordinate",
+                        "name": "xMax",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum value for y coordinate",
+                        "name": "yMax",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum value for z coordinate",
+                        "name": "zMax",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/region/{metric}/min": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Sensors"
+                ],
+                "summary": "Min of a metric in region",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Measurement type, e.g. temperature or salinity",
+                        "name": "metric",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum value for x coordinate",
+                        "name": "xMin",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum value for y coordinate",
+                        "name": "yMin",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum value for z coordinate",
+                        "name": "zMin",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum value for x coordinate",
+                        "name": "xMax",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum value for y coordinate",
+                        "name": "yMax",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum value for z coordinate",
+                        "name": "zMax",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/sensor/{codeName}": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Sensors"
+                ],
+                "summary": "Update sensor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Codename of the sensor",
+                        "name": "codeName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "sensor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/sensor.UpdateSensorDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/sensor/{codeName}/calibrations": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Calibrations ordered by calibrated_at, a calibration applies till the next one of the same metric.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sensors"
+                ],
+                "summary": "Calibrations of sensor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Codename of the sensor",
+                        "name": "codeName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Readings from calibrated_at are written as raw * gain + offset, raw values are kept.\nThe generator applies the calibration after the next refresh.",
+                "tags": [
+                    "Sensors"
+                ],
+                "summary": "Add calibration of sensor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Codename of the sensor",
+                        "name": "codeName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Calibration",
+                        "name": "calibration",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/sensor.CreateCalibrationDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/sensor/{codeName}/maintenance": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Maintenance windows ordered by start.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sensors"
+                ],
+                "summary": "Maintenance of sensor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Codename of the sensor",
+                        "name": "codeName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Keep maintenance which ends after the timestamp",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Keep maintenance which starts before the timestamp",
+                        "name": "till",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Readings taken from starts_at till ends_at are flagged with maintenance, including written ones.\nKind is inspection, cleaning, repair, replacement, firmware_update or calibration.",
+                "tags": [
+                    "Sensors"
+                ],
+                "summary": "Add maintenance of sensor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Codename of the sensor",
+                        "name": "codeName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Maintenance",
+                        "name": "maintenance",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/sensor.CreateMaintenanceDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/sensor/{codeName}/{metric}/average": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Sensors"
+                ],
+                "summary": "Average of a metric for sensor",
+                "parameters": [
+                    {
                         "type": "string",
                         "description": "Name of the group",
                         "name": "codeName",
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "Measurement type, e.g. temperature or salinity",
+                        "name": "metric",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "description": "from",
@@ -335,11 +1447,565 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
+        },
+        "/graphql": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Queries groups, sensors, readings and spieces in one request. The schema is in internal/gql/schema.graphql.\nPOST takes JSON body with query, operationName and variables, GET takes them as query parameters,\nvariables as JSON. Sensors, their average temperatures and readings are loaded in batches.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GraphQL"
+                ],
+                "summary": "GraphQL query",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Query, for GET requests",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Operation name, for GET requests",
+                        "name": "operationName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variables as JSON, for GET requests",
+                        "name": "variables",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Queries groups, sensors, readings and spieces in one request. The schema is in internal/gql/schema.graphql.\nPOST takes JSON body with query, operationName and variables, GET takes them as query parameters,\nvariables as JSON. Sensors, their average temperatures and readings are loaded in batches.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GraphQL"
+                ],
+                "summary": "GraphQL query",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Query, for GET requests",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Operation name, for GET requests",
+                        "name": "operationName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variables as JSON, for GET requests",
+                        "name": "variables",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apikey.CreateAPIKeyDTO": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/apikey.Role"
+                }
+            }
+        },
+        "apikey.Role": {
+            "type": "string",
+            "enum": [
+                "reader",
+                "operator",
+                "admin"
+            ],
+            "x-enum-varnames": [
+                "RoleReader",
+                "RoleOperator",
+                "RoleAdmin"
+            ]
+        },
+        "environment.EventKind": {
+            "type": "string",
+            "enum": [
+                "storm",
+                "upwelling",
+                "algal_bloom"
+            ],
+            "x-enum-varnames": [
+                "KindStorm",
+                "KindUpwelling",
+                "KindAlgalBloom"
+            ]
+        },
+        "environment.Point": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "type": "number"
+                },
+                "y": {
+                    "type": "number"
+                },
+                "z": {
+                    "type": "number"
+                }
+            }
+        },
+        "environment.Region": {
+            "type": "object",
+            "properties": {
+                "max": {
+                    "$ref": "#/definitions/environment.Point"
+                },
+                "min": {
+                    "$ref": "#/definitions/environment.Point"
+                }
+            }
+        },
+        "environment.Shape": {
+            "type": "string",
+            "enum": [
+                "linear",
+                "smooth"
+            ],
+            "x-enum-varnames": [
+                "ShapeLinear",
+                "ShapeSmooth"
+            ]
+        },
+        "event.CreateEventDTO": {
+            "type": "object",
+            "properties": {
+                "decay": {
+                    "type": "string",
+                    "example": "1h"
+                },
+                "edge": {
+                    "type": "number"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "kind": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/environment.EventKind"
+                        }
+                    ],
+                    "example": "storm"
+                },
+                "magnitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ramp_up": {
+                    "description": "RampUp and Decay are durations, e.g. 30m.",
+                    "type": "string",
+                    "example": "30m"
+                },
+                "region": {
+                    "$ref": "#/definitions/environment.Region"
+                },
+                "shape": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/environment.Shape"
+                        }
+                    ],
+                    "example": "smooth"
+                },
+                "spieces": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "generator.AdvanceClockDTO": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "string",
+                    "example": "1h"
+                }
+            }
+        },
+        "generator.Lag": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "description": "Buckets count readings by lag, keys are upper bounds, e.g. \"100ms\", and \"+Inf\".",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "last_ms": {
+                    "type": "number"
+                },
+                "max_ms": {
+                    "type": "number"
+                },
+                "mean_ms": {
+                    "type": "number"
+                }
+            }
+        },
+        "generator.Stats": {
+            "type": "object",
+            "properties": {
+                "busy": {
+                    "type": "integer"
+                },
+                "lag": {
+                    "$ref": "#/definitions/generator.Lag"
+                },
+                "queued": {
+                    "type": "integer"
+                },
+                "readings": {
+                    "type": "integer"
+                },
+                "running": {
+                    "type": "boolean"
+                },
+                "sensors": {
+                    "type": "integer"
+                },
+                "workers": {
+                    "type": "integer"
+                },
+                "writes": {
+                    "description": "Writes count readings of the write queue, they are kept when the generator stops.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/generator.WriteStats"
+                        }
+                    ]
+                }
+            }
+        },
+        "generator.WriteStats": {
+            "type": "object",
+            "properties": {
+                "dropped": {
+                    "description": "Dropped by when_full policy, or left in memory when the queue was closed without a spill file.",
+                    "type": "integer"
+                },
+                "failed": {
+                    "description": "Failed readings kept failing with a permanent error, e.g. a constraint violation,\nafter delays between attempts reached retry_max, they are dropped.",
+                    "type": "integer"
+                },
+                "queued": {
+                    "description": "Queued readings are in memory, retried ones included.",
+                    "type": "integer"
+                },
+                "rejected": {
+                    "description": "Rejected readings are not retried, e.g. their sensor was deleted.",
+                    "type": "integer"
+                },
+                "retried": {
+                    "type": "integer"
+                },
+                "spilled": {
+                    "description": "Spilled readings wait in the spill file.",
+                    "type": "integer"
+                },
+                "written": {
+                    "type": "integer"
+                }
+            }
+        },
+        "importer.Report": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/importer.RowError"
+                    }
+                },
+                "errors_truncated": {
+                    "type": "boolean"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "importer.RowError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "sensor.Coordinates": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "type": "number"
+                },
+                "y": {
+                    "type": "number"
+                },
+                "z": {
+                    "type": "number"
+                }
+            }
+        },
+        "sensor.CreateCalibrationDTO": {
+            "type": "object",
+            "properties": {
+                "calibrated_at": {
+                    "description": "CalibratedAt is now when it is not set.",
+                    "type": "string"
+                },
+                "gain": {
+                    "description": "Gain is 1 when it is not set.",
+                    "type": "number",
+                    "example": 1.002
+                },
+                "metric": {
+                    "type": "string",
+                    "example": "temperature"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "number",
+                    "example": -0.12
+                }
+            }
+        },
+        "sensor.CreateMaintenanceDTO": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string"
+                },
+                "kind": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/sensor.MaintenanceKind"
+                        }
+                    ],
+                    "example": "cleaning"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "sensor.MaintenanceKind": {
+            "type": "string",
+            "enum": [
+                "inspection",
+                "cleaning",
+                "repair",
+                "replacement",
+                "firmware_update",
+                "calibration"
+            ],
+            "x-enum-varnames": [
+                "MaintenanceInspection",
+                "MaintenanceCleaning",
+                "MaintenanceRepair",
+                "MaintenanceReplacement",
+                "MaintenanceFirmwareUpdate",
+                "MaintenanceCalibration"
+            ]
+        },
+        "sensor.UpdateSensorDTO": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "$ref": "#/definitions/sensor.Coordinates"
+                },
+                "data_output_rate": {
+                    "type": "integer"
+                },
+                "firmware": {
+                    "type": "string"
+                },
+                "installed_at": {
+                    "type": "string"
+                },
+                "measurements": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "model": {
+                    "type": "string"
+                },
+                "trajectory": {
+                    "description": "Trajectory of kind static makes the sensor static.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/trajectory.Trajectory"
+                        }
+                    ]
+                }
+            }
+        },
+        "trajectory.Kind": {
+            "type": "string",
+            "enum": [
+                "waypoints",
+                "random_walk",
+                "current",
+                "static"
+            ],
+            "x-enum-varnames": [
+                "KindWaypoints",
+                "KindRandomWalk",
+                "KindCurrent",
+                "KindStatic"
+            ]
+        },
+        "trajectory.Trajectory": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/trajectory.Kind"
+                        }
+                    ],
+                    "example": "waypoints"
+                },
+                "radius": {
+                    "description": "Radius keeps random walks and drifters within a box of Radius meters around the home position,\n0 does not limit them.",
+                    "type": "number",
+                    "example": 100
+                },
+                "speed": {
+                    "description": "Speed along waypoints, m/s.",
+                    "type": "number",
+                    "example": 0.3
+                },
+                "starts_at": {
+                    "description": "StartsAt is when the sensor leaves home. Without it waypoints are followed since the epoch,\nso the position depends only on the time, and other kinds start from home at the first reading.",
+                    "type": "string"
+                },
+                "step": {
+                    "description": "Step of KindRandomWalk is the standard deviation of the distance moved in an hour along X and Y, meters.",
+                    "type": "number",
+                    "example": 20
+                },
+                "waypoints": {
+                    "description": "Waypoints of KindWaypoints, visited in order.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/environment.Point"
+                    }
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
         }
     }
 }`
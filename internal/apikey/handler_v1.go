@@ -0,0 +1,125 @@
+package apikey
+
+import (
+	"net/http"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/pkg/logging"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	keysPath = "api/v1/admin/keys"
+	keyPath  = "/:id"
+)
+
+type handler struct {
+	apiKeyService IAPIKeyService
+	logger        *logging.Logger
+}
+
+func NewHandler(apiKeyService IAPIKeyService, logger *logging.Logger) *handler {
+	return &handler{
+		apiKeyService: apiKeyService,
+		logger:        logger,
+	}
+}
+
+func (h *handler) Register(router gin.IRouter) {
+	keys := router.Group(keysPath)
+	{
+		keys.GET("", h.GetAPIKeys)
+		keys.POST("", h.CreateAPIKey)
+		keys.DELETE(keyPath, h.RevokeAPIKey)
+	}
+}
+
+// GetAPIKeys
+// @Summary Api keys
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Param revoked query bool false "Include revoked keys"
+// @Success 200
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /api/v1/admin/keys [get]
+func (h *handler) GetAPIKeys(c *gin.Context) {
+	filters := APIKeyFilters{}
+
+	if revoked, ok := c.GetQuery("revoked"); ok {
+		includeRevoked, err := strconv.ParseBool(revoked)
+		if err != nil {
+			h.logger.LWithContext(c.Request.Context()).Errorf("Cannot parse string, due to error: %v", err)
+			c.Error(apperror.ErrBadRequest)
+			return
+		}
+		filters.IncludeRevoked = includeRevoked
+	}
+
+	apiKeys, err := h.apiKeyService.GetAll(c.Request.Context(), filters)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"keys": apiKeys})
+}
+
+// CreateAPIKey
+// @Summary Create api key
+// @Description The key is returned only once, only its hash is stored.
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Param key body CreateAPIKeyDTO true "Name and role (reader, operator or admin)"
+// @Success 201
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /api/v1/admin/keys [post]
+func (h *handler) CreateAPIKey(c *gin.Context) {
+	var dto CreateAPIKeyDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.LWithContext(c.Request.Context()).Errorf("Cannot parse body, due to error: %v", err)
+		c.Error(apperror.ErrBadRequest)
+		return
+	}
+
+	key, apiKey, err := h.apiKeyService.Create(c.Request.Context(), dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"key": key, "api_key": apiKey})
+}
+
+// RevokeAPIKey
+// @Summary Revoke api key
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Param id path int true "Api key ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/admin/keys/{id} [delete]
+func (h *handler) RevokeAPIKey(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.LWithContext(c.Request.Context()).Errorf("Cannot parse id, due to error: %v", err)
+		c.Error(apperror.ErrBadRequest)
+		return
+	}
+
+	if err := h.apiKeyService.Revoke(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
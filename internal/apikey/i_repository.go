@@ -0,0 +1,10 @@
+package apikey
+
+import "context"
+
+type IAPIKeyRepository interface {
+	FindAll(ctx context.Context, filters APIKeyFilters) ([]APIKey, error)
+	FindOneByHash(ctx context.Context, hash string) (*APIKey, error)
+	Create(ctx context.Context, apiKey CreateAPIKeyDTO, prefix, hash string) (int, error)
+	Revoke(ctx context.Context, id int) error
+}
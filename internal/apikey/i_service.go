@@ -0,0 +1,10 @@
+package apikey
+
+import "context"
+
+type IAPIKeyService interface {
+	GetAll(ctx context.Context, filters APIKeyFilters) ([]APIKey, error)
+	Create(ctx context.Context, apiKey CreateAPIKeyDTO) (string, *APIKey, error)
+	Revoke(ctx context.Context, id int) error
+	Authenticate(ctx context.Context, key string) (*APIKey, error)
+}
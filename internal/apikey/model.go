@@ -0,0 +1,89 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sensors-generator/internal/apperror"
+	"time"
+)
+
+const (
+	keyPrefix    = "sg_"
+	prefixLength = 8
+	secretLength = 32
+)
+
+type Role string
+
+const (
+	RoleReader   Role = "reader"
+	RoleOperator Role = "operator"
+	RoleAdmin    Role = "admin"
+)
+
+var roleLevels = map[Role]int{
+	RoleReader:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+type APIKey struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Role      Role       `json:"role"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+type CreateAPIKeyDTO struct {
+	Name string `json:"name"`
+	Role Role   `json:"role"`
+}
+
+type APIKeyFilters struct {
+	IncludeRevoked bool
+}
+
+func NewRoleFromString(role string) (Role, error) {
+	r := Role(role)
+	if _, ok := roleLevels[r]; !ok {
+		return "", apperror.ErrorWithMessage(apperror.ErrValidation, "Unknown role.")
+	}
+
+	return r, nil
+}
+
+// Allows reports whether the role has at least the permissions of required role.
+func (r Role) Allows(required Role) bool {
+	level, ok := roleLevels[r]
+	if !ok {
+		return false
+	}
+
+	return level >= roleLevels[required]
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// GenerateKey returns new raw key and its prefix, which is safe to show in listings.
+func GenerateKey() (string, string, error) {
+	b := make([]byte, (prefixLength+secretLength)/2)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	random := hex.EncodeToString(b)
+	prefix := random[:prefixLength]
+
+	return keyPrefix + prefix + "_" + random[prefixLength:], prefix, nil
+}
+
+// HashKey returns the value stored in the database instead of the raw key.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
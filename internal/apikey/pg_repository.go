@@ -0,0 +1,114 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sensors-generator/config"
+	"sensors-generator/internal/apperror"
+	clients "sensors-generator/pkg/client/interfaces"
+	"sensors-generator/pkg/logging"
+	"time"
+)
+
+type repository struct {
+	client clients.DBClient
+	logger *logging.Logger
+	cfg    *config.Config
+}
+
+func NewPostgresqlRepository(client *sql.DB,
+	logger *logging.Logger, cfg *config.Config) *repository {
+	return &repository{
+		client: client,
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (r *repository) FindAll(ctx context.Context, filters APIKeyFilters) ([]APIKey, error) {
+	q := `SELECT id, name, prefix, role, created_at, revoked_at FROM api_keys`
+
+	if !filters.IncludeRevoked {
+		q += ` WHERE revoked_at IS NULL`
+	}
+
+	q += ` ORDER BY id`
+
+	rows, err := r.client.QueryContext(ctx, q)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to get api keys, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+	defer rows.Close()
+
+	apiKeys := make([]APIKey, 0)
+
+	for rows.Next() {
+		var apiKey APIKey
+		if err := rows.Scan(&apiKey.ID, &apiKey.Name, &apiKey.Prefix, &apiKey.Role,
+			&apiKey.CreatedAt, &apiKey.RevokedAt); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Failed to fetch row, due to error: %v", err)
+			return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to iterate rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return apiKeys, nil
+}
+
+func (r *repository) FindOneByHash(ctx context.Context, hash string) (*APIKey, error) {
+	q := `SELECT id, name, prefix, role, created_at, revoked_at FROM api_keys WHERE key_hash=$1`
+
+	var apiKey APIKey
+
+	if err := r.client.QueryRowContext(ctx, q, hash).Scan(&apiKey.ID, &apiKey.Name, &apiKey.Prefix,
+		&apiKey.Role, &apiKey.CreatedAt, &apiKey.RevokedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrUnauthorized
+		}
+
+		r.logger.LWithContext(ctx).Errorf("Cannot find api key, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return &apiKey, nil
+}
+
+func (r *repository) Create(ctx context.Context, apiKey CreateAPIKeyDTO, prefix, hash string) (int, error) {
+	q := `INSERT INTO api_keys(name, prefix, key_hash, role, created_at)
+		VALUES($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	var id int
+
+	if err := r.client.QueryRowContext(ctx, q, apiKey.Name, prefix, hash,
+		apiKey.Role, time.Now()).Scan(&id); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot create api key, due to error: %v", err)
+		return 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return id, nil
+}
+
+func (r *repository) Revoke(ctx context.Context, id int) error {
+	q := `UPDATE api_keys SET revoked_at=$1 WHERE id=$2 AND revoked_at IS NULL`
+
+	result, err := r.client.ExecContext(ctx, q, time.Now(), id)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot revoke api key, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return apperror.ErrorWithMessage(apperror.ErrNotFound, "Api key not found.")
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package apikey
+
+import (
+	"context"
+	"sensors-generator/config"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/pkg/logging"
+	"strings"
+)
+
+type service struct {
+	apiKeyRepo IAPIKeyRepository
+	logger     *logging.Logger
+	cfg        *config.Config
+}
+
+func NewService(apiKeyRepo IAPIKeyRepository,
+	logger *logging.Logger, cfg *config.Config) *service {
+	return &service{
+		apiKeyRepo: apiKeyRepo,
+		logger:     logger,
+		cfg:        cfg,
+	}
+}
+
+func (s *service) GetAll(ctx context.Context, filters APIKeyFilters) ([]APIKey, error) {
+	s.logger.LWithContext(ctx).Debug("Get api keys.")
+	return s.apiKeyRepo.FindAll(ctx, filters)
+}
+
+// Create stores hash of the new key and returns the raw key. It cannot be restored later.
+func (s *service) Create(ctx context.Context, apiKey CreateAPIKeyDTO) (string, *APIKey, error) {
+	s.logger.LWithContext(ctx).Debug("Create api key.")
+
+	apiKey.Name = strings.TrimSpace(apiKey.Name)
+	if apiKey.Name == "" {
+		return "", nil, apperror.ErrorWithMessage(apperror.ErrValidation, "Name is required.")
+	}
+
+	if _, err := NewRoleFromString(string(apiKey.Role)); err != nil {
+		return "", nil, err
+	}
+
+	key, prefix, err := GenerateKey()
+	if err != nil {
+		s.logger.LWithContext(ctx).Errorf("Cannot generate api key, due to error: %v", err)
+		return "", nil, apperror.ErrInternalSystem
+	}
+
+	id, err := s.apiKeyRepo.Create(ctx, apiKey, prefix, HashKey(key))
+	if err != nil {
+		return "", nil, err
+	}
+
+	s.logger.LWithContext(ctx).Infof("Api key %s with role %s was created.", prefix, apiKey.Role)
+	return key, &APIKey{
+		ID:     id,
+		Name:   apiKey.Name,
+		Prefix: prefix,
+		Role:   apiKey.Role,
+	}, nil
+}
+
+func (s *service) Revoke(ctx context.Context, id int) error {
+	s.logger.LWithContext(ctx).Debug("Revoke api key.")
+	return s.apiKeyRepo.Revoke(ctx, id)
+}
+
+func (s *service) Authenticate(ctx context.Context, key string) (*APIKey, error) {
+	if !strings.HasPrefix(key, keyPrefix) {
+		return nil, apperror.ErrUnauthorized
+	}
+
+	apiKey, err := s.apiKeyRepo.FindOneByHash(ctx, HashKey(key))
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey.IsRevoked() {
+		return nil, apperror.ErrUnauthorized
+	}
+
+	return apiKey, nil
+}
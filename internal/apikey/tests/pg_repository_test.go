@@ -0,0 +1,86 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"sensors-generator/internal/apikey"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func Test_APIKeyRepository_FindOneByHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logging.Init("trace", true)
+
+	repo := apikey.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	mock.ExpectQuery("SELECT id, name, prefix, role, created_at, revoked_at FROM api_keys WHERE key_hash=\\$1").
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "prefix", "role", "created_at", "revoked_at"}).
+			AddRow(1, "dashboard", "abcd1234", "reader", time.Now(), nil))
+
+	apiKey, err := repo.FindOneByHash(context.Background(), "hash")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if apiKey.Role != apikey.RoleReader {
+		t.Errorf("unexpected role, got: %s, want: %s", apiKey.Role, apikey.RoleReader)
+	}
+	if apiKey.IsRevoked() {
+		t.Errorf("api key should not be revoked")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_APIKeyRepository_FindOneByHash_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := apikey.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	mock.ExpectQuery("SELECT id, name, prefix, role, created_at, revoked_at FROM api_keys").
+		WithArgs("hash").
+		WillReturnError(sql.ErrNoRows)
+
+	if _, err := repo.FindOneByHash(context.Background(), "hash"); err != apperror.ErrUnauthorized {
+		t.Errorf("unexpected error, got: %v, want: %v", err, apperror.ErrUnauthorized)
+	}
+}
+
+func Test_APIKeyRepository_Revoke(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := apikey.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	mock.ExpectExec("UPDATE api_keys SET revoked_at=\\$1 WHERE id=\\$2 AND revoked_at IS NULL").
+		WithArgs(sqlmock.AnyArg(), 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.Revoke(context.Background(), 3); err != nil {
+		t.Errorf("error was not expected while revoking key: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,93 @@
+package apikey
+
+import (
+	"context"
+	"sensors-generator/internal/apikey"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/pkg/logging"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_APIKeyService_Create(t *testing.T) {
+	logging.Init("trace", true)
+	repo := &MockAPIKeyRepository{}
+
+	service := apikey.NewService(repo, logging.GetLogger(), nil)
+
+	ctx := context.Background()
+	dto := apikey.CreateAPIKeyDTO{Name: "dashboard", Role: apikey.RoleReader}
+
+	var storedHash string
+	repo.On("Create", ctx, dto, mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { storedHash = args.String(3) }).
+		Return(7, nil)
+
+	key, apiKey, err := service.Create(ctx, dto)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, "sg_"+apiKey.Prefix+"_"))
+	assert.Equal(t, 7, apiKey.ID)
+	assert.Equal(t, apikey.HashKey(key), storedHash)
+	assert.NotContains(t, storedHash, apiKey.Prefix)
+
+	repo.AssertExpectations(t)
+}
+
+func Test_APIKeyService_Create_UnknownRole(t *testing.T) {
+	repo := &MockAPIKeyRepository{}
+
+	service := apikey.NewService(repo, logging.GetLogger(), nil)
+
+	_, _, err := service.Create(context.Background(), apikey.CreateAPIKeyDTO{Name: "dashboard", Role: "root"})
+
+	assert.Error(t, err)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_APIKeyService_Authenticate(t *testing.T) {
+	repo := &MockAPIKeyRepository{}
+
+	service := apikey.NewService(repo, logging.GetLogger(), nil)
+
+	ctx := context.Background()
+	key, prefix, err := apikey.GenerateKey()
+	assert.NoError(t, err)
+
+	expectedAPIKey := &apikey.APIKey{ID: 1, Name: "dashboard", Prefix: prefix, Role: apikey.RoleOperator}
+	repo.On("FindOneByHash", ctx, apikey.HashKey(key)).Return(expectedAPIKey, nil)
+
+	apiKey, err := service.Authenticate(ctx, key)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAPIKey, apiKey)
+}
+
+func Test_APIKeyService_Authenticate_Revoked(t *testing.T) {
+	repo := &MockAPIKeyRepository{}
+
+	service := apikey.NewService(repo, logging.GetLogger(), nil)
+
+	ctx := context.Background()
+	key, prefix, err := apikey.GenerateKey()
+	assert.NoError(t, err)
+
+	revokedAt := time.Now()
+	repo.On("FindOneByHash", ctx, apikey.HashKey(key)).
+		Return(&apikey.APIKey{ID: 1, Prefix: prefix, Role: apikey.RoleAdmin, RevokedAt: &revokedAt}, nil)
+
+	_, err = service.Authenticate(ctx, key)
+
+	assert.Equal(t, apperror.ErrUnauthorized, err)
+}
+
+func Test_Role_Allows(t *testing.T) {
+	assert.True(t, apikey.RoleAdmin.Allows(apikey.RoleReader))
+	assert.True(t, apikey.RoleOperator.Allows(apikey.RoleOperator))
+	assert.False(t, apikey.RoleReader.Allows(apikey.RoleOperator))
+	assert.False(t, apikey.Role("root").Allows(apikey.RoleReader))
+}
@@ -10,17 +10,33 @@ import (
 	"path"
 	"path/filepath"
 	"sensors-generator/config"
+	"sensors-generator/internal/apikey"
+	"sensors-generator/internal/event"
+	"sensors-generator/internal/export"
 	"sensors-generator/internal/generator"
+	"sensors-generator/internal/gql"
+	"sensors-generator/internal/groundtruth"
 	"sensors-generator/internal/group"
+	"sensors-generator/internal/importer"
 	"sensors-generator/internal/middleware"
 	"sensors-generator/internal/mocks"
+	"sensors-generator/internal/population"
+	"sensors-generator/internal/reload"
+	"sensors-generator/internal/retention"
 	"sensors-generator/internal/sensor"
 	sensordata "sensors-generator/internal/sensorData"
 	"sensors-generator/internal/spiece"
+	"sensors-generator/migrations"
+	sensorgenv1 "sensors-generator/pkg/api/sensorgen/v1"
 	"sensors-generator/pkg/client/postgresql"
 	"sensors-generator/pkg/client/redis"
+	"sensors-generator/pkg/clock"
 	"sensors-generator/pkg/logging"
 	"sensors-generator/pkg/metric"
+	"sensors-generator/pkg/migrate"
+	"sensors-generator/pkg/ratelimit"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	_ "sensors-generator/docs"
@@ -29,6 +45,7 @@ import (
 	"github.com/rs/cors"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"google.golang.org/grpc"
 )
 
 const (
@@ -40,10 +57,48 @@ type App struct {
 	cfg        *config.Config
 	logger     *logging.Logger
 	router     *gin.Engine
+	cors       *corsHandler
 	httpServer *http.Server
+	grpcServer *grpc.Server
+	dataGen    *generator.DataGenerator
+	// populationRunning is done when the simulation saved its last checkpoint.
+	populationRunning *sync.WaitGroup
 }
 
-func NewApp(ctx context.Context, cfg *config.Config, logger *logging.Logger) (App, error) {
+// corsHandler serves requests with cors options of the last applied config.
+type corsHandler struct {
+	cors atomic.Pointer[cors.Cors]
+	next http.Handler
+}
+
+func newCorsHandler(cfg *config.Config, next http.Handler) *corsHandler {
+	h := &corsHandler{next: next}
+	h.apply(cfg)
+	return h
+}
+
+func (h *corsHandler) apply(cfg *config.Config) error {
+	h.cors.Store(cors.New(cors.Options{
+		AllowedMethods:     cfg.CorsConfig.AllowedMethods,
+		AllowedOrigins:     cfg.CorsConfig.AllowedOrigins,
+		AllowCredentials:   cfg.CorsConfig.AllowCredentials,
+		AllowedHeaders:     cfg.CorsConfig.AllowedHeaders,
+		OptionsPassthrough: cfg.CorsConfig.OptionsPassthrough,
+		ExposedHeaders:     cfg.CorsConfig.ExposedHeaders,
+		Debug:              cfg.IsDebug,
+	}))
+	return nil
+}
+
+func (h *corsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.cors.Load().ServeHTTP(w, r, h.next.ServeHTTP)
+}
+
+// NewApp starts the app with the current config of the watcher. Settings which can be changed live
+// are subscribed to the watcher, changes of others wait for a restart.
+func NewApp(ctx context.Context, watcher *config.Watcher, logger *logging.Logger) (App, error) {
+	cfg := watcher.Config()
+
 	logger.Info("DB init")
 	dbClient, err := postgresql.NewClient(cfg.PgConfig)
 	if err != nil {
@@ -51,12 +106,31 @@ func NewApp(ctx context.Context, cfg *config.Config, logger *logging.Logger) (Ap
 		return App{}, err
 	}
 
+	if cfg.AppConfig.AutoMigrate {
+		logger.Info("Apply migrations")
+		migrator, err := migrate.New(dbClient, migrations.FS, logger)
+		if err != nil {
+			return App{}, err
+		}
+
+		if err := migrator.Up(ctx); err != nil {
+			logger.Errorf("Failed to apply migrations, due to error: %v", err)
+			return App{}, err
+		}
+	}
+
 	logger.Info("Redis init")
 	redisCache := redis.NewRedisCache(cfg.RedisConfig)
 
 	logger.Info("Gin init")
-	router := gin.Default()
+	router := gin.New()
+	if err := router.SetTrustedProxies(cfg.Listen.TrustedProxies); err != nil {
+		return App{}, err
+	}
+	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID(logger))
 	router.Use(middleware.HandleErrors())
+	router.Use(middleware.QueryTimeout(cfg.QueryConfig.DefaultTimeout, cfg.QueryConfig.RouteTimeouts))
 
 	logger.Info("Swagger docs init")
 	router.GET("/swagger", func(ctx *gin.Context) {
@@ -67,6 +141,51 @@ func NewApp(ctx context.Context, cfg *config.Config, logger *logging.Logger) (Ap
 	heartbeatHandler := &metric.Handler{}
 	heartbeatHandler.Register(router)
 
+	logger.Info("Create api key repo.")
+	apiKeyRepo := apikey.NewPostgresqlRepository(dbClient, logger, cfg)
+	logger.Info("Create api key service.")
+	apiKeyService := apikey.NewService(apiKeyRepo, logger, cfg)
+
+	var limiter ratelimit.Limiter
+	if cfg.RateLimitConfig.Enabled {
+		logger.Infof("Rate limiter init, store: %s", cfg.RateLimitConfig.Store)
+		switch cfg.RateLimitConfig.Store {
+		case ratelimit.StoreRedis:
+			limiter = ratelimit.NewRedisLimiter(redisCache.Client())
+		case ratelimit.StoreMemory:
+			limiter = ratelimit.NewMemoryLimiter()
+		default:
+			return App{}, fmt.Errorf("unknown rate limit store: %s", cfg.RateLimitConfig.Store)
+		}
+	}
+
+	if !cfg.AuthConfig.Enabled {
+		logger.Warn("Auth is disabled, all routes are public.")
+	}
+
+	roleGroup := func(role apikey.Role) gin.IRouter {
+		handlers := make([]gin.HandlerFunc, 0, 4)
+		if limiter != nil {
+			handlers = append(handlers, middleware.RateLimitIP(limiter, cfg.RateLimitConfig.IP, logger))
+		}
+		if cfg.AuthConfig.Enabled {
+			handlers = append(handlers, middleware.Authenticate(apiKeyService), middleware.Authorize(role))
+		}
+		if limiter != nil {
+			handlers = append(handlers, middleware.RateLimit(limiter, cfg.RateLimitConfig.Rules, logger))
+		}
+		return router.Group("", handlers...)
+	}
+
+	readers := roleGroup(apikey.RoleReader)
+	operators := roleGroup(apikey.RoleOperator)
+	admins := roleGroup(apikey.RoleAdmin)
+
+	logger.Info("Create api key handler.")
+	apiKeyHandler := apikey.NewHandler(apiKeyService, logger)
+	logger.Info("Register router for api key handler.")
+	apiKeyHandler.Register(admins)
+
 	logger.Info("Create sensor group repo.")
 	sensorGroupRepo := group.NewPostgresqlRepository(dbClient, logger, cfg)
 	logger.Info("Create sensor group service.")
@@ -74,7 +193,7 @@ func NewApp(ctx context.Context, cfg *config.Config, logger *logging.Logger) (Ap
 	logger.Info("Create sensor group handler.")
 	sensorGroupHandler := group.NewHandler(sensorGroupService, logger)
 	logger.Info("Register router for sensor group handler.")
-	sensorGroupHandler.Register(router)
+	sensorGroupHandler.Register(readers)
 
 	logger.Info("Create sensor repo.")
 	sensorRepo := sensor.NewPostgresqlRepository(dbClient, logger, cfg)
@@ -83,67 +202,279 @@ func NewApp(ctx context.Context, cfg *config.Config, logger *logging.Logger) (Ap
 	logger.Info("Create sensor handler.")
 	sensorHandler := sensor.NewHandler(sensorService, logger)
 	logger.Info("Register router for sensor handler.")
-	sensorHandler.Register(router)
+	sensorHandler.Register(readers)
+	sensorHandler.RegisterManagement(operators)
+
+	// Readings, their rows and schools follow the same clock, a stepped clock is advanced with the generator api.
+	logger.Infof("Create %s clock.", cfg.ClockConfig.Mode)
+	clk, err := clock.New(cfg.ClockConfig)
+	if err != nil {
+		logger.Errorf("Failed to create clock, due to error: %v", err)
+		return App{}, err
+	}
 
 	logger.Info("Create sensor data repo.")
-	sensorDataRepo := sensordata.NewPostgresqlRepository(dbClient, logger, cfg)
+	sensorDataRepo := sensordata.NewPostgresqlRepository(dbClient, clk, logger, cfg)
 	logger.Info("Create sensor data service.")
 	sensorDataService := sensordata.NewService(sensorDataRepo, logger, cfg)
 
+	logger.Info("Create export service.")
+	exportService := export.NewService(sensorDataService, logger, cfg)
+	logger.Info("Create export handler.")
+	exportHandler := export.NewHandler(exportService, logger)
+	logger.Info("Register router for export handler.")
+	exportHandler.Register(readers)
+
 	logger.Info("Create spiece repo.")
 	spieceRepo := spiece.NewPostgresqlRepository(dbClient, logger, cfg)
 	logger.Info("Create spiece service.")
 	spieceService := spiece.NewService(spieceRepo, logger, cfg)
 
+	logger.Info("Create retention repo.")
+	retentionRepo := retention.NewPostgresqlRepository(dbClient, logger, cfg)
+	logger.Info("Create retention service.")
+	retentionService := retention.NewService(retentionRepo, logger, cfg)
+
+	if cfg.RetentionConfig.Enabled || cfg.PartitionConfig.Enabled {
+		logger.Info("Start retention job.")
+		go retentionService.Run(ctx)
+	}
+
+	logger.Info("Create import repo.")
+	importRepo := importer.NewPostgresqlRepository(dbClient, clk, logger, cfg)
+	logger.Info("Create import service.")
+	importService := importer.NewService(importRepo, sensorService, spieceService, retentionService, logger, cfg)
+	logger.Info("Create import handler.")
+	importHandler := importer.NewHandler(importService, logger)
+	logger.Info("Register router for import handler.")
+	importHandler.Register(operators)
+
+	logger.Info("Create injected fault repo.")
+	injectedFaultRepo := groundtruth.NewPostgresqlRepository(dbClient, logger, cfg)
+	logger.Info("Create injected fault service.")
+	injectedFaultService := groundtruth.NewService(injectedFaultRepo, logger, cfg)
+	logger.Info("Create injected fault handler.")
+	injectedFaultHandler := groundtruth.NewHandler(injectedFaultService, logger)
+	logger.Info("Register router for injected fault handler.")
+	injectedFaultHandler.Register(readers)
+
+	logger.Info("Create population repo.")
+	populationRepo := population.NewPostgresqlRepository(dbClient, logger, cfg)
+	logger.Info("Create population service.")
+	populationService := population.NewService(populationRepo, spieceService, sensorService, clk, logger, cfg)
+	logger.Info("Create population handler.")
+	populationHandler := population.NewHandler(populationService, logger)
+	logger.Info("Register router for population handler.")
+	populationHandler.Register(readers)
+
+	// Events change readings, so the random generator is their sink.
+	randomGen := generator.NewRandomGenerator(cfg.EnvironmentConfig)
+	logger.Info("Create event repo.")
+	eventRepo := event.NewPostgresqlRepository(dbClient, clk, logger, cfg)
+	logger.Info("Create event service.")
+	eventService := event.NewService(eventRepo, randomGen, logger, cfg)
+	if err := eventService.Apply(ctx); err != nil {
+		logger.Errorf("Failed to apply events, due to error: %v", err)
+	}
+	logger.Info("Create event handler.")
+	eventHandler := event.NewHandler(eventService, logger)
+	logger.Info("Register router for event handler.")
+	eventHandler.Register(readers)
+	eventHandler.RegisterManagement(operators)
+
+	services := generator.Services{
+		SensorService:        sensorService,
+		SensorGroupService:   sensorGroupService,
+		SpieceService:        spieceService,
+		SensorDataService:    sensorDataService,
+		InjectedFaultService: injectedFaultService,
+	}
+	if cfg.PartitionConfig.Enabled {
+		services.PartitionService = retentionService
+	}
+	if cfg.RetentionConfig.Enabled {
+		services.RollupService = retentionService
+	}
+
+	logger.Info("Create graphql handler.")
+	graphqlHandler := gql.NewHandler(gql.Services{
+		SensorGroupService: sensorGroupService,
+		SensorService:      sensorService,
+		SensorDataService:  sensorDataService,
+		SpieceService:      spieceService,
+	}, logger)
+	logger.Info("Register router for graphql handler.")
+	graphqlHandler.Register(readers)
+
 	logger.Info("Create Main Entities Generator.")
 	meGen := generator.NewMainEntitiesGenerator(generator.MainEntities{
 		Groups:  mocks.CreateSensorGroups,
 		Sensors: mocks.CreateSensors,
 		Spieces: mocks.CreateSpieces,
-	}, generator.Services{
-		SensorService:      sensorService,
-		SensorGroupService: sensorGroupService,
-		SpieceService:      spieceService,
-	})
+	}, services)
 
-	meGen.Generate()
+	if cfg.AppConfig.Seed && !meGen.IsGenerated() {
+		if err := meGen.Generate(); err != nil {
+			logger.Errorf("Failed to seed main entities, due to error: %v", err)
+			return App{}, err
+		}
+	}
+
+	// Schools are spawned for seeded spieces, so the simulation starts after seeding.
+	populationRunning := &sync.WaitGroup{}
+	if cfg.PopulationConfig.Enabled {
+		logger.Info("Start population simulation.")
+		services.PopulationService = populationService
+		populationRunning.Add(1)
+		go func() {
+			defer populationRunning.Done()
+			populationService.Run(ctx)
+		}()
+	}
 
 	logger.Info("Create Data Generator.")
-	dataGen := generator.NewDataGenerator(generator.Services{
-		SensorService:      sensorService,
-		SensorGroupService: sensorGroupService,
-		SpieceService:      spieceService,
-		SensorDataService:  sensorDataService,
-	}, generator.NewRandomGenerator())
+	dataGen := generator.NewDataGenerator(services, randomGen, clk, cfg)
+	dataGen.SetFaultConfig(cfg.FaultConfig)
 
 	// Start data generator only if main entities created.
-	for {
+	if cfg.AppConfig.Generate {
 		if meGen.IsGenerated() {
 			dataGen.Generate()
-			break
+		} else {
+			logger.Warn("There are no sensor groups, data generator is not started.")
 		}
+	}
 
-		time.Sleep(1 * time.Second)
+	logger.Info("Create generator handler.")
+	generatorHandler := generator.NewHandler(dataGen, clk, logger)
+	logger.Info("Register router for generator handler.")
+	generatorHandler.Register(operators)
+
+	corsHandler := newCorsHandler(cfg, router)
+
+	logger.Info("Subscribe to config reloads.")
+	watcher.Subscribe("logger", func(cfg *config.Config) error {
+		if err := logging.SetLevel(cfg.AppConfig.LogLevel); err != nil {
+			return err
+		}
+		return logging.SetFormat(cfg.LogConfig.Format)
+	}, "app_config.log_level", "log_config.format")
+	watcher.Subscribe("cors", corsHandler.apply, "cors_config", "is_debug")
+	watcher.Subscribe("cache", func(cfg *config.Config) error {
+		redisCache.SetTTL(cfg.RedisConfig.TTL)
+		return nil
+	}, "redis_config.ttl")
+	// Sensors and events are stored in the database, every reload applies their new output rates,
+	// fault profiles and events, including events created by other instances.
+	watcher.Subscribe("generator", func(cfg *config.Config) error {
+		dataGen.SetFaultConfig(cfg.FaultConfig)
+		if err := eventService.SetConfigEvents(ctx, cfg.EnvironmentConfig.Events); err != nil {
+			return err
+		}
+		report, err := dataGen.Refresh(ctx)
+		if err != nil {
+			return err
+		}
+		logger.Infof("Generator refreshed, started: %d, updated: %d, stopped: %d",
+			report.Started, report.Updated, report.Stopped)
+		return nil
+	})
+
+	logger.Info("Create config handler.")
+	configHandler := reload.NewHandler(watcher, logger)
+	logger.Info("Register router for config handler.")
+	configHandler.Register(admins)
+
+	logger.Info("Start config watcher.")
+	go watcher.Run(ctx)
+
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Enabled {
+		logger.Info("Grpc init")
+		grpcOptions := middleware.GRPCOptions{
+			Roles: map[string]apikey.Role{
+				sensorgenv1.SensorService_UpdateSensor_FullMethodName: apikey.RoleOperator,
+			},
+			Limiter: limiter,
+			Rules:   cfg.RateLimitConfig.Rules,
+			IPLimit: cfg.RateLimitConfig.IP,
+			Timeout: cfg.QueryConfig.DefaultTimeout,
+		}
+		if cfg.AuthConfig.Enabled {
+			grpcOptions.Authenticator = apiKeyService
+		}
+
+		grpcServer = grpc.NewServer(
+			grpc.UnaryInterceptor(middleware.GRPCUnary(grpcOptions, logger)),
+			grpc.StreamInterceptor(middleware.GRPCStream(grpcOptions, logger)),
+		)
+
+		logger.Info("Register grpc services.")
+		sensor.NewGRPCServer(sensorService, logger).Register(grpcServer)
+		group.NewGRPCServer(sensorGroupService, logger).Register(grpcServer)
+		spiece.NewGRPCServer(spieceService, logger).Register(grpcServer)
+		sensordata.NewGRPCServer(sensorDataService, logger).Register(grpcServer)
 	}
 
 	// Start app only if data generator started.
 	return App{
-		cfg:    cfg,
-		logger: logger,
-		router: router,
+		cfg:        cfg,
+		logger:     logger,
+		router:     router,
+		cors:       corsHandler,
+		grpcServer: grpcServer,
+		dataGen:    dataGen,
+
+		populationRunning: populationRunning,
 	}, nil
 }
 
-func (a *App) Run() {
-	a.startHTTP()
-}
+// Run serves till ctx is done, it should be the ctx of NewApp. Then servers finish their requests,
+// the generator stops and writes queued readings for flushTimeout, the rest spills to the spill file.
+func (a *App) Run(ctx context.Context, flushTimeout time.Duration) {
+	a.httpServer = &http.Server{
+		Handler:      a.cors,
+		WriteTimeout: WriteTimeout * time.Second,
+		ReadTimeout:  ReadTimeout * time.Second,
+	}
 
-func (a *App) startHTTP() error {
-	a.logger.Info("Start http")
+	if a.grpcServer != nil {
+		go a.startGRPC()
+	}
 
-	var listener net.Listener
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
 
-	if a.cfg.Listen.Type == "sock" {
+		a.logger.Info("Shut down servers.")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), WriteTimeout*time.Second)
+		defer cancel()
+		if err := a.httpServer.Shutdown(shutdownCtx); err != nil {
+			a.logger.Errorf("Failed to shut down http server, due to error: %v", err)
+		}
+		if a.grpcServer != nil {
+			a.grpcServer.GracefulStop()
+		}
+	}()
+
+	a.startHTTP()
+	<-stopped
+
+	a.logger.Info("Stop data generator.")
+	a.dataGen.Stop()
+	a.dataGen.Wait()
+	flushCtx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	a.dataGen.Close(flushCtx)
+	// The simulation saves its last checkpoint when it is stopped.
+	a.populationRunning.Wait()
+	a.logger.Info("Application is stopped.")
+}
+
+// listen opens unix socket next to the binary for "sock" type, otherwise tcp port.
+func (a *App) listen(listenType, bindIP, port, socketFile string) net.Listener {
+	if listenType == "sock" {
 		appDir, err := filepath.Abs(filepath.Dir(os.Args[0]))
 
 		if err != nil {
@@ -151,43 +482,43 @@ func (a *App) startHTTP() error {
 		}
 
 		a.logger.Info("Create socket")
-		socketPath := path.Join(appDir, a.cfg.Listen.SocketFile)
+		socketPath := path.Join(appDir, socketFile)
 
 		a.logger.Debugf("Socket path: %s", socketPath)
 
 		a.logger.Info("Listen unix socket")
 
-		listener, err = net.Listen("unix", socketPath)
-		a.logger.Infof("Server is listening unix socket: %s", socketPath)
-
-	} else {
-		var err error
-
-		a.logger.Info("Listen tcp")
-		listener, err = net.Listen("tcp", fmt.Sprintf("%s:%s", a.cfg.Listen.BindIP, a.cfg.Listen.Port))
-		a.logger.Infof("Server is listening %s:%s", a.cfg.Listen.BindIP, a.cfg.Listen.Port)
+		listener, err := net.Listen("unix", socketPath)
 		if err != nil {
 			a.logger.Fatal(err)
 		}
+		a.logger.Infof("Server is listening unix socket: %s", socketPath)
+		return listener
 	}
 
-	c := cors.New(cors.Options{
-		AllowedMethods:     a.cfg.CorsConfig.AllowedMethods,
-		AllowedOrigins:     a.cfg.CorsConfig.AllowedOrigins,
-		AllowCredentials:   a.cfg.CorsConfig.AllowCredentials,
-		AllowedHeaders:     a.cfg.CorsConfig.AllowedHeaders,
-		OptionsPassthrough: a.cfg.CorsConfig.OptionsPassthrough,
-		ExposedHeaders:     a.cfg.CorsConfig.ExposedHeaders,
-		Debug:              a.cfg.IsDebug,
-	})
+	a.logger.Info("Listen tcp")
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%s", bindIP, port))
+	if err != nil {
+		a.logger.Fatal(err)
+	}
+	a.logger.Infof("Server is listening %s:%s", bindIP, port)
+	return listener
+}
 
-	handler := c.Handler(a.router)
+func (a *App) startGRPC() {
+	a.logger.Info("Start grpc")
 
-	a.httpServer = &http.Server{
-		Handler:      handler,
-		WriteTimeout: WriteTimeout * time.Second,
-		ReadTimeout:  ReadTimeout * time.Second,
+	listener := a.listen(a.cfg.GRPC.Type, a.cfg.GRPC.BindIP, a.cfg.GRPC.Port, a.cfg.GRPC.SocketFile)
+	if err := a.grpcServer.Serve(listener); err != nil {
+		a.logger.Fatal(err)
 	}
+}
+
+// startHTTP serves till the server is shut down.
+func (a *App) startHTTP() {
+	a.logger.Info("Start http")
+
+	listener := a.listen(a.cfg.Listen.Type, a.cfg.Listen.BindIP, a.cfg.Listen.Port, a.cfg.Listen.SocketFile)
 
 	if err := a.httpServer.Serve(listener); err != nil {
 		switch {
@@ -197,9 +528,4 @@ func (a *App) startHTTP() error {
 			a.logger.Fatal(err)
 		}
 	}
-	err := a.httpServer.Shutdown(context.Background())
-	if err != nil {
-		a.logger.Fatal(err)
-	}
-	return err
 }
@@ -1,6 +1,8 @@
 package apperror
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -8,12 +10,14 @@ import (
 const name = "PS"
 
 var (
-	ErrInternalSystem = NewAppError("00100", http.StatusInternalServerError, "internal system error")
-	ErrBadRequest     = NewAppError("00101", http.StatusBadRequest, "bad request")
-	ErrValidation     = NewAppError("00102", http.StatusBadRequest, "validation error")
-	ErrNotFound       = NewAppError("00103", http.StatusNotFound, "not found")
-	ErrUnauthorized   = NewAppError("00104", http.StatusUnauthorized, "Not Authorized")
-	ErrForbidden      = NewAppError("00105", http.StatusForbidden, "access forbidden")
+	ErrInternalSystem  = NewAppError("00100", http.StatusInternalServerError, "internal system error")
+	ErrBadRequest      = NewAppError("00101", http.StatusBadRequest, "bad request")
+	ErrValidation      = NewAppError("00102", http.StatusBadRequest, "validation error")
+	ErrNotFound        = NewAppError("00103", http.StatusNotFound, "not found")
+	ErrUnauthorized    = NewAppError("00104", http.StatusUnauthorized, "Not Authorized")
+	ErrForbidden       = NewAppError("00105", http.StatusForbidden, "access forbidden")
+	ErrTimeout         = NewAppError("00106", http.StatusGatewayTimeout, "request timeout")
+	ErrTooManyRequests = NewAppError("00107", http.StatusTooManyRequests, "too many requests")
 )
 
 type AppError struct {
@@ -44,3 +48,14 @@ func ErrorWithMessage(err *AppError, message string) *AppError {
 		return err
 	}
 }
+
+// FromDBError returns ErrTimeout if the query was stopped by the context deadline,
+// otherwise it returns fallback. The driver does not always wrap the context error,
+// so ctx itself is checked too.
+func FromDBError(ctx context.Context, err error, fallback *AppError) *AppError {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrTimeout
+	}
+
+	return fallback
+}
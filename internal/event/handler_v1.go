@@ -0,0 +1,143 @@
+package event
+
+import (
+	"net/http"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/pkg/logging"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	eventsPath = "api/v1/events"
+	eventPath  = "api/v1/events/:id"
+)
+
+type handler struct {
+	eventService IEventService
+	logger       *logging.Logger
+}
+
+func NewHandler(eventService IEventService, logger *logging.Logger) *handler {
+	return &handler{
+		eventService: eventService,
+		logger:       logger,
+	}
+}
+
+func (h *handler) Register(router gin.IRouter) {
+	router.GET(eventsPath, h.GetEvents)
+}
+
+// RegisterManagement registers routes which change events.
+func (h *handler) RegisterManagement(router gin.IRouter) {
+	router.POST(eventsPath, h.CreateEvent)
+	router.DELETE(eventPath, h.DeleteEvent)
+}
+
+// GetEvents
+// @Summary Environmental events
+// @Description Storms, upwellings and algal blooms of the config and of the api, ordered by start.
+// @Tags Events
+// @Security ApiKeyAuth
+// @Produce json
+// @Param from query int false "Keep events which end after the timestamp"
+// @Param till query int false "Keep events which start before the timestamp"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /api/v1/events [get]
+func (h *handler) GetEvents(c *gin.Context) {
+	filters := EventFilters{}
+
+	if from, ok := c.GetQuery("from"); ok {
+		fromTS, err := strconv.Atoi(from)
+		if err != nil {
+			c.Error(apperror.ErrBadRequest)
+			return
+		}
+		fromDate := time.Unix(int64(fromTS), 0)
+		filters.From = &fromDate
+	}
+
+	if till, ok := c.GetQuery("till"); ok {
+		tillTS, err := strconv.Atoi(till)
+		if err != nil {
+			c.Error(apperror.ErrBadRequest)
+			return
+		}
+		tillDate := time.Unix(int64(tillTS), 0)
+		filters.Till = &tillDate
+	}
+
+	events, err := h.eventService.GetAll(c.Request.Context(), filters)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": events})
+}
+
+// CreateEvent
+// @Summary Create environmental event
+// @Description The event is applied to readings at once. Kind is storm, upwelling or algal_bloom,
+// @Description shape of ramp up, decay and edges is linear or smooth.
+// @Tags Events
+// @Security ApiKeyAuth
+// @Param event body CreateEventDTO true "Event"
+// @Success 201
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /api/v1/events [post]
+func (h *handler) CreateEvent(c *gin.Context) {
+	var dto CreateEventDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.LWithContext(c.Request.Context()).Errorf("Cannot parse body, due to error: %v", err)
+		c.Error(apperror.ErrBadRequest)
+		return
+	}
+
+	event, err := h.eventService.Create(c.Request.Context(), dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, event)
+}
+
+// DeleteEvent
+// @Summary Delete environmental event
+// @Description Only events created with the api can be deleted.
+// @Tags Events
+// @Security ApiKeyAuth
+// @Param id path int true "Event ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/events/{id} [delete]
+func (h *handler) DeleteEvent(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.LWithContext(c.Request.Context()).Errorf("Cannot parse id, due to error: %v", err)
+		c.Error(apperror.ErrBadRequest)
+		return
+	}
+
+	if err := h.eventService.Delete(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
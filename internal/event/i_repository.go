@@ -0,0 +1,12 @@
+package event
+
+import (
+	"context"
+	"sensors-generator/pkg/environment"
+)
+
+type IEventRepository interface {
+	FindAll(ctx context.Context) ([]Event, error)
+	Create(ctx context.Context, e environment.Event) (int, error)
+	Delete(ctx context.Context, id int) error
+}
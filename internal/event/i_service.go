@@ -0,0 +1,21 @@
+package event
+
+import (
+	"context"
+	"sensors-generator/pkg/environment"
+)
+
+type IEventService interface {
+	GetAll(ctx context.Context, filters EventFilters) ([]Event, error)
+	Create(ctx context.Context, dto CreateEventDTO) (*Event, error)
+	Delete(ctx context.Context, id int) error
+	// Apply passes events of the config and of the database to the sink.
+	Apply(ctx context.Context) error
+	// SetConfigEvents replaces events of the config after a reload and applies all events.
+	SetConfigEvents(ctx context.Context, events []environment.Event) error
+}
+
+// IEventSink receives all events, the random generator of readings applies them.
+type IEventSink interface {
+	SetEvents(events []environment.Event)
+}
@@ -0,0 +1,148 @@
+package event
+
+import (
+	"fmt"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/pkg/environment"
+	"strings"
+	"time"
+)
+
+// Source tells where the event is defined, only events of the api can be deleted.
+type Source string
+
+const (
+	SourceConfig Source = "config"
+	SourceAPI    Source = "api"
+)
+
+type Event struct {
+	// ID is 0 for events of the config.
+	ID        int                   `json:"id,omitempty"`
+	Source    Source                `json:"source"`
+	Name      string                `json:"name"`
+	Kind      environment.EventKind `json:"kind"`
+	Region    environment.Region    `json:"region"`
+	Edge      float64               `json:"edge"`
+	StartsAt  time.Time             `json:"starts_at"`
+	EndsAt    time.Time             `json:"ends_at"`
+	RampUp    string                `json:"ramp_up"`
+	Decay     string                `json:"decay"`
+	Shape     environment.Shape     `json:"shape"`
+	Magnitude float64               `json:"magnitude"`
+	Spieces   map[string]float64    `json:"spieces,omitempty"`
+	CreatedAt *time.Time            `json:"created_at,omitempty"`
+}
+
+type CreateEventDTO struct {
+	Name     string                `json:"name"`
+	Kind     environment.EventKind `json:"kind" example:"storm"`
+	Region   environment.Region    `json:"region"`
+	Edge     float64               `json:"edge"`
+	StartsAt time.Time             `json:"starts_at"`
+	EndsAt   time.Time             `json:"ends_at"`
+	// RampUp and Decay are durations, e.g. 30m.
+	RampUp    string             `json:"ramp_up" example:"30m"`
+	Decay     string             `json:"decay" example:"1h"`
+	Shape     environment.Shape  `json:"shape" example:"smooth"`
+	Magnitude float64            `json:"magnitude"`
+	Spieces   map[string]float64 `json:"spieces,omitempty"`
+}
+
+// EventFilters keep events which overlap the window from From till Till.
+type EventFilters struct {
+	From *time.Time
+	Till *time.Time
+}
+
+// NewEvent returns the event of the environment as it is shown by the api.
+func NewEvent(source Source, id int, e environment.Event) Event {
+	shape := e.Shape
+	if shape == "" {
+		shape = environment.ShapeLinear
+	}
+
+	return Event{
+		ID:        id,
+		Source:    source,
+		Name:      e.Name,
+		Kind:      e.Kind,
+		Region:    e.Region,
+		Edge:      e.Edge,
+		StartsAt:  e.StartsAt,
+		EndsAt:    e.EndsAt,
+		RampUp:    e.RampUp.String(),
+		Decay:     e.Decay.String(),
+		Shape:     shape,
+		Magnitude: e.Magnitude,
+		Spieces:   e.Spieces,
+	}
+}
+
+// DTO returns fields of the event which are stored.
+func (e Event) DTO() CreateEventDTO {
+	return CreateEventDTO{
+		Name:      e.Name,
+		Kind:      e.Kind,
+		Region:    e.Region,
+		Edge:      e.Edge,
+		StartsAt:  e.StartsAt,
+		EndsAt:    e.EndsAt,
+		RampUp:    e.RampUp,
+		Decay:     e.Decay,
+		Shape:     e.Shape,
+		Magnitude: e.Magnitude,
+		Spieces:   e.Spieces,
+	}
+}
+
+// Environment parses durations of the dto and validates the event.
+func (dto CreateEventDTO) Environment() (environment.Event, error) {
+	e := environment.Event{
+		Name:      strings.TrimSpace(dto.Name),
+		Kind:      dto.Kind,
+		Region:    dto.Region,
+		Edge:      dto.Edge,
+		StartsAt:  dto.StartsAt,
+		EndsAt:    dto.EndsAt,
+		Shape:     dto.Shape,
+		Magnitude: dto.Magnitude,
+		Spieces:   dto.Spieces,
+	}
+
+	var err error
+	if e.RampUp, err = parseDuration(dto.RampUp); err != nil {
+		return environment.Event{}, apperror.ErrorWithMessage(apperror.ErrValidation,
+			fmt.Sprintf("Cannot parse ramp_up: %v.", err))
+	}
+	if e.Decay, err = parseDuration(dto.Decay); err != nil {
+		return environment.Event{}, apperror.ErrorWithMessage(apperror.ErrValidation,
+			fmt.Sprintf("Cannot parse decay: %v.", err))
+	}
+
+	if problems := e.Validate(); len(problems) > 0 {
+		return environment.Event{}, apperror.ErrorWithMessage(apperror.ErrValidation,
+			strings.Join(problems, "; ")+".")
+	}
+
+	return e, nil
+}
+
+// Overlaps reports whether the event lasts at least a part of the window of filters.
+func (f EventFilters) Overlaps(e environment.Event) bool {
+	if f.From != nil && !e.EndsAt.After(*f.From) {
+		return false
+	}
+	if f.Till != nil && !e.StartsAt.Before(*f.Till) {
+		return false
+	}
+	return true
+}
+
+// parseDuration parses an empty string as no duration.
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
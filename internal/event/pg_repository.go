@@ -0,0 +1,136 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sensors-generator/config"
+	"sensors-generator/internal/apperror"
+	clients "sensors-generator/pkg/client/interfaces"
+	"sensors-generator/pkg/clock"
+	"sensors-generator/pkg/environment"
+	"sensors-generator/pkg/logging"
+	"time"
+)
+
+type repository struct {
+	client clients.DBClient
+	clk    clock.Clock
+	logger *logging.Logger
+	cfg    *config.Config
+}
+
+// NewPostgresqlRepository stamps rows with time of clk, so they follow the clock of the generator.
+func NewPostgresqlRepository(client *sql.DB, clk clock.Clock,
+	logger *logging.Logger, cfg *config.Config) *repository {
+	return &repository{
+		client: client,
+		clk:    clk,
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (r *repository) FindAll(ctx context.Context) ([]Event, error) {
+	q := `SELECT id, name, kind, min_x, min_y, min_z, max_x, max_y, max_z, edge,
+		starts_at, ends_at, ramp_up, decay, shape, magnitude, spieces, created_at
+		FROM events
+		ORDER BY starts_at, id`
+
+	rows, err := r.client.QueryContext(ctx, q)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to get events, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+	defer rows.Close()
+
+	events := make([]Event, 0)
+
+	for rows.Next() {
+		var (
+			id            int
+			e             environment.Event
+			rampUp, decay int
+			spieces       []byte
+			createdAt     time.Time
+		)
+		if err := rows.Scan(&id, &e.Name, &e.Kind,
+			&e.Region.Min.X, &e.Region.Min.Y, &e.Region.Min.Z, &e.Region.Max.X, &e.Region.Max.Y, &e.Region.Max.Z,
+			&e.Edge, &e.StartsAt, &e.EndsAt, &rampUp, &decay, &e.Shape, &e.Magnitude, &spieces, &createdAt); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Failed to fetch row, due to error: %v", err)
+			return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+
+		if err := json.Unmarshal(spieces, &e.Spieces); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Cannot parse event spieces, due to error: %v", err)
+			return nil, apperror.ErrInternalSystem
+		}
+		if len(e.Spieces) == 0 {
+			e.Spieces = nil
+		}
+		e.RampUp = time.Duration(rampUp) * time.Second
+		e.Decay = time.Duration(decay) * time.Second
+
+		event := NewEvent(SourceAPI, id, e)
+		event.CreatedAt = &createdAt
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to iterate rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return events, nil
+}
+
+// Create stores ramp up and decay in whole seconds.
+func (r *repository) Create(ctx context.Context, e environment.Event) (int, error) {
+	q := `INSERT INTO events(name, kind, min_x, min_y, min_z, max_x, max_y, max_z, edge,
+		starts_at, ends_at, ramp_up, decay, shape, magnitude, spieces, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		RETURNING id`
+
+	spieces := e.Spieces
+	if spieces == nil {
+		spieces = map[string]float64{}
+	}
+	encoded, err := json.Marshal(spieces)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot encode event spieces, due to error: %v", err)
+		return 0, apperror.ErrInternalSystem
+	}
+
+	shape := e.Shape
+	if shape == "" {
+		shape = environment.ShapeLinear
+	}
+
+	var id int
+
+	if err := r.client.QueryRowContext(ctx, q, e.Name, e.Kind,
+		e.Region.Min.X, e.Region.Min.Y, e.Region.Min.Z, e.Region.Max.X, e.Region.Max.Y, e.Region.Max.Z,
+		e.Edge, e.StartsAt, e.EndsAt, int(e.RampUp/time.Second), int(e.Decay/time.Second),
+		shape, e.Magnitude, encoded, r.clk.Now()).Scan(&id); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot create event, due to error: %v", err)
+		return 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return id, nil
+}
+
+func (r *repository) Delete(ctx context.Context, id int) error {
+	q := `DELETE FROM events WHERE id=$1`
+
+	result, err := r.client.ExecContext(ctx, q, id)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot delete event, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return apperror.ErrorWithMessage(apperror.ErrNotFound, "Event not found.")
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package event
+
+import (
+	"context"
+	"sensors-generator/config"
+	"sensors-generator/pkg/environment"
+	"sensors-generator/pkg/logging"
+	"sort"
+	"sync"
+)
+
+type service struct {
+	eventRepo IEventRepository
+	sink      IEventSink
+	logger    *logging.Logger
+	cfg       *config.Config
+
+	mu           sync.Mutex
+	configEvents []environment.Event
+}
+
+// NewService returns the service which applies events to the sink, nil sink only stores events.
+func NewService(eventRepo IEventRepository, sink IEventSink,
+	logger *logging.Logger, cfg *config.Config) *service {
+	return &service{
+		eventRepo:    eventRepo,
+		sink:         sink,
+		logger:       logger,
+		cfg:          cfg,
+		configEvents: cfg.EnvironmentConfig.Events,
+	}
+}
+
+// GetAll returns events of the config and of the database ordered by start.
+func (s *service) GetAll(ctx context.Context, filters EventFilters) ([]Event, error) {
+	s.logger.LWithContext(ctx).Debug("Get events.")
+
+	stored, err := s.eventRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	events := make([]Event, 0, len(s.configEvents)+len(stored))
+	for _, e := range s.configEvents {
+		if filters.Overlaps(e) {
+			events = append(events, NewEvent(SourceConfig, 0, e))
+		}
+	}
+	s.mu.Unlock()
+
+	for _, e := range stored {
+		if filters.Overlaps(environment.Event{StartsAt: e.StartsAt, EndsAt: e.EndsAt}) {
+			events = append(events, e)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].StartsAt.Before(events[j].StartsAt)
+	})
+
+	return events, nil
+}
+
+func (s *service) Create(ctx context.Context, dto CreateEventDTO) (*Event, error) {
+	s.logger.LWithContext(ctx).Debug("Create event.")
+
+	e, err := dto.Environment()
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := s.eventRepo.Create(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.LWithContext(ctx).Infof("Event %d %s (%s) was created.", id, e.Name, e.Kind)
+	if err := s.Apply(ctx); err != nil {
+		return nil, err
+	}
+
+	event := NewEvent(SourceAPI, id, e)
+	return &event, nil
+}
+
+// Delete removes an event of the api, events of the config are removed from the config.
+func (s *service) Delete(ctx context.Context, id int) error {
+	s.logger.LWithContext(ctx).Debug("Delete event.")
+
+	if err := s.eventRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.LWithContext(ctx).Infof("Event %d was deleted.", id)
+	return s.Apply(ctx)
+}
+
+func (s *service) Apply(ctx context.Context) error {
+	if s.sink == nil {
+		return nil
+	}
+
+	stored, err := s.eventRepo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	events := append([]environment.Event(nil), s.configEvents...)
+	s.mu.Unlock()
+
+	for _, e := range stored {
+		converted, err := e.DTO().Environment()
+		if err != nil {
+			// Events are validated when they are created, so it happens only if validation changed.
+			s.logger.LWithContext(ctx).Warnf("Event %d %s is skipped, due to error: %v", e.ID, e.Name, err)
+			continue
+		}
+		events = append(events, converted)
+	}
+
+	s.sink.SetEvents(events)
+	s.logger.LWithContext(ctx).Debugf("%d events are applied.", len(events))
+	return nil
+}
+
+func (s *service) SetConfigEvents(ctx context.Context, events []environment.Event) error {
+	s.mu.Lock()
+	s.configEvents = events
+	s.mu.Unlock()
+
+	return s.Apply(ctx)
+}
@@ -0,0 +1,36 @@
+package event
+
+import (
+	"context"
+	"sensors-generator/internal/event"
+	"sensors-generator/pkg/environment"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockEventRepository) FindAll(ctx context.Context) ([]event.Event, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]event.Event), args.Error(1)
+}
+
+func (m *MockEventRepository) Create(ctx context.Context, e environment.Event) (int, error) {
+	args := m.Called(ctx, e)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockEventRepository) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockEventSink struct {
+	mock.Mock
+}
+
+func (m *MockEventSink) SetEvents(events []environment.Event) {
+	m.Called(events)
+}
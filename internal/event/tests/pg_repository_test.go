@@ -0,0 +1,101 @@
+package event
+
+import (
+	"context"
+	"sensors-generator/internal/event"
+	"sensors-generator/pkg/clock"
+	"sensors-generator/pkg/environment"
+	"sensors-generator/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func Test_EventRepository_FindAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logging.Init("trace", true)
+
+	repo := event.NewPostgresqlRepository(db, clock.Real{}, logging.GetLogger(), nil)
+
+	mock.ExpectQuery("SELECT (.+) FROM events ORDER BY starts_at, id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "kind", "min_x", "min_y", "min_z", "max_x", "max_y",
+			"max_z", "edge", "starts_at", "ends_at", "ramp_up", "decay", "shape", "magnitude", "spieces", "created_at"}).
+			AddRow(3, "bloom", "algal_bloom", 0, 0, 0, 100, 100, 50, 10, startsAt, startsAt.Add(48*time.Hour),
+				1800, 3600, "smooth", 20, []byte(`{"Herring": 2}`), time.Now()))
+
+	events, err := repo.FindAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("unexpected events, got: %d, want: 1", len(events))
+	}
+	e := events[0]
+	if e.Source != event.SourceAPI || e.Kind != environment.KindAlgalBloom || e.RampUp != "30m0s" || e.Decay != "1h0m0s" {
+		t.Errorf("unexpected event: %+v", e)
+	}
+	if e.Spieces["Herring"] != 2 {
+		t.Errorf("unexpected spieces, got: %v", e.Spieces)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_EventRepository_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	repo := event.NewPostgresqlRepository(db, clock.NewStepped(now), logging.GetLogger(), nil)
+
+	mock.ExpectQuery("INSERT INTO events").
+		WithArgs("storm", environment.KindStorm, 0.0, 0.0, 0.0, 100.0, 100.0, 50.0, 0.0,
+			storedEvent.StartsAt, storedEvent.EndsAt, 1800, 3600, environment.ShapeLinear, 0.0, []byte(`{}`), now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	id, err := repo.Create(context.Background(), storedEvent)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != 3 {
+		t.Errorf("unexpected id, got: %d, want: 3", id)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_EventRepository_Delete_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := event.NewPostgresqlRepository(db, clock.Real{}, logging.GetLogger(), nil)
+
+	mock.ExpectExec("DELETE FROM events WHERE id=\\$1").
+		WithArgs(5).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.Delete(context.Background(), 5); err == nil {
+		t.Errorf("expected not found error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,146 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sensors-generator/config"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/event"
+	"sensors-generator/pkg/environment"
+	"sensors-generator/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	startsAt = time.Date(2023, time.August, 8, 12, 0, 0, 0, time.UTC)
+	region   = environment.Region{Max: environment.Point{X: 100, Y: 100, Z: 50}}
+
+	configEvent = environment.Event{
+		Name:     "spring upwelling",
+		Kind:     environment.KindUpwelling,
+		Region:   region,
+		StartsAt: startsAt.Add(-24 * time.Hour),
+		EndsAt:   startsAt.Add(-12 * time.Hour),
+	}
+	storedEvent = environment.Event{
+		Name:     "storm",
+		Kind:     environment.KindStorm,
+		Region:   region,
+		StartsAt: startsAt,
+		EndsAt:   startsAt.Add(6 * time.Hour),
+		RampUp:   30 * time.Minute,
+		Decay:    time.Hour,
+		Shape:    environment.ShapeLinear,
+	}
+)
+
+func newConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.EnvironmentConfig.Events = []environment.Event{configEvent}
+	return cfg
+}
+
+func Test_EventService_GetAll(t *testing.T) {
+	logging.Init("trace", true)
+	repo := &MockEventRepository{}
+	service := event.NewService(repo, nil, logging.GetLogger(), newConfig())
+
+	ctx := context.Background()
+	repo.On("FindAll", ctx).Return([]event.Event{event.NewEvent(event.SourceAPI, 3, storedEvent)}, nil)
+
+	events, err := service.GetAll(ctx, event.EventFilters{})
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, event.SourceConfig, events[0].Source)
+	assert.Equal(t, 0, events[0].ID)
+	assert.Equal(t, event.SourceAPI, events[1].Source)
+	assert.Equal(t, "30m0s", events[1].RampUp)
+
+	from := startsAt.Add(-time.Hour)
+	events, err = service.GetAll(ctx, event.EventFilters{From: &from})
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, 3, events[0].ID)
+}
+
+func Test_EventService_Create(t *testing.T) {
+	repo := &MockEventRepository{}
+	sink := &MockEventSink{}
+	service := event.NewService(repo, sink, logging.GetLogger(), newConfig())
+
+	ctx := context.Background()
+	dto := event.CreateEventDTO{
+		Name:     " storm ",
+		Kind:     environment.KindStorm,
+		Region:   region,
+		StartsAt: startsAt,
+		EndsAt:   startsAt.Add(6 * time.Hour),
+		RampUp:   "30m",
+		Decay:    "1h",
+		Shape:    environment.ShapeLinear,
+	}
+
+	repo.On("Create", ctx, storedEvent).Return(3, nil)
+	repo.On("FindAll", ctx).Return([]event.Event{event.NewEvent(event.SourceAPI, 3, storedEvent)}, nil)
+	sink.On("SetEvents", []environment.Event{configEvent, storedEvent}).Return()
+
+	created, err := service.Create(ctx, dto)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, created.ID)
+	assert.Equal(t, "storm", created.Name)
+	repo.AssertExpectations(t)
+	sink.AssertExpectations(t)
+}
+
+func Test_EventService_Create_Invalid(t *testing.T) {
+	repo := &MockEventRepository{}
+	service := event.NewService(repo, &MockEventSink{}, logging.GetLogger(), newConfig())
+
+	for _, dto := range []event.CreateEventDTO{
+		{Name: "storm", Kind: environment.KindStorm, StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour), RampUp: "soon"},
+		{Name: "storm", Kind: "tsunami", StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour)},
+		{Name: "storm", Kind: environment.KindStorm, StartsAt: startsAt, EndsAt: startsAt},
+	} {
+		_, err := service.Create(context.Background(), dto)
+
+		var appErr *apperror.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, apperror.ErrValidation.Code, appErr.Code)
+	}
+
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func Test_EventService_Delete_NotFound(t *testing.T) {
+	repo := &MockEventRepository{}
+	sink := &MockEventSink{}
+	service := event.NewService(repo, sink, logging.GetLogger(), newConfig())
+
+	ctx := context.Background()
+	repo.On("Delete", ctx, 5).Return(apperror.ErrNotFound)
+
+	err := service.Delete(ctx, 5)
+
+	assert.ErrorIs(t, err, apperror.ErrNotFound)
+	sink.AssertNotCalled(t, "SetEvents", mock.Anything)
+}
+
+func Test_EventService_SetConfigEvents(t *testing.T) {
+	repo := &MockEventRepository{}
+	sink := &MockEventSink{}
+	service := event.NewService(repo, sink, logging.GetLogger(), newConfig())
+
+	ctx := context.Background()
+	repo.On("FindAll", ctx).Return([]event.Event{}, nil)
+	sink.On("SetEvents", []environment.Event(nil)).Return()
+
+	assert.NoError(t, service.SetConfigEvents(ctx, nil))
+	sink.AssertExpectations(t)
+}
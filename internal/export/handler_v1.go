@@ -0,0 +1,146 @@
+package export
+
+import (
+	"fmt"
+	"net/http"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/sensor"
+	sensordata "sensors-generator/internal/sensorData"
+	"sensors-generator/pkg/logging"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	readingsPath = "api/v1/export/readings"
+)
+
+type handler struct {
+	exportService IExportService
+	logger        *logging.Logger
+}
+
+func NewHandler(exportService IExportService, logger *logging.Logger) *handler {
+	return &handler{
+		exportService: exportService,
+		logger:        logger,
+	}
+}
+
+func (h *handler) Register(router gin.IRouter) {
+	router.GET(readingsPath, h.ExportReadings)
+}
+
+// ExportReadings
+// @Summary Export readings
+// @Description Streams readings with codename, coordinates and detected spieces. Ordered by creation time.
+// @Description Values are calibrated unless raw is set, readings taken during maintenance are flagged.
+// @Tags Export
+// @Security ApiKeyAuth
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/octet-stream
+// @Param format query string false "csv (default), ndjson or parquet"
+// @Param gzip query bool false "Compress with gzip"
+// @Param codeName query string false "Codename of the sensor, e.g. 'alpha 1'"
+// @Param group query string false "Name of the group"
+// @Param from query int false "from"
+// @Param till query int false "till"
+// @Param limit query int false "Max number of readings"
+// @Param raw query bool false "Values before calibration"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /api/v1/export/readings [get]
+func (h *handler) ExportReadings(c *gin.Context) {
+	format, err := NewFormatFromString(c.DefaultQuery("format", string(FormatCSV)))
+	if err != nil {
+		c.Error(apperror.ErrorWithMessage(apperror.ErrBadRequest, err.Error()))
+		return
+	}
+
+	gzipped, err := strconv.ParseBool(c.DefaultQuery("gzip", "false"))
+	if err != nil {
+		c.Error(apperror.ErrBadRequest)
+		return
+	}
+
+	filters, err := filtersFromQuery(c)
+	if err != nil {
+		h.logger.LWithContext(c.Request.Context()).Errorf("Cannot parse filters, due to error: %v", err)
+		c.Error(err)
+		return
+	}
+
+	// Export may take longer than server write timeout, the request context deadline is used instead.
+	deadline, _ := c.Request.Context().Deadline()
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(deadline); err != nil {
+		h.logger.LWithContext(c.Request.Context()).Warnf("Cannot extend write deadline, due to error: %v", err)
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, format.FileName(gzipped)))
+
+	count, err := h.exportService.Export(c.Request.Context(), c.Writer, filters, Options{Format: format, Gzip: gzipped})
+	if err != nil {
+		if c.Writer.Written() {
+			// Status is already sent, so client only sees truncated file.
+			h.logger.LWithContext(c.Request.Context()).Errorf("Export was interrupted after %d readings.", count)
+			return
+		}
+		c.Header("Content-Disposition", "")
+		c.Error(err)
+	}
+}
+
+func filtersFromQuery(c *gin.Context) (sensordata.SensorDataFilters, error) {
+	filters := sensordata.SensorDataFilters{
+		GroupName: c.Query("group"),
+	}
+
+	if codeName, ok := c.GetQuery("codeName"); ok {
+		cdn, err := sensor.NewCodenameFromString(codeName)
+		if err != nil {
+			return filters, err
+		}
+		filters.CodeName = cdn
+	}
+
+	if from, ok := c.GetQuery("from"); ok {
+		fromTS, err := strconv.Atoi(from)
+		if err != nil {
+			return filters, apperror.ErrBadRequest
+		}
+		filters.FromDate = time.Unix(int64(fromTS), 0)
+	}
+
+	if till, ok := c.GetQuery("till"); ok {
+		tillTS, err := strconv.Atoi(till)
+		if err != nil {
+			return filters, apperror.ErrBadRequest
+		}
+		filters.TillDate = time.Unix(int64(tillTS), 0)
+	}
+
+	if limit, ok := c.GetQuery("limit"); ok {
+		limitN, err := strconv.Atoi(limit)
+		if err != nil || limitN < 0 {
+			return filters, apperror.ErrBadRequest
+		}
+		filters.Limit = limitN
+	}
+
+	if raw, ok := c.GetQuery("raw"); ok {
+		rawValues, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filters, apperror.ErrBadRequest
+		}
+		filters.Raw = rawValues
+	}
+
+	return filters, nil
+}
@@ -0,0 +1,11 @@
+package export
+
+import (
+	"context"
+	"io"
+	sensordata "sensors-generator/internal/sensorData"
+)
+
+type IExportService interface {
+	Export(ctx context.Context, w io.Writer, filters sensordata.SensorDataFilters, opts Options) (int, error)
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"fmt"
+	"sensors-generator/internal/sensor"
+	sensordata "sensors-generator/internal/sensorData"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatNDJSON  Format = "ndjson"
+	FormatParquet Format = "parquet"
+)
+
+func NewFormatFromString(format string) (Format, error) {
+	switch Format(format) {
+	case FormatCSV, FormatNDJSON, FormatParquet:
+		return Format(format), nil
+	default:
+		return "", fmt.Errorf("unknown export format: %s", format)
+	}
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// FileName returns name of the export file, .gz is added for gzipped exports.
+func (f Format) FileName(gzipped bool) string {
+	name := "readings." + string(f)
+	if gzipped {
+		name += ".gz"
+	}
+	return name
+}
+
+type Options struct {
+	Format Format
+	Gzip   bool
+}
+
+// Reading is one exported row.
+type Reading struct {
+	ID           int                `json:"id"`
+	CodeName     sensor.Codename    `json:"codename"`
+	Coords       sensor.Coordinates `json:"coordinates"`
+	Temperature  float32            `json:"temperature"`
+	Transparency uint8              `json:"transparency"`
+	Spieces      []string           `json:"spieces"`
+	Measurements map[string]float64 `json:"measurements,omitempty"`
+	// Maintenance is true when the reading was taken during maintenance of the sensor.
+	Maintenance bool      `json:"maintenance"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewReading(sd sensordata.SensorData) Reading {
+	spieces := make([]string, 0, len(sd.DetectedSpieces))
+	for _, spiece := range sd.DetectedSpieces {
+		spieces = append(spieces, spiece.Name)
+	}
+
+	return Reading{
+		ID:           sd.ID,
+		CodeName:     sd.CodeName,
+		Coords:       sd.Coords,
+		Temperature:  sd.Temperature,
+		Transparency: sd.Transparency,
+		Spieces:      spieces,
+		Measurements: sd.Measurements,
+		Maintenance:  sd.Maintenance,
+		CreatedAt:    sd.CreatedAt,
+	}
+}
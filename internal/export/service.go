@@ -0,0 +1,61 @@
+package export
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"sensors-generator/config"
+	sensordata "sensors-generator/internal/sensorData"
+	"sensors-generator/pkg/logging"
+)
+
+type service struct {
+	sensorDataService sensordata.ISensorDataService
+	logger            *logging.Logger
+	cfg               *config.Config
+}
+
+func NewService(sensorDataService sensordata.ISensorDataService,
+	logger *logging.Logger, cfg *config.Config) *service {
+	return &service{
+		sensorDataService: sensorDataService,
+		logger:            logger,
+		cfg:               cfg,
+	}
+}
+
+// Export streams readings selected by filters into w and returns the number of written readings.
+func (s *service) Export(ctx context.Context, w io.Writer, filters sensordata.SensorDataFilters, opts Options) (int, error) {
+	s.logger.LWithContext(ctx).Debugf("Export readings to %s.", opts.Format)
+
+	var gzipWriter *gzip.Writer
+	if opts.Gzip {
+		gzipWriter = gzip.NewWriter(w)
+		w = gzipWriter
+	}
+
+	rowWriter := NewRowWriter(opts.Format, w)
+	count := 0
+
+	err := s.sensorDataService.Iterate(ctx, filters, func(sd sensordata.SensorData) error {
+		count++
+		return rowWriter.Write(NewReading(sd))
+	})
+	if err != nil {
+		s.logger.LWithContext(ctx).Errorf("Export failed after %d readings, due to error: %v", count, err)
+		return count, err
+	}
+
+	if err := rowWriter.Close(); err != nil {
+		return count, err
+	}
+
+	if gzipWriter != nil {
+		if err := gzipWriter.Close(); err != nil {
+			return count, err
+		}
+	}
+
+	s.logger.LWithContext(ctx).Infof("Exported %d readings.", count)
+	return count, nil
+}
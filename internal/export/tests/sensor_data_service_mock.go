@@ -0,0 +1,67 @@
+package export
+
+import (
+	"context"
+	sensordata "sensors-generator/internal/sensorData"
+	"sensors-generator/internal/spiece"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSensorDataService struct {
+	mock.Mock
+	Readings []sensordata.SensorData
+}
+
+func (m *MockSensorDataService) GetAll(ctx context.Context, filters sensordata.SensorDataFilters) ([]sensordata.SensorData, error) {
+	args := m.Called(ctx, filters)
+	return args.Get(0).([]sensordata.SensorData), args.Error(1)
+}
+
+// Iterate passes Readings to fn before returning the mocked error.
+func (m *MockSensorDataService) Iterate(ctx context.Context, filters sensordata.SensorDataFilters, fn func(sensordata.SensorData) error) error {
+	args := m.Called(ctx, filters)
+	for _, reading := range m.Readings {
+		if err := fn(reading); err != nil {
+			return err
+		}
+	}
+	return args.Error(0)
+}
+
+func (m *MockSensorDataService) GetOneByID(ctx context.Context, id int, filters sensordata.SensorDataFilters) (*sensordata.SensorData, error) {
+	args := m.Called(ctx, id, filters)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*sensordata.SensorData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorDataService) Create(ctx context.Context, sensorData ...sensordata.CreateSensorDataDTO) ([]int, error) {
+	args := m.Called(ctx, sensorData)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockSensorDataService) AddDetectedSpieces(ctx context.Context, sensorDataID int, spieces ...spiece.Spiece) error {
+	args := m.Called(ctx, sensorDataID, spieces)
+	return args.Error(0)
+}
+
+func (m *MockSensorDataService) CreateWithSpieces(ctx context.Context, sensorData sensordata.CreateSensorDataDTO, spieces ...spiece.Spiece) (int, error) {
+	args := m.Called(ctx, sensorData, spieces)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSensorDataService) Publish(sensorData sensordata.SensorData) {
+	m.Called(sensorData)
+}
+
+func (m *MockSensorDataService) Subscribe(ctx context.Context, filters sensordata.SensorDataFilters) <-chan sensordata.Delivery {
+	args := m.Called(ctx, filters)
+	return args.Get(0).(<-chan sensordata.Delivery)
+}
+
+func (m *MockSensorDataService) GetLatestForSensors(ctx context.Context, sensorIDs []int, filters sensordata.SensorDataFilters) ([]sensordata.SensorData, error) {
+	args := m.Called(ctx, sensorIDs, filters)
+	return args.Get(0).([]sensordata.SensorData), args.Error(1)
+}
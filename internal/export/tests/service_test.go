@@ -0,0 +1,131 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"sensors-generator/internal/export"
+	"sensors-generator/internal/sensor"
+	sensordata "sensors-generator/internal/sensorData"
+	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var readings = []sensordata.SensorData{
+	{
+		ID:              1,
+		CodeName:        sensor.Codename{GroupName: "alpha", Index: 1},
+		Coords:          sensor.Coordinates{X: 1.5, Y: 2, Z: -3},
+		Temperature:     12.5,
+		Transparency:    80,
+		DetectedSpieces: []spiece.Spiece{{ID: 1, Name: "Atlantic cod"}, {ID: 2, Name: "Herring"}},
+		CreatedAt:       time.Date(2023, time.July, 1, 10, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:           2,
+		CodeName:     sensor.Codename{GroupName: "beta", Index: 3},
+		Coords:       sensor.Coordinates{X: 4, Y: 5, Z: -6},
+		Temperature:  9,
+		Transparency: 40,
+		Measurements: map[string]float64{"salinity": 34.5, "ph": 8.05},
+		Maintenance:  true,
+		CreatedAt:    time.Date(2023, time.July, 1, 10, 0, 5, 0, time.UTC),
+	},
+}
+
+func newService(err error) (*MockSensorDataService, export.IExportService) {
+	logging.Init("trace", true)
+	sensorDataService := &MockSensorDataService{Readings: readings}
+	sensorDataService.On("Iterate", context.Background(), sensordata.SensorDataFilters{}).Return(err)
+
+	return sensorDataService, export.NewService(sensorDataService, logging.GetLogger(), nil)
+}
+
+func Test_ExportService_CSV(t *testing.T) {
+	_, service := newService(nil)
+
+	var buf bytes.Buffer
+	count, err := service.Export(context.Background(), &buf, sensordata.SensorDataFilters{}, export.Options{Format: export.FormatCSV})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, "group_name", records[0][1])
+	assert.Equal(t, []string{"maintenance", "salinity", "pressure", "dissolved_oxygen", "ph"}, records[0][10:])
+	assert.Equal(t, []string{"1", "alpha", "1", "1.5", "2", "-3", "12.5", "80",
+		"Atlantic cod;Herring", "2023-07-01T10:00:00Z", "false", "", "", "", ""}, records[1])
+	assert.Equal(t, "", records[2][8])
+	assert.Equal(t, []string{"true", "34.5", "", "", "8.05"}, records[2][10:])
+}
+
+func Test_ExportService_NDJSONGzip(t *testing.T) {
+	_, service := newService(nil)
+
+	var buf bytes.Buffer
+	_, err := service.Export(context.Background(), &buf, sensordata.SensorDataFilters{},
+		export.Options{Format: export.FormatNDJSON, Gzip: true})
+	assert.NoError(t, err)
+
+	gzipReader, err := gzip.NewReader(&buf)
+	assert.NoError(t, err)
+
+	scanner := bufio.NewScanner(gzipReader)
+	lines := make([]export.Reading, 0)
+	for scanner.Scan() {
+		var reading export.Reading
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &reading))
+		lines = append(lines, reading)
+	}
+
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "beta", lines[1].CodeName.GroupName)
+	assert.Equal(t, []string{"Atlantic cod", "Herring"}, lines[0].Spieces)
+	assert.Equal(t, []string{}, lines[1].Spieces)
+	assert.Nil(t, lines[0].Measurements)
+	assert.Equal(t, map[string]float64{"salinity": 34.5, "ph": 8.05}, lines[1].Measurements)
+	assert.False(t, lines[0].Maintenance)
+	assert.True(t, lines[1].Maintenance)
+}
+
+func Test_ExportService_Parquet(t *testing.T) {
+	_, service := newService(nil)
+
+	var buf bytes.Buffer
+	_, err := service.Export(context.Background(), &buf, sensordata.SensorDataFilters{}, export.Options{Format: export.FormatParquet})
+	assert.NoError(t, err)
+
+	file, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), file.NumRows())
+
+	rows := make([]parquet.Row, 2)
+	reader := parquet.NewReader(file)
+	n, err := reader.ReadRows(rows)
+	if !errors.Is(err, io.EOF) {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, n)
+}
+
+func Test_ExportService_IterateError(t *testing.T) {
+	_, service := newService(errors.New("conn reset"))
+
+	var buf bytes.Buffer
+	count, err := service.Export(context.Background(), &buf, sensordata.SensorDataFilters{}, export.Options{Format: export.FormatCSV})
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, count)
+}
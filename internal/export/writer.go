@@ -0,0 +1,160 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sensors-generator/pkg/measurement"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetRowGroupSize bounds the number of rows kept in memory by parquet writer.
+const parquetRowGroupSize = 10000
+
+// RowWriter writes readings one by one, Close flushes buffered data but does not close underlying writer.
+type RowWriter interface {
+	Write(reading Reading) error
+	Close() error
+}
+
+func NewRowWriter(format Format, w io.Writer) RowWriter {
+	switch format {
+	case FormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}
+	case FormatParquet:
+		return &parquetWriter{writer: parquet.NewGenericWriter[parquetRow](w)}
+	default:
+		return &csvWriter{writer: csv.NewWriter(w)}
+	}
+}
+
+var csvHeader = append([]string{"id", "group_name", "index", "x", "y", "z", "temperature", "transparency", "spieces", "created_at",
+	"maintenance"}, additionalMeasurements()...)
+
+// additionalMeasurements returns names of measurement types exported in their own columns after maintenance.
+func additionalMeasurements() []string {
+	names := make([]string, 0)
+	for _, name := range measurement.Names() {
+		if !measurement.IsBase(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+type csvWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+// Write puts spieces into one column separated by ';', measurements the sensor does not carry are left empty.
+func (w *csvWriter) Write(reading Reading) error {
+	if !w.headerWritten {
+		if err := w.writer.Write(csvHeader); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+
+	record := []string{
+		strconv.Itoa(reading.ID),
+		reading.CodeName.GroupName,
+		strconv.Itoa(reading.CodeName.Index),
+		strconv.FormatFloat(reading.Coords.X, 'f', -1, 64),
+		strconv.FormatFloat(reading.Coords.Y, 'f', -1, 64),
+		strconv.FormatFloat(reading.Coords.Z, 'f', -1, 64),
+		strconv.FormatFloat(float64(reading.Temperature), 'f', -1, 32),
+		strconv.Itoa(int(reading.Transparency)),
+		strings.Join(reading.Spieces, ";"),
+		reading.CreatedAt.UTC().Format(time.RFC3339Nano),
+		strconv.FormatBool(reading.Maintenance),
+	}
+	for _, name := range csvHeader[len(record):] {
+		value, ok := reading.Measurements[name]
+		if !ok {
+			record = append(record, "")
+			continue
+		}
+		record = append(record, strconv.FormatFloat(value, 'f', -1, 64))
+	}
+
+	return w.writer.Write(record)
+}
+
+func (w *csvWriter) Close() error {
+	if !w.headerWritten {
+		if err := w.writer.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(reading Reading) error {
+	return w.encoder.Encode(reading)
+}
+
+func (w *ndjsonWriter) Close() error {
+	return nil
+}
+
+type parquetRow struct {
+	ID           int64              `parquet:"id"`
+	GroupName    string             `parquet:"group_name,dict"`
+	Index        int32              `parquet:"index"`
+	X            float64            `parquet:"x"`
+	Y            float64            `parquet:"y"`
+	Z            float64            `parquet:"z"`
+	Temperature  float32            `parquet:"temperature"`
+	Transparency int32              `parquet:"transparency"`
+	Spieces      []string           `parquet:"spieces,list"`
+	Measurements map[string]float64 `parquet:"measurements"`
+	Maintenance  bool               `parquet:"maintenance"`
+	CreatedAt    int64              `parquet:"created_at,timestamp(millisecond)"`
+}
+
+type parquetWriter struct {
+	writer   *parquet.GenericWriter[parquetRow]
+	buffered int
+}
+
+func (w *parquetWriter) Write(reading Reading) error {
+	if _, err := w.writer.Write([]parquetRow{{
+		ID:           int64(reading.ID),
+		GroupName:    reading.CodeName.GroupName,
+		Index:        int32(reading.CodeName.Index),
+		X:            reading.Coords.X,
+		Y:            reading.Coords.Y,
+		Z:            reading.Coords.Z,
+		Temperature:  reading.Temperature,
+		Transparency: int32(reading.Transparency),
+		Spieces:      reading.Spieces,
+		Measurements: reading.Measurements,
+		Maintenance:  reading.Maintenance,
+		CreatedAt:    reading.CreatedAt.UnixMilli(),
+	}}); err != nil {
+		return err
+	}
+
+	w.buffered++
+	if w.buffered >= parquetRowGroupSize {
+		w.buffered = 0
+		return w.writer.Flush()
+	}
+
+	return nil
+}
+
+func (w *parquetWriter) Close() error {
+	return w.writer.Close()
+}
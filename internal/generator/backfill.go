@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sensors-generator/internal/importer"
+	"sensors-generator/internal/sensor"
+	sensordata "sensors-generator/internal/sensorData"
+	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/environment"
+	"sensors-generator/pkg/logging"
+	"time"
+)
+
+// IReadingsWriter writes generated history, import repository writes every call in one transaction.
+type IReadingsWriter interface {
+	InsertReadings(ctx context.Context, readings []importer.Reading, batchSize int) (int, error)
+}
+
+// Backfiller generates readings of the past, as if the data generator was running then.
+type Backfiller struct {
+	services  Services
+	randomGen IRandomGenerator
+	writer    IReadingsWriter
+	history   importer.IHistoryService
+	batchSize int
+	logger    *logging.Logger
+	rand      *rand.Rand
+	currents  environment.Currents
+}
+
+func NewBackfiller(services Services, randomGen IRandomGenerator, writer IReadingsWriter,
+	history importer.IHistoryService, batchSize int, logger *logging.Logger) *Backfiller {
+	return &Backfiller{
+		services:  services,
+		randomGen: randomGen,
+		writer:    writer,
+		history:   history,
+		batchSize: batchSize,
+		logger:    logger,
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// SetCurrents sets currents which carry drifting sensors.
+func (b *Backfiller) SetCurrents(currents environment.Currents) {
+	b.currents = currents
+}
+
+// Backfill writes readings of every sensor each DataOutputRate seconds in [from, till) and returns their number.
+// Readings are committed by batches, so interrupted backfill keeps already written batches,
+// their hours and days are rolled up again in both cases. From should not be older than retention keeps
+// raw readings.
+// Mobile sensors start their trajectories from home at from, unless the trajectory starts later.
+func (b *Backfiller) Backfill(ctx context.Context, sensors []sensor.Sensor, from, till time.Time) (written int, err error) {
+	if !from.Before(till) {
+		return 0, fmt.Errorf("from %s should be before till %s", from, till)
+	}
+
+	if b.history != nil {
+		if limit := b.history.HistoryLimit(time.Now()); from.Before(limit) {
+			return 0, fmt.Errorf("from %s is older than retention keeps raw readings, the earliest is %s",
+				from.Format(time.RFC3339), limit.Format(time.RFC3339))
+		}
+
+		if _, err := b.history.EnsurePartitions(ctx, from, till); err != nil {
+			return 0, err
+		}
+
+		// Readings behind rollup watermarks would never be rolled up and retention would delete them.
+		defer func() {
+			if written == 0 {
+				return
+			}
+			if rerollErr := b.history.Reroll(context.WithoutCancel(ctx), from); rerollErr != nil && err == nil {
+				err = rerollErr
+			}
+		}()
+	}
+
+	spieces, err := b.services.SpieceService.GetAll(ctx, spiece.SpieceFilters{})
+	if err != nil {
+		return 0, err
+	}
+
+	batchSize := b.batchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	batch := make([]importer.Reading, 0, batchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		n, err := b.writer.InsertReadings(ctx, batch, batchSize)
+		if err != nil {
+			return err
+		}
+
+		written += n
+		batch = batch[:0]
+		b.logger.LWithContext(ctx).Debugf("Backfilled %d readings.", written)
+		return nil
+	}
+
+	for _, sens := range sensors {
+		if sens.DataOutputRate <= 0 {
+			b.logger.LWithContext(ctx).Warnf("Sensor %s %d has no data output rate, skip it.",
+				sens.CodeName.GroupName, sens.CodeName.Index)
+			continue
+		}
+
+		mover := newMover(sens, b.currents, rand.New(rand.NewSource(b.rand.Int63())))
+
+		// Rate is kept in seconds, the same way as the data generator reads it.
+		for t := from; t.Before(till); t = t.Add(sens.DataOutputRate * time.Second) {
+			sens := located(sens, mover, t)
+			temperature, transparency := b.randomGen.GenerateReading(sens, t)
+			measurements := b.randomGen.GenerateMeasurements(sens, temperature)
+			// Spieces depend on the water, calibration changes only values which are written.
+			detected := DetectSpieces(b.rand, spieces, Conditions{
+				Depth:        sens.Coords.Z,
+				Temperature:  temperature,
+				Transparency: transparency,
+				Abundance:    b.randomGen.SpieceFactors(sens, t),
+			})
+
+			sdata := sensordata.CreateSensorDataDTO{
+				SensorID:     sens.ID,
+				Temperature:  temperature,
+				Transparency: transparency,
+				Measurements: measurements,
+				CreatedAt:    t,
+			}.Calibrated(&sens)
+
+			reading := importer.Reading{
+				CodeName:     sens.CodeName,
+				SensorID:     sens.ID,
+				Temperature:  sdata.Temperature,
+				Transparency: sdata.Transparency,
+				Measurements: sdata.Measurements,
+				Raw:          sdata.Raw,
+				Spieces:      detected,
+				CreatedAt:    t,
+			}
+			if mover != nil {
+				reading.Coords = &sensor.Coordinates{X: sens.Coords.X, Y: sens.Coords.Y, Z: sens.Coords.Z}
+			}
+			batch = append(batch, reading)
+
+			if len(batch) >= batchSize {
+				if err := flush(); err != nil {
+					return written, err
+				}
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return written, err
+	}
+
+	return written, nil
+}
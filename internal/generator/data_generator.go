@@ -2,78 +2,547 @@ package generator
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
+	"reflect"
+	"sensors-generator/config"
 	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/groundtruth"
 	"sensors-generator/internal/sensor"
 	sensordata "sensors-generator/internal/sensorData"
 	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/clock"
+	"sensors-generator/pkg/fault"
 	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/trajectory"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// DataGenerator writes readings of all sensors. A scheduler keeps sensors in a priority queue by the time
+// of their next reading and dispatches due sensors to a bounded pool of workers. Every sensor has its own
+// random source, so workers do not share a lock. Workers put readings to the write queue, which writes
+// them to Postgres and retries them during outages.
 type DataGenerator struct {
 	services  Services
 	randomGen IRandomGenerator
-	sync.Mutex
+	catalogue *SpieceCatalogue
+	// clock schedules readings and stamps them, it is real, accelerated or stepped.
+	clock clock.Clock
+	cfg   *config.Config
+	stats *lagStats
+	queue *writeQueue
+
+	stateMu   sync.Mutex
+	ctx       context.Context
+	cancel    context.CancelFunc
+	sensors   map[int]*sensorState
+	scheduler *scheduler
+	workers   int
+	running   sync.WaitGroup
+
+	faults atomic.Pointer[fault.Config]
+
+	// partitionsTill is when premade partitions end, readings of later times premake more first.
+	partitionsMu   sync.Mutex
+	partitionsTill time.Time
+}
+
+// sensorState is a sensor of the running generator. Sensor, injector and mover are replaced on Refresh,
+// rand draws detected spieces and start jitter and is used only by the worker which holds the sensor,
+// so is the mover. Injector is nil when the sensor has no fault profile, mover is nil for static sensors.
+type sensorState struct {
+	sensor   atomic.Pointer[sensor.Sensor]
+	injector atomic.Pointer[fault.Injector]
+	mover    atomic.Pointer[trajectory.Mover]
+	rand     *rand.Rand
+	removed  atomic.Bool
 }
 
-func NewDataGenerator(services Services, randomGen IRandomGenerator) *DataGenerator {
-	return &DataGenerator{
+// RefreshReport counts sensors changed by Refresh.
+type RefreshReport struct {
+	Started int `json:"started"`
+	Updated int `json:"updated"`
+	Stopped int `json:"stopped"`
+}
+
+func NewDataGenerator(services Services, randomGen IRandomGenerator, clk clock.Clock, cfg *config.Config) *DataGenerator {
+	dg := &DataGenerator{
 		services:  services,
 		randomGen: randomGen,
+		catalogue: NewSpieceCatalogue(services.SpieceService, spieceCatalogueCheckEvery),
+		clock:     clk,
+		cfg:       cfg,
+		stats:     newLagStats(),
+	}
+
+	queueCfg := cfg.WriteQueueConfig
+	var reroll func(ctx context.Context, from time.Time) error
+	if services.RollupService != nil {
+		reroll = services.RollupService.Reroll
 	}
+	dg.queue = newWriteQueue(dg.store, reroll, queueCfg.Size, queueCfg.Writers, queueCfg.WhenFull,
+		queueCfg.RetryMin, queueCfg.RetryMax, queueCfg.SpillFile)
+
+	return dg
+}
+
+// SetFaultConfig sets fault profiles of sensors, running sensors get them on Refresh.
+func (dg *DataGenerator) SetFaultConfig(cfg fault.Config) {
+	dg.faults.Store(&cfg)
 }
 
+// Generate starts the scheduler and generator_config.workers workers.
 func (dg *DataGenerator) Generate() error {
+	dg.stateMu.Lock()
+	defer dg.stateMu.Unlock()
+
+	if dg.cancel != nil {
+		return apperror.ErrorWithMessage(apperror.ErrBadRequest, "Generator is already running.")
+	}
+
 	sensors, err := dg.services.SensorService.GetAll(context.Background(), sensor.SensorFilters{})
 	if err != nil {
 		return apperror.ErrInternalSystem
 	}
 
-	for _, sensor := range sensors {
-		go dg.generateData(sensor)
+	dg.queue.Start()
+
+	dg.ctx, dg.cancel = context.WithCancel(context.Background())
+	dg.sensors = make(map[int]*sensorState, len(sensors))
+	dg.scheduler = newScheduler(dg.clock)
+	dg.workers = dg.cfg.GeneratorConfig.Workers
+
+	now := dg.clock.Now()
+	for _, sens := range sensors {
+		dg.addSensor(sens, now)
+	}
+	dg.resumeMovers(dg.ctx, sensors)
+
+	due := make(chan dispatch)
+	dg.running.Add(1 + dg.workers)
+	go func(ctx context.Context, scheduler *scheduler) {
+		defer dg.running.Done()
+		scheduler.Run(ctx, due)
+	}(dg.ctx, dg.scheduler)
+	for i := 0; i < dg.workers; i++ {
+		go func(ctx context.Context, scheduler *scheduler) {
+			defer dg.running.Done()
+			dg.work(ctx, scheduler, due)
+		}(dg.ctx, dg.scheduler)
 	}
 
 	return nil
 }
 
-func (dg *DataGenerator) generateData(sensor sensor.Sensor) {
+// Refresh re-reads sensors of the running generator: new output rates, coordinates, trajectories, calibrations
+// and fault profiles are applied, new sensors are scheduled and deleted ones are removed. Other sensors keep
+// their schedule, so faults in progress are not reset. Sensors with a new trajectory or coordinates start
+// the trajectory from home. Cached spieces are checked with the next reading.
+func (dg *DataGenerator) Refresh(ctx context.Context) (RefreshReport, error) {
+	dg.stateMu.Lock()
+	defer dg.stateMu.Unlock()
+
+	dg.catalogue.Invalidate()
+
+	var report RefreshReport
+	if dg.cancel == nil {
+		return report, nil
+	}
+
+	sensors, err := dg.services.SensorService.GetAll(ctx, sensor.SensorFilters{})
+	if err != nil {
+		return report, apperror.ErrInternalSystem
+	}
+
+	now := dg.clock.Now()
+	found := make(map[int]bool, len(sensors))
+	for _, sens := range sensors {
+		found[sens.ID] = true
+
+		state, ok := dg.sensors[sens.ID]
+		if !ok {
+			dg.addSensor(sens, now)
+			report.Started++
+			continue
+		}
+
+		injectorChanged := dg.refreshInjector(state, sens)
+
+		// Measurements and calibrations change readings too, so the sensor is replaced on any change.
+		old := state.sensor.Load()
+		if reflect.DeepEqual(*old, sens) {
+			if injectorChanged {
+				report.Updated++
+			}
+			continue
+		}
+
+		if old.Coords != sens.Coords || !reflect.DeepEqual(old.Trajectory, sens.Trajectory) {
+			state.mover.Store(dg.newMover(sens))
+		}
+
+		s := sens
+		state.sensor.Store(&s)
+		if old.DataOutputRate != sens.DataOutputRate {
+			dg.scheduler.Retime(sens.ID, interval(&sens))
+		}
+		report.Updated++
+	}
+
+	for id, state := range dg.sensors {
+		if !found[id] {
+			state.removed.Store(true)
+			dg.scheduler.Remove(id)
+			delete(dg.sensors, id)
+			report.Stopped++
+		}
+	}
+
+	return report, nil
+}
+
+// addSensor schedules the first reading of the sensor, with start_jitter it is spread over the output rate,
+// so sensors do not write at once. It should be called with stateMu locked.
+func (dg *DataGenerator) addSensor(sens sensor.Sensor, now time.Time) {
+	state := &sensorState{rand: rand.New(rand.NewSource(dg.seed(sens)))}
+	state.sensor.Store(&sens)
+	state.injector.Store(dg.newInjector(sens))
+	state.mover.Store(dg.newMover(sens))
+	dg.sensors[sens.ID] = state
+
+	at := now
+	if dg.cfg.GeneratorConfig.StartJitter {
+		at = at.Add(time.Duration(state.rand.Int63n(int64(interval(&sens)))))
+	}
+	dg.scheduler.Schedule(sens.ID, state, time.Time{}, at)
+}
+
+// seed of the random source of the sensor, with generator_config.seed sensors draw the same values on every run.
+func (dg *DataGenerator) seed(sens sensor.Sensor) int64 {
+	if dg.cfg.GeneratorConfig.Seed != 0 {
+		return dg.cfg.GeneratorConfig.Seed + int64(sens.ID)
+	}
+	return time.Now().UnixNano() + int64(sens.ID)
+}
+
+// newMover returns nil for static sensors.
+func (dg *DataGenerator) newMover(sens sensor.Sensor) *trajectory.Mover {
+	return newMover(sens, dg.cfg.EnvironmentConfig.Currents, rand.New(rand.NewSource(dg.seed(sens)+moverSeedShift)))
+}
+
+// Stop stops the scheduler and workers. Readings which are being written are finished.
+func (dg *DataGenerator) Stop() {
+	dg.stateMu.Lock()
+	defer dg.stateMu.Unlock()
+
+	if dg.cancel != nil {
+		dg.cancel()
+		dg.cancel = nil
+		dg.sensors = nil
+	}
+}
+
+// Wait blocks till workers of the stopped generator finish their last readings.
+func (dg *DataGenerator) Wait() {
+	dg.running.Wait()
+}
+
+// Close writes queued readings till ctx is done, the rest spill to write_queue_config.spill_file
+// and are written on the next start. It should be called after Wait, the generator cannot be started again.
+func (dg *DataGenerator) Close(ctx context.Context) {
+	dg.queue.Close(ctx)
+}
+
+func (dg *DataGenerator) IsRunning() bool {
+	dg.stateMu.Lock()
+	defer dg.stateMu.Unlock()
+
+	return dg.cancel != nil
+}
+
+// Stats returns the number of sensors, workers, lag of readings and counters of the write queue.
+func (dg *DataGenerator) Stats() Stats {
+	dg.stateMu.Lock()
+	stats := Stats{Running: dg.cancel != nil}
+	if stats.Running {
+		stats.Sensors = len(dg.sensors)
+		stats.Queued = dg.scheduler.Len()
+		stats.Workers = dg.workers
+	}
+	dg.stateMu.Unlock()
+
+	dg.stats.fill(&stats)
+	stats.Writes = dg.queue.Stats()
+	return stats
+}
+
+// work writes readings of due sensors and schedules their next readings DataOutputRate seconds later.
+// When the clock is ahead of the schedule, e.g. a stepped clock was advanced by an hour,
+// readings of the missed times are written one after another.
+func (dg *DataGenerator) work(ctx context.Context, scheduler *scheduler, due <-chan dispatch) {
 	for {
-		dg.Lock()
-		sdata := sensordata.CreateSensorDataDTO{
-			SensorID:     sensor.ID,
-			Temperature:  dg.randomGen.GenerateTemperatureBasedOnZ(sensor.Coords.Z),
-			Transparency: dg.randomGen.GenerateTransparency(),
+		var d dispatch
+		select {
+		case <-ctx.Done():
+			return
+		case d = <-due:
 		}
-		dg.Unlock()
 
-		sensorDataIDS, err := dg.services.SensorDataService.Create(context.Background(), sdata)
-		if err != nil {
-			logging.GetLogger().Errorf("Sensor data generetor error: %v", err)
+		state := d.state
+		if state.removed.Load() {
+			continue
 		}
-		if err := dg.generateDetectedSpieces(sensorDataIDS[0], dg.services); err != nil {
-			logging.GetLogger().Errorf("Sensor data spieces generetor error: %v", err)
+
+		dg.stats.observe(dg.clock.Now().Sub(d.at))
+		dg.stats.setBusy(1)
+		dg.generateReading(ctx, state, d.at)
+		dg.stats.setBusy(-1)
+
+		if !state.removed.Load() {
+			sens := state.sensor.Load()
+			scheduler.Schedule(sens.ID, state, d.at, d.at.Add(interval(sens)))
 		}
-		time.Sleep(sensor.DataOutputRate * time.Second)
 	}
 }
 
-func (dg *DataGenerator) generateDetectedSpieces(sensorDataID int, services Services) error {
-	spieces, err := services.SpieceService.GetAll(context.Background(), spiece.SpieceFilters{})
+// generateReading samples the water where the sensor is at the time, mobile sensors are moved first.
+func (dg *DataGenerator) generateReading(ctx context.Context, state *sensorState, at time.Time) {
+	here := located(*state.sensor.Load(), state.mover.Load(), at)
+	sens := &here
+
+	spieces, err := dg.catalogue.Get(context.Background())
+	if err != nil {
+		logging.GetLogger().Errorf("Sensor data spieces generetor error: %v", err)
+	}
+
+	dg.ensurePartitions(ctx, at)
+
+	sdata := sensordata.CreateSensorDataDTO{SensorID: sens.ID, CreatedAt: at}
+	sdata.Temperature, sdata.Transparency = dg.randomGen.GenerateReading(*sens, at)
+	sdata.Measurements = dg.randomGen.GenerateMeasurements(*sens, sdata.Temperature)
+	// Spieces depend on the water, so they are drawn before faults change the reading.
+	detectedSpieces := dg.detectSpieces(state.rand, sens, sdata, spieces, at)
+
+	if injector := state.injector.Load(); injector != nil {
+		dg.writeWithFaults(ctx, sens, injector, sdata, detectedSpieces)
+	} else {
+		dg.write(ctx, sens, sdata, detectedSpieces, false)
+	}
+}
+
+// ensurePartitions premakes partitions when the clock passes premade ones, so readings of an accelerated
+// or stepped clock do not fail. Errors are logged, the retention job tries again.
+func (dg *DataGenerator) ensurePartitions(ctx context.Context, at time.Time) {
+	if dg.services.PartitionService == nil {
+		return
+	}
+
+	dg.partitionsMu.Lock()
+	defer dg.partitionsMu.Unlock()
+
+	if at.Before(dg.partitionsTill) {
+		return
+	}
+
+	till, created, err := dg.services.PartitionService.Premake(ctx, at)
+	if err != nil {
+		logging.GetLogger().Errorf("Cannot premake partitions for %s, due to error: %v", at.Format(time.RFC3339), err)
+		return
+	}
+	if len(created) > 0 {
+		logging.GetLogger().Infof("Created partitions ahead of the clock: %s.", strings.Join(created, ", "))
+	}
+	dg.partitionsTill = till
+}
+
+// detectSpieces draws spieces of the reading with the random source of the sensor,
+// schools come from the population simulation when it runs.
+func (dg *DataGenerator) detectSpieces(rnd *rand.Rand, sens *sensor.Sensor, sdata sensordata.CreateSensorDataDTO,
+	spieces []spiece.Spiece, at time.Time) []spiece.Spiece {
+	conditions := Conditions{
+		Depth:        sens.Coords.Z,
+		Temperature:  sdata.Temperature,
+		Transparency: sdata.Transparency,
+		Abundance:    dg.randomGen.SpieceFactors(*sens, at),
+	}
+
+	populationService := dg.services.PopulationService
+	if populationService == nil {
+		return DetectSpieces(rnd, spieces, conditions)
+	}
+
+	populationService.Observe(sens.ID, sens.Coords, sdata.Temperature)
+	return DetectWithSchools(rnd, spieces, conditions, populationService.Detect(sens.Coords))
+}
+
+// write stores the position of a mobile sensor with the reading, calibrates it and puts it to the write queue, faults are recorded once the reading is written.
+// Faults happen to the hardware, so they change raw values.
+func (dg *DataGenerator) write(ctx context.Context, sens *sensor.Sensor, sdata sensordata.CreateSensorDataDTO,
+	detectedSpieces []spiece.Spiece, late bool, faults ...fault.Fault) {
+	coords := sens.Coords
+	// Static sensors are always at home, their readings keep no position.
+	if sens.Trajectory != nil {
+		sdata.Coords = &coords
+	}
+
+	dg.queue.Push(ctx, pendingReading{
+		SensorID: sens.ID,
+		CodeName: sens.CodeName,
+		Coords:   coords,
+		Reading:  sdata.Calibrated(sens),
+		Spieces:  detectedSpieces,
+		Faults:   faults,
+		Late:     late,
+	})
+}
+
+// store writes the reading with its detected spieces at once, publishes it and records its faults.
+// It is called by writers of the write queue, on error the reading is retried from the step which failed.
+func (dg *DataGenerator) store(ctx context.Context, reading *pendingReading) error {
+	if reading.Started || reading.ID != 0 {
+		return dg.recordFaults(ctx, reading)
+	}
+
+	id, err := dg.services.SensorDataService.CreateWithSpieces(ctx, reading.Reading, reading.Spieces...)
 	if err != nil {
 		return err
 	}
+	reading.ID = id
+
+	dg.services.SensorDataService.Publish(sensordata.SensorData{
+		ID:              id,
+		SensorID:        reading.SensorID,
+		CodeName:        reading.CodeName,
+		Coords:          reading.Coords,
+		Temperature:     reading.Reading.Temperature,
+		Transparency:    reading.Reading.Transparency,
+		Measurements:    reading.Reading.Measurements,
+		Raw:             reading.Reading.Raw,
+		DetectedSpieces: reading.Spieces,
+		CreatedAt:       reading.Reading.CreatedAt,
+	})
 
-	detectedSpieces := make([]spiece.Spiece, 0)
+	return dg.recordFaults(ctx, reading)
+}
 
-	count := rand.Intn(50)
+// writeWithFaults injects faults into the reading and records them. Delayed readings are written
+// by a separate goroutine, so the schedule of the sensor is kept. They are dropped when the generator stops.
+func (dg *DataGenerator) writeWithFaults(ctx context.Context, sens *sensor.Sensor,
+	injector *fault.Injector, sdata sensordata.CreateSensorDataDTO, detectedSpieces []spiece.Spiece) {
+	decision := injector.Apply(fault.Reading{
+		Temperature:  sdata.Temperature,
+		Transparency: sdata.Transparency,
+		At:           sdata.CreatedAt,
+	})
 
-	for i := 0; i < count; i++ {
-		index := rand.Intn(len(spieces))
-		detectedSpieces = append(detectedSpieces, spieces[index])
+	if len(decision.Started) > 0 && dg.services.InjectedFaultService != nil {
+		dg.queue.Push(ctx, pendingReading{
+			SensorID: sens.ID,
+			CodeName: sens.CodeName,
+			Faults:   decision.Started,
+			Started:  true,
+		})
+	}
+	if decision.Skip {
+		return
+	}
+
+	// Delayed and out-of-order readings are written after the time they are stamped with.
+	late := decision.Delay > 0 || !decision.Reading.At.Equal(sdata.CreatedAt)
+
+	sdata.Temperature = decision.Reading.Temperature
+	sdata.Transparency = decision.Reading.Transparency
+	sdata.CreatedAt = decision.Reading.At
+
+	write := func() {
+		dg.write(ctx, sens, sdata, detectedSpieces, late, decision.Faults...)
+
+		if decision.Duplicate != nil {
+			// The copy has the same values and spieces, only its id differs.
+			dg.write(ctx, sens, sdata, detectedSpieces, late, *decision.Duplicate)
+		}
+	}
+
+	if decision.Delay <= 0 {
+		write()
+		return
+	}
+
+	dg.running.Add(1)
+	go func() {
+		defer dg.running.Done()
+
+		timer := dg.clock.NewTimer(decision.Delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+		case <-timer.C():
+			write()
+		}
+	}()
+}
+
+// recordFaults records faults of the reading one by one and forgets recorded ones,
+// so a retry after an error records only the rest.
+func (dg *DataGenerator) recordFaults(ctx context.Context, reading *pendingReading) error {
+	if dg.services.InjectedFaultService == nil {
+		return nil
+	}
+
+	for len(reading.Faults) > 0 {
+		injectedFault := groundtruth.CreateInjectedFaultDTO{SensorID: reading.SensorID, Fault: reading.Faults[0]}
+		if !reading.Started {
+			id, createdAt := reading.ID, reading.Reading.CreatedAt
+			injectedFault.SensorDataID = &id
+			injectedFault.SensorDataCreatedAt = &createdAt
+		}
+
+		if err := dg.services.InjectedFaultService.Record(ctx, injectedFault); err != nil {
+			return err
+		}
+		reading.Faults = reading.Faults[1:]
 	}
 
-	services.SensorDataService.AddDetectedSpieces(context.Background(), sensorDataID, detectedSpieces...)
 	return nil
 }
+
+// newInjector returns nil when faults are disabled or no profile matches the sensor.
+// With a seed every sensor gets the same faults on every run.
+func (dg *DataGenerator) newInjector(sens sensor.Sensor) *fault.Injector {
+	cfg := dg.faults.Load()
+	if cfg == nil {
+		return nil
+	}
+
+	profile, ok := cfg.ProfileFor(sens.CodeName.GroupName, fmt.Sprintf("%s %d", sens.CodeName.GroupName, sens.CodeName.Index))
+	if !ok {
+		return nil
+	}
+
+	seed := time.Now().UnixNano()
+	if cfg.Seed != 0 {
+		seed = cfg.Seed + int64(sens.ID)
+	}
+
+	return fault.NewInjector(profile, rand.New(rand.NewSource(seed)))
+}
+
+// refreshInjector replaces the injector when the profile of the sensor changed and reports it.
+func (dg *DataGenerator) refreshInjector(state *sensorState, sens sensor.Sensor) bool {
+	injector := dg.newInjector(sens)
+	old := state.injector.Load()
+
+	switch {
+	case old == nil && injector == nil:
+		return false
+	case old != nil && injector != nil && reflect.DeepEqual(old.Profile(), injector.Profile()):
+		return false
+	}
+
+	state.injector.Store(injector)
+	return true
+}
@@ -0,0 +1,166 @@
+package generator
+
+import (
+	"net/http"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/pkg/clock"
+	"sensors-generator/pkg/logging"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	generatorPath = "api/v1/generator"
+	startPath     = "/start"
+	stopPath      = "/stop"
+	statusPath    = "/status"
+	statsPath     = "/stats"
+	clockPath     = "/clock"
+	advancePath   = "/clock/advance"
+)
+
+type handler struct {
+	generator IControlledGenerator
+	clock     clock.Clock
+	logger    *logging.Logger
+}
+
+func NewHandler(generator IControlledGenerator, clk clock.Clock, logger *logging.Logger) *handler {
+	return &handler{
+		generator: generator,
+		clock:     clk,
+		logger:    logger,
+	}
+}
+
+func (h *handler) Register(router gin.IRouter) {
+	generator := router.Group(generatorPath)
+	{
+		generator.GET(statusPath, h.Status)
+		generator.GET(statsPath, h.Stats)
+		generator.POST(startPath, h.Start)
+		generator.POST(stopPath, h.Stop)
+		generator.GET(clockPath, h.Clock)
+		generator.POST(advancePath, h.Advance)
+	}
+}
+
+// Status
+// @Summary Generator status
+// @Tags Generator
+// @Security ApiKeyAuth
+// @Success 200
+// @Failure 401
+// @Failure 403
+// @Router /api/v1/generator/status [get]
+func (h *handler) Status(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"running": h.generator.IsRunning()})
+}
+
+// Stats
+// @Summary Generator stats
+// @Description Sensors, queued sensors, busy workers, written readings and scheduling lag: how late readings start
+// @Description after their scheduled time. Lag buckets count readings by lag since the app started.
+// @Tags Generator
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} Stats
+// @Failure 401
+// @Failure 403
+// @Router /api/v1/generator/stats [get]
+func (h *handler) Stats(c *gin.Context) {
+	c.JSON(http.StatusOK, h.generator.Stats())
+}
+
+// Start
+// @Summary Start generator
+// @Tags Generator
+// @Security ApiKeyAuth
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /api/v1/generator/start [post]
+func (h *handler) Start(c *gin.Context) {
+	if err := h.generator.Generate(); err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.logger.LWithContext(c.Request.Context()).Info("Generator was started.")
+	c.JSON(http.StatusOK, gin.H{"running": true})
+}
+
+// Stop
+// @Summary Stop generator
+// @Tags Generator
+// @Security ApiKeyAuth
+// @Success 200
+// @Failure 401
+// @Failure 403
+// @Router /api/v1/generator/stop [post]
+func (h *handler) Stop(c *gin.Context) {
+	h.generator.Stop()
+
+	h.logger.LWithContext(c.Request.Context()).Info("Generator was stopped.")
+	c.JSON(http.StatusOK, gin.H{"running": false})
+}
+
+// Clock
+// @Summary Generator clock
+// @Description Mode (real, accelerated or stepped), current time of the generator and speed of accelerated time.
+// @Tags Generator
+// @Security ApiKeyAuth
+// @Success 200
+// @Failure 401
+// @Failure 403
+// @Router /api/v1/generator/clock [get]
+func (h *handler) Clock(c *gin.Context) {
+	c.JSON(http.StatusOK, h.clockStatus())
+}
+
+// Advance
+// @Summary Advance stepped clock
+// @Description Moves the stepped clock forward, readings of the passed time are written at once.
+// @Tags Generator
+// @Security ApiKeyAuth
+// @Param advance body AdvanceClockDTO true "Duration, e.g. 1h"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /api/v1/generator/clock/advance [post]
+func (h *handler) Advance(c *gin.Context) {
+	advancer, ok := h.clock.(clock.Advancer)
+	if !ok {
+		c.Error(apperror.ErrorWithMessage(apperror.ErrBadRequest, "Only stepped clock can be advanced."))
+		return
+	}
+
+	var dto AdvanceClockDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.LWithContext(c.Request.Context()).Errorf("Cannot parse body, due to error: %v", err)
+		c.Error(apperror.ErrBadRequest)
+		return
+	}
+
+	d, err := time.ParseDuration(dto.Duration)
+	if err != nil || d <= 0 {
+		c.Error(apperror.ErrorWithMessage(apperror.ErrValidation, "Duration should be positive, e.g. 1h."))
+		return
+	}
+
+	now := advancer.Advance(d)
+	h.logger.LWithContext(c.Request.Context()).Infof("Clock was advanced by %s to %s.", d, now.Format(time.RFC3339))
+	c.JSON(http.StatusOK, h.clockStatus())
+}
+
+func (h *handler) clockStatus() gin.H {
+	status := gin.H{"mode": clock.ModeOf(h.clock), "now": h.clock.Now()}
+	if accelerated, ok := h.clock.(*clock.Accelerated); ok {
+		status["speed"] = accelerated.Speed()
+	}
+	return status
+}
@@ -3,3 +3,11 @@ package generator
 type IGenerator interface {
 	Generate() error
 }
+
+// IControlledGenerator is a generator which can be stopped and started again.
+type IControlledGenerator interface {
+	IGenerator
+	Stop()
+	IsRunning() bool
+	Stats() Stats
+}
@@ -1,7 +1,16 @@
 package generator
 
+import (
+	"sensors-generator/internal/sensor"
+	"time"
+)
+
 type IRandomGenerator interface {
-	GenerateTemperatureBasedOnZ(z float64) float32
-	GenerateTemperature() float32
-	GenerateTransparency() uint8
+	// GenerateReading returns temperature and transparency measured by the sensor at the time.
+	GenerateReading(sens sensor.Sensor, at time.Time) (float32, uint8)
+	// GenerateMeasurements returns additional measurements the sensor carries, e.g. salinity,
+	// nil when it carries only temperature and transparency.
+	GenerateMeasurements(sens sensor.Sensor, temperature float32) map[string]float64
+	// SpieceFactors returns factors of abundance of spieces by name at the sensor, nil when nothing changes them.
+	SpieceFactors(sens sensor.Sensor, at time.Time) map[string]float64
 }
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"context"
+	"math/rand"
+	"sensors-generator/internal/sensor"
+	sensordata "sensors-generator/internal/sensorData"
+	"sensors-generator/pkg/environment"
+	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/trajectory"
+	"time"
+)
+
+// moverSeedShift separates random sources of movers from random sources of sensors,
+// which are seeded with the seed plus sensor id.
+const moverSeedShift = 1 << 32
+
+// newMover returns nil for static sensors.
+func newMover(sens sensor.Sensor, currents environment.Currents, rnd *rand.Rand) *trajectory.Mover {
+	if sens.Trajectory == nil {
+		return nil
+	}
+	return trajectory.NewMover(*sens.Trajectory, point(sens), currents, rnd)
+}
+
+// located returns the sensor at its position at the time, readings are sampled and stored there.
+// Static sensors are returned as is.
+func located(sens sensor.Sensor, mover *trajectory.Mover, at time.Time) sensor.Sensor {
+	if mover == nil {
+		return sens
+	}
+
+	p := mover.Position(at)
+	sens.Coords = sensor.Coordinates{X: p.X, Y: p.Y, Z: p.Z}
+	return sens
+}
+
+// resumeMovers continues random walks and drifters from positions of their last readings,
+// so they do not jump home after a restart. Without readings they start from home.
+func (dg *DataGenerator) resumeMovers(ctx context.Context, sensors []sensor.Sensor) {
+	ids := make([]int, 0)
+	for _, sens := range sensors {
+		if sens.Trajectory != nil && sens.Trajectory.Stateful() {
+			ids = append(ids, sens.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	readings, err := dg.services.SensorDataService.GetLatestForSensors(ctx, ids, sensordata.SensorDataFilters{Limit: 1})
+	if err != nil {
+		logging.GetLogger().Errorf("Cannot resume mobile sensors, they start from home, due to error: %v", err)
+		return
+	}
+
+	for _, reading := range readings {
+		if state, ok := dg.sensors[reading.SensorID]; ok {
+			if mover := state.mover.Load(); mover != nil {
+				mover.Resume(environment.Point{X: reading.Coords.X, Y: reading.Coords.Y, Z: reading.Coords.Z},
+					reading.CreatedAt)
+			}
+		}
+	}
+}
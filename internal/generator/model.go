@@ -1,10 +1,14 @@
 package generator
 
 import (
+	"context"
+	"sensors-generator/internal/groundtruth"
 	"sensors-generator/internal/group"
+	"sensors-generator/internal/population"
 	"sensors-generator/internal/sensor"
 	sensordata "sensors-generator/internal/sensorData"
 	"sensors-generator/internal/spiece"
+	"time"
 )
 
 type Services struct {
@@ -12,4 +16,28 @@ type Services struct {
 	SensorGroupService group.ISensorGroupService
 	SpieceService      spiece.ISpiecesService
 	SensorDataService  sensordata.ISensorDataService
+	// InjectedFaultService records faults injected by the data generator, faults are not recorded when it is nil.
+	InjectedFaultService groundtruth.IInjectedFaultService
+	// PopulationService moves schools which sensors detect, schools are drawn per reading when it is nil.
+	PopulationService population.IPopulationService
+	// PartitionService premakes partitions for the time of the clock, which may run ahead of the real time.
+	// Partitions are left to the retention job when it is nil.
+	PartitionService IPartitionService
+	// RollupService recomputes rollups of late readings, which may be written behind the rollup watermark.
+	// Rollups of late readings are lost when it is nil.
+	RollupService IRollupService
+}
+
+type AdvanceClockDTO struct {
+	Duration string `json:"duration" example:"1h"`
+}
+
+// IPartitionService premakes partitions for the given time, retention service implements it.
+type IPartitionService interface {
+	Premake(ctx context.Context, now time.Time) (time.Time, []string, error)
+}
+
+// IRollupService recomputes rollups from the given time, retention service implements it.
+type IRollupService interface {
+	Reroll(ctx context.Context, from time.Time) error
 }
@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+	"sensors-generator/internal/population"
+	"sensors-generator/internal/spiece"
+)
+
+// maxDetectedSpieces bounds detections of one reading, a big school would write hundreds of rows otherwise.
+const maxDetectedSpieces = 50
+
+// Conditions of a reading which decide which spieces are detected.
+type Conditions struct {
+	// Depth is Z of the sensor.
+	Depth        float64
+	Temperature  float32
+	Transparency uint8
+	// Abundance multiplies abundance of spieces by name, e.g. during an algal bloom.
+	Abundance map[string]float64
+}
+
+// DetectSpieces draws spieces detected with one reading. Schools of every spiece are met
+// as a Poisson process with the mean of its abundance scaled by suitability of the conditions,
+// a school adds from half of SchoolSize to SchoolSize fish. Every detected fish is one entry,
+// so the same spiece is repeated. When there are more than maxDetectedSpieces fish,
+// a random part of them is kept, proportions of spieces stay the same.
+func DetectSpieces(rnd *rand.Rand, spieces []spiece.Spiece, c Conditions) []spiece.Spiece {
+	return bound(rnd, detect(rnd, spieces, c))
+}
+
+// DetectWithSchools draws solitary spieces as DetectSpieces does and adds fish of schools seen by the sensor,
+// schooling spieces are detected only with schools of the population simulation.
+func DetectWithSchools(rnd *rand.Rand, spieces []spiece.Spiece, c Conditions, detections []population.Detection) []spiece.Spiece {
+	solitary := make([]spiece.Spiece, 0, len(spieces))
+	for _, s := range spieces {
+		if s.SchoolSize <= 1 {
+			solitary = append(solitary, s)
+		}
+	}
+
+	detectedSpieces := detect(rnd, solitary, c)
+	for _, d := range detections {
+		detected := spiece.Spiece{ID: d.Spiece.ID, Name: d.Spiece.Name}
+		for fish := c.fish(d); fish > 0; fish-- {
+			detectedSpieces = append(detectedSpieces, detected)
+		}
+	}
+
+	return bound(rnd, detectedSpieces)
+}
+
+func detect(rnd *rand.Rand, spieces []spiece.Spiece, c Conditions) []spiece.Spiece {
+	detectedSpieces := make([]spiece.Spiece, 0)
+
+	for _, s := range spieces {
+		mean := s.Abundance * s.Suitability(c.Depth, c.Temperature, c.Transparency) * c.factor(s.Name)
+		// Readings keep only id and name of detected spieces.
+		detected := spiece.Spiece{ID: s.ID, Name: s.Name}
+
+		for schools := poisson(rnd, mean); schools > 0; schools-- {
+			for fish := schoolSize(rnd, s.SchoolSize); fish > 0; fish-- {
+				detectedSpieces = append(detectedSpieces, detected)
+			}
+		}
+	}
+
+	return detectedSpieces
+}
+
+// factor of abundance of the spiece, 1 when the conditions do not change it.
+func (c Conditions) factor(name string) float64 {
+	if factor, ok := c.Abundance[name]; ok {
+		return factor
+	}
+	return 1
+}
+
+// fish of the school seen by the sensor, scaled by the factor of its spiece.
+func (c Conditions) fish(d population.Detection) int {
+	return int(math.Round(float64(d.Fish) * c.factor(d.Spiece.Name)))
+}
+
+// bound keeps a random part of maxDetectedSpieces fish, proportions of spieces stay the same.
+func bound(rnd *rand.Rand, detectedSpieces []spiece.Spiece) []spiece.Spiece {
+	if len(detectedSpieces) > maxDetectedSpieces {
+		rnd.Shuffle(len(detectedSpieces), func(i, j int) {
+			detectedSpieces[i], detectedSpieces[j] = detectedSpieces[j], detectedSpieces[i]
+		})
+		detectedSpieces = detectedSpieces[:maxDetectedSpieces]
+	}
+
+	return detectedSpieces
+}
+
+// poisson draws the number of events with the given mean, means of spieces are small.
+func poisson(rnd *rand.Rand, mean float64) int {
+	if mean <= 0 {
+		return 0
+	}
+
+	limit := math.Exp(-mean)
+	n := 0
+	for p := rnd.Float64(); p > limit; p *= rnd.Float64() {
+		n++
+	}
+
+	return n
+}
+
+// schoolSize draws the number of fish in [ceil(size/2), size], at least one.
+func schoolSize(rnd *rand.Rand, size int) int {
+	if size <= 1 {
+		return 1
+	}
+
+	min := (size + 1) / 2
+	return min + rnd.Intn(size-min+1)
+}
@@ -2,44 +2,44 @@ package generator
 
 import (
 	"math"
-	"math/rand"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/pkg/environment"
+	"time"
 )
 
+// RandomGenerator samples the shared environment, so neighbouring sensors measure close values.
 type RandomGenerator struct {
+	sampler *environment.Sampler
 }
 
-func NewRandomGenerator() *RandomGenerator {
-	return &RandomGenerator{}
+func NewRandomGenerator(cfg environment.Config) *RandomGenerator {
+	return &RandomGenerator{sampler: environment.NewSampler(cfg)}
 }
 
-func (rg *RandomGenerator) GenerateTemperatureBasedOnZ(z float64) float32 {
-	depthMultiplier := 1.0 + float64(z)/100.0
-
-	temperature := float32(rand.Intn(30) + 1)
-	temperature *= rand.Float32()
-	temperature = float32(math.Round(float64(temperature)*100) / 100)
-
-	temperature *= float32(depthMultiplier)
-
-	return temperature
+// SetEvents replaces environmental events applied to readings.
+func (rg *RandomGenerator) SetEvents(events []environment.Event) {
+	rg.sampler.SetEvents(events)
 }
 
-func (rg *RandomGenerator) GenerateTemperature() float32 {
-	temperature := float32(rand.Intn(30) + 1)
+func (rg *RandomGenerator) GenerateReading(sens sensor.Sensor, at time.Time) (float32, uint8) {
+	reading := rg.sampler.Sample(sens.ID, point(sens), at)
 
-	return temperature
+	temperature := float32(math.Round(reading.Temperature*100) / 100)
+	return temperature, uint8(math.Round(reading.Transparency))
 }
 
-func (rg *RandomGenerator) GenerateTransparency() uint8 {
-	baseTransparency := rand.Intn(101)
-	offset := rand.Intn(11) - 5
-	transparency := baseTransparency + offset
-
-	if transparency < 0 {
-		transparency = 0
-	} else if transparency > 100 {
-		transparency = 100
+func (rg *RandomGenerator) GenerateMeasurements(sens sensor.Sensor, temperature float32) map[string]float64 {
+	values := rg.sampler.Measure(sens.ID, point(sens), float64(temperature), sens.Measurements)
+	for name, value := range values {
+		values[name] = math.Round(value*1000) / 1000
 	}
+	return values
+}
+
+func (rg *RandomGenerator) SpieceFactors(sens sensor.Sensor, at time.Time) map[string]float64 {
+	return rg.sampler.Effect(point(sens), at).Spieces
+}
 
-	return uint8(transparency)
+func point(sens sensor.Sensor) environment.Point {
+	return environment.Point{X: sens.Coords.X, Y: sens.Coords.Y, Z: sens.Coords.Z}
 }
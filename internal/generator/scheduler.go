@@ -0,0 +1,191 @@
+package generator
+
+import (
+	"container/heap"
+	"context"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/pkg/clock"
+	"sync"
+	"time"
+)
+
+// minInterval is the shortest time between readings of a sensor. Sensors are validated to have a positive
+// output rate, it keeps a sensor stored without one from taking a worker with readings of the same time.
+const minInterval = time.Second
+
+// interval between readings of the sensor, output rate is kept in seconds.
+func interval(sens *sensor.Sensor) time.Duration {
+	if rate := sens.DataOutputRate * time.Second; rate > minInterval {
+		return rate
+	}
+	return minInterval
+}
+
+// dispatch is a reading of the sensor which is due at the time.
+type dispatch struct {
+	state *sensorState
+	at    time.Time
+}
+
+// scheduled is a sensor waiting for its next reading. Last is the time of its previous reading,
+// it is zero before the first one.
+type scheduled struct {
+	sensorID int
+	state    *sensorState
+	at       time.Time
+	last     time.Time
+	index    int
+}
+
+// schedule is a min-heap of sensors by the time of their next reading.
+type schedule []*scheduled
+
+func (s schedule) Len() int { return len(s) }
+
+func (s schedule) Less(i, j int) bool {
+	if s[i].at.Equal(s[j].at) {
+		return s[i].sensorID < s[j].sensorID
+	}
+	return s[i].at.Before(s[j].at)
+}
+
+func (s schedule) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+	s[i].index = i
+	s[j].index = j
+}
+
+func (s *schedule) Push(x any) {
+	item := x.(*scheduled)
+	item.index = len(*s)
+	*s = append(*s, item)
+}
+
+func (s *schedule) Pop() any {
+	old := *s
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	*s = old[:len(old)-1]
+	return item
+}
+
+// scheduler keeps sensors in a priority queue and sends due ones to workers. A sensor leaves the queue
+// when it is dispatched and comes back when its reading is written, so one sensor is never written
+// by two workers at once and its readings keep their order.
+type scheduler struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	queue   schedule
+	entries map[int]*scheduled
+	wake    chan struct{}
+}
+
+func newScheduler(clk clock.Clock) *scheduler {
+	return &scheduler{
+		clock:   clk,
+		entries: make(map[int]*scheduled),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Schedule queues the next reading of the sensor at the time, last is the time of its previous reading.
+func (s *scheduler) Schedule(sensorID int, state *sensorState, last, at time.Time) {
+	s.mu.Lock()
+	if entry, ok := s.entries[sensorID]; ok {
+		entry.state, entry.at, entry.last = state, at, last
+		heap.Fix(&s.queue, entry.index)
+	} else {
+		entry := &scheduled{sensorID: sensorID, state: state, at: at, last: last}
+		heap.Push(&s.queue, entry)
+		s.entries[sensorID] = entry
+	}
+	s.mu.Unlock()
+
+	s.notify()
+}
+
+// Retime moves the queued reading of the sensor to rate after its previous reading. A sensor which is being
+// written or has not written its first reading is not moved, its rate is applied to the next reading.
+func (s *scheduler) Retime(sensorID int, rate time.Duration) {
+	s.mu.Lock()
+	entry, ok := s.entries[sensorID]
+	if !ok || entry.last.IsZero() {
+		s.mu.Unlock()
+		return
+	}
+	entry.at = entry.last.Add(rate)
+	heap.Fix(&s.queue, entry.index)
+	s.mu.Unlock()
+
+	s.notify()
+}
+
+func (s *scheduler) Remove(sensorID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[sensorID]; ok {
+		heap.Remove(&s.queue, entry.index)
+		delete(s.entries, sensorID)
+	}
+}
+
+// Len returns the number of queued sensors.
+func (s *scheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.queue)
+}
+
+// Run sends due sensors to due till ctx is done. When workers are busy, sending blocks,
+// so readings are late instead of piling up in memory.
+func (s *scheduler) Run(ctx context.Context, due chan<- dispatch) {
+	for {
+		s.mu.Lock()
+		var wait time.Duration
+		var next *scheduled
+		if len(s.queue) > 0 {
+			next = s.queue[0]
+			wait = next.at.Sub(s.clock.Now())
+		}
+		if next != nil && wait <= 0 {
+			heap.Pop(&s.queue)
+			delete(s.entries, next.sensorID)
+		}
+		s.mu.Unlock()
+
+		switch {
+		case next == nil:
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.wake:
+			}
+		case wait > 0:
+			timer := s.clock.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-s.wake:
+				timer.Stop()
+			case <-timer.C():
+			}
+		default:
+			select {
+			case <-ctx.Done():
+				return
+			case due <- dispatch{state: next.state, at: next.at}:
+			}
+		}
+	}
+}
+
+func (s *scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"context"
+	"sensors-generator/internal/spiece"
+	"sync"
+	"time"
+)
+
+// spieceCatalogueCheckEvery is how often the catalogue asks for the version of spieces.
+const spieceCatalogueCheckEvery = 30 * time.Second
+
+// SpieceCatalogue caches spieces for readings. Every checkEvery it compares the version of spieces
+// and reloads them only when spieces were created or updated.
+type SpieceCatalogue struct {
+	spieceService spiece.ISpiecesService
+	checkEvery    time.Duration
+
+	mu        sync.Mutex
+	spieces   []spiece.Spiece
+	version   spiece.Version
+	loaded    bool
+	checkedAt time.Time
+}
+
+func NewSpieceCatalogue(spieceService spiece.ISpiecesService, checkEvery time.Duration) *SpieceCatalogue {
+	return &SpieceCatalogue{
+		spieceService: spieceService,
+		checkEvery:    checkEvery,
+	}
+}
+
+// Get returns cached spieces. When the check fails, the last loaded spieces are returned with the error.
+func (c *SpieceCatalogue) Get(ctx context.Context) ([]spiece.Spiece, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loaded && time.Since(c.checkedAt) < c.checkEvery {
+		return c.spieces, nil
+	}
+
+	version, err := c.spieceService.GetVersion(ctx)
+	if err != nil {
+		return c.spieces, err
+	}
+
+	if !c.loaded || !version.Equal(c.version) {
+		spieces, err := c.spieceService.GetAll(ctx, spiece.SpieceFilters{})
+		if err != nil {
+			return c.spieces, err
+		}
+		c.spieces, c.version, c.loaded = spieces, version, true
+	}
+
+	c.checkedAt = time.Now()
+	return c.spieces, nil
+}
+
+// Invalidate makes the next Get check the version.
+func (c *SpieceCatalogue) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checkedAt = time.Time{}
+}
@@ -0,0 +1,207 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sensors-generator/pkg/logging"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// spillFile keeps readings which did not fit the write queue, one json reading per line.
+// Readings are taken from the head of the file, the file is truncated once all of them are taken.
+// It survives restarts, readings spilled by the last run are restored when the queue starts.
+// The offset of the head is synced to <path>.offset with every take, so readings taken before a crash
+// are not written again.
+type spillFile struct {
+	mu     sync.Mutex
+	path   string
+	offset int64
+	count  int
+}
+
+func openSpillFile(path string) (*spillFile, error) {
+	f, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	spill := &spillFile{path: path}
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if spill.offset, err = readOffset(spill.offsetPath()); err != nil {
+		return nil, err
+	}
+	// The file was truncated after the offset was saved.
+	if spill.offset > info.Size() {
+		spill.offset = 0
+	}
+	if _, err := f.Seek(spill.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) > 0 {
+			spill.count++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return spill, nil
+}
+
+// Append writes readings to the end of the file and syncs it.
+func (s *spillFile) Append(readings ...pendingReading) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	encoder := json.NewEncoder(w)
+	for _, reading := range readings {
+		if err := encoder.Encode(reading); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+
+	s.count += len(readings)
+	return nil
+}
+
+// Take reads up to n readings from the head of the file and saves the new head. Lines which cannot be decoded
+// are skipped. Readings which were taken are not in the file anymore, so the queue spills them again on close.
+func (s *spillFile) Take(n int) ([]pendingReading, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.count == 0 {
+		return nil, nil
+	}
+
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(s.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	readings := make([]pendingReading, 0, n)
+	r := bufio.NewReader(f)
+	for len(readings) < n {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 && line[len(line)-1] != '\n' {
+			// The last line was cut by a crash while it was appended.
+			err = io.EOF
+			line = nil
+		}
+		s.offset += int64(len(line))
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			var reading pendingReading
+			if err := json.Unmarshal(line, &reading); err != nil {
+				logging.GetLogger().Errorf("Cannot decode spilled reading, it is skipped, due to error: %v", err)
+			} else {
+				readings = append(readings, reading)
+			}
+			s.count--
+		}
+
+		if err == io.EOF {
+			s.count = 0
+			break
+		}
+		if err != nil {
+			return readings, err
+		}
+	}
+
+	// The file is truncated before the offset is reset, so a crash in between does not replay readings.
+	if s.count == 0 {
+		if err := os.Truncate(s.path, 0); err != nil {
+			return readings, err
+		}
+		s.offset = 0
+	}
+
+	return readings, writeOffset(s.offsetPath(), s.offset)
+}
+
+// Len returns the number of readings in the file.
+func (s *spillFile) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.count
+}
+
+func (s *spillFile) offsetPath() string {
+	return s.path + ".offset"
+}
+
+// readOffset returns 0 when the offset was never saved.
+func readOffset(path string) (int64, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	offset, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || offset < 0 {
+		logging.GetLogger().Errorf("Spill file offset %q is broken, readings are taken from the start.", data)
+		return 0, nil
+	}
+	return offset, nil
+}
+
+// writeOffset replaces the offset file with a synced temporary one, so a crash leaves the old or the new offset.
+func writeOffset(path string, offset int64) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.WriteString(strconv.FormatInt(offset, 10)); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"sync"
+	"time"
+)
+
+// lagBuckets are upper bounds of lag histogram buckets, the last bucket counts longer lags.
+var lagBuckets = []time.Duration{
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+	time.Minute,
+}
+
+// Stats of the generator engine. Lag is how late a reading starts after its scheduled time,
+// it grows when workers cannot keep up with sensors.
+type Stats struct {
+	Running  bool   `json:"running"`
+	Sensors  int    `json:"sensors"`
+	Queued   int    `json:"queued"`
+	Workers  int    `json:"workers"`
+	Busy     int    `json:"busy"`
+	Readings uint64 `json:"readings"`
+	Lag      Lag    `json:"lag"`
+	// Writes count readings of the write queue, they are kept when the generator stops.
+	Writes WriteStats `json:"writes"`
+}
+
+type Lag struct {
+	LastMs float64 `json:"last_ms"`
+	MeanMs float64 `json:"mean_ms"`
+	MaxMs  float64 `json:"max_ms"`
+	// Buckets count readings by lag, keys are upper bounds, e.g. "100ms", and "+Inf".
+	Buckets map[string]uint64 `json:"buckets"`
+}
+
+// lagStats collects lags of readings since the generator was created.
+type lagStats struct {
+	mu      sync.Mutex
+	count   uint64
+	sum     time.Duration
+	max     time.Duration
+	last    time.Duration
+	buckets []uint64
+	busy    int
+}
+
+func newLagStats() *lagStats {
+	return &lagStats{buckets: make([]uint64, len(lagBuckets)+1)}
+}
+
+func (s *lagStats) observe(lag time.Duration) {
+	if lag < 0 {
+		lag = 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.count++
+	s.sum += lag
+	s.last = lag
+	if lag > s.max {
+		s.max = lag
+	}
+
+	i := 0
+	for i < len(lagBuckets) && lag > lagBuckets[i] {
+		i++
+	}
+	s.buckets[i]++
+}
+
+// setBusy changes the number of workers which write readings.
+func (s *lagStats) setBusy(delta int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.busy += delta
+}
+
+// fill sets readings, busy workers and lag of stats.
+func (s *lagStats) fill(stats *Stats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ms := func(d time.Duration) float64 {
+		return float64(d) / float64(time.Millisecond)
+	}
+
+	stats.Readings = s.count
+	stats.Busy = s.busy
+	stats.Lag = Lag{LastMs: ms(s.last), MaxMs: ms(s.max), Buckets: make(map[string]uint64, len(s.buckets))}
+	if s.count > 0 {
+		stats.Lag.MeanMs = ms(s.sum / time.Duration(s.count))
+	}
+	for i, count := range s.buckets {
+		key := "+Inf"
+		if i < len(lagBuckets) {
+			key = lagBuckets[i].String()
+		}
+		stats.Lag.Buckets[key] = count
+	}
+}
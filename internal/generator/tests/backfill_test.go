@@ -0,0 +1,176 @@
+package generator
+
+import (
+	"context"
+	"sensors-generator/internal/generator"
+	"sensors-generator/internal/importer"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/environment"
+	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/trajectory"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReadingsWriter struct {
+	mock.Mock
+	Written [][]importer.Reading
+}
+
+func (m *MockReadingsWriter) InsertReadings(ctx context.Context, readings []importer.Reading, batchSize int) (int, error) {
+	args := m.Called(ctx, batchSize)
+	m.Written = append(m.Written, append([]importer.Reading(nil), readings...))
+	return len(readings), args.Error(0)
+}
+
+type MockHistoryService struct {
+	mock.Mock
+}
+
+func (m *MockHistoryService) EnsurePartitions(ctx context.Context, from, till time.Time) ([]string, error) {
+	args := m.Called(ctx, from, till)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockHistoryService) HistoryLimit(now time.Time) time.Time {
+	args := m.Called(now)
+	return args.Get(0).(time.Time)
+}
+
+func (m *MockHistoryService) Reroll(ctx context.Context, from time.Time) error {
+	args := m.Called(ctx, from)
+	return args.Error(0)
+}
+
+type MockSpieceService struct {
+	mock.Mock
+}
+
+func (m *MockSpieceService) GetAll(ctx context.Context, filters spiece.SpieceFilters) ([]spiece.Spiece, error) {
+	args := m.Called(ctx, filters)
+	return args.Get(0).([]spiece.Spiece), args.Error(1)
+}
+
+func (m *MockSpieceService) Create(ctx context.Context, spieces ...spiece.CreateSpieceDTO) error {
+	args := m.Called(ctx, spieces)
+	return args.Error(0)
+}
+
+func (m *MockSpieceService) GetVersion(ctx context.Context) (spiece.Version, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(spiece.Version), args.Error(1)
+}
+
+type stubRandomGenerator struct{}
+
+func (stubRandomGenerator) GenerateReading(sens sensor.Sensor, at time.Time) (float32, uint8) {
+	return float32(sens.Coords.Z), 50
+}
+
+func (stubRandomGenerator) GenerateMeasurements(sens sensor.Sensor, temperature float32) map[string]float64 {
+	return nil
+}
+
+func (stubRandomGenerator) SpieceFactors(sens sensor.Sensor, at time.Time) map[string]float64 {
+	return nil
+}
+
+func Test_Backfiller_Backfill(t *testing.T) {
+	logging.Init("trace", true)
+	ctx := context.Background()
+
+	from := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	till := from.Add(time.Minute)
+
+	spieceService := &MockSpieceService{}
+	spieceService.On("GetAll", ctx, spiece.SpieceFilters{}).Return([]spiece.Spiece{{ID: 1, Name: "Herring"}}, nil)
+	history := &MockHistoryService{}
+	history.On("HistoryLimit", mock.Anything).Return(time.Time{})
+	history.On("EnsurePartitions", ctx, from, till).Return([]string{}, nil)
+	history.On("Reroll", mock.Anything, from).Return(nil)
+	writer := &MockReadingsWriter{}
+	writer.On("InsertReadings", ctx, 4).Return(nil)
+
+	backfiller := generator.NewBackfiller(generator.Services{SpieceService: spieceService},
+		stubRandomGenerator{}, writer, history, 4, logging.GetLogger())
+
+	sensors := []sensor.Sensor{
+		{ID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1}, Coords: sensor.Coordinates{Z: 4}, DataOutputRate: 30},
+		{ID: 2, CodeName: sensor.Codename{GroupName: "beta", Index: 1}, Coords: sensor.Coordinates{Z: 8}, DataOutputRate: 20},
+		{ID: 3, CodeName: sensor.Codename{GroupName: "beta", Index: 2}},
+	}
+
+	written, err := backfiller.Backfill(ctx, sensors, from, till)
+
+	assert.NoError(t, err)
+	// 2 readings of the first sensor and 3 of the second one, the third sensor has no rate.
+	assert.Equal(t, 5, written)
+	assert.Len(t, writer.Written, 2)
+	assert.Len(t, writer.Written[0], 4)
+	assert.Equal(t, from.Add(30*time.Second), writer.Written[0][1].CreatedAt)
+	assert.Equal(t, 2, writer.Written[1][0].SensorID)
+	assert.Equal(t, from.Add(40*time.Second), writer.Written[1][0].CreatedAt)
+	assert.Equal(t, float32(8), writer.Written[1][0].Temperature)
+	// Readings of static sensors keep no position.
+	assert.Nil(t, writer.Written[1][0].Coords)
+	history.AssertExpectations(t)
+}
+
+func Test_Backfiller_Backfill_OlderThanRetention(t *testing.T) {
+	from := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	history := &MockHistoryService{}
+	history.On("HistoryLimit", mock.Anything).Return(from.Add(time.Hour))
+	backfiller := generator.NewBackfiller(generator.Services{}, stubRandomGenerator{},
+		&MockReadingsWriter{}, history, 10, logging.GetLogger())
+
+	_, err := backfiller.Backfill(context.Background(), nil, from, from.Add(2*time.Hour))
+
+	assert.Error(t, err)
+	history.AssertNotCalled(t, "EnsurePartitions", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_Backfiller_Backfill_WrongRange(t *testing.T) {
+	backfiller := generator.NewBackfiller(generator.Services{}, stubRandomGenerator{},
+		&MockReadingsWriter{}, nil, 10, logging.GetLogger())
+
+	now := time.Now()
+	_, err := backfiller.Backfill(context.Background(), nil, now, now.Add(-time.Hour))
+
+	assert.Error(t, err)
+}
+
+func Test_Backfiller_Backfill_MobileSensor(t *testing.T) {
+	ctx := context.Background()
+
+	from := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	till := from.Add(time.Minute)
+
+	spieceService := &MockSpieceService{}
+	spieceService.On("GetAll", ctx, spiece.SpieceFilters{}).Return([]spiece.Spiece{}, nil)
+	writer := &MockReadingsWriter{}
+	writer.On("InsertReadings", ctx, 10).Return(nil)
+
+	backfiller := generator.NewBackfiller(generator.Services{SpieceService: spieceService},
+		stubRandomGenerator{}, writer, nil, 10, logging.GetLogger())
+	backfiller.SetCurrents(environment.Currents{U: 1, TidalPeriod: time.Hour, Depth: 50})
+
+	sensors := []sensor.Sensor{
+		{ID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1}, Coords: sensor.Coordinates{X: 10}, DataOutputRate: 20,
+			Trajectory: &trajectory.Trajectory{Kind: trajectory.KindCurrent}},
+	}
+
+	written, err := backfiller.Backfill(ctx, sensors, from, till)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, written)
+	// The drifter starts from home and goes east with the current at the surface.
+	for i, reading := range writer.Written[0] {
+		assert.InDelta(t, 10+20*float64(i), reading.Coords.X, 1e-9)
+		assert.Equal(t, 0.0, reading.Coords.Y)
+	}
+}
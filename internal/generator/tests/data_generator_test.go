@@ -0,0 +1,265 @@
+package generator
+
+import (
+	"context"
+	"sensors-generator/config"
+	"sensors-generator/internal/generator"
+	"sensors-generator/internal/sensor"
+	sensordata "sensors-generator/internal/sensorData"
+	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/clock"
+	"sensors-generator/pkg/environment"
+	"sensors-generator/pkg/fault"
+	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/trajectory"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var generatorStart = time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+func newGeneratorConfig(workers int, startJitter bool) *config.Config {
+	cfg := &config.Config{}
+	cfg.GeneratorConfig.Workers = workers
+	cfg.GeneratorConfig.StartJitter = startJitter
+	cfg.GeneratorConfig.Seed = 7
+	cfg.WriteQueueConfig.Size = 100
+	cfg.WriteQueueConfig.Writers = 1
+	cfg.WriteQueueConfig.WhenFull = generator.WhenFullBlock
+	cfg.WriteQueueConfig.RetryMin = time.Millisecond
+	cfg.WriteQueueConfig.RetryMax = 5 * time.Millisecond
+	return cfg
+}
+
+func newDataGenerator(clk clock.Clock, cfg *config.Config, sensors ...[]sensor.Sensor) (*generator.DataGenerator, *MockSensorDataService) {
+	return newDataGeneratorWithServices(clk, cfg, generator.Services{}, sensors...)
+}
+
+// newDataGeneratorWithServices fills sensor, spiece and sensor data services of services with mocks,
+// optional services are kept.
+func newDataGeneratorWithServices(clk clock.Clock, cfg *config.Config, services generator.Services,
+	sensors ...[]sensor.Sensor) (*generator.DataGenerator, *MockSensorDataService) {
+	sensorService := &MockSensorService{}
+	for _, s := range sensors {
+		sensorService.On("GetAll", mock.Anything, sensor.SensorFilters{}).Return(s, nil).Once()
+	}
+	spieceService := &MockSpieceService{}
+	spieceService.On("GetVersion", mock.Anything).Return(spiece.Version{}, nil)
+	spieceService.On("GetAll", mock.Anything, spiece.SpieceFilters{}).Return([]spiece.Spiece{}, nil)
+	sensorDataService := &MockSensorDataService{}
+
+	services.SensorService = sensorService
+	services.SpieceService = spieceService
+	services.SensorDataService = sensorDataService
+	return generator.NewDataGenerator(services, stubRandomGenerator{}, clk, cfg), sensorDataService
+}
+
+func Test_DataGenerator_SteppedClock(t *testing.T) {
+	logging.Init("trace", true)
+	clk := clock.NewStepped(generatorStart)
+
+	dataGen, sensorDataService := newDataGenerator(clk, newGeneratorConfig(2, false), []sensor.Sensor{
+		{ID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1}, Coords: sensor.Coordinates{Z: 4}, DataOutputRate: 10},
+	})
+
+	assert.NoError(t, dataGen.Generate())
+
+	// The first reading is written at once, then the sensor waits for the clock.
+	assert.Eventually(t, func() bool { return clk.Waiting() == 1 && dataGen.Stats().Queued == 1 }, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == 1 }, time.Second, time.Millisecond)
+
+	// Readings of the whole minute are written one after another.
+	clk.Advance(time.Minute)
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == 7 }, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { return clk.Waiting() == 1 }, time.Second, time.Millisecond)
+
+	stats := dataGen.Stats()
+	assert.True(t, stats.Running)
+	assert.Equal(t, 1, stats.Sensors)
+	assert.Equal(t, 2, stats.Workers)
+	assert.Equal(t, uint64(7), stats.Readings)
+	// The last caught up reading was scheduled 10 seconds before the clock.
+	assert.Equal(t, 50000.0, stats.Lag.MaxMs)
+	assert.Equal(t, uint64(2), stats.Lag.Buckets["10ms"])
+
+	dataGen.Stop()
+	dataGen.Wait()
+
+	for i, reading := range sensorDataService.Created {
+		assert.Equal(t, generatorStart.Add(time.Duration(i)*10*time.Second), reading.CreatedAt)
+		assert.Equal(t, float32(4), reading.Temperature)
+		// Readings of static sensors keep no position.
+		assert.Nil(t, reading.Coords)
+	}
+	assert.Equal(t, 7, sensorDataService.CreatedCount())
+	assert.False(t, dataGen.Stats().Running)
+}
+
+func Test_DataGenerator_PremakesPartitionsForClock(t *testing.T) {
+	clk := clock.NewStepped(generatorStart)
+	august := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+	october := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	partitionService := &MockPartitionService{}
+	partitionService.On("Premake", mock.Anything, generatorStart).Return(august, []string{}, nil).Once()
+	partitionService.On("Premake", mock.Anything, august).
+		Return(october, []string{"sensor_data_p202309", "detected_spieces_p202309"}, nil).Once()
+
+	dataGen, sensorDataService := newDataGeneratorWithServices(clk, newGeneratorConfig(1, false),
+		generator.Services{PartitionService: partitionService}, []sensor.Sensor{
+			{ID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1}, DataOutputRate: 24 * 60 * 60},
+		})
+
+	assert.NoError(t, dataGen.Generate())
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == 1 }, time.Second, time.Millisecond)
+
+	// Readings of July are within premade partitions, the first one of August premakes more.
+	clk.Advance(31 * 24 * time.Hour)
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == 32 }, time.Second, time.Millisecond)
+
+	dataGen.Stop()
+	dataGen.Wait()
+
+	partitionService.AssertExpectations(t)
+	assert.Equal(t, august, sensorDataService.Created[31].CreatedAt)
+}
+
+func Test_DataGenerator_NoDataOutputRate(t *testing.T) {
+	clk := clock.NewStepped(generatorStart)
+
+	// A sensor stored without output rate writes once a second instead of taking the worker.
+	dataGen, sensorDataService := newDataGenerator(clk, newGeneratorConfig(1, false), []sensor.Sensor{
+		{ID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1}},
+	})
+
+	assert.NoError(t, dataGen.Generate())
+	assert.Eventually(t, func() bool { return clk.Waiting() == 1 && sensorDataService.CreatedCount() == 1 },
+		time.Second, time.Millisecond)
+
+	clk.Advance(3 * time.Second)
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == 4 }, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { return clk.Waiting() == 1 }, time.Second, time.Millisecond)
+
+	dataGen.Stop()
+	dataGen.Wait()
+
+	assert.Equal(t, 4, sensorDataService.CreatedCount())
+	assert.Equal(t, generatorStart.Add(3*time.Second), sensorDataService.Created[3].CreatedAt)
+}
+
+func Test_DataGenerator_StartJitter(t *testing.T) {
+	clk := clock.NewStepped(generatorStart)
+
+	sensors := make([]sensor.Sensor, 0, 50)
+	for i := 1; i <= 50; i++ {
+		sensors = append(sensors, sensor.Sensor{ID: i, CodeName: sensor.Codename{GroupName: "alpha", Index: i}, DataOutputRate: 60})
+	}
+	dataGen, sensorDataService := newDataGenerator(clk, newGeneratorConfig(4, true), sensors)
+
+	assert.NoError(t, dataGen.Generate())
+	defer dataGen.Wait()
+	defer dataGen.Stop()
+
+	// First readings are spread over the minute, so only a few sensors write in the first seconds.
+	clk.Advance(5 * time.Second)
+	assert.Eventually(t, func() bool {
+		stats := dataGen.Stats()
+		return clk.Waiting() == 1 && stats.Queued == 50 && stats.Writes.Queued == 0
+	}, time.Second, time.Millisecond)
+	assert.Greater(t, sensorDataService.CreatedCount(), 0)
+	assert.Less(t, sensorDataService.CreatedCount(), 15)
+
+	// Every sensor writes its first reading within the minute.
+	clk.Advance(55 * time.Second)
+	assert.Eventually(t, func() bool { return len(sensorDataService.SensorIDs()) == 50 }, time.Second, time.Millisecond)
+}
+
+func Test_DataGenerator_Refresh(t *testing.T) {
+	clk := clock.NewStepped(generatorStart)
+
+	alpha := sensor.Sensor{ID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1}, DataOutputRate: 60}
+	beta := sensor.Sensor{ID: 2, CodeName: sensor.Codename{GroupName: "beta", Index: 1}, DataOutputRate: 60}
+	faster := alpha
+	faster.DataOutputRate = 10
+
+	dataGen, sensorDataService := newDataGenerator(clk, newGeneratorConfig(2, false),
+		[]sensor.Sensor{alpha, beta}, []sensor.Sensor{faster})
+
+	assert.NoError(t, dataGen.Generate())
+	defer dataGen.Wait()
+	defer dataGen.Stop()
+	assert.Eventually(t, func() bool { return clk.Waiting() == 1 && dataGen.Stats().Queued == 2 }, time.Second, time.Millisecond)
+
+	report, err := dataGen.Refresh(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, generator.RefreshReport{Updated: 1, Stopped: 1}, report)
+	assert.Equal(t, 1, dataGen.Stats().Sensors)
+
+	// The new rate counts from the last reading, beta is not written anymore.
+	clk.Advance(10 * time.Second)
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, 1, sensorDataService.Created[2].SensorID)
+	assert.Equal(t, generatorStart.Add(10*time.Second), sensorDataService.Created[2].CreatedAt)
+}
+
+func Test_DataGenerator_MobileSensors(t *testing.T) {
+	clk := clock.NewStepped(generatorStart)
+
+	starts := generatorStart
+	glider := sensor.Sensor{ID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1},
+		Coords: sensor.Coordinates{Z: 4}, DataOutputRate: 10,
+		Trajectory: &trajectory.Trajectory{Kind: trajectory.KindWaypoints,
+			Waypoints: []environment.Point{{Z: 24}}, Speed: 1, StartsAt: &starts}}
+	buoy := sensor.Sensor{ID: 2, CodeName: sensor.Codename{GroupName: "beta", Index: 1}, DataOutputRate: 60,
+		Trajectory: &trajectory.Trajectory{Kind: trajectory.KindRandomWalk, Step: 0.001}}
+
+	dataGen, sensorDataService := newDataGenerator(clk, newGeneratorConfig(1, false), []sensor.Sensor{glider, buoy})
+	// The buoy continues from its last reading.
+	sensorDataService.On("GetLatestForSensors", mock.Anything, []int{2}, sensordata.SensorDataFilters{Limit: 1}).
+		Return([]sensordata.SensorData{{SensorID: 2, Coords: sensor.Coordinates{X: 100, Y: 50},
+			CreatedAt: generatorStart.Add(-time.Minute)}}, nil)
+
+	assert.NoError(t, dataGen.Generate())
+	clk.Advance(40 * time.Second)
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == 6 }, time.Second, time.Millisecond)
+	dataGen.Stop()
+	dataGen.Wait()
+
+	// The glider dives to the waypoint and comes back in 40 seconds, water is sampled where it is.
+	depths := make([]float64, 0)
+	for _, reading := range sensorDataService.Created {
+		if reading.SensorID == 1 {
+			assert.Equal(t, float32(reading.Coords.Z), reading.Temperature)
+			depths = append(depths, reading.Coords.Z)
+		} else {
+			assert.InDelta(t, 100, reading.Coords.X, 0.1)
+			assert.InDelta(t, 50, reading.Coords.Y, 0.1)
+		}
+	}
+	assert.Equal(t, []float64{4, 14, 24, 14, 4}, depths)
+}
+
+func Test_DataGenerator_RerollsOutOfOrderReadings(t *testing.T) {
+	clk := clock.NewStepped(generatorStart)
+	rollupService := &MockRollupService{}
+
+	dataGen, sensorDataService := newDataGeneratorWithServices(clk, newGeneratorConfig(1, false),
+		generator.Services{RollupService: rollupService}, []sensor.Sensor{queueSensor})
+	dataGen.SetFaultConfig(fault.Config{Enabled: true, Seed: 1, Profiles: []fault.Profile{
+		{Name: "late", OutOfOrder: fault.Shift{Probability: 1, Max: time.Hour}},
+	}})
+
+	assert.NoError(t, dataGen.Generate())
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == 1 }, time.Second, time.Millisecond)
+	dataGen.Stop()
+	dataGen.Wait()
+
+	// The reading is stamped before it was measured, its hour may be rolled up already.
+	createdAt := sensorDataService.Created[0].CreatedAt
+	assert.True(t, createdAt.Before(generatorStart))
+	assert.Contains(t, rollupService.Rerolled(), createdAt)
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"context"
+	"math/rand"
+	"sensors-generator/internal/generator"
+	"sensors-generator/internal/population"
+	"sensors-generator/internal/spiece"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	reefFish = spiece.Spiece{ID: 1, Name: "Blue Tang", Habitat: spiece.Habitat{MinDepth: 2, MaxDepth: 40,
+		MinTemperature: 24, MaxTemperature: 30, MinTransparency: 50, Abundance: 1, SchoolSize: 1}}
+	herring = spiece.Spiece{ID: 2, Name: "Pacific Herring", Habitat: spiece.Habitat{MinDepth: 0, MaxDepth: 200,
+		MinTemperature: 1, MaxTemperature: 18, Abundance: 1, SchoolSize: 20}}
+	coelacanth = spiece.Spiece{ID: 3, Name: "Coelacanth", Habitat: spiece.Habitat{MinDepth: 150, MaxDepth: 500,
+		MinTemperature: 14, MaxTemperature: 22, Abundance: 1, SchoolSize: 1}}
+)
+
+func Test_DetectSpieces_Habitat(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	spieces := []spiece.Spiece{reefFish, herring, coelacanth}
+
+	counts := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		detected := generator.DetectSpieces(rnd, spieces, generator.Conditions{Depth: 10, Temperature: 27, Transparency: 80})
+		assert.LessOrEqual(t, len(detected), 50)
+		for _, s := range detected {
+			counts[s.ID]++
+		}
+	}
+
+	// About one reef fish per reading, cold water herring and deep water coelacanth are not met.
+	assert.InDelta(t, 1000, counts[reefFish.ID], 150)
+	assert.Zero(t, counts[herring.ID])
+	assert.Zero(t, counts[coelacanth.ID])
+
+	counts = make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		for _, s := range generator.DetectSpieces(rnd, spieces, generator.Conditions{Depth: 10, Temperature: 8, Transparency: 80}) {
+			counts[s.ID]++
+		}
+	}
+
+	// Herring is met in schools of 10 to 20 fish.
+	assert.Zero(t, counts[reefFish.ID])
+	assert.InDelta(t, 15000, counts[herring.ID], 2000)
+}
+
+func Test_DetectSpieces_Bounded(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	school := herring
+	school.Abundance, school.SchoolSize = 10, 200
+
+	detected := generator.DetectSpieces(rnd, []spiece.Spiece{school, reefFish}, generator.Conditions{Depth: 10, Temperature: 10})
+
+	assert.Len(t, detected, 50)
+	assert.Equal(t, spiece.Spiece{ID: school.ID, Name: school.Name}, detected[0])
+}
+
+func Test_DetectWithSchools(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	detections := []population.Detection{{SchoolID: 1, Spiece: herring, Fish: 12}}
+
+	detected := generator.DetectWithSchools(rnd, []spiece.Spiece{herring}, generator.Conditions{Depth: 10, Temperature: 8}, detections)
+
+	// Herring is schooling, it is detected only with schools of the simulation.
+	assert.Len(t, detected, 12)
+	assert.Equal(t, spiece.Spiece{ID: herring.ID, Name: herring.Name}, detected[0])
+	assert.Empty(t, generator.DetectWithSchools(rnd, []spiece.Spiece{herring}, generator.Conditions{Depth: 10, Temperature: 8}, nil))
+
+	solitary := reefFish
+	solitary.Abundance = 5
+	detected = generator.DetectWithSchools(rnd, []spiece.Spiece{solitary},
+		generator.Conditions{Depth: 10, Temperature: 27, Transparency: 80}, []population.Detection{{Spiece: herring, Fish: 60}})
+	assert.Len(t, detected, 50)
+}
+
+func Test_DetectSpieces_Abundance(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	bloom := generator.Conditions{Depth: 10, Temperature: 27, Transparency: 80,
+		Abundance: map[string]float64{reefFish.Name: 0.25, herring.Name: 2}}
+
+	count := 0
+	for i := 0; i < 1000; i++ {
+		count += len(generator.DetectSpieces(rnd, []spiece.Spiece{reefFish}, bloom))
+	}
+
+	// An algal bloom makes the reef fish four times rarer.
+	assert.InDelta(t, 250, count, 60)
+
+	detections := []population.Detection{{SchoolID: 1, Spiece: herring, Fish: 12}}
+	assert.Len(t, generator.DetectWithSchools(rnd, nil, bloom, detections), 24)
+}
+
+func Test_SpieceCatalogue_Get(t *testing.T) {
+	ctx := context.Background()
+	version := spiece.Version{Count: 1, UpdatedAt: time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)}
+
+	spieceService := &MockSpieceService{}
+	spieceService.On("GetVersion", ctx).Return(version, nil).Times(2)
+	spieceService.On("GetAll", ctx, spiece.SpieceFilters{}).Return([]spiece.Spiece{herring}, nil).Once()
+
+	catalogue := generator.NewSpieceCatalogue(spieceService, time.Hour)
+
+	spieces, err := catalogue.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []spiece.Spiece{herring}, spieces)
+
+	// Spieces are not checked again till checkEvery passes or the catalogue is invalidated.
+	_, err = catalogue.Get(ctx)
+	assert.NoError(t, err)
+
+	// The version is the same, spieces are not reloaded.
+	catalogue.Invalidate()
+	_, err = catalogue.Get(ctx)
+	assert.NoError(t, err)
+
+	version.Count++
+	spieceService.On("GetVersion", ctx).Return(version, nil).Once()
+	spieceService.On("GetAll", ctx, spiece.SpieceFilters{}).Return([]spiece.Spiece{herring, reefFish}, nil).Once()
+
+	catalogue.Invalidate()
+	spieces, err = catalogue.Get(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, spieces, 2)
+	spieceService.AssertExpectations(t)
+}
@@ -0,0 +1,258 @@
+package generator
+
+import (
+	"context"
+	"sensors-generator/internal/groundtruth"
+	"sensors-generator/internal/sensor"
+	sensordata "sensors-generator/internal/sensorData"
+	"sensors-generator/internal/spiece"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSensorService struct {
+	mock.Mock
+}
+
+func (m *MockSensorService) GetAll(ctx context.Context, filters sensor.SensorFilters) ([]sensor.Sensor, error) {
+	args := m.Called(ctx, filters)
+	return args.Get(0).([]sensor.Sensor), args.Error(1)
+}
+
+func (m *MockSensorService) Create(ctx context.Context, sensors ...sensor.CreateSensorDTO) error {
+	args := m.Called(ctx, sensors)
+	return args.Error(0)
+}
+
+func (m *MockSensorService) Update(ctx context.Context, codeName sensor.Codename, sens sensor.UpdateSensorDTO) error {
+	args := m.Called(ctx, codeName, sens)
+	return args.Error(0)
+}
+
+func (m *MockSensorService) AddSensorToGroup(ctx context.Context, sensorID int, groupID int) error {
+	args := m.Called(ctx, sensorID, groupID)
+	return args.Error(0)
+}
+
+func (m *MockSensorService) GetExtremumForRegion(ctx context.Context, metric string, minCoords, maxCoords sensor.Coordinates, min bool) (float64, error) {
+	args := m.Called(ctx, metric, minCoords, maxCoords, min)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockSensorService) GetAvgForSensor(ctx context.Context, metric string, filters sensor.SensorFilters) (float64, error) {
+	args := m.Called(ctx, metric, filters)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockSensorService) GetAvgForSensors(ctx context.Context, metric string, sensorIDs []int, filters sensor.SensorFilters) (map[int]float64, error) {
+	args := m.Called(ctx, metric, sensorIDs, filters)
+	return args.Get(0).(map[int]float64), args.Error(1)
+}
+
+func (m *MockSensorService) GetCalibrations(ctx context.Context, codeName sensor.Codename) ([]sensor.Calibration, error) {
+	args := m.Called(ctx, codeName)
+	if obj := args.Get(0); obj != nil {
+		return obj.([]sensor.Calibration), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorService) AddCalibration(ctx context.Context, codeName sensor.Codename, calibration sensor.CreateCalibrationDTO) (*sensor.Calibration, error) {
+	args := m.Called(ctx, codeName, calibration)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*sensor.Calibration), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorService) GetMaintenance(ctx context.Context, filters sensor.SensorFilters) ([]sensor.Maintenance, error) {
+	args := m.Called(ctx, filters)
+	if obj := args.Get(0); obj != nil {
+		return obj.([]sensor.Maintenance), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorService) AddMaintenance(ctx context.Context, codeName sensor.Codename, maintenance sensor.CreateMaintenanceDTO) (*sensor.Maintenance, error) {
+	args := m.Called(ctx, codeName, maintenance)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*sensor.Maintenance), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// MockSensorDataService keeps created readings, so tests can wait for goroutines of the generator.
+// While Err is set readings are not created, it imitates an outage of Postgres.
+type MockSensorDataService struct {
+	mock.Mock
+
+	mu      sync.Mutex
+	Created []sensordata.CreateSensorDataDTO
+	Spieces [][]spiece.Spiece
+	err     error
+}
+
+func (m *MockSensorDataService) GetAll(ctx context.Context, filters sensordata.SensorDataFilters) ([]sensordata.SensorData, error) {
+	args := m.Called(ctx, filters)
+	return args.Get(0).([]sensordata.SensorData), args.Error(1)
+}
+
+func (m *MockSensorDataService) Iterate(ctx context.Context, filters sensordata.SensorDataFilters, fn func(sensordata.SensorData) error) error {
+	args := m.Called(ctx, filters, fn)
+	return args.Error(0)
+}
+
+func (m *MockSensorDataService) GetLatestForSensors(ctx context.Context, sensorIDs []int, filters sensordata.SensorDataFilters) ([]sensordata.SensorData, error) {
+	args := m.Called(ctx, sensorIDs, filters)
+	return args.Get(0).([]sensordata.SensorData), args.Error(1)
+}
+
+func (m *MockSensorDataService) GetOneByID(ctx context.Context, id int, filters sensordata.SensorDataFilters) (*sensordata.SensorData, error) {
+	args := m.Called(ctx, id, filters)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*sensordata.SensorData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorDataService) Create(ctx context.Context, sensorData ...sensordata.CreateSensorDataDTO) ([]int, error) {
+	m.mu.Lock()
+	m.Created = append(m.Created, sensorData...)
+	id := len(m.Created)
+	m.mu.Unlock()
+
+	return []int{id}, nil
+}
+
+func (m *MockSensorDataService) CreateWithSpieces(ctx context.Context, sensorData sensordata.CreateSensorDataDTO, spieces ...spiece.Spiece) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return 0, m.err
+	}
+
+	m.Created = append(m.Created, sensorData)
+	m.Spieces = append(m.Spieces, spieces)
+	return len(m.Created), nil
+}
+
+// SetErr makes CreateWithSpieces fail with err, nil makes it create readings again.
+func (m *MockSensorDataService) SetErr(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.err = err
+}
+
+// CreatedCount returns the number of created readings.
+func (m *MockSensorDataService) CreatedCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.Created)
+}
+
+// SensorIDs returns sensors of created readings.
+func (m *MockSensorDataService) SensorIDs() map[int]bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make(map[int]bool)
+	for _, reading := range m.Created {
+		ids[reading.SensorID] = true
+	}
+	return ids
+}
+
+func (m *MockSensorDataService) AddDetectedSpieces(ctx context.Context, sensorDataID int, spieces ...spiece.Spiece) error {
+	return nil
+}
+
+func (m *MockSensorDataService) Publish(sensorData sensordata.SensorData) {}
+
+func (m *MockSensorDataService) Subscribe(ctx context.Context, filters sensordata.SensorDataFilters) <-chan sensordata.Delivery {
+	args := m.Called(ctx, filters)
+	return args.Get(0).(<-chan sensordata.Delivery)
+}
+
+type MockPartitionService struct {
+	mock.Mock
+}
+
+func (m *MockPartitionService) Premake(ctx context.Context, now time.Time) (time.Time, []string, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(time.Time), args.Get(1).([]string), args.Error(2)
+}
+
+// MockRollupService keeps times rollups were recomputed from, failed attempts included, SetErr makes Reroll fail.
+type MockRollupService struct {
+	mu       sync.Mutex
+	err      error
+	rerolled []time.Time
+}
+
+func (m *MockRollupService) Reroll(ctx context.Context, from time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rerolled = append(m.rerolled, from)
+	return m.err
+}
+
+// SetErr makes Reroll fail with err, nil makes it succeed again.
+func (m *MockRollupService) SetErr(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.err = err
+}
+
+// Rerolled returns times rollups were recomputed from.
+func (m *MockRollupService) Rerolled() []time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]time.Time(nil), m.rerolled...)
+}
+
+// MockInjectedFaultService keeps recorded faults, SetErr makes Record fail.
+type MockInjectedFaultService struct {
+	mu       sync.Mutex
+	err      error
+	Recorded []groundtruth.CreateInjectedFaultDTO
+}
+
+func (m *MockInjectedFaultService) GetAll(ctx context.Context, filters groundtruth.InjectedFaultFilters) ([]groundtruth.InjectedFault, error) {
+	return nil, nil
+}
+
+func (m *MockInjectedFaultService) Record(ctx context.Context, injectedFaults ...groundtruth.CreateInjectedFaultDTO) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return m.err
+	}
+
+	m.Recorded = append(m.Recorded, injectedFaults...)
+	return nil
+}
+
+// SetErr makes Record fail with err, nil makes it record faults again.
+func (m *MockInjectedFaultService) SetErr(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.err = err
+}
+
+// RecordedCount returns the number of recorded faults.
+func (m *MockInjectedFaultService) RecordedCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.Recorded)
+}
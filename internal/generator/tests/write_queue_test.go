@@ -0,0 +1,310 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sensors-generator/config"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/generator"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/pkg/clock"
+	"sensors-generator/pkg/fault"
+	"sensors-generator/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errOutage = errors.New("connection refused")
+
+var queueSensor = sensor.Sensor{ID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1}, DataOutputRate: 10}
+
+func newQueueConfig(size int, whenFull string) *config.Config {
+	cfg := newGeneratorConfig(1, false)
+	cfg.WriteQueueConfig.Size = size
+	cfg.WriteQueueConfig.WhenFull = whenFull
+	return cfg
+}
+
+// generateDuringOutage writes 7 readings of a minute while Postgres is down.
+func generateDuringOutage(t *testing.T, cfg *config.Config) (*generator.DataGenerator, *MockSensorDataService) {
+	clk := clock.NewStepped(generatorStart)
+	dataGen, sensorDataService := newDataGenerator(clk, cfg, []sensor.Sensor{queueSensor})
+	sensorDataService.SetErr(errOutage)
+
+	assert.NoError(t, dataGen.Generate())
+	clk.Advance(time.Minute)
+	assert.Eventually(t, func() bool { return dataGen.Stats().Readings == 7 && clk.Waiting() == 1 }, time.Second, time.Millisecond)
+
+	return dataGen, sensorDataService
+}
+
+func Test_WriteQueue_RetriesDuringOutage(t *testing.T) {
+	dataGen, sensorDataService := generateDuringOutage(t, newQueueConfig(100, generator.WhenFullBlock))
+	defer dataGen.Wait()
+	defer dataGen.Stop()
+
+	assert.Eventually(t, func() bool { return dataGen.Stats().Writes.Retried > 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, 7, dataGen.Stats().Writes.Queued)
+	assert.Equal(t, 0, sensorDataService.CreatedCount())
+
+	sensorDataService.SetErr(nil)
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == 7 }, time.Second, time.Millisecond)
+
+	stats := dataGen.Stats().Writes
+	assert.Equal(t, 0, stats.Queued)
+	assert.Equal(t, uint64(7), stats.Written)
+	assert.Equal(t, uint64(0), stats.Dropped)
+	// Readings keep their order and every one is written with its spieces once.
+	for i, reading := range sensorDataService.Created {
+		assert.Equal(t, generatorStart.Add(time.Duration(i)*10*time.Second), reading.CreatedAt)
+	}
+	assert.Len(t, sensorDataService.Spieces, 7)
+}
+
+func Test_WriteQueue_RerollsLateReadings(t *testing.T) {
+	clk := clock.NewStepped(generatorStart)
+	rollupService := &MockRollupService{}
+	rollupService.SetErr(errOutage)
+
+	dataGen, sensorDataService := newDataGeneratorWithServices(clk, newQueueConfig(100, generator.WhenFullBlock),
+		generator.Services{RollupService: rollupService}, []sensor.Sensor{queueSensor})
+	sensorDataService.SetErr(errOutage)
+	assert.NoError(t, dataGen.Generate())
+	defer dataGen.Wait()
+	defer dataGen.Stop()
+
+	clk.Advance(time.Minute)
+	assert.Eventually(t, func() bool { return dataGen.Stats().Writes.Retried > 3 }, time.Second, time.Millisecond)
+	assert.Empty(t, rollupService.Rerolled())
+
+	// Once the queue is flushed rollups are recomputed from the oldest retried reading,
+	// it is tried again with the next flush when it fails.
+	sensorDataService.SetErr(nil)
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == 7 }, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { return len(rollupService.Rerolled()) == 1 }, time.Second, time.Millisecond)
+
+	rollupService.SetErr(nil)
+	clk.Advance(10 * time.Second)
+	assert.Eventually(t, func() bool { return len(rollupService.Rerolled()) == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, []time.Time{generatorStart, generatorStart}, rollupService.Rerolled())
+
+	// Readings written in time do not recompute rollups.
+	clk.Advance(time.Minute)
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == 14 }, time.Second, time.Millisecond)
+	assert.Never(t, func() bool { return len(rollupService.Rerolled()) > 2 }, 50*time.Millisecond, time.Millisecond)
+}
+
+func Test_WriteQueue_RetriesFaultRecords(t *testing.T) {
+	clk := clock.NewStepped(generatorStart)
+	injectedFaultService := &MockInjectedFaultService{}
+	injectedFaultService.SetErr(errOutage)
+
+	dataGen, sensorDataService := newDataGeneratorWithServices(clk, newGeneratorConfig(1, false),
+		generator.Services{InjectedFaultService: injectedFaultService}, []sensor.Sensor{queueSensor})
+	dataGen.SetFaultConfig(fault.Config{Enabled: true, Seed: 1, Profiles: []fault.Profile{
+		{Name: "spiky", Spike: fault.Spike{Probability: 1, Magnitude: 1}},
+	}})
+	assert.NoError(t, dataGen.Generate())
+	defer dataGen.Wait()
+	defer dataGen.Stop()
+
+	assert.Eventually(t, func() bool { return dataGen.Stats().Writes.Retried > 2 }, time.Second, time.Millisecond)
+
+	// The reading is written once, only its fault is retried.
+	injectedFaultService.SetErr(nil)
+	assert.Eventually(t, func() bool { return injectedFaultService.RecordedCount() == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, 1, sensorDataService.CreatedCount())
+	assert.Equal(t, uint64(1), dataGen.Stats().Writes.Written)
+
+	recorded := injectedFaultService.Recorded[0]
+	assert.Equal(t, fault.KindSpike, recorded.Fault.Kind)
+	if assert.NotNil(t, recorded.SensorDataID) {
+		assert.Equal(t, 1, *recorded.SensorDataID)
+	}
+}
+
+func Test_WriteQueue_StartedFaults(t *testing.T) {
+	clk := clock.NewStepped(generatorStart)
+	injectedFaultService := &MockInjectedFaultService{}
+	injectedFaultService.SetErr(errOutage)
+
+	dataGen, sensorDataService := newDataGeneratorWithServices(clk, newGeneratorConfig(1, false),
+		generator.Services{InjectedFaultService: injectedFaultService}, []sensor.Sensor{queueSensor})
+	dataGen.SetFaultConfig(fault.Config{Enabled: true, Seed: 1, Profiles: []fault.Profile{
+		{Name: "down", Outage: fault.Window{Probability: 1, Duration: time.Hour}},
+	}})
+	assert.NoError(t, dataGen.Generate())
+	defer dataGen.Wait()
+	defer dataGen.Stop()
+
+	// The outage is recorded by the write queue, the scheduler does not wait for Postgres.
+	assert.Eventually(t, func() bool { return dataGen.Stats().Writes.Retried > 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, 1, dataGen.Stats().Writes.Queued)
+
+	injectedFaultService.SetErr(nil)
+	assert.Eventually(t, func() bool { return injectedFaultService.RecordedCount() == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, 0, sensorDataService.CreatedCount())
+	assert.Equal(t, uint64(0), dataGen.Stats().Writes.Written)
+
+	recorded := injectedFaultService.Recorded[0]
+	assert.Equal(t, fault.KindOutage, recorded.Fault.Kind)
+	assert.Nil(t, recorded.SensorDataID)
+}
+
+func Test_WriteQueue_Block(t *testing.T) {
+	clk := clock.NewStepped(generatorStart)
+	dataGen, sensorDataService := newDataGenerator(clk, newQueueConfig(1, generator.WhenFullBlock), []sensor.Sensor{queueSensor})
+	sensorDataService.SetErr(errOutage)
+
+	assert.NoError(t, dataGen.Generate())
+	defer dataGen.Wait()
+	defer dataGen.Stop()
+	clk.Advance(time.Minute)
+
+	// One reading is retried, one waits in the queue and the worker waits for room, so the schedule lags.
+	assert.Eventually(t, func() bool { return dataGen.Stats().Writes.Queued == 2 && dataGen.Stats().Busy == 1 },
+		time.Second, time.Millisecond)
+	assert.Equal(t, 0, clk.Waiting())
+
+	sensorDataService.SetErr(nil)
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == 7 }, time.Second, time.Millisecond)
+	assert.Equal(t, uint64(0), dataGen.Stats().Writes.Dropped)
+}
+
+func Test_WriteQueue_DropNewest(t *testing.T) {
+	dataGen, sensorDataService := generateDuringOutage(t, newQueueConfig(3, generator.WhenFullDropNewest))
+	defer dataGen.Wait()
+	defer dataGen.Stop()
+
+	stats := dataGen.Stats().Writes
+	assert.Equal(t, 7, stats.Queued+int(stats.Dropped))
+	assert.LessOrEqual(t, stats.Queued, 4)
+
+	sensorDataService.SetErr(nil)
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == stats.Queued }, time.Second, time.Millisecond)
+	assert.Equal(t, generatorStart, sensorDataService.Created[0].CreatedAt)
+}
+
+func Test_WriteQueue_DropOldest(t *testing.T) {
+	dataGen, sensorDataService := generateDuringOutage(t, newQueueConfig(3, generator.WhenFullDropOldest))
+	defer dataGen.Wait()
+	defer dataGen.Stop()
+
+	stats := dataGen.Stats().Writes
+	assert.Equal(t, 7, stats.Queued+int(stats.Dropped))
+	assert.LessOrEqual(t, stats.Queued, 4)
+
+	sensorDataService.SetErr(nil)
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == stats.Queued }, time.Second, time.Millisecond)
+	assert.Equal(t, generatorStart.Add(time.Minute), sensorDataService.Created[stats.Queued-1].CreatedAt)
+}
+
+func Test_WriteQueue_Rejected(t *testing.T) {
+	clk := clock.NewStepped(generatorStart)
+	dataGen, sensorDataService := newDataGenerator(clk, newQueueConfig(100, generator.WhenFullBlock), []sensor.Sensor{queueSensor})
+	sensorDataService.SetErr(apperror.ErrNotFound)
+
+	assert.NoError(t, dataGen.Generate())
+	defer dataGen.Wait()
+	defer dataGen.Stop()
+
+	assert.Eventually(t, func() bool { return dataGen.Stats().Writes.Rejected == 1 }, time.Second, time.Millisecond)
+	stats := dataGen.Stats().Writes
+	assert.Equal(t, 0, stats.Queued)
+	assert.Equal(t, uint64(0), stats.Retried)
+}
+
+func Test_WriteQueue_PermanentError(t *testing.T) {
+	clk := clock.NewStepped(generatorStart)
+	dataGen, sensorDataService := newDataGenerator(clk, newQueueConfig(100, generator.WhenFullBlock), []sensor.Sensor{queueSensor})
+	sensorDataService.SetErr(apperror.ErrValidation)
+
+	assert.NoError(t, dataGen.Generate())
+	defer dataGen.Wait()
+	defer dataGen.Stop()
+
+	// Delays of 1, 2 and 4ms reach retry_max of 5ms, then the reading is dropped and the writer goes on.
+	assert.Eventually(t, func() bool { return dataGen.Stats().Writes.Failed == 1 }, time.Second, time.Millisecond)
+	stats := dataGen.Stats().Writes
+	assert.Equal(t, 0, stats.Queued)
+	assert.Equal(t, uint64(3), stats.Retried)
+
+	sensorDataService.SetErr(nil)
+	clk.Advance(10 * time.Second)
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == 1 }, time.Second, time.Millisecond)
+}
+
+func Test_WriteQueue_Spill(t *testing.T) {
+	cfg := newQueueConfig(2, generator.WhenFullDropNewest)
+	cfg.WriteQueueConfig.SpillFile = filepath.Join(t.TempDir(), "readings.spill")
+
+	dataGen, _ := generateDuringOutage(t, cfg)
+
+	stats := dataGen.Stats().Writes
+	assert.Equal(t, 7, stats.Queued+stats.Spilled)
+	assert.Greater(t, stats.Spilled, 0)
+	assert.Equal(t, uint64(0), stats.Dropped)
+
+	// Readings left in memory spill when the queue is closed during the outage.
+	dataGen.Stop()
+	dataGen.Wait()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	dataGen.Close(ctx)
+	assert.Equal(t, 7, dataGen.Stats().Writes.Spilled)
+
+	// The next run writes spilled readings after Postgres is back.
+	clk := clock.NewStepped(generatorStart.Add(time.Hour))
+	dataGen, sensorDataService := newDataGenerator(clk, cfg, []sensor.Sensor{queueSensor})
+	assert.Equal(t, 7, dataGen.Stats().Writes.Spilled)
+
+	assert.NoError(t, dataGen.Generate())
+	defer dataGen.Wait()
+	defer dataGen.Stop()
+
+	assert.Eventually(t, func() bool { return sensorDataService.CreatedCount() == 8 }, 3*time.Second, time.Millisecond)
+	assert.Equal(t, 0, dataGen.Stats().Writes.Spilled)
+
+	restored := make(map[time.Time]bool)
+	for _, reading := range sensorDataService.Created {
+		restored[reading.CreatedAt] = true
+	}
+	for i := 0; i < 7; i++ {
+		assert.True(t, restored[generatorStart.Add(time.Duration(i)*10*time.Second)])
+	}
+}
+
+func Test_WriteQueue_SpillRestartDuringRestore(t *testing.T) {
+	logging.Init("trace", true)
+	cfg := newQueueConfig(2, generator.WhenFullDropNewest)
+	cfg.WriteQueueConfig.SpillFile = filepath.Join(t.TempDir(), "readings.spill")
+
+	dataGen, _ := generateDuringOutage(t, cfg)
+	dataGen.Stop()
+	dataGen.Wait()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	dataGen.Close(ctx)
+
+	// The next run takes spilled readings into the queue, but Postgres is still down.
+	clk := clock.NewStepped(generatorStart.Add(time.Hour))
+	dataGen, sensorDataService := newDataGenerator(clk, cfg, []sensor.Sensor{queueSensor})
+	sensorDataService.SetErr(errOutage)
+	assert.NoError(t, dataGen.Generate())
+	assert.Eventually(t, func() bool {
+		stats := dataGen.Stats()
+		return stats.Readings == 1 && stats.Writes.Queued == 2 && stats.Writes.Spilled == 6
+	}, 3*time.Second, time.Millisecond)
+
+	dataGen.Stop()
+	dataGen.Wait()
+	dataGen.Close(ctx)
+	assert.Equal(t, 8, dataGen.Stats().Writes.Spilled)
+
+	// Taken readings are not in the file anymore, so they are not restored twice.
+	dataGen, _ = newDataGenerator(clk, cfg, []sensor.Sensor{queueSensor})
+	assert.Equal(t, 8, dataGen.Stats().Writes.Spilled)
+}
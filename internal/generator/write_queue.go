@@ -0,0 +1,455 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/sensor"
+	sensordata "sensors-generator/internal/sensorData"
+	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/fault"
+	"sensors-generator/pkg/logging"
+	"sync"
+	"time"
+)
+
+// Policies of a full write queue.
+const (
+	WhenFullBlock      = "block"
+	WhenFullDropOldest = "drop_oldest"
+	WhenFullDropNewest = "drop_newest"
+)
+
+// spillRestoreEvery is how often the spill file is checked when the queue does not report free room.
+const spillRestoreEvery = time.Second
+
+// restoreBatchSize is the most readings moved from the spill file to the queue at once.
+const restoreBatchSize = 100
+
+// pendingReading is a generated reading waiting to be written. Faults are recorded with the id of the reading
+// once it is written. It is kept in the spill file as json, so it does not refer to the sensor.
+// Late readings may be written after their hour was rolled up, e.g. delayed by a fault or spilled.
+// ID is set once the reading is written and Faults keep the faults which are not recorded yet,
+// so a retry neither writes the reading nor records its faults twice.
+// Started items have no reading, they only record faults which started with a reading of the sensor.
+type pendingReading struct {
+	SensorID int                            `json:"sensor_id"`
+	CodeName sensor.Codename                `json:"codename"`
+	Coords   sensor.Coordinates             `json:"coordinates"`
+	Reading  sensordata.CreateSensorDataDTO `json:"reading"`
+	Spieces  []spiece.Spiece                `json:"spieces"`
+	Faults   []fault.Fault                  `json:"faults,omitempty"`
+	Late     bool                           `json:"late,omitempty"`
+	ID       int                            `json:"id,omitempty"`
+	Started  bool                           `json:"started,omitempty"`
+}
+
+// WriteStats count readings which went through the write queue since the generator was created.
+// Started faults are queued as well, they are counted by all counters but written.
+type WriteStats struct {
+	// Queued readings are in memory, retried ones included.
+	Queued int `json:"queued"`
+	// Spilled readings wait in the spill file.
+	Spilled int    `json:"spilled"`
+	Written uint64 `json:"written"`
+	Retried uint64 `json:"retried"`
+	// Dropped by when_full policy, or left in memory when the queue was closed without a spill file.
+	Dropped uint64 `json:"dropped"`
+	// Rejected readings are not retried, e.g. their sensor was deleted.
+	Rejected uint64 `json:"rejected"`
+	// Failed readings kept failing with a permanent error, e.g. a constraint violation,
+	// after delays between attempts reached retry_max, they are dropped.
+	Failed uint64 `json:"failed"`
+}
+
+// writeQueue is a bounded queue between the generator and Postgres. Writers retry failed readings
+// with exponential backoff, so an outage keeps readings in the queue. When the queue is full readings
+// spill to the spill file, without it when_full policy blocks the generator or drops readings.
+type writeQueue struct {
+	write    func(ctx context.Context, reading *pendingReading) error
+	reroll   func(ctx context.Context, from time.Time) error
+	size     int
+	writers  int
+	whenFull string
+	retryMin time.Duration
+	retryMax time.Duration
+	spill    *spillFile
+	logger   *logging.Logger
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	items    []pendingReading
+	inFlight int
+	// restoring is the room reserved for readings on the way from the spill file to items.
+	restoring int
+	stats     WriteStats
+	// late is the oldest created_at of late readings written since rollups were recomputed last.
+	late time.Time
+	// room wakes the restorer when a writer takes a reading.
+	room chan struct{}
+
+	start   sync.Once
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+}
+
+// newWriteQueue returns a queue which writes readings with write. Once the queue is flushed, reroll recomputes
+// rollups from the oldest late reading, so readings written behind the rollup watermark are not lost from them.
+// Reroll is nil when rollups are left to the retention job.
+func newWriteQueue(write func(ctx context.Context, reading *pendingReading) error,
+	reroll func(ctx context.Context, from time.Time) error, size, writers int, whenFull string,
+	retryMin, retryMax time.Duration, spillPath string) *writeQueue {
+	q := &writeQueue{
+		write:    write,
+		reroll:   reroll,
+		size:     size,
+		writers:  writers,
+		whenFull: whenFull,
+		retryMin: retryMin,
+		retryMax: retryMax,
+		logger:   logging.GetLogger(),
+		room:     make(chan struct{}, 1),
+	}
+	q.notEmpty = sync.NewCond(&q.mu)
+	q.notFull = sync.NewCond(&q.mu)
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+
+	if spillPath != "" {
+		spill, err := openSpillFile(spillPath)
+		if err != nil {
+			q.logger.Errorf("Cannot open spill file %s, readings will not spill, due to error: %v", spillPath, err)
+		} else {
+			q.spill = spill
+		}
+	}
+
+	return q
+}
+
+// Start runs writers, and the restorer when readings can spill. Readings spilled by the last run
+// are written first. Start can be called many times, writers are started once.
+func (q *writeQueue) Start() {
+	q.start.Do(func() {
+		q.running.Add(q.writers)
+		for i := 0; i < q.writers; i++ {
+			go func() {
+				defer q.running.Done()
+				q.work()
+			}()
+		}
+
+		if q.spill != nil {
+			q.running.Add(1)
+			go func() {
+				defer q.running.Done()
+				q.restore()
+			}()
+		}
+	})
+}
+
+// Push queues the reading. When the queue is full the reading spills, otherwise when_full applies:
+// block waits for room till ctx is done and then queues the reading anyway, so it is not lost.
+// The spill file is synced without the lock, so other producers and writers do not wait for the disk.
+func (q *writeQueue) Push(ctx context.Context, reading pendingReading) {
+	q.mu.Lock()
+	if len(q.items)+q.restoring >= q.size && q.spill != nil {
+		q.mu.Unlock()
+		err := q.spill.Append(reading)
+		if err == nil {
+			return
+		}
+		q.logger.Errorf("Cannot spill reading of sensor %d, due to error: %v", reading.SensorID, err)
+		q.mu.Lock()
+	}
+	defer q.mu.Unlock()
+
+	if len(q.items) >= q.size {
+		switch q.whenFull {
+		case WhenFullDropNewest:
+			q.stats.Dropped++
+			return
+		case WhenFullDropOldest:
+			q.items = q.items[1:]
+			q.stats.Dropped++
+		default:
+			stop := context.AfterFunc(ctx, func() {
+				q.mu.Lock()
+				defer q.mu.Unlock()
+				q.notFull.Broadcast()
+			})
+			for len(q.items) >= q.size && ctx.Err() == nil {
+				q.notFull.Wait()
+			}
+			stop()
+		}
+	}
+
+	q.items = append(q.items, reading)
+	q.notEmpty.Signal()
+}
+
+// Close waits till queued readings are written or ctx is done, then stops writers.
+// Readings which are left spill to the spill file, without it they are dropped.
+func (q *writeQueue) Close(ctx context.Context) {
+	stop := context.AfterFunc(ctx, func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.notFull.Broadcast()
+	})
+	q.mu.Lock()
+	for (len(q.items) > 0 || q.inFlight > 0) && ctx.Err() == nil {
+		q.notFull.Wait()
+	}
+	q.mu.Unlock()
+	stop()
+
+	q.cancel()
+	q.mu.Lock()
+	q.notEmpty.Broadcast()
+	q.mu.Unlock()
+	q.running.Wait()
+
+	q.rerollLate(ctx, true)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) == 0 {
+		return
+	}
+	if q.spill != nil {
+		err := q.spill.Append(q.items...)
+		if err == nil {
+			q.items = nil
+			return
+		}
+		q.logger.Errorf("Cannot spill %d queued readings, due to error: %v", len(q.items), err)
+	}
+
+	q.logger.Errorf("%d queued readings are dropped.", len(q.items))
+	q.stats.Dropped += uint64(len(q.items))
+	q.items = nil
+}
+
+// Stats returns counters of the queue.
+func (q *writeQueue) Stats() WriteStats {
+	q.mu.Lock()
+	stats := q.stats
+	stats.Queued = len(q.items) + q.inFlight
+	q.mu.Unlock()
+
+	if q.spill != nil {
+		stats.Spilled = q.spill.Len()
+	}
+	return stats
+}
+
+// pop takes the first reading, it waits for one till the queue is closed.
+func (q *writeQueue) pop() (pendingReading, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.items) == 0 && q.ctx.Err() == nil {
+		q.notEmpty.Wait()
+	}
+	if q.ctx.Err() != nil {
+		return pendingReading{}, false
+	}
+
+	reading := q.items[0]
+	q.items = q.items[1:]
+	q.inFlight++
+	q.notFull.Broadcast()
+
+	select {
+	case q.room <- struct{}{}:
+	default:
+	}
+
+	return reading, true
+}
+
+// done finishes the reading taken by pop. A reading which was not written returns to the head of the queue.
+func (q *writeQueue) done(reading pendingReading, written bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.inFlight--
+	if !written {
+		q.items = append([]pendingReading{reading}, q.items...)
+	}
+	q.notFull.Broadcast()
+}
+
+func (q *writeQueue) work() {
+	for {
+		reading, ok := q.pop()
+		if !ok {
+			return
+		}
+
+		written := q.writeWithRetry(&reading)
+		q.done(reading, written)
+		q.rerollLate(q.ctx, false)
+	}
+}
+
+// rerollLate recomputes rollups from the oldest late reading once the queue and the spill file are empty,
+// or anyway when the queue is closed. On error late readings are kept for the next flush.
+func (q *writeQueue) rerollLate(ctx context.Context, closing bool) {
+	if q.reroll == nil {
+		return
+	}
+
+	q.mu.Lock()
+	from := q.late
+	flushed := len(q.items) == 0 && q.inFlight == 0 && q.restoring == 0 && (q.spill == nil || q.spill.Len() == 0)
+	if from.IsZero() || !(flushed || closing) {
+		q.mu.Unlock()
+		return
+	}
+	q.late = time.Time{}
+	q.mu.Unlock()
+
+	// Writers are stopped by the closed queue, rollups are recomputed anyway.
+	if err := q.reroll(context.WithoutCancel(ctx), from); err != nil {
+		q.logger.Errorf("Cannot recompute rollups of late readings from %s, due to error: %v",
+			from.Format(time.RFC3339), err)
+		q.mu.Lock()
+		q.addLate(from)
+		q.mu.Unlock()
+	}
+}
+
+// writeWithRetry writes the reading till it succeeds, delays between attempts double up to retry_max.
+// It returns false when the queue was closed before the reading was written. Rejected readings are not retried.
+// Permanent errors may pass meanwhile, e.g. a missing partition is created, so they are retried
+// till the delay reaches retry_max, then the reading is dropped, so it does not block the writer.
+// Readings which were retried are late, their hour may be rolled up meanwhile.
+func (q *writeQueue) writeWithRetry(reading *pendingReading) bool {
+	delay := q.retryMin
+	for {
+		err := q.write(q.ctx, reading)
+		switch {
+		case err == nil:
+			q.mu.Lock()
+			if !reading.Started {
+				q.stats.Written++
+			}
+			if reading.Late && !reading.Started {
+				q.addLate(reading.Reading.CreatedAt)
+			}
+			q.mu.Unlock()
+			return true
+		case errors.Is(err, apperror.ErrNotFound):
+			q.logger.Errorf("Reading of sensor %d is rejected, due to error: %v", reading.SensorID, err)
+			q.count(func(stats *WriteStats) { stats.Rejected++ })
+			return true
+		case isPermanent(err) && delay >= q.retryMax:
+			q.logger.Errorf("Reading of sensor %d is dropped after retries, due to error: %v", reading.SensorID, err)
+			q.count(func(stats *WriteStats) { stats.Failed++ })
+			return true
+		}
+
+		q.logger.Errorf("Cannot write reading of sensor %d, retry in %s, due to error: %v", reading.SensorID, delay, err)
+		q.count(func(stats *WriteStats) { stats.Retried++ })
+		// Only faults are left of a written reading, it is not late.
+		if reading.ID == 0 {
+			reading.Late = true
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-q.ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if delay > q.retryMax {
+			delay = q.retryMax
+		}
+	}
+}
+
+// isPermanent tells errors which repeat when the same reading is written again from outages.
+func isPermanent(err error) bool {
+	return errors.Is(err, apperror.ErrValidation) || errors.Is(err, apperror.ErrBadRequest)
+}
+
+// addLate keeps the oldest created_at of written late readings, it should be called with mu locked.
+func (q *writeQueue) addLate(createdAt time.Time) {
+	if q.late.IsZero() || createdAt.Before(q.late) {
+		q.late = createdAt
+	}
+}
+
+func (q *writeQueue) count(fn func(stats *WriteStats)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	fn(&q.stats)
+}
+
+// restore moves spilled readings back to the queue while it has room.
+func (q *writeQueue) restore() {
+	ticker := time.NewTicker(spillRestoreEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-q.room:
+		}
+
+		for q.spill.Len() > 0 && q.ctx.Err() == nil {
+			if !q.restoreBatch() {
+				break
+			}
+		}
+	}
+}
+
+// restoreBatch moves spilled readings to the free room of the queue and reports whether any were moved.
+// The room is reserved while the file is read without the lock, so pushed readings spill meanwhile.
+func (q *writeQueue) restoreBatch() bool {
+	q.mu.Lock()
+	free := q.size - len(q.items) - q.inFlight
+	if free <= 0 {
+		q.mu.Unlock()
+		return false
+	}
+	if free > restoreBatchSize {
+		free = restoreBatchSize
+	}
+	q.restoring = free
+	q.mu.Unlock()
+
+	readings, err := q.spill.Take(free)
+	if err != nil {
+		q.logger.Errorf("Cannot restore spilled readings, due to error: %v", err)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.restoring = 0
+	if len(readings) == 0 {
+		return false
+	}
+
+	// Spilled readings waited for room, their hours may be rolled up meanwhile.
+	for i := range readings {
+		readings[i].Late = true
+	}
+
+	q.items = append(q.items, readings...)
+	q.notEmpty.Broadcast()
+	return true
+}
@@ -0,0 +1,98 @@
+package gql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+const (
+	graphqlPath = "graphql"
+	// maxDepth stops queries nested deeper than group, sensors, readings and spieces need.
+	maxDepth = 8
+)
+
+//go:embed schema.graphql
+var schemaString string
+
+type handler struct {
+	schema   *graphql.Schema
+	services Services
+	logger   *logging.Logger
+}
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func NewHandler(services Services, logger *logging.Logger) *handler {
+	return &handler{
+		schema:   graphql.MustParseSchema(schemaString, &resolver{services: services}, graphql.MaxDepth(maxDepth)),
+		services: services,
+		logger:   logger,
+	}
+}
+
+func (h *handler) Register(router gin.IRouter) {
+	router.GET(graphqlPath, h.Query)
+	router.POST(graphqlPath, h.Query)
+}
+
+// Query
+// @Summary GraphQL query
+// @Description Queries groups, sensors, readings and spieces in one request. The schema is in internal/gql/schema.graphql.
+// @Description POST takes JSON body with query, operationName and variables, GET takes them as query parameters,
+// @Description variables as JSON. Sensors, their average temperatures and readings are loaded in batches.
+// @Tags GraphQL
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param query query string false "Query, for GET requests"
+// @Param operationName query string false "Operation name, for GET requests"
+// @Param variables query string false "Variables as JSON, for GET requests"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /graphql [get]
+// @Router /graphql [post]
+func (h *handler) Query(c *gin.Context) {
+	var req request
+
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				c.Error(apperror.ErrorWithMessage(apperror.ErrBadRequest, "Variables should be JSON object."))
+				return
+			}
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.LWithContext(c.Request.Context()).Errorf("Cannot parse request, due to error: %v", err)
+		c.Error(apperror.ErrBadRequest)
+		return
+	}
+
+	if req.Query == "" {
+		c.Error(apperror.ErrorWithMessage(apperror.ErrBadRequest, "Query is required."))
+		return
+	}
+
+	ctx := contextWithLoaders(c.Request.Context(),
+		newLoaders(h.services.SensorService, h.services.SensorDataService))
+
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	if len(resp.Errors) > 0 {
+		h.logger.LWithContext(ctx).Warnf("Query has errors: %v", resp.Errors)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
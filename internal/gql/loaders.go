@@ -0,0 +1,184 @@
+package gql
+
+import (
+	"context"
+	"sensors-generator/internal/sensor"
+	sensordata "sensors-generator/internal/sensorData"
+	"time"
+
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// loaderWait is how long loaders collect keys of sibling fields before one batched query.
+const loaderWait = 5 * time.Millisecond
+
+type loadersKey struct{}
+
+// timeRange is comparable form of TimeRange argument, zero bounds do not filter.
+type timeRange struct {
+	from int64
+	till int64
+}
+
+func (r timeRange) bounds() (from, till time.Time) {
+	if r.from != 0 {
+		from = time.Unix(0, r.from).UTC()
+	}
+	if r.till != 0 {
+		till = time.Unix(0, r.till).UTC()
+	}
+	return from, till
+}
+
+type avgKey struct {
+	sensorID int
+	metric   string
+	timeRange
+}
+
+type readingsKey struct {
+	sensorID int
+	timeRange
+	last int
+	raw  bool
+}
+
+// loaders batch queries of one request, a naive resolver would query sensors of every group
+// and readings of every sensor one by one.
+type loaders struct {
+	sensorsByGroup *dataloader.Loader[string, []sensor.Sensor]
+	avg            *dataloader.Loader[avgKey, *float64]
+	readings       *dataloader.Loader[readingsKey, []sensordata.SensorData]
+}
+
+func newLoaders(sensorService sensor.ISensorService, sensorDataService sensordata.ISensorDataService) *loaders {
+	return &loaders{
+		sensorsByGroup: dataloader.NewBatchedLoader(sensorsByGroupBatch(sensorService),
+			dataloader.WithWait[string, []sensor.Sensor](loaderWait)),
+		avg: dataloader.NewBatchedLoader(avgBatch(sensorService),
+			dataloader.WithWait[avgKey, *float64](loaderWait)),
+		readings: dataloader.NewBatchedLoader(readingsBatch(sensorDataService),
+			dataloader.WithWait[readingsKey, []sensordata.SensorData](loaderWait)),
+	}
+}
+
+func contextWithLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// sensorsByGroupBatch loads all sensors once, there are few of them.
+func sensorsByGroupBatch(sensorService sensor.ISensorService) dataloader.BatchFunc[string, []sensor.Sensor] {
+	return func(ctx context.Context, groupNames []string) []*dataloader.Result[[]sensor.Sensor] {
+		results := make([]*dataloader.Result[[]sensor.Sensor], len(groupNames))
+
+		sensors, err := sensorService.GetAll(ctx, sensor.SensorFilters{})
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[[]sensor.Sensor]{Error: err}
+			}
+			return results
+		}
+
+		byGroup := make(map[string][]sensor.Sensor)
+		for _, s := range sensors {
+			byGroup[s.CodeName.GroupName] = append(byGroup[s.CodeName.GroupName], s)
+		}
+
+		for i, groupName := range groupNames {
+			results[i] = &dataloader.Result[[]sensor.Sensor]{Data: byGroup[groupName]}
+		}
+
+		return results
+	}
+}
+
+// avgBatch queries once per distinct metric and time range.
+func avgBatch(sensorService sensor.ISensorService) dataloader.BatchFunc[avgKey, *float64] {
+	type args struct {
+		metric string
+		timeRange
+	}
+
+	return func(ctx context.Context, keys []avgKey) []*dataloader.Result[*float64] {
+		results := make([]*dataloader.Result[*float64], len(keys))
+
+		for a, indexes := range groupIndexes(keys, func(key avgKey) args { return args{key.metric, key.timeRange} }) {
+			sensorIDs := make([]int, 0, len(indexes))
+			for _, i := range indexes {
+				sensorIDs = append(sensorIDs, keys[i].sensorID)
+			}
+
+			filters := sensor.SensorFilters{}
+			filters.FromDate, filters.TillDate = a.bounds()
+
+			values, err := sensorService.GetAvgForSensors(ctx, a.metric, sensorIDs, filters)
+			for _, i := range indexes {
+				if err != nil {
+					results[i] = &dataloader.Result[*float64]{Error: err}
+					continue
+				}
+
+				results[i] = &dataloader.Result[*float64]{}
+				if value, ok := values[keys[i].sensorID]; ok {
+					results[i].Data = &value
+				}
+			}
+		}
+
+		return results
+	}
+}
+
+// readingsBatch queries once per distinct time range, limit and raw.
+func readingsBatch(sensorDataService sensordata.ISensorDataService) dataloader.BatchFunc[readingsKey, []sensordata.SensorData] {
+	type args struct {
+		timeRange
+		last int
+		raw  bool
+	}
+
+	return func(ctx context.Context, keys []readingsKey) []*dataloader.Result[[]sensordata.SensorData] {
+		results := make([]*dataloader.Result[[]sensordata.SensorData], len(keys))
+
+		for a, indexes := range groupIndexes(keys, func(key readingsKey) args { return args{key.timeRange, key.last, key.raw} }) {
+			sensorIDs := make([]int, 0, len(indexes))
+			for _, i := range indexes {
+				sensorIDs = append(sensorIDs, keys[i].sensorID)
+			}
+
+			filters := sensordata.SensorDataFilters{Limit: a.last, Raw: a.raw}
+			filters.FromDate, filters.TillDate = a.bounds()
+
+			readings, err := sensorDataService.GetLatestForSensors(ctx, sensorIDs, filters)
+
+			bySensor := make(map[int][]sensordata.SensorData)
+			for _, reading := range readings {
+				bySensor[reading.SensorID] = append(bySensor[reading.SensorID], reading)
+			}
+
+			for _, i := range indexes {
+				if err != nil {
+					results[i] = &dataloader.Result[[]sensordata.SensorData]{Error: err}
+					continue
+				}
+				results[i] = &dataloader.Result[[]sensordata.SensorData]{Data: bySensor[keys[i].sensorID]}
+			}
+		}
+
+		return results
+	}
+}
+
+// groupIndexes groups indexes of keys by arguments which need a separate query.
+func groupIndexes[K any, A comparable](keys []K, argsOf func(K) A) map[A][]int {
+	groups := make(map[A][]int)
+	for i, key := range keys {
+		a := argsOf(key)
+		groups[a] = append(groups[a], i)
+	}
+	return groups
+}
@@ -0,0 +1,516 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/group"
+	"sensors-generator/internal/sensor"
+	sensordata "sensors-generator/internal/sensorData"
+	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/measurement"
+	"sensors-generator/pkg/trajectory"
+	"sort"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// maxReadings limits readings of a sensor in one query, the schema default is 10.
+const maxReadings = 1000
+
+type Services struct {
+	SensorGroupService group.ISensorGroupService
+	SensorService      sensor.ISensorService
+	SensorDataService  sensordata.ISensorDataService
+	SpieceService      spiece.ISpiecesService
+}
+
+type timeRangeInput struct {
+	From *graphql.Time
+	Till *graphql.Time
+}
+
+func (r *timeRangeInput) key() timeRange {
+	var key timeRange
+	if r == nil {
+		return key
+	}
+	if r.From != nil {
+		key.from = r.From.UnixNano()
+	}
+	if r.Till != nil {
+		key.till = r.Till.UnixNano()
+	}
+	return key
+}
+
+type resolver struct {
+	services Services
+}
+
+func (r *resolver) Groups(ctx context.Context) ([]*groupResolver, error) {
+	groups, err := r.services.SensorGroupService.GetAll(ctx, group.SensorGroupFilters{})
+	if err != nil {
+		return nil, queryError(ctx, err)
+	}
+
+	resolvers := make([]*groupResolver, 0, len(groups))
+	for _, g := range groups {
+		resolvers = append(resolvers, &groupResolver{services: r.services, group: g})
+	}
+
+	return resolvers, nil
+}
+
+func (r *resolver) Group(ctx context.Context, args struct{ Name string }) (*groupResolver, error) {
+	groups, err := r.Groups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range groups {
+		if g.group.Name == args.Name {
+			return g, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *resolver) Sensors(ctx context.Context, args struct{ Group *string }) ([]*sensorResolver, error) {
+	if args.Group != nil {
+		return loadSensors(ctx, r.services, *args.Group)
+	}
+
+	sensors, err := r.services.SensorService.GetAll(ctx, sensor.SensorFilters{})
+	if err != nil {
+		return nil, queryError(ctx, err)
+	}
+
+	return newSensorResolvers(r.services, sensors), nil
+}
+
+func (r *resolver) Sensor(ctx context.Context, args struct{ Codename string }) (*sensorResolver, error) {
+	codeName, err := sensor.NewCodenameFromString(args.Codename)
+	if err != nil {
+		return nil, queryError(ctx, err)
+	}
+
+	sensors, err := loadSensors(ctx, r.services, codeName.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sensors {
+		if s.sensor.CodeName == codeName {
+			return s, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *resolver) Spieces(ctx context.Context) ([]*spieceResolver, error) {
+	spieces, err := r.services.SpieceService.GetAll(ctx, spiece.SpieceFilters{})
+	if err != nil {
+		return nil, queryError(ctx, err)
+	}
+
+	resolvers := make([]*spieceResolver, 0, len(spieces))
+	for _, s := range spieces {
+		resolvers = append(resolvers, &spieceResolver{spiece: s})
+	}
+
+	return resolvers, nil
+}
+
+type groupResolver struct {
+	services Services
+	group    group.SensorGroup
+}
+
+func (r *groupResolver) ID() int32 {
+	return int32(r.group.ID)
+}
+
+func (r *groupResolver) Name() string {
+	return r.group.Name
+}
+
+func (r *groupResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.group.CreatedAt}
+}
+
+func (r *groupResolver) Sensors(ctx context.Context) ([]*sensorResolver, error) {
+	return loadSensors(ctx, r.services, r.group.Name)
+}
+
+func (r *groupResolver) AvgTemperature(ctx context.Context) (float64, error) {
+	temperature, err := r.services.SensorGroupService.GetAvgTemperatureInGroup(ctx, r.group.Name, group.SensorGroupFilters{})
+	if err != nil {
+		return 0, queryError(ctx, err)
+	}
+	return float64(temperature), nil
+}
+
+func (r *groupResolver) AvgTransparency(ctx context.Context) (int32, error) {
+	transparency, err := r.services.SensorGroupService.GetAvgTrasparencyInGroup(ctx, r.group.Name, group.SensorGroupFilters{})
+	if err != nil {
+		return 0, queryError(ctx, err)
+	}
+	return int32(transparency), nil
+}
+
+func (r *groupResolver) Avg(ctx context.Context, args struct{ Metric string }) (float64, error) {
+	avg, err := r.services.SensorGroupService.GetAvgInGroup(ctx, args.Metric, r.group.Name, group.SensorGroupFilters{})
+	if err != nil {
+		return 0, queryError(ctx, err)
+	}
+	return avg, nil
+}
+
+func (r *groupResolver) Spieces(ctx context.Context, args struct {
+	Range *timeRangeInput
+	Top   *int32
+}) ([]*spieceCountResolver, error) {
+	filters := group.SensorGroupFilters{}
+	if args.Top != nil {
+		if *args.Top < 0 {
+			return nil, queryError(ctx, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Top should be >= 0."))
+		}
+		filters.TopLimit = int(*args.Top)
+	}
+	filters.FromDate, filters.TillDate = args.Range.key().bounds()
+
+	spieces, err := r.services.SensorGroupService.GetSpiecesInGroup(ctx, r.group.Name, filters)
+	if err != nil {
+		return nil, queryError(ctx, err)
+	}
+
+	resolvers := make([]*spieceCountResolver, 0, len(spieces))
+	for s, count := range spieces {
+		resolvers = append(resolvers, &spieceCountResolver{spiece: *s, count: count})
+	}
+
+	sort.Slice(resolvers, func(i, j int) bool {
+		if resolvers[i].count != resolvers[j].count {
+			return resolvers[i].count > resolvers[j].count
+		}
+		return resolvers[i].spiece.Name < resolvers[j].spiece.Name
+	})
+
+	return resolvers, nil
+}
+
+type sensorResolver struct {
+	services Services
+	sensor   sensor.Sensor
+}
+
+func loadSensors(ctx context.Context, services Services, groupName string) ([]*sensorResolver, error) {
+	sensors, err := loadersFrom(ctx).sensorsByGroup.Load(ctx, groupName)()
+	if err != nil {
+		return nil, queryError(ctx, err)
+	}
+	return newSensorResolvers(services, sensors), nil
+}
+
+func newSensorResolvers(services Services, sensors []sensor.Sensor) []*sensorResolver {
+	resolvers := make([]*sensorResolver, 0, len(sensors))
+	for _, s := range sensors {
+		resolvers = append(resolvers, &sensorResolver{services: services, sensor: s})
+	}
+	return resolvers
+}
+
+func (r *sensorResolver) Codename() string {
+	return fmt.Sprintf("%s %d", r.sensor.CodeName.GroupName, r.sensor.CodeName.Index)
+}
+
+func (r *sensorResolver) Group() string {
+	return r.sensor.CodeName.GroupName
+}
+
+func (r *sensorResolver) Index() int32 {
+	return int32(r.sensor.CodeName.Index)
+}
+
+func (r *sensorResolver) Coordinates() *coordinatesResolver {
+	return &coordinatesResolver{coords: r.sensor.Coords}
+}
+
+func (r *sensorResolver) Trajectory() *trajectoryResolver {
+	if r.sensor.Trajectory == nil {
+		return nil
+	}
+	return &trajectoryResolver{trajectory: *r.sensor.Trajectory}
+}
+
+func (r *sensorResolver) DataOutputRate() int32 {
+	return int32(r.sensor.DataOutputRate)
+}
+
+func (r *sensorResolver) Model() *string {
+	return optionalString(r.sensor.Model)
+}
+
+func (r *sensorResolver) Firmware() *string {
+	return optionalString(r.sensor.Firmware)
+}
+
+func (r *sensorResolver) InstalledAt() *graphql.Time {
+	if r.sensor.InstalledAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.sensor.InstalledAt}
+}
+
+func (r *sensorResolver) Calibrations() []*calibrationResolver {
+	resolvers := make([]*calibrationResolver, 0, len(r.sensor.Calibrations))
+	for _, c := range r.sensor.Calibrations {
+		resolvers = append(resolvers, &calibrationResolver{calibration: c})
+	}
+	return resolvers
+}
+
+func (r *sensorResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.sensor.CreatedAt}
+}
+
+func (r *sensorResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.sensor.UpdatedAt}
+}
+
+func (r *sensorResolver) AvgTemperature(ctx context.Context, args struct{ Range *timeRangeInput }) (*float64, error) {
+	return r.Avg(ctx, struct {
+		Metric string
+		Range  *timeRangeInput
+	}{Metric: measurement.Temperature, Range: args.Range})
+}
+
+func (r *sensorResolver) Avg(ctx context.Context, args struct {
+	Metric string
+	Range  *timeRangeInput
+}) (*float64, error) {
+	avg, err := loadersFrom(ctx).avg.Load(ctx, avgKey{
+		sensorID:  r.sensor.ID,
+		metric:    args.Metric,
+		timeRange: args.Range.key(),
+	})()
+	if err != nil {
+		return nil, queryError(ctx, err)
+	}
+
+	return avg, nil
+}
+
+func (r *sensorResolver) Readings(ctx context.Context, args struct {
+	Range *timeRangeInput
+	Last  int32
+	Raw   bool
+}) ([]*sensorDataResolver, error) {
+	last := int(args.Last)
+
+	if last < 1 || last > maxReadings {
+		return nil, queryError(ctx, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Last should be from 1 to 1000."))
+	}
+
+	readings, err := loadersFrom(ctx).readings.Load(ctx, readingsKey{
+		sensorID:  r.sensor.ID,
+		timeRange: args.Range.key(),
+		last:      last,
+		raw:       args.Raw,
+	})()
+	if err != nil {
+		return nil, queryError(ctx, err)
+	}
+
+	resolvers := make([]*sensorDataResolver, 0, len(readings))
+	for _, reading := range readings {
+		resolvers = append(resolvers, &sensorDataResolver{sensorData: reading})
+	}
+
+	return resolvers, nil
+}
+
+type calibrationResolver struct {
+	calibration sensor.Calibration
+}
+
+func (r *calibrationResolver) Metric() string {
+	return r.calibration.Metric
+}
+
+func (r *calibrationResolver) Offset() float64 {
+	return r.calibration.Offset
+}
+
+func (r *calibrationResolver) Gain() float64 {
+	return r.calibration.Gain
+}
+
+func (r *calibrationResolver) CalibratedAt() graphql.Time {
+	return graphql.Time{Time: r.calibration.CalibratedAt}
+}
+
+type trajectoryResolver struct {
+	trajectory trajectory.Trajectory
+}
+
+func (r *trajectoryResolver) Kind() string {
+	return string(r.trajectory.Kind)
+}
+
+func (r *trajectoryResolver) Waypoints() []*coordinatesResolver {
+	resolvers := make([]*coordinatesResolver, 0, len(r.trajectory.Waypoints))
+	for _, p := range r.trajectory.Waypoints {
+		resolvers = append(resolvers, &coordinatesResolver{coords: sensor.Coordinates{X: p.X, Y: p.Y, Z: p.Z}})
+	}
+	return resolvers
+}
+
+func (r *trajectoryResolver) Speed() *float64 {
+	return optionalFloat(r.trajectory.Speed)
+}
+
+func (r *trajectoryResolver) Step() *float64 {
+	return optionalFloat(r.trajectory.Step)
+}
+
+func (r *trajectoryResolver) Radius() *float64 {
+	return optionalFloat(r.trajectory.Radius)
+}
+
+func (r *trajectoryResolver) StartsAt() *graphql.Time {
+	if r.trajectory.StartsAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.trajectory.StartsAt}
+}
+
+type coordinatesResolver struct {
+	coords sensor.Coordinates
+}
+
+func (r *coordinatesResolver) X() float64 {
+	return r.coords.X
+}
+
+func (r *coordinatesResolver) Y() float64 {
+	return r.coords.Y
+}
+
+func (r *coordinatesResolver) Z() float64 {
+	return r.coords.Z
+}
+
+type sensorDataResolver struct {
+	sensorData sensordata.SensorData
+}
+
+func (r *sensorDataResolver) ID() int32 {
+	return int32(r.sensorData.ID)
+}
+
+func (r *sensorDataResolver) Temperature() float64 {
+	return float64(r.sensorData.Temperature)
+}
+
+func (r *sensorDataResolver) Transparency() int32 {
+	return int32(r.sensorData.Transparency)
+}
+
+func (r *sensorDataResolver) Coordinates() *coordinatesResolver {
+	return &coordinatesResolver{coords: r.sensorData.Coords}
+}
+
+func (r *sensorDataResolver) Maintenance() bool {
+	return r.sensorData.Maintenance
+}
+
+func (r *sensorDataResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.sensorData.CreatedAt}
+}
+
+func (r *sensorDataResolver) Spieces() []*spieceResolver {
+	resolvers := make([]*spieceResolver, 0, len(r.sensorData.DetectedSpieces))
+	for _, s := range r.sensorData.DetectedSpieces {
+		resolvers = append(resolvers, &spieceResolver{spiece: s})
+	}
+	return resolvers
+}
+
+type spieceResolver struct {
+	spiece spiece.Spiece
+}
+
+func (r *spieceResolver) ID() int32 {
+	return int32(r.spiece.ID)
+}
+
+func (r *spieceResolver) Name() string {
+	return r.spiece.Name
+}
+
+type spieceCountResolver struct {
+	spiece spiece.Spiece
+	count  int
+}
+
+func (r *spieceCountResolver) Spiece() *spieceResolver {
+	return &spieceResolver{spiece: r.spiece}
+}
+
+func (r *spieceCountResolver) Count() int32 {
+	return int32(r.count)
+}
+
+// appError is shown in errors of the response with the same message as in REST responses.
+type appError struct {
+	message string
+	code    string
+}
+
+func (e *appError) Error() string {
+	return e.message
+}
+
+func (e *appError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// queryError hides internal errors, as errors of resolvers are returned to clients.
+func queryError(ctx context.Context, err error) error {
+	var target *appError
+	if errors.As(err, &target) {
+		return err
+	}
+
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) {
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			appErr = apperror.ErrTimeout
+		} else {
+			appErr = apperror.ErrInternalSystem
+		}
+	}
+
+	return &appError{message: appErr.Message, code: appErr.Code}
+}
+
+// optionalString returns null for an empty string.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// optionalFloat returns null for fields which the trajectory kind does not use.
+func optionalFloat(f float64) *float64 {
+	if f == 0 {
+		return nil
+	}
+	return &f
+}
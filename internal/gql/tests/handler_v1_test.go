@@ -0,0 +1,202 @@
+package gql
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sensors-generator/internal/gql"
+	"sensors-generator/internal/group"
+	"sensors-generator/internal/sensor"
+	sensordata "sensors-generator/internal/sensorData"
+	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/environment"
+	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/trajectory"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func query(t *testing.T, services gql.Services, body string) response {
+	handler := gql.NewHandler(services, logging.GetLogger())
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.Query(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp
+}
+
+func sameIDs(expected ...int) interface{} {
+	return mock.MatchedBy(func(ids []int) bool {
+		sorted := append([]int(nil), ids...)
+		sort.Ints(sorted)
+		return assert.ObjectsAreEqual(expected, sorted)
+	})
+}
+
+func Test_Handler_Query_BatchesNestedFields(t *testing.T) {
+	logging.Init("trace", true)
+	mockGroupService := &MockSensorGroupService{}
+	mockSensorService := &MockSensorService{}
+	mockSensorDataService := &MockSensorDataService{}
+
+	mockGroupService.On("GetAll", mock.Anything, group.SensorGroupFilters{}).Return([]group.SensorGroup{
+		{ID: 1, Name: "alpha"},
+		{ID: 2, Name: "beta"},
+	}, nil)
+	mockSensorService.On("GetAll", mock.Anything, sensor.SensorFilters{}).Return([]sensor.Sensor{
+		{ID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1}},
+		{ID: 2, CodeName: sensor.Codename{GroupName: "alpha", Index: 2}},
+		{ID: 3, CodeName: sensor.Codename{GroupName: "beta", Index: 1}},
+	}, nil)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockSensorService.On("GetAvgForSensors", mock.Anything, "temperature", sameIDs(1, 2, 3),
+		sensor.SensorFilters{FromDate: from}).
+		Return(map[int]float64{1: 10.5, 3: 7}, nil)
+	mockSensorService.On("GetAvgForSensors", mock.Anything, "salinity", sameIDs(1, 2, 3),
+		sensor.SensorFilters{FromDate: from}).
+		Return(map[int]float64{2: 33.5}, nil)
+	mockSensorDataService.On("GetLatestForSensors", mock.Anything, sameIDs(1, 2, 3),
+		sensordata.SensorDataFilters{FromDate: from, Limit: 2}).
+		Return([]sensordata.SensorData{
+			{ID: 11, SensorID: 1, Temperature: 11, DetectedSpieces: []spiece.Spiece{{ID: 1, Name: "Tuna"}}},
+			{ID: 10, SensorID: 1, Temperature: 10},
+			{ID: 30, SensorID: 3, Temperature: 7},
+		}, nil)
+
+	resp := query(t, gql.Services{
+		SensorGroupService: mockGroupService,
+		SensorService:      mockSensorService,
+		SensorDataService:  mockSensorDataService,
+		SpieceService:      &MockSpieceService{},
+	}, `{"query": "query($from: Time) { groups { name sensors { codename avgTemperature(range: {from: $from}) salinity: avg(metric: \"salinity\", range: {from: $from}) readings(range: {from: $from}, last: 2) { id spieces { name } } } } }",
+		"variables": {"from": "2024-01-01T00:00:00Z"}}`)
+
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"groups": [
+		{"name": "alpha", "sensors": [
+			{"codename": "alpha 1", "avgTemperature": 10.5, "salinity": null, "readings": [{"id": 11, "spieces": [{"name": "Tuna"}]}, {"id": 10, "spieces": []}]},
+			{"codename": "alpha 2", "avgTemperature": null, "salinity": 33.5, "readings": []}
+		]},
+		{"name": "beta", "sensors": [
+			{"codename": "beta 1", "avgTemperature": 7, "salinity": null, "readings": [{"id": 30, "spieces": []}]}
+		]}
+	]}`, string(resp.Data))
+
+	mockSensorService.AssertNumberOfCalls(t, "GetAll", 1)
+	// One query per metric for all sensors.
+	mockSensorService.AssertNumberOfCalls(t, "GetAvgForSensors", 2)
+	mockSensorDataService.AssertNumberOfCalls(t, "GetLatestForSensors", 1)
+}
+
+func Test_Handler_Query_GroupAvg(t *testing.T) {
+	logging.Init("trace", true)
+	mockGroupService := &MockSensorGroupService{}
+
+	mockGroupService.On("GetAll", mock.Anything, group.SensorGroupFilters{}).Return([]group.SensorGroup{
+		{ID: 1, Name: "alpha"},
+	}, nil)
+	mockGroupService.On("GetAvgInGroup", mock.Anything, "salinity", "alpha", group.SensorGroupFilters{}).Return(33.5, nil)
+
+	resp := query(t, gql.Services{
+		SensorGroupService: mockGroupService,
+		SensorService:      &MockSensorService{},
+		SensorDataService:  &MockSensorDataService{},
+		SpieceService:      &MockSpieceService{},
+	}, `{"query": "{ groups { name avg(metric: \"salinity\") } }"}`)
+
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"groups": [{"name": "alpha", "avg": 33.5}]}`, string(resp.Data))
+}
+
+func Test_Handler_Query_HidesInternalErrors(t *testing.T) {
+	logging.Init("trace", true)
+	mockSensorService := &MockSensorService{}
+
+	mockSensorService.On("GetAll", mock.Anything, sensor.SensorFilters{}).
+		Return([]sensor.Sensor{}, errors.New("pq: connection refused"))
+
+	resp := query(t, gql.Services{
+		SensorGroupService: &MockSensorGroupService{},
+		SensorService:      mockSensorService,
+		SensorDataService:  &MockSensorDataService{},
+		SpieceService:      &MockSpieceService{},
+	}, `{"query": "{ sensor(codename: \"alpha 1\") { codename } }"}`)
+
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "internal system error", resp.Errors[0].Message)
+	assert.Equal(t, "PS-00100", resp.Errors[0].Extensions["code"])
+}
+
+func Test_Handler_Query_LimitsReadings(t *testing.T) {
+	logging.Init("trace", true)
+	mockSensorService := &MockSensorService{}
+
+	mockSensorService.On("GetAll", mock.Anything, sensor.SensorFilters{}).Return([]sensor.Sensor{
+		{ID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1}},
+	}, nil)
+
+	resp := query(t, gql.Services{
+		SensorGroupService: &MockSensorGroupService{},
+		SensorService:      mockSensorService,
+		SensorDataService:  &MockSensorDataService{},
+		SpieceService:      &MockSpieceService{},
+	}, `{"query": "{ sensors { readings(last: 5000) { id } } }"}`)
+
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "Last should be from 1 to 1000.", resp.Errors[0].Message)
+}
+
+func Test_Handler_Query_MobileSensor(t *testing.T) {
+	logging.Init("trace", true)
+	mockSensorService := &MockSensorService{}
+	mockSensorDataService := &MockSensorDataService{}
+
+	mockSensorService.On("GetAll", mock.Anything, sensor.SensorFilters{}).Return([]sensor.Sensor{
+		{ID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1}, Coords: sensor.Coordinates{X: 1, Y: 2, Z: 3},
+			Trajectory: &trajectory.Trajectory{Kind: trajectory.KindWaypoints,
+				Waypoints: []environment.Point{{X: 10, Y: 2, Z: 3}}, Speed: 0.5}},
+		{ID: 2, CodeName: sensor.Codename{GroupName: "alpha", Index: 2}},
+	}, nil)
+	mockSensorDataService.On("GetLatestForSensors", mock.Anything, sameIDs(1, 2),
+		sensordata.SensorDataFilters{Limit: 1}).
+		Return([]sensordata.SensorData{{ID: 11, SensorID: 1, Coords: sensor.Coordinates{X: 4, Y: 2, Z: 3}}}, nil)
+
+	resp := query(t, gql.Services{
+		SensorGroupService: &MockSensorGroupService{},
+		SensorService:      mockSensorService,
+		SensorDataService:  mockSensorDataService,
+		SpieceService:      &MockSpieceService{},
+	}, `{"query": "{ sensors { codename trajectory { kind waypoints { x } speed step } readings(last: 1) { coordinates { x y z } } } }"}`)
+
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"sensors": [
+		{"codename": "alpha 1", "trajectory": {"kind": "waypoints", "waypoints": [{"x": 10}], "speed": 0.5, "step": null},
+			"readings": [{"coordinates": {"x": 4, "y": 2, "z": 3}}]},
+		{"codename": "alpha 2", "trajectory": null, "readings": []}
+	]}`, string(resp.Data))
+}
@@ -0,0 +1,194 @@
+package gql
+
+import (
+	"context"
+	"sensors-generator/internal/group"
+	"sensors-generator/internal/sensor"
+	sensordata "sensors-generator/internal/sensorData"
+	"sensors-generator/internal/spiece"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSensorGroupService struct {
+	mock.Mock
+}
+
+func (m *MockSensorGroupService) GetSpiecesInGroup(ctx context.Context, groupName string, filters group.SensorGroupFilters) (map[*spiece.Spiece]int, error) {
+	args := m.Called(ctx, groupName, filters)
+	actualSpieces := args.Get(0).(map[spiece.Spiece]int)
+	expectedSpieces := make(map[*spiece.Spiece]int)
+
+	for key, value := range actualSpieces {
+		tempKey := key
+		expectedSpieces[&tempKey] = value
+	}
+
+	return expectedSpieces, args.Error(1)
+}
+
+func (m *MockSensorGroupService) GetAvgTrasparencyInGroup(ctx context.Context, groupName string, filters group.SensorGroupFilters) (uint8, error) {
+	args := m.Called(ctx, groupName, filters)
+	return args.Get(0).(uint8), args.Error(1)
+}
+
+func (m *MockSensorGroupService) GetAvgTemperatureInGroup(ctx context.Context, groupName string, filters group.SensorGroupFilters) (float32, error) {
+	args := m.Called(ctx, groupName, filters)
+	return args.Get(0).(float32), args.Error(1)
+}
+
+func (m *MockSensorGroupService) GetAvgInGroup(ctx context.Context, metric string, groupName string, filters group.SensorGroupFilters) (float64, error) {
+	args := m.Called(ctx, metric, groupName, filters)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockSensorGroupService) Create(ctx context.Context, groups ...group.CreateSensorGroupDTO) error {
+	args := m.Called(ctx, groups)
+	return args.Error(0)
+}
+
+func (m *MockSensorGroupService) GetAll(ctx context.Context, filters group.SensorGroupFilters) ([]group.SensorGroup, error) {
+	args := m.Called(ctx, filters)
+	return args.Get(0).([]group.SensorGroup), args.Error(1)
+}
+
+type MockSensorService struct {
+	mock.Mock
+}
+
+func (m *MockSensorService) GetAll(ctx context.Context, filters sensor.SensorFilters) ([]sensor.Sensor, error) {
+	args := m.Called(ctx, filters)
+	return args.Get(0).([]sensor.Sensor), args.Error(1)
+}
+
+func (m *MockSensorService) Create(ctx context.Context, sensors ...sensor.CreateSensorDTO) error {
+	args := m.Called(ctx, sensors)
+	return args.Error(0)
+}
+
+func (m *MockSensorService) Update(ctx context.Context, codeName sensor.Codename, sensor sensor.UpdateSensorDTO) error {
+	args := m.Called(ctx, codeName, sensor)
+	return args.Error(0)
+}
+
+func (m *MockSensorService) AddSensorToGroup(ctx context.Context, sensorID int, groupID int) error {
+	args := m.Called(ctx, sensorID, groupID)
+	return args.Error(0)
+}
+
+func (m *MockSensorService) GetExtremumForRegion(ctx context.Context, metric string, minCoords, maxCoords sensor.Coordinates, min bool) (float64, error) {
+	args := m.Called(ctx, metric, minCoords, maxCoords, min)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockSensorService) GetAvgForSensor(ctx context.Context, metric string, filters sensor.SensorFilters) (float64, error) {
+	args := m.Called(ctx, metric, filters)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockSensorService) GetAvgForSensors(ctx context.Context, metric string, sensorIDs []int, filters sensor.SensorFilters) (map[int]float64, error) {
+	args := m.Called(ctx, metric, sensorIDs, filters)
+	return args.Get(0).(map[int]float64), args.Error(1)
+}
+
+func (m *MockSensorService) GetCalibrations(ctx context.Context, codeName sensor.Codename) ([]sensor.Calibration, error) {
+	args := m.Called(ctx, codeName)
+	if obj := args.Get(0); obj != nil {
+		return obj.([]sensor.Calibration), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorService) AddCalibration(ctx context.Context, codeName sensor.Codename, calibration sensor.CreateCalibrationDTO) (*sensor.Calibration, error) {
+	args := m.Called(ctx, codeName, calibration)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*sensor.Calibration), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorService) GetMaintenance(ctx context.Context, filters sensor.SensorFilters) ([]sensor.Maintenance, error) {
+	args := m.Called(ctx, filters)
+	if obj := args.Get(0); obj != nil {
+		return obj.([]sensor.Maintenance), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorService) AddMaintenance(ctx context.Context, codeName sensor.Codename, maintenance sensor.CreateMaintenanceDTO) (*sensor.Maintenance, error) {
+	args := m.Called(ctx, codeName, maintenance)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*sensor.Maintenance), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MockSensorDataService struct {
+	mock.Mock
+}
+
+func (m *MockSensorDataService) GetAll(ctx context.Context, filters sensordata.SensorDataFilters) ([]sensordata.SensorData, error) {
+	args := m.Called(ctx, filters)
+	return args.Get(0).([]sensordata.SensorData), args.Error(1)
+}
+
+func (m *MockSensorDataService) Iterate(ctx context.Context, filters sensordata.SensorDataFilters, fn func(sensordata.SensorData) error) error {
+	args := m.Called(ctx, filters)
+	return args.Error(0)
+}
+
+func (m *MockSensorDataService) GetOneByID(ctx context.Context, id int, filters sensordata.SensorDataFilters) (*sensordata.SensorData, error) {
+	args := m.Called(ctx, id, filters)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*sensordata.SensorData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorDataService) Create(ctx context.Context, sensorData ...sensordata.CreateSensorDataDTO) ([]int, error) {
+	args := m.Called(ctx, sensorData)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockSensorDataService) AddDetectedSpieces(ctx context.Context, sensorDataID int, spieces ...spiece.Spiece) error {
+	args := m.Called(ctx, sensorDataID, spieces)
+	return args.Error(0)
+}
+
+func (m *MockSensorDataService) CreateWithSpieces(ctx context.Context, sensorData sensordata.CreateSensorDataDTO, spieces ...spiece.Spiece) (int, error) {
+	args := m.Called(ctx, sensorData, spieces)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSensorDataService) Publish(sensorData sensordata.SensorData) {
+	m.Called(sensorData)
+}
+
+func (m *MockSensorDataService) Subscribe(ctx context.Context, filters sensordata.SensorDataFilters) <-chan sensordata.Delivery {
+	args := m.Called(ctx, filters)
+	return args.Get(0).(<-chan sensordata.Delivery)
+}
+
+func (m *MockSensorDataService) GetLatestForSensors(ctx context.Context, sensorIDs []int, filters sensordata.SensorDataFilters) ([]sensordata.SensorData, error) {
+	args := m.Called(ctx, sensorIDs, filters)
+	return args.Get(0).([]sensordata.SensorData), args.Error(1)
+}
+
+type MockSpieceService struct {
+	mock.Mock
+}
+
+func (m *MockSpieceService) GetAll(ctx context.Context, filters spiece.SpieceFilters) ([]spiece.Spiece, error) {
+	args := m.Called(ctx, filters)
+	return args.Get(0).([]spiece.Spiece), args.Error(1)
+}
+
+func (m *MockSpieceService) Create(ctx context.Context, spieces ...spiece.CreateSpieceDTO) error {
+	args := m.Called(ctx, spieces)
+	return args.Error(0)
+}
+
+func (m *MockSpieceService) GetVersion(ctx context.Context) (spiece.Version, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(spiece.Version), args.Error(1)
+}
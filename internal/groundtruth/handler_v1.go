@@ -0,0 +1,112 @@
+package groundtruth
+
+import (
+	"net/http"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/pkg/fault"
+	"sensors-generator/pkg/logging"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	faultsPath = "api/v1/faults"
+)
+
+type handler struct {
+	injectedFaultService IInjectedFaultService
+	logger               *logging.Logger
+}
+
+func NewHandler(injectedFaultService IInjectedFaultService, logger *logging.Logger) *handler {
+	return &handler{
+		injectedFaultService: injectedFaultService,
+		logger:               logger,
+	}
+}
+
+func (h *handler) Register(router gin.IRouter) {
+	router.GET(faultsPath, h.GetAll)
+}
+
+// GetAll
+// @Summary Injected faults
+// @Description Ground truth of faults which the generator injected into readings, ordered by start time.
+// @Description Outage, stuck and drift cover all readings of the sensor from started_at till ended_at,
+// @Description other faults reference one reading by sensor_data_id.
+// @Tags Faults
+// @Security ApiKeyAuth
+// @Produce json
+// @Param codeName query string false "Codename of the sensor, e.g. 'alpha 1'"
+// @Param group query string false "Name of the group"
+// @Param kind query string false "outage, stuck, drift, spike, duplicate, out_of_order or delay"
+// @Param from query int false "from"
+// @Param till query int false "till"
+// @Param limit query int false "Max number of faults"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /api/v1/faults [get]
+func (h *handler) GetAll(c *gin.Context) {
+	filters := InjectedFaultFilters{
+		GroupName: c.Query("group"),
+	}
+
+	if codeName, ok := c.GetQuery("codeName"); ok {
+		cdn, err := sensor.NewCodenameFromString(codeName)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		filters.CodeName = cdn
+	}
+
+	if kind, ok := c.GetQuery("kind"); ok {
+		k, err := fault.NewKindFromString(kind)
+		if err != nil {
+			c.Error(apperror.ErrorWithMessage(apperror.ErrBadRequest, "Unknown fault kind."))
+			return
+		}
+		filters.Kind = k
+	}
+
+	if from, ok := c.GetQuery("from"); ok {
+		fromTS, err := strconv.Atoi(from)
+		if err != nil {
+			c.Error(apperror.ErrBadRequest)
+			return
+		}
+		filters.FromDate = time.Unix(int64(fromTS), 0)
+	}
+
+	if till, ok := c.GetQuery("till"); ok {
+		tillTS, err := strconv.Atoi(till)
+		if err != nil {
+			c.Error(apperror.ErrBadRequest)
+			return
+		}
+		filters.TillDate = time.Unix(int64(tillTS), 0)
+	}
+
+	if limit, ok := c.GetQuery("limit"); ok {
+		limitN, err := strconv.Atoi(limit)
+		if err != nil || limitN < 0 {
+			c.Error(apperror.ErrBadRequest)
+			return
+		}
+		filters.Limit = limitN
+	}
+
+	injectedFaults, err := h.injectedFaultService.GetAll(c.Request.Context(), filters)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"faults": injectedFaults})
+}
@@ -0,0 +1,8 @@
+package groundtruth
+
+import "context"
+
+type IInjectedFaultRepository interface {
+	FindAll(ctx context.Context, filters InjectedFaultFilters) ([]InjectedFault, error)
+	Create(ctx context.Context, injectedFault CreateInjectedFaultDTO) (int, error)
+}
@@ -0,0 +1,8 @@
+package groundtruth
+
+import "context"
+
+type IInjectedFaultService interface {
+	GetAll(ctx context.Context, filters InjectedFaultFilters) ([]InjectedFault, error)
+	Record(ctx context.Context, injectedFaults ...CreateInjectedFaultDTO) error
+}
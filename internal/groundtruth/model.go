@@ -0,0 +1,39 @@
+package groundtruth
+
+import (
+	"sensors-generator/internal/sensor"
+	"sensors-generator/pkg/fault"
+	"time"
+)
+
+// InjectedFault is a fault which the generator put into readings on purpose.
+// Reading fields are set for faults of one reading, window faults cover all readings in [StartedAt, EndedAt].
+type InjectedFault struct {
+	ID                  int             `json:"id"`
+	SensorID            int             `json:"-"`
+	CodeName            sensor.Codename `json:"codename"`
+	SensorDataID        *int            `json:"sensor_data_id,omitempty"`
+	SensorDataCreatedAt *time.Time      `json:"sensor_data_created_at,omitempty"`
+	Kind                fault.Kind      `json:"kind"`
+	Profile             string          `json:"profile"`
+	StartedAt           time.Time       `json:"started_at"`
+	EndedAt             time.Time       `json:"ended_at"`
+	Details             fault.Details   `json:"details"`
+}
+
+type CreateInjectedFaultDTO struct {
+	SensorID            int
+	SensorDataID        *int
+	SensorDataCreatedAt *time.Time
+	Fault               fault.Fault
+}
+
+// InjectedFaultFilters select faults which overlap [FromDate, TillDate]. Zero values do not filter.
+type InjectedFaultFilters struct {
+	CodeName  sensor.Codename
+	GroupName string
+	Kind      fault.Kind
+	FromDate  time.Time
+	TillDate  time.Time
+	Limit     int
+}
@@ -0,0 +1,151 @@
+package groundtruth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sensors-generator/config"
+	"sensors-generator/internal/apperror"
+	clients "sensors-generator/pkg/client/interfaces"
+	"sensors-generator/pkg/logging"
+	"strings"
+)
+
+type repository struct {
+	client clients.DBClient
+	logger *logging.Logger
+	cfg    *config.Config
+}
+
+func NewPostgresqlRepository(client *sql.DB,
+	logger *logging.Logger, cfg *config.Config) *repository {
+	return &repository{
+		client: client,
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+// FindAll returns faults ordered by start time.
+func (r *repository) FindAll(ctx context.Context, filters InjectedFaultFilters) ([]InjectedFault, error) {
+	q := `SELECT f.id, sens.id, sg.name, sens.index, f.sensor_data_id, f.sensor_data_created_at,
+			f.kind, f.profile, f.started_at, f.ended_at, f.details
+		FROM injected_faults AS f
+		JOIN sensors sens ON f.sensor_id=sens.id
+		JOIN sensor_groups sg ON sg.id=sens.group_id`
+
+	conditions := make([]string, 0)
+	args := []interface{}{}
+	argsCounter := 1
+
+	if !filters.CodeName.IsEmpty() {
+		conditions = append(conditions, fmt.Sprintf(`sg.name=$%d AND sens.index=$%d`, argsCounter, argsCounter+1))
+		args = append(args, filters.CodeName.GroupName, filters.CodeName.Index)
+		argsCounter += 2
+	}
+
+	if filters.GroupName != "" {
+		conditions = append(conditions, fmt.Sprintf(`sg.name=$%d`, argsCounter))
+		args = append(args, filters.GroupName)
+		argsCounter++
+	}
+
+	if filters.Kind != "" {
+		conditions = append(conditions, fmt.Sprintf(`f.kind=$%d`, argsCounter))
+		args = append(args, filters.Kind)
+		argsCounter++
+	}
+
+	if !filters.FromDate.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(`f.ended_at >= $%d`, argsCounter))
+		args = append(args, filters.FromDate)
+		argsCounter++
+	}
+
+	if !filters.TillDate.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(`f.started_at <= $%d`, argsCounter))
+		args = append(args, filters.TillDate)
+		argsCounter++
+	}
+
+	if len(conditions) > 0 {
+		q += "\n\t\tWHERE " + strings.Join(conditions, " AND ")
+	}
+
+	q += "\n\t\tORDER BY f.started_at, f.id"
+
+	if filters.Limit > 0 {
+		q += fmt.Sprintf(` LIMIT $%d`, argsCounter)
+		args = append(args, filters.Limit)
+	}
+
+	rows, err := r.client.QueryContext(ctx, q, args...)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to get injected faults, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+	defer rows.Close()
+
+	injectedFaults := make([]InjectedFault, 0)
+
+	for rows.Next() {
+		var injectedFault InjectedFault
+		var sensorDataID sql.NullInt64
+		var sensorDataCreatedAt sql.NullTime
+		var details []byte
+
+		if err := rows.Scan(&injectedFault.ID, &injectedFault.SensorID, &injectedFault.CodeName.GroupName,
+			&injectedFault.CodeName.Index, &sensorDataID, &sensorDataCreatedAt, &injectedFault.Kind,
+			&injectedFault.Profile, &injectedFault.StartedAt, &injectedFault.EndedAt, &details); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Failed to fetch row, due to error: %v", err)
+			return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+
+		if sensorDataID.Valid {
+			id := int(sensorDataID.Int64)
+			injectedFault.SensorDataID = &id
+		}
+		if sensorDataCreatedAt.Valid {
+			injectedFault.SensorDataCreatedAt = &sensorDataCreatedAt.Time
+		}
+
+		if err := json.Unmarshal(details, &injectedFault.Details); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Cannot parse fault details, due to error: %v", err)
+			return nil, apperror.ErrInternalSystem
+		}
+
+		injectedFaults = append(injectedFaults, injectedFault)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to iterate rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return injectedFaults, nil
+}
+
+func (r *repository) Create(ctx context.Context, injectedFault CreateInjectedFaultDTO) (int, error) {
+	q := `INSERT INTO injected_faults(sensor_id, sensor_data_id, sensor_data_created_at,
+			kind, profile, started_at, ended_at, details)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	details, err := json.Marshal(injectedFault.Fault.Details)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot encode fault details, due to error: %v", err)
+		return 0, apperror.ErrInternalSystem
+	}
+
+	var id int
+
+	if err := r.client.QueryRowContext(ctx, q, injectedFault.SensorID, injectedFault.SensorDataID,
+		injectedFault.SensorDataCreatedAt, injectedFault.Fault.Kind, injectedFault.Fault.Profile,
+		injectedFault.Fault.StartedAt, injectedFault.Fault.EndedAt, details).Scan(&id); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot create injected fault, due to error: %v", err)
+		return 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return id, nil
+}
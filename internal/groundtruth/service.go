@@ -0,0 +1,40 @@
+package groundtruth
+
+import (
+	"context"
+	"sensors-generator/config"
+	"sensors-generator/pkg/logging"
+)
+
+type service struct {
+	injectedFaultRepo IInjectedFaultRepository
+	logger            *logging.Logger
+	cfg               *config.Config
+}
+
+func NewService(injectedFaultRepo IInjectedFaultRepository,
+	logger *logging.Logger, cfg *config.Config) *service {
+	return &service{
+		injectedFaultRepo: injectedFaultRepo,
+		logger:            logger,
+		cfg:               cfg,
+	}
+}
+
+func (s *service) GetAll(ctx context.Context, filters InjectedFaultFilters) ([]InjectedFault, error) {
+	s.logger.LWithContext(ctx).Debug("Get injected faults.")
+	return s.injectedFaultRepo.FindAll(ctx, filters)
+}
+
+// Record stores faults one by one, faults stored before an error are kept.
+func (s *service) Record(ctx context.Context, injectedFaults ...CreateInjectedFaultDTO) error {
+	s.logger.LWithContext(ctx).Debug("Record injected faults.")
+
+	for _, injectedFault := range injectedFaults {
+		if _, err := s.injectedFaultRepo.Create(ctx, injectedFault); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
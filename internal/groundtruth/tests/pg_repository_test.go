@@ -0,0 +1,88 @@
+package groundtruth
+
+import (
+	"context"
+	"sensors-generator/internal/groundtruth"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/pkg/fault"
+	"sensors-generator/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InjectedFaultRepository_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	logging.Init("trace", true)
+
+	repo := groundtruth.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	at := time.Date(2023, time.July, 1, 12, 0, 0, 0, time.UTC)
+	sensorDataID := 100
+	original := float32(10)
+	injected := float32(25)
+
+	mock.ExpectQuery(`INSERT INTO injected_faults`).
+		WithArgs(1, &sensorDataID, &at, fault.KindSpike, "flaky", at, at,
+			[]byte(`{"original_temperature":10,"injected_temperature":25}`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+	id, err := repo.Create(context.Background(), groundtruth.CreateInjectedFaultDTO{
+		SensorID:            1,
+		SensorDataID:        &sensorDataID,
+		SensorDataCreatedAt: &at,
+		Fault: fault.Fault{
+			Kind:      fault.KindSpike,
+			Profile:   "flaky",
+			StartedAt: at,
+			EndedAt:   at,
+			Details:   fault.Details{OriginalTemperature: &original, InjectedTemperature: &injected},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 7, id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_InjectedFaultRepository_FindAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	logging.Init("trace", true)
+
+	repo := groundtruth.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	filters := groundtruth.InjectedFaultFilters{
+		CodeName: sensor.Codename{GroupName: "alpha", Index: 1},
+		Kind:     fault.KindOutage,
+		FromDate: time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC),
+		TillDate: time.Date(2023, time.July, 2, 0, 0, 0, 0, time.UTC),
+		Limit:    10,
+	}
+	startedAt := time.Date(2023, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`FROM injected_faults AS f(.+)WHERE sg\.name=\$1 AND sens\.index=\$2 AND f\.kind=\$3 `+
+		`AND f\.ended_at >= \$4 AND f\.started_at <= \$5\s+ORDER BY f\.started_at, f\.id LIMIT \$6`).
+		WithArgs("alpha", 1, fault.KindOutage, filters.FromDate, filters.TillDate, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sensor_id", "name", "index", "sensor_data_id",
+			"sensor_data_created_at", "kind", "profile", "started_at", "ended_at", "details"}).
+			AddRow(1, 1, "alpha", 1, nil, nil, "outage", "flaky", startedAt, startedAt.Add(5*time.Minute), []byte(`{}`)))
+
+	injectedFaults, err := repo.FindAll(context.Background(), filters)
+
+	require.NoError(t, err)
+	require.Len(t, injectedFaults, 1)
+	assert.Equal(t, fault.KindOutage, injectedFaults[0].Kind)
+	assert.Equal(t, filters.CodeName, injectedFaults[0].CodeName)
+	assert.Nil(t, injectedFaults[0].SensorDataID)
+	assert.Equal(t, startedAt.Add(5*time.Minute), injectedFaults[0].EndedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,105 @@
+package group
+
+import (
+	"context"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/sensor"
+	sensorgenv1 "sensors-generator/pkg/api/sensorgen/v1"
+	"sensors-generator/pkg/logging"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type grpcServer struct {
+	sensorgenv1.UnimplementedGroupServiceServer
+	sensorGroupService ISensorGroupService
+	logger             *logging.Logger
+}
+
+// NewGRPCServer serves the same calls as the REST handler.
+func NewGRPCServer(sensorGroupService ISensorGroupService, logger *logging.Logger) *grpcServer {
+	return &grpcServer{
+		sensorGroupService: sensorGroupService,
+		logger:             logger,
+	}
+}
+
+func (s *grpcServer) Register(server grpc.ServiceRegistrar) {
+	sensorgenv1.RegisterGroupServiceServer(server, s)
+}
+
+func (s *grpcServer) ListGroups(ctx context.Context, req *sensorgenv1.ListGroupsRequest) (*sensorgenv1.ListGroupsResponse, error) {
+	groups, err := s.sensorGroupService.GetAll(ctx, SensorGroupFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &sensorgenv1.ListGroupsResponse{Groups: make([]*sensorgenv1.Group, 0, len(groups))}
+	for _, group := range groups {
+		resp.Groups = append(resp.Groups, &sensorgenv1.Group{
+			Id:        int32(group.ID),
+			Name:      group.Name,
+			CreatedAt: timestamppb.New(group.CreatedAt),
+		})
+	}
+
+	return resp, nil
+}
+
+// GetSpiecesInGroup returns spieces ordered by count, the REST handler returns them unordered.
+func (s *grpcServer) GetSpiecesInGroup(ctx context.Context, req *sensorgenv1.SpiecesInGroupRequest) (*sensorgenv1.SpiecesInGroupResponse, error) {
+	if req.GetTop() < 0 {
+		return nil, apperror.ErrorWithMessage(apperror.ErrBadRequest, "N should be >= 0.")
+	}
+
+	filters := SensorGroupFilters{TopLimit: int(req.GetTop())}
+	filters.FromDate, filters.TillDate = sensor.TimeRangeFromProto(req.GetRange())
+
+	spieces, err := s.sensorGroupService.GetSpiecesInGroup(ctx, req.GetGroupName(), filters)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &sensorgenv1.SpiecesInGroupResponse{Spieces: make([]*sensorgenv1.SpieceCount, 0, len(spieces))}
+	for spiece, count := range spieces {
+		resp.Spieces = append(resp.Spieces, &sensorgenv1.SpieceCount{Name: spiece.Name, Count: int32(count)})
+	}
+
+	sort.Slice(resp.Spieces, func(i, j int) bool {
+		if resp.Spieces[i].Count != resp.Spieces[j].Count {
+			return resp.Spieces[i].Count > resp.Spieces[j].Count
+		}
+		return resp.Spieces[i].Name < resp.Spieces[j].Name
+	})
+
+	return resp, nil
+}
+
+func (s *grpcServer) GetAvgInGroup(ctx context.Context, req *sensorgenv1.GroupMetricRequest) (*sensorgenv1.MetricResponse, error) {
+	avgValue, err := s.sensorGroupService.GetAvgInGroup(ctx, req.GetMetric(), req.GetGroupName(), SensorGroupFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &sensorgenv1.MetricResponse{Metric: req.GetMetric(), Value: avgValue}, nil
+}
+
+func (s *grpcServer) GetAvgTemperatureInGroup(ctx context.Context, req *sensorgenv1.GroupRequest) (*sensorgenv1.TemperatureResponse, error) {
+	temperature, err := s.sensorGroupService.GetAvgTemperatureInGroup(ctx, req.GetGroupName(), SensorGroupFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &sensorgenv1.TemperatureResponse{Temperature: temperature}, nil
+}
+
+func (s *grpcServer) GetAvgTransparencyInGroup(ctx context.Context, req *sensorgenv1.GroupRequest) (*sensorgenv1.TransparencyResponse, error) {
+	transparency, err := s.sensorGroupService.GetAvgTrasparencyInGroup(ctx, req.GetGroupName(), SensorGroupFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &sensorgenv1.TransparencyResponse{Transparency: uint32(transparency)}, nil
+}
@@ -1,7 +1,6 @@
 package group
 
 import (
-	"context"
 	"net/http"
 	"sensors-generator/internal/apperror"
 	"sensors-generator/pkg/logging"
@@ -12,11 +11,10 @@ import (
 )
 
 const (
-	basicPath           = "api/v1/group/:groupName"
-	spiecesPath         = "/spieces"
-	topNSpiecesPath     = spiecesPath + "/top/:N"
-	temperatureAvgPath  = "/temperature/average"
-	transparencyAvgPath = "/transparency/average"
+	basicPath       = "api/v1/group/:groupName"
+	spiecesPath     = "/spieces"
+	topNSpiecesPath = spiecesPath + "/top/:N"
+	metricAvgPath   = "/:metric/average"
 )
 
 type handler struct {
@@ -31,24 +29,26 @@ func NewHandler(sensorGroupService ISensorGroupService, logger *logging.Logger)
 	}
 }
 
-func (h *handler) Register(router *gin.Engine) {
+func (h *handler) Register(router gin.IRouter) {
 	group := router.Group(basicPath)
 	{
 		group.GET(spiecesPath, h.GetSpiecesInGroup)
 		group.GET(topNSpiecesPath, h.GetTopNSpiecesInGroup)
-		group.GET(temperatureAvgPath, h.GetAvgTemperatureInGroup)
-		group.GET(transparencyAvgPath, h.GetAvgTransparencyInGroup)
+		group.GET(metricAvgPath, h.GetAvgInGroup)
 	}
 }
 
 // GetSpiecesInGroupHandler
 // @Summary Spieces in group
 // @Tags Groups
+// @Security ApiKeyAuth
 // @Param groupName path string true "Name of the group"
 // @Param from query int false "from"
 // @Param till query int false "till"
 // @Success 200
 // @Failure 400
+// @Failure 401
+// @Failure 403
 // @Failure 500
 // @Router /api/v1/group/{groupName}/spieces [get]
 func (h *handler) GetSpiecesInGroup(c *gin.Context) {
@@ -76,7 +76,7 @@ func (h *handler) GetSpiecesInGroup(c *gin.Context) {
 		filters.TillDate = time.Unix(int64(tillTS), 0)
 	}
 
-	spieces, err := h.sensorGroupService.GetSpiecesInGroup(context.Background(), groupName, filters)
+	spieces, err := h.sensorGroupService.GetSpiecesInGroup(c.Request.Context(), groupName, filters)
 	if err != nil {
 		c.Error(err)
 		return
@@ -94,12 +94,15 @@ func (h *handler) GetSpiecesInGroup(c *gin.Context) {
 // GetTopNSpiecesInGroupHandler
 // @Summary Top N spieces in group
 // @Tags Groups
+// @Security ApiKeyAuth
 // @Param groupName path string true "Name of the group"
 // @Param N path string true "Top N"
 // @Param from query int false "from"
 // @Param till query int false "till"
 // @Success 200
 // @Failure 400
+// @Failure 401
+// @Failure 403
 // @Failure 500
 // @Router /api/v1/group/{groupName}/spieces/top/{N} [get]
 func (h *handler) GetTopNSpiecesInGroup(c *gin.Context) {
@@ -140,7 +143,7 @@ func (h *handler) GetTopNSpiecesInGroup(c *gin.Context) {
 
 	filters.TopLimit = N
 
-	spieces, err := h.sensorGroupService.GetSpiecesInGroup(context.Background(),
+	spieces, err := h.sensorGroupService.GetSpiecesInGroup(c.Request.Context(),
 		groupName, filters)
 	if err != nil {
 		c.Error(err)
@@ -156,42 +159,27 @@ func (h *handler) GetTopNSpiecesInGroup(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"spieces": spiecesJSON})
 }
 
-// GetAvgTransparencyInGroupHandler
-// @Summary Average transparency in group
+// GetAvgInGroupHandler
+// @Summary Average of a metric in group
 // @Tags Groups
+// @Security ApiKeyAuth
 // @Param groupName path string true "Name of the group"
+// @Param metric path string true "Measurement type, e.g. temperature or salinity"
 // @Success 200
 // @Failure 400
+// @Failure 401
+// @Failure 403
 // @Failure 500
-// @Router /api/v1/group/{groupName}/transparency/average [get]
-func (h *handler) GetAvgTransparencyInGroup(c *gin.Context) {
+// @Router /api/v1/group/{groupName}/{metric}/average [get]
+func (h *handler) GetAvgInGroup(c *gin.Context) {
 	groupName := c.Param("groupName")
+	metric := c.Param("metric")
 
-	avgTransparency, err := h.sensorGroupService.GetAvgTrasparencyInGroup(context.Background(), groupName, SensorGroupFilters{})
+	avgValue, err := h.sensorGroupService.GetAvgInGroup(c.Request.Context(), metric, groupName, SensorGroupFilters{})
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"average_transparency": avgTransparency})
-}
-
-// GetAvgTemperatureInGroupHandler
-// @Summary Average temperature in group
-// @Tags Groups
-// @Param groupName path string true "Name of the group"
-// @Success 200
-// @Failure 400
-// @Failure 500
-// @Router /api/v1/group/{groupName}/temperature/average [get]
-func (h *handler) GetAvgTemperatureInGroup(c *gin.Context) {
-	groupName := c.Param("groupName")
-
-	avgTemperature, err := h.sensorGroupService.GetAvgTemperatureInGroup(context.Background(), groupName, SensorGroupFilters{})
-	if err != nil {
-		c.Error(err)
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{"average_temperature": avgTemperature})
+	c.JSON(http.StatusOK, gin.H{"average_" + metric: avgValue})
 }
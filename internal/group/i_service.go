@@ -10,5 +10,6 @@ type ISensorGroupService interface {
 	GetSpiecesInGroup(ctx context.Context, groupName string, filters SensorGroupFilters) (map[*spiece.Spiece]int, error)
 	GetAvgTrasparencyInGroup(ctx context.Context, groupName string, filters SensorGroupFilters) (uint8, error)
 	GetAvgTemperatureInGroup(ctx context.Context, groupName string, filters SensorGroupFilters) (float32, error)
+	GetAvgInGroup(ctx context.Context, metric string, groupName string, filters SensorGroupFilters) (float64, error)
 	Create(ctx context.Context, groups ...CreateSensorGroupDTO) error
 }
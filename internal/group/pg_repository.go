@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sensors-generator/config"
 	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/retention"
 	"sensors-generator/internal/spiece"
 	clients "sensors-generator/pkg/client/interfaces"
 	"sensors-generator/pkg/logging"
@@ -27,16 +28,59 @@ func NewPostgresqlRepository(client *sql.DB,
 	}
 }
 
+// FindSpiecesInGroup counts detections of the last TopLimit readings in raw data, otherwise
+// detections are read from rollups when from and till are aligned to hours (or days).
 func (r *repository) FindSpiecesInGroup(ctx context.Context, groupName string, filters SensorGroupFilters) (map[*spiece.Spiece]int, error) {
-	q := ``
+	var q string
+	var args []interface{}
 
-	detectedSpiecesTbl := "detected_spieces"
+	if filters.TopLimit > 0 {
+		q, args = topSpiecesQuery(groupName, filters)
+	} else {
+		plan := retention.PlanFor(filters.FromDate, filters.TillDate)
+		source, sourceArgs := retention.DetectedSpiecesSource(plan, filters.FromDate, filters.TillDate, 2)
 
-	argsCounter := 1
-	args := []interface{}{}
+		q = fmt.Sprintf(`SELECT s.id, s.name, s.created_at, s.updated_at, SUM(ds.detections_count)
+	FROM sensor_groups as sg
+	JOIN (%s) ds ON sg.id = ds.group_id
+	JOIN spieces s ON s.id = ds.spiece_id
+	WHERE sg.name = $1
+	GROUP BY s.id, s.name, s.created_at, s.updated_at`, source)
 
-	if filters.TopLimit > 0 {
-		ds := fmt.Sprintf(`WITH det_s AS (
+		args = append([]interface{}{groupName}, sourceArgs...)
+	}
+
+	spieces := make(map[*spiece.Spiece]int)
+
+	rows, err := r.client.QueryContext(ctx, q, args...)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot find spieces, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Spieces not found."))
+	}
+	defer rows.Close()
+
+	var count int
+
+	for rows.Next() {
+		var spiece spiece.Spiece
+		if err := rows.Scan(&spiece.ID, &spiece.Name,
+			&spiece.CreatedAt, &spiece.UpdatedAt, &count); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Cannot scan spieces row.")
+			return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+		spieces[&spiece] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to iterate rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return spieces, nil
+}
+
+func topSpiecesQuery(groupName string, filters SensorGroupFilters) (string, []interface{}) {
+	q := fmt.Sprintf(`WITH det_s AS (
 			SELECT ds.spiece_id, ds.sensor_data_id
 			FROM detected_spieces ds
 			JOIN sensor_data sd ON ds.sensor_data_id=sd.id
@@ -46,17 +90,17 @@ func (r *repository) FindSpiecesInGroup(ctx context.Context, groupName string, f
 			ORDER BY sd.created_at DESC
 			LIMIT %d
 		)`, groupName, filters.TopLimit)
-		q += ds
-		detectedSpiecesTbl = "det_s"
-	}
+
+	argsCounter := 1
+	args := []interface{}{}
 
 	q += fmt.Sprintf(`SELECT s.id, s.name, s.created_at, s.updated_at, COUNT(s.id)
 	FROM sensor_groups as sg
 	JOIN sensors sens ON sg.id = sens.group_id
 	JOIN sensor_data sd ON sens.id = sd.sensor_id
-	JOIN %s ds ON sd.id = ds.sensor_data_id
+	JOIN det_s ds ON sd.id = ds.sensor_data_id
 	JOIN spieces s ON s.id = ds.spiece_id
-	WHERE sg.name = $%d`, detectedSpiecesTbl, argsCounter)
+	WHERE sg.name = $%d`, argsCounter)
 
 	args = append(args, groupName)
 	argsCounter++
@@ -73,47 +117,19 @@ func (r *repository) FindSpiecesInGroup(ctx context.Context, groupName string, f
 		argsCounter++
 	}
 
-	// if !filters.FromDate.IsZero() && !filters.TillDate.IsZero() {
-	// 	q += fmt.Sprintf(` AND sd.created_at BETWEEN $%d AND $%d`, argsCounter, argsCounter+1)
-	// 	args = append(args, filters.FromDate)
-	// 	args = append(args, filters.TillDate)
-	// 	argsCounter += 2
-	// }
-
 	q += "\n" + `GROUP BY s.id, s.name, s.created_at, s.updated_at`
 
-	spieces := make(map[*spiece.Spiece]int)
-
-	rows, err := r.client.Query(q, args...)
-	defer rows.Close()
-	if err != nil {
-		r.logger.Errorf("Cannot find spieces, due to error: %v", err)
-		return nil, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Spieces not found.")
-	}
-
-	var count int
-
-	for rows.Next() {
-		var spiece spiece.Spiece
-		if err := rows.Scan(&spiece.ID, &spiece.Name,
-			&spiece.CreatedAt, &spiece.UpdatedAt, &count); err != nil {
-			r.logger.Errorf("Cannot scan spieces row.")
-			return nil, apperror.ErrInternalSystem
-		}
-		spieces[&spiece] = count
-	}
-
-	return spieces, nil
+	return q, args
 }
 
 func (r *repository) FindOneByID(ctx context.Context, id int, filters SensorGroupFilters) (*SensorGroup, error) {
 	q := `SELECT id, name, created_at, updated_at FROM sensor_groups WHERE id=$1`
 	var sensorGroup SensorGroup
 
-	if err := r.client.QueryRow(q, id).Scan(&sensorGroup.ID, &sensorGroup.Name,
+	if err := r.client.QueryRowContext(ctx, q, id).Scan(&sensorGroup.ID, &sensorGroup.Name,
 		&sensorGroup.CreatedAt, &sensorGroup.UpdatedAt); err != nil {
-		r.logger.Errorf("Cannot scan sensor group row.")
-		return nil, apperror.ErrInternalSystem
+		r.logger.LWithContext(ctx).Errorf("Cannot scan sensor group row.")
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
 	}
 
 	return &sensorGroup, nil
@@ -124,8 +140,8 @@ func (r *repository) FindAll(ctx context.Context, filters SensorGroupFilters) ([
 
 	rows, err := r.client.QueryContext(ctx, q)
 	if err != nil {
-		r.logger.Errorf("Failed to get rows, due to error: %v", err)
-		return nil, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Sensor groups not found.")
+		r.logger.LWithContext(ctx).Errorf("Failed to get rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Sensor groups not found."))
 	}
 	defer rows.Close()
 
@@ -134,56 +150,43 @@ func (r *repository) FindAll(ctx context.Context, filters SensorGroupFilters) ([
 	for rows.Next() {
 		var sensorGroup SensorGroup
 		if err := rows.Scan(&sensorGroup.ID, &sensorGroup.Name, &sensorGroup.CreatedAt, &sensorGroup.UpdatedAt); err != nil {
-			r.logger.Errorf("Failed to fetch row, due to error: %v", err)
-			return nil, apperror.ErrInternalSystem
+			r.logger.LWithContext(ctx).Errorf("Failed to fetch row, due to error: %v", err)
+			return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
 		}
 
 		sensorGroups = append(sensorGroups, sensorGroup)
 	}
 
-	return sensorGroups, nil
-}
-
-func (r *repository) FindAvgTransparencyInGroup(ctx context.Context, groupName string, filters SensorGroupFilters) (uint8, error) {
-	q := `SELECT AVG(sd.transparency) FROM sensor_groups as sg
-		JOIN sensors sens ON sg.id=sens.group_id
-		JOIN sensor_data sd ON sens.id=sd.sensor_id
-		WHERE sg.name=$1`
-
-	args := []interface{}{
-		groupName,
+	if err := rows.Err(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to iterate rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
 	}
-	// argsCounter := 2
-	var transparency float32
 
-	if err := r.client.QueryRow(q, args...).Scan(&transparency); err != nil {
-		r.logger.Errorf("Cannot measure transparency, due to error: %v", err)
-		return 0, apperror.ErrorWithMessage(apperror.ErrBadRequest, "No data was found.")
-	}
-
-	r.logger.Info("Transparency were successfully measured.")
-	return uint8(transparency), nil
+	return sensorGroups, nil
 }
 
-func (r *repository) FindAvgTemperatureInGroup(ctx context.Context, groupName string, filters SensorGroupFilters) (float32, error) {
-	r.logger.Info("FIND AVERAGE TEMPERATURE IN GROUP.")
-	q := `SELECT AVG(sd.temperature) FROM sensor_groups as sg
+// FindAvgInGroup measures the average of the metric over all readings of the group.
+// The metric should be a known measurement type.
+func (r *repository) FindAvgInGroup(ctx context.Context, metric string, groupName string, filters SensorGroupFilters) (float64, error) {
+	source, _ := retention.MetricSource(metric, retention.PlanDaily, time.Time{}, time.Time{}, 2)
+
+	q := fmt.Sprintf(`SELECT SUM(sd.value_sum)::FLOAT / SUM(sd.readings_count) FROM sensor_groups as sg
 		JOIN sensors sens ON sg.id=sens.group_id
-		JOIN sensor_data sd ON sens.id=sd.sensor_id
-		WHERE sg.name=$1`
+		JOIN (%s) sd ON sens.id=sd.sensor_id
+		WHERE sg.name=$1`, source)
 
 	args := []interface{}{
 		groupName,
 	}
 	// argsCounter := 2
-	var temperature float32
+	var value float64
 
-	if err := r.client.QueryRow(q, args...).Scan(&temperature); err != nil {
-		r.logger.Errorf("Cannot measure transparency, due to error: %v", err)
-		return 0.0, apperror.ErrorWithMessage(apperror.ErrBadRequest, "No data was found.")
+	if err := r.client.QueryRowContext(ctx, q, args...).Scan(&value); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot measure average %s, due to error: %v", metric, err)
+		return 0.0, apperror.FromDBError(ctx, err, apperror.ErrorWithMessage(apperror.ErrBadRequest, "No data was found."))
 	}
 
-	return temperature, nil
+	return value, nil
 }
 
 func (r *repository) Create(ctx context.Context, grp CreateSensorGroupDTO) error {
@@ -193,10 +196,10 @@ func (r *repository) Create(ctx context.Context, grp CreateSensorGroupDTO) error
 	t := time.Now()
 
 	if _, err := r.client.ExecContext(ctx, q, grp.Name, t, t); err != nil {
-		r.logger.Errorf("Cannot create group, due to error: %v", err)
-		return apperror.ErrInternalSystem
+		r.logger.LWithContext(ctx).Errorf("Cannot create group, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
 	}
 
-	r.logger.Info("Group was created successfully.")
+	r.logger.LWithContext(ctx).Info("Group was created successfully.")
 	return nil
 }
@@ -3,10 +3,12 @@ package group
 import (
 	"context"
 	"sensors-generator/config"
+	"sensors-generator/internal/apperror"
 	"sensors-generator/internal/spiece"
 	clients "sensors-generator/pkg/client/interfaces"
 	"sensors-generator/pkg/client/redis"
 	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/measurement"
 	"strconv"
 )
 
@@ -28,86 +30,67 @@ func NewService(sensorGroupRepo ISensorGroupRepository, cache clients.Cache,
 }
 
 func (s *service) GetSpiecesInGroup(ctx context.Context, groupName string, filters SensorGroupFilters) (map[*spiece.Spiece]int, error) {
-	s.logger.Info("GET SPIECES IN GROUP.")
+	s.logger.LWithContext(ctx).Debug("Get spieces in group.")
 	return s.sensorGroupRepo.FindSpiecesInGroup(ctx, groupName, filters)
 }
 
 func (s *service) GetAvgTrasparencyInGroup(ctx context.Context, groupName string, filters SensorGroupFilters) (uint8, error) {
-	s.logger.Info("GET AVERAGE TRANSPARENCY IN GROUP.")
-	transparencyKey := groupName + "AvgTransparency"
-	var transparency uint8
-
-	transparencyValue, err := s.cache.Get(ctx, transparencyKey)
-	if err != nil {
-		s.logger.Warn("Transparency not found in cache.")
-	}
+	transparency, err := s.GetAvgInGroup(ctx, measurement.Transparency, groupName, filters)
+	return uint8(transparency), err
+}
 
-	if transparencyValue == "" {
-		transparency, err = s.sensorGroupRepo.FindAvgTransparencyInGroup(ctx, groupName, filters)
-		if err != nil {
-			return 0, err
-		}
+func (s *service) GetAvgTemperatureInGroup(ctx context.Context, groupName string, filters SensorGroupFilters) (float32, error) {
+	temperature, err := s.GetAvgInGroup(ctx, measurement.Temperature, groupName, filters)
+	return float32(temperature), err
+}
 
-		if err := s.cache.Set(ctx, transparencyKey, transparency, redis.TTL); err != nil {
-			s.logger.Errorf("Cannot set cache value: %d.", transparency)
-			return 0, err
-		}
-	} else {
-		transparencyInt, err := strconv.Atoi(transparencyValue)
-		if err != nil {
-			s.logger.Errorf("String \"%s\" cannot be converted to int.", transparencyValue)
-			return 0, err
-		}
-		transparency = uint8(transparencyInt)
+func (s *service) GetAvgInGroup(ctx context.Context, metric string, groupName string, filters SensorGroupFilters) (float64, error) {
+	s.logger.LWithContext(ctx).Debugf("Get average %s in group.", metric)
+	if _, ok := measurement.Lookup(metric); !ok {
+		return 0, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Unknown metric.")
 	}
 
-	return transparency, nil
-}
-
-func (s *service) GetAvgTemperatureInGroup(ctx context.Context, groupName string, filters SensorGroupFilters) (float32, error) {
-	s.logger.Info("GET AVERAGE TEMPERATURE IN GROUP.")
-	temperatureKey := groupName + "AvgTemperature"
-	var temperature float32
+	key := groupName + "Avg" + metric
+	var value float64
 
-	temperatureValue, err := s.cache.Get(ctx, temperatureKey)
+	cached, err := s.cache.Get(ctx, key)
 	if err != nil {
-		s.logger.Warn("Temperature not found in cache.")
+		s.logger.LWithContext(ctx).Warnf("Average %s not found in cache.", metric)
 	}
 
-	if temperatureValue == "" {
-		temperature, err = s.sensorGroupRepo.FindAvgTemperatureInGroup(ctx, groupName, filters)
+	if cached == "" {
+		value, err = s.sensorGroupRepo.FindAvgInGroup(ctx, metric, groupName, filters)
 		if err != nil {
 			return 0, err
 		}
 
-		if err := s.cache.Set(ctx, temperatureKey, temperature, redis.TTL); err != nil {
-			s.logger.Errorf("Cannot set cache value: %f.", temperature)
+		if err := s.cache.Set(ctx, key, value, redis.DefaultTTL); err != nil {
+			s.logger.LWithContext(ctx).Errorf("Cannot set cache value: %f.", value)
 			return 0, err
 		}
 	} else {
-		temperatureF64, err := strconv.ParseFloat(temperatureValue, 32)
+		value, err = strconv.ParseFloat(cached, 64)
 		if err != nil {
-			s.logger.Errorf("String \"%s\" cannot be converted to float.", temperatureValue)
+			s.logger.LWithContext(ctx).Errorf("String \"%s\" cannot be converted to float.", cached)
 			return 0, err
 		}
-		temperature = float32(temperatureF64)
 	}
 
-	return temperature, nil
+	return value, nil
 }
 
 func (s *service) Create(ctx context.Context, groups ...CreateSensorGroupDTO) error {
-	s.logger.Info("CREATE SENSOR GROUPS.")
+	s.logger.LWithContext(ctx).Debug("Create sensor groups.")
 	for _, grp := range groups {
 		if err := s.sensorGroupRepo.Create(ctx, grp); err != nil {
 			return err
 		}
 	}
-	s.logger.Info("Sensor groups was created successfully.")
+	s.logger.LWithContext(ctx).Info("Sensor groups was created successfully.")
 	return nil
 }
 
 func (s *service) GetAll(ctx context.Context, filters SensorGroupFilters) ([]SensorGroup, error) {
-	s.logger.Info("GET SENSOR GROUPS.")
+	s.logger.LWithContext(ctx).Debug("Get sensor groups.")
 	return s.sensorGroupRepo.FindAll(ctx, filters)
 }
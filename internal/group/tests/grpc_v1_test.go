@@ -0,0 +1,55 @@
+package group
+
+import (
+	"context"
+	"sensors-generator/internal/group"
+	"sensors-generator/internal/spiece"
+	sensorgenv1 "sensors-generator/pkg/api/sensorgen/v1"
+	"sensors-generator/pkg/logging"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GRPCServer_GetSpiecesInGroup(t *testing.T) {
+	logging.Init("trace", true)
+	mockService := &MockSensorGroupService{}
+	server := group.NewGRPCServer(mockService, logging.GetLogger())
+
+	mockService.On("GetSpiecesInGroup", mock.Anything, "alpha", group.SensorGroupFilters{TopLimit: 3}).
+		Return(map[spiece.Spiece]int{
+			{ID: 1, Name: "Herring"}:      5,
+			{ID: 2, Name: "Atlantic cod"}: 10,
+			{ID: 3, Name: "Anchovy"}:      5,
+		}, nil)
+
+	resp, err := server.GetSpiecesInGroup(context.Background(), &sensorgenv1.SpiecesInGroupRequest{GroupName: "alpha", Top: 3})
+
+	require.NoError(t, err)
+	names := make([]string, 0, len(resp.Spieces))
+	for _, s := range resp.Spieces {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"Atlantic cod", "Anchovy", "Herring"}, names)
+	assert.Equal(t, int32(10), resp.Spieces[0].Count)
+
+	_, err = server.GetSpiecesInGroup(context.Background(), &sensorgenv1.SpiecesInGroupRequest{GroupName: "alpha", Top: -1})
+	assert.Error(t, err)
+}
+
+func Test_GRPCServer_GetAvgInGroup(t *testing.T) {
+	logging.Init("trace", true)
+	mockService := &MockSensorGroupService{}
+	server := group.NewGRPCServer(mockService, logging.GetLogger())
+
+	mockService.On("GetAvgInGroup", mock.Anything, "salinity", "alpha", group.SensorGroupFilters{}).Return(33.5, nil)
+
+	resp, err := server.GetAvgInGroup(context.Background(), &sensorgenv1.GroupMetricRequest{GroupName: "alpha", Metric: "salinity"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "salinity", resp.Metric)
+	assert.Equal(t, 33.5, resp.Value)
+	mockService.AssertExpectations(t)
+}
@@ -36,6 +36,7 @@ func Test_Handler_GetSpiecesInGroup(t *testing.T) {
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 
 	c.Params = append(c.Params, gin.Param{Key: "groupName", Value: "alpha"})
 
@@ -75,10 +76,11 @@ func Test_Handler_GetTopNSpiecesInGroup(t *testing.T) {
 		{ID: 1, Name: "Spiece1"}: 10,
 		{ID: 2, Name: "Spiece2"}: 5,
 	}
-	mockService.On("GetSpiecesInGroup", mock.AnythingOfType("*context.emptyCtx"), "alpha", mock.Anything).Return(expectedSpieces, nil)
+	mockService.On("GetSpiecesInGroup", mock.Anything, "alpha", mock.Anything).Return(expectedSpieces, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 
 	c.Params = append(c.Params, gin.Param{Key: "groupName", Value: "alpha"})
 	c.Params = append(c.Params, gin.Param{Key: "N", Value: "2"})
@@ -110,62 +112,51 @@ func Test_Handler_GetTopNSpiecesInGroup(t *testing.T) {
 	assert.Equal(t, expectedResponse, response)
 }
 
-func Test_Handler_GetAvgTransparencyInGroup(t *testing.T) {
+func Test_Handler_GetAvgInGroup(t *testing.T) {
 	mockService := &MockSensorGroupService{}
 	handler := group.NewHandler(mockService, logging.GetLogger())
 
-	expectedAvgTransparency := uint8(80)
-	mockService.On("GetAvgTrasparencyInGroup", mock.Anything, "alpha", group.SensorGroupFilters{}).Return(expectedAvgTransparency, nil)
+	expectedAvgTemperature := 25.5
+	mockService.On("GetAvgInGroup", mock.Anything, "temperature", "alpha", group.SensorGroupFilters{}).Return(expectedAvgTemperature, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 
-	c.Params = append(c.Params, gin.Param{Key: "groupName", Value: "alpha"})
+	c.Params = append(c.Params, gin.Param{Key: "groupName", Value: "alpha"}, gin.Param{Key: "metric", Value: "temperature"})
 
-	handler.GetAvgTransparencyInGroup(c)
+	handler.GetAvgInGroup(c)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
 	}
 
-	var response map[string]uint8
+	var response map[string]float64
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	if err != nil {
 		t.Fatalf("Failed to parse JSON response: %v", err)
 	}
 
-	expectedResponse := map[string]uint8{
-		"average_transparency": expectedAvgTransparency,
+	expectedResponse := map[string]float64{
+		"average_temperature": expectedAvgTemperature,
 	}
 	assert.Equal(t, expectedResponse, response)
 }
 
-func Test_Handler_GetAvgTemperatureInGroup(t *testing.T) {
+func Test_Handler_GetAvgInGroup_Salinity(t *testing.T) {
 	mockService := &MockSensorGroupService{}
 	handler := group.NewHandler(mockService, logging.GetLogger())
 
-	expectedAvgTemperature := float32(25.5)
-	mockService.On("GetAvgTemperatureInGroup", mock.Anything, "alpha", group.SensorGroupFilters{}).Return(expectedAvgTemperature, nil)
+	mockService.On("GetAvgInGroup", mock.Anything, "salinity", "alpha", group.SensorGroupFilters{}).Return(34.2, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 
-	c.Params = append(c.Params, gin.Param{Key: "groupName", Value: "alpha"})
-
-	handler.GetAvgTemperatureInGroup(c)
+	c.Params = append(c.Params, gin.Param{Key: "groupName", Value: "alpha"}, gin.Param{Key: "metric", Value: "salinity"})
 
-	if w.Code != http.StatusOK {
-		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
-	}
+	handler.GetAvgInGroup(c)
 
-	var response map[string]float32
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	if err != nil {
-		t.Fatalf("Failed to parse JSON response: %v", err)
-	}
-
-	expectedResponse := map[string]float32{
-		"average_temperature": expectedAvgTemperature,
-	}
-	assert.Equal(t, expectedResponse, response)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"average_salinity": 34.2}`, w.Body.String())
 }
@@ -27,14 +27,9 @@ func (m *MockGroupRepository) FindAll(ctx context.Context, filters group.SensorG
 	return args.Get(0).([]group.SensorGroup), args.Error(1)
 }
 
-func (m *MockGroupRepository) FindAvgTransparencyInGroup(ctx context.Context, groupName string, filters group.SensorGroupFilters) (uint8, error) {
-	args := m.Called(ctx, groupName, filters)
-	return args.Get(0).(uint8), args.Error(1)
-}
-
-func (m *MockGroupRepository) FindAvgTemperatureInGroup(ctx context.Context, groupName string, filters group.SensorGroupFilters) (float32, error) {
-	args := m.Called(ctx, groupName, filters)
-	return args.Get(0).(float32), args.Error(1)
+func (m *MockGroupRepository) FindAvgInGroup(ctx context.Context, metric string, groupName string, filters group.SensorGroupFilters) (float64, error) {
+	args := m.Called(ctx, metric, groupName, filters)
+	return args.Get(0).(float64), args.Error(1)
 }
 
 func (m *MockGroupRepository) Create(ctx context.Context, grp group.CreateSensorGroupDTO) error {
@@ -185,7 +185,7 @@ func Test_SensorGroupRepository_FindAll(t *testing.T) {
 	}
 }
 
-func Test_SensorGroupRepository_FindAvgTransparencyInGroup(t *testing.T) {
+func Test_SensorGroupRepository_FindAvgInGroup(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
@@ -196,20 +196,20 @@ func Test_SensorGroupRepository_FindAvgTransparencyInGroup(t *testing.T) {
 
 	groupName := "alpha"
 
-	expectedTransparency := 50.0
+	expectedTemperature := 25.0
 
-	mock.ExpectQuery("SELECT AVG\\(sd\\.transparency\\) FROM sensor_groups as sg").
+	mock.ExpectQuery("SELECT SUM\\(sd\\.value_sum\\)::FLOAT / SUM\\(sd\\.readings_count\\) FROM sensor_groups as sg(.+)temperature_avg(.+)FROM sensor_data_daily").
 		WithArgs(groupName).
-		WillReturnRows(sqlmock.NewRows([]string{"avg"}).AddRow(expectedTransparency))
+		WillReturnRows(sqlmock.NewRows([]string{"avg"}).AddRow(expectedTemperature))
 
-	transparency, err := repo.FindAvgTransparencyInGroup(context.Background(), groupName, group.SensorGroupFilters{})
+	temperature, err := repo.FindAvgInGroup(context.Background(), "temperature", groupName, group.SensorGroupFilters{})
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if float64(transparency) != expectedTransparency {
-		t.Errorf("unexpected transparency value, got: %f, want: %f", float64(transparency), expectedTransparency)
+	if temperature != expectedTemperature {
+		t.Errorf("unexpected temperature value, got: %f, want: %f", temperature, expectedTemperature)
 	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -217,7 +217,7 @@ func Test_SensorGroupRepository_FindAvgTransparencyInGroup(t *testing.T) {
 	}
 }
 
-func Test_SensorGroupRepository_FindAvgTemperatureInGroup(t *testing.T) {
+func Test_SensorGroupRepository_FindAvgInGroup_Measurement(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
@@ -226,22 +226,20 @@ func Test_SensorGroupRepository_FindAvgTemperatureInGroup(t *testing.T) {
 
 	repo := group.NewPostgresqlRepository(db, logging.GetLogger(), nil)
 
-	groupName := "alpha"
-
-	expectedTemperature := 25.0
-
-	mock.ExpectQuery("SELECT AVG\\(sd\\.temperature\\) FROM sensor_groups as sg").
-		WithArgs(groupName).
-		WillReturnRows(sqlmock.NewRows([]string{"avg"}).AddRow(expectedTemperature))
-
-	temperature, err := repo.FindAvgTemperatureInGroup(context.Background(), groupName, group.SensorGroupFilters{})
+	mock.ExpectQuery("SELECT SUM\\(sd\\.value_sum\\)::FLOAT / SUM\\(sd\\.readings_count\\) FROM sensor_groups as sg" +
+		"(.+)FROM measurements_daily WHERE metric='salinity'" +
+		"(.+)FROM measurements_hourly WHERE metric='salinity'" +
+		"(.+)\\(measurements->>'salinity'\\)::FLOAT(.+)FROM sensor_data").
+		WithArgs("alpha").
+		WillReturnRows(sqlmock.NewRows([]string{"avg"}).AddRow(34.2))
 
+	salinity, err := repo.FindAvgInGroup(context.Background(), "salinity", "alpha", group.SensorGroupFilters{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if float64(temperature) != expectedTemperature {
-		t.Errorf("unexpected temperature value, got: %f, want: %f", float64(temperature), expectedTemperature)
+	if salinity != 34.2 {
+		t.Errorf("unexpected salinity value, got: %f, want: %f", salinity, 34.2)
 	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -273,3 +271,45 @@ func Test_SensorGroupRepository_Create(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func Test_SensorGroupRepository_FindSpiecesInGroup_HourlyRollups(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := group.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	groupName := "alpha"
+	filters := group.SensorGroupFilters{
+		FromDate: time.Date(2023, time.July, 1, 10, 0, 0, 0, time.UTC),
+		TillDate: time.Date(2023, time.July, 1, 18, 0, 0, 0, time.UTC),
+	}
+
+	mock.ExpectQuery(`SELECT s\.id, s\.name, s\.created_at, s\.updated_at, SUM\(ds\.detections_count\)`+
+		`(.+)FROM detected_spieces_hourly WHERE bucket < (.+) AND bucket >= \$2 AND bucket < \$3`+
+		`(.+)FROM detected_spieces ds(.+)WHERE ds\.created_at >= (.+) AND ds\.created_at >= \$2 AND ds\.created_at < \$3`).
+		WithArgs(groupName, filters.FromDate, filters.TillDate).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at", "sum"}).
+			AddRow(1, "Species1", time.Now(), time.Now(), 5).
+			AddRow(2, "Species2", time.Now(), time.Now(), 10))
+
+	spieces, err := repo.FindSpiecesInGroup(context.Background(), groupName, filters)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	counts := make(map[string]int)
+	for sp, count := range spieces {
+		counts[sp.Name] = count
+	}
+
+	if counts["Species1"] != 5 || counts["Species2"] != 10 {
+		t.Errorf("unexpected spieces counts: %v", counts)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -35,6 +35,11 @@ func (m *MockSensorGroupService) GetAvgTemperatureInGroup(ctx context.Context, g
 	return args.Get(0).(float32), args.Error(1)
 }
 
+func (m *MockSensorGroupService) GetAvgInGroup(ctx context.Context, metric string, groupName string, filters group.SensorGroupFilters) (float64, error) {
+	args := m.Called(ctx, metric, groupName, filters)
+	return args.Get(0).(float64), args.Error(1)
+}
+
 func (m *MockSensorGroupService) Create(ctx context.Context, groups ...group.CreateSensorGroupDTO) error {
 	args := m.Called(ctx, groups)
 	return args.Error(0)
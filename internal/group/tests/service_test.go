@@ -2,6 +2,7 @@ package group
 
 import (
 	"context"
+	"sensors-generator/internal/apperror"
 	"sensors-generator/internal/group"
 	"sensors-generator/internal/spiece"
 	"sensors-generator/pkg/logging"
@@ -48,20 +49,19 @@ func Test_GroupService_GetAvgTransparencyInGroup(t *testing.T) {
 	service := group.NewService(mockRepo, mockCache, logging.GetLogger(), nil)
 
 	groupName := "alpha"
-	expectedTransparency := uint8(80)
 
-	mockCache.On("Get", mock.Anything, groupName+"AvgTransparency").
+	mockCache.On("Get", mock.Anything, groupName+"Avgtransparency").
 		Return("", nil)
 
-	mockRepo.On("FindAvgTransparencyInGroup", mock.Anything, groupName, group.SensorGroupFilters{}).
-		Return(expectedTransparency, nil)
+	mockRepo.On("FindAvgInGroup", mock.Anything, "transparency", groupName, group.SensorGroupFilters{}).
+		Return(80.4, nil)
 
-	mockCache.On("Set", mock.Anything, groupName+"AvgTransparency", expectedTransparency, mock.Anything).
+	mockCache.On("Set", mock.Anything, groupName+"Avgtransparency", 80.4, mock.Anything).
 		Return(nil)
 
 	transparency, err := service.GetAvgTrasparencyInGroup(context.Background(), groupName, group.SensorGroupFilters{})
 	assert.NoError(t, err)
-	assert.Equal(t, expectedTransparency, transparency)
+	assert.Equal(t, uint8(80), transparency)
 
 	mockCache.AssertExpectations(t)
 	mockRepo.AssertExpectations(t)
@@ -76,13 +76,13 @@ func Test_GroupService_GetAvgTemperatureInGroup(t *testing.T) {
 	groupName := "alpha"
 	expectedTemperature := float32(25.5)
 
-	mockCache.On("Get", mock.Anything, groupName+"AvgTemperature").
+	mockCache.On("Get", mock.Anything, groupName+"Avgtemperature").
 		Return("", nil)
 
-	mockRepo.On("FindAvgTemperatureInGroup", mock.Anything, groupName, group.SensorGroupFilters{}).
-		Return(expectedTemperature, nil)
+	mockRepo.On("FindAvgInGroup", mock.Anything, "temperature", groupName, group.SensorGroupFilters{}).
+		Return(25.5, nil)
 
-	mockCache.On("Set", mock.Anything, groupName+"AvgTemperature", expectedTemperature, mock.Anything).
+	mockCache.On("Set", mock.Anything, groupName+"Avgtemperature", 25.5, mock.Anything).
 		Return(nil)
 
 	temperature, err := service.GetAvgTemperatureInGroup(context.Background(), groupName, group.SensorGroupFilters{})
@@ -93,6 +93,34 @@ func Test_GroupService_GetAvgTemperatureInGroup(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func Test_GroupService_GetAvgInGroup_Cached(t *testing.T) {
+	mockCache := &MockCache{}
+	mockRepo := &MockGroupRepository{}
+
+	service := group.NewService(mockRepo, mockCache, logging.GetLogger(), nil)
+
+	mockCache.On("Get", mock.Anything, "alphaAvgph").Return("8.05", nil)
+
+	ph, err := service.GetAvgInGroup(context.Background(), "ph", "alpha", group.SensorGroupFilters{})
+	assert.NoError(t, err)
+	assert.Equal(t, 8.05, ph)
+
+	mockRepo.AssertNotCalled(t, "FindAvgInGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_GroupService_GetAvgInGroup_UnknownMetric(t *testing.T) {
+	mockCache := &MockCache{}
+	mockRepo := &MockGroupRepository{}
+
+	service := group.NewService(mockRepo, mockCache, logging.GetLogger(), nil)
+
+	_, err := service.GetAvgInGroup(context.Background(), "turbidity", "alpha", group.SensorGroupFilters{})
+	assert.ErrorIs(t, err, apperror.ErrBadRequest)
+
+	mockCache.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "FindAvgInGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func Test_GroupService_Create(t *testing.T) {
 	mockRepo := &MockGroupRepository{}
 
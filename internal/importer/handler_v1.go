@@ -0,0 +1,132 @@
+package importer
+
+import (
+	"net/http"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/pkg/logging"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	readingsPath = "api/v1/import/readings"
+	sensorsPath  = "api/v1/import/sensors"
+)
+
+type handler struct {
+	importService IImportService
+	logger        *logging.Logger
+}
+
+func NewHandler(importService IImportService, logger *logging.Logger) *handler {
+	return &handler{
+		importService: importService,
+		logger:        logger,
+	}
+}
+
+func (h *handler) Register(router gin.IRouter) {
+	router.POST(readingsPath, h.ImportReadings)
+	router.POST(sensorsPath, h.ImportSensors)
+}
+
+// ImportReadings
+// @Summary Import readings
+// @Description Imports readings keyed by sensor codename. CSV needs codename, temperature, transparency,
+// @Description created_at (RFC3339) and optional spieces (separated by ';') columns, NDJSON has the same fields.
+// @Description Nothing is written if any row is invalid.
+// @Tags Import
+// @Security ApiKeyAuth
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param format query string false "csv (default) or ndjson"
+// @Param dry_run query bool false "Only validate the file"
+// @Success 200 {object} Report
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 422 {object} Report
+// @Failure 500
+// @Router /api/v1/import/readings [post]
+func (h *handler) ImportReadings(c *gin.Context) {
+	format, err := NewFormatFromString(c.DefaultQuery("format", string(FormatCSV)))
+	if err != nil {
+		c.Error(apperror.ErrorWithMessage(apperror.ErrBadRequest, err.Error()))
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		c.Error(apperror.ErrBadRequest)
+		return
+	}
+
+	h.extendDeadlines(c)
+
+	report, err := h.importService.ImportReadings(c.Request.Context(), c.Request.Body, format, Options{DryRun: dryRun})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(reportStatus(report), report)
+}
+
+// ImportSensors
+// @Summary Import sensors layout
+// @Description Imports GeoJSON FeatureCollection of points with group, index, depth and data_output_rate (seconds) properties.
+// @Description Existing sensors are moved, new sensors and groups are created. Nothing is written if any feature is invalid.
+// @Tags Import
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param dry_run query bool false "Only validate the file"
+// @Success 200 {object} Report
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 422 {object} Report
+// @Failure 500
+// @Router /api/v1/import/sensors [post]
+func (h *handler) ImportSensors(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		c.Error(apperror.ErrBadRequest)
+		return
+	}
+
+	h.extendDeadlines(c)
+
+	report, err := h.importService.ImportSensors(c.Request.Context(), c.Request.Body, Options{DryRun: dryRun})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(reportStatus(report), report)
+}
+
+// extendDeadlines lets big files be uploaded and imported longer than server timeouts,
+// the request context deadline is used instead.
+func (h *handler) extendDeadlines(c *gin.Context) {
+	deadline, _ := c.Request.Context().Deadline()
+	rc := http.NewResponseController(c.Writer)
+
+	if err := rc.SetReadDeadline(deadline); err != nil {
+		h.logger.LWithContext(c.Request.Context()).Warnf("Cannot extend read deadline, due to error: %v", err)
+	}
+	if err := rc.SetWriteDeadline(deadline); err != nil {
+		h.logger.LWithContext(c.Request.Context()).Warnf("Cannot extend write deadline, due to error: %v", err)
+	}
+}
+
+// reportStatus is 422 when the file was rejected, dry run report with errors is still a successful check.
+func reportStatus(report Report) int {
+	if report.HasErrors() && !report.DryRun {
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusOK
+}
@@ -0,0 +1,8 @@
+package importer
+
+import "context"
+
+type IImportRepository interface {
+	InsertReadings(ctx context.Context, readings []Reading, batchSize int) (int, error)
+	SaveSensors(ctx context.Context, layouts []SensorLayout) (created int, updated int, err error)
+}
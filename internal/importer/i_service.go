@@ -0,0 +1,20 @@
+package importer
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type IImportService interface {
+	ImportReadings(ctx context.Context, r io.Reader, format Format, opts Options) (Report, error)
+	ImportSensors(ctx context.Context, r io.Reader, opts Options) (Report, error)
+}
+
+// IHistoryService prepares storage for readings of the past: it creates partitions, which may be older
+// than premade ones, and rolls up hours and days of written readings again.
+type IHistoryService interface {
+	EnsurePartitions(ctx context.Context, from, till time.Time) ([]string, error)
+	HistoryLimit(now time.Time) time.Time
+	Reroll(ctx context.Context, from time.Time) error
+}
@@ -0,0 +1,94 @@
+package importer
+
+import (
+	"fmt"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/internal/spiece"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+const (
+	MinTemperature  = -10
+	MaxTemperature  = 50
+	MaxTransparency = 100
+
+	// allowedClockSkew lets readings of sources with slightly faster clocks in.
+	allowedClockSkew = time.Minute
+)
+
+func NewFormatFromString(format string) (Format, error) {
+	switch Format(format) {
+	case FormatCSV, FormatNDJSON:
+		return Format(format), nil
+	default:
+		return "", fmt.Errorf("unknown import format: %s", format)
+	}
+}
+
+type Options struct {
+	DryRun bool
+}
+
+// Reading is a validated row of readings file.
+type Reading struct {
+	Row          int
+	CodeName     sensor.Codename
+	SensorID     int
+	Temperature  float32
+	Transparency uint8
+	Measurements map[string]float64
+	// Raw are values before calibration, readings of files are not calibrated.
+	Raw map[string]float64
+	// Coords is where a mobile sensor took the reading, nil means the position of the sensor.
+	Coords    *sensor.Coordinates
+	Spieces   []spiece.Spiece
+	CreatedAt time.Time
+}
+
+// SensorLayout is a validated point of GeoJSON file. SensorID is 0 for new sensors.
+type SensorLayout struct {
+	Row            int
+	SensorID       int
+	CodeName       sensor.Codename
+	Coords         sensor.Coordinates
+	DataOutputRate time.Duration
+}
+
+type RowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// Report describes import result. Rows are numbered from 1 without CSV header.
+// Nothing is written when Errors is not empty or DryRun is set.
+type Report struct {
+	DryRun          bool       `json:"dry_run"`
+	Rows            int        `json:"rows"`
+	Valid           int        `json:"valid"`
+	Imported        int        `json:"imported"`
+	Created         int        `json:"created,omitempty"`
+	Updated         int        `json:"updated,omitempty"`
+	Errors          []RowError `json:"errors"`
+	ErrorsTruncated bool       `json:"errors_truncated,omitempty"`
+}
+
+func (r *Report) addError(maxErrors, row int, format string, args ...interface{}) {
+	if maxErrors > 0 && len(r.Errors) >= maxErrors {
+		r.ErrorsTruncated = true
+		return
+	}
+
+	r.Errors = append(r.Errors, RowError{Row: row, Message: fmt.Sprintf(format, args...)})
+}
+
+// HasErrors is true when at least one row is invalid, even if its error was truncated.
+func (r *Report) HasErrors() bool {
+	return len(r.Errors) > 0 || r.ErrorsTruncated
+}
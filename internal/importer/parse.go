@@ -0,0 +1,219 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/pkg/measurement"
+	"strconv"
+	"strings"
+)
+
+// rawReading is a row of readings file before validation.
+// Number fields are pointers, so missing values differ from zeros.
+type rawReading struct {
+	CodeName     string   `json:"codename"`
+	Temperature  *float64 `json:"temperature"`
+	Transparency *int     `json:"transparency"`
+	Spieces      []string `json:"spieces"`
+	CreatedAt    string   `json:"created_at"`
+	// Measurements are values of other measurement types by name.
+	Measurements map[string]float64 `json:"measurements"`
+	// Coords is where a mobile sensor took the reading.
+	Coords *sensor.Coordinates `json:"coordinates"`
+}
+
+// parseReadings calls fn for every row, err is not nil for rows which cannot be parsed.
+// Error returned by fn or broken file stops parsing.
+func parseReadings(r io.Reader, format Format, fn func(row int, raw rawReading, err error) error) error {
+	if format == FormatNDJSON {
+		return parseNDJSONReadings(r, fn)
+	}
+
+	return parseCSVReadings(r, fn)
+}
+
+// parseCSVReadings needs header with codename, temperature, transparency and created_at columns.
+// Optional spieces column has names separated by ';', columns named after other measurement types,
+// e.g. salinity, have their values. Optional x, y and z columns are the position of the reading,
+// they are set all together or left empty. Other columns are ignored,
+// so files of csv export can be imported back after adding codename column.
+func parseCSVReadings(r io.Reader, fn func(row int, raw rawReading, err error) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("cannot read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, required := range []string{"codename", "temperature", "transparency", "created_at"} {
+		if _, ok := columns[required]; !ok {
+			return fmt.Errorf("csv header has no %s column", required)
+		}
+	}
+
+	value := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			if err := fn(row, rawReading{}, err); err != nil {
+				return err
+			}
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		raw := rawReading{
+			CodeName:  value(record, "codename"),
+			CreatedAt: value(record, "created_at"),
+		}
+
+		var rowErr error
+		if temperature := value(record, "temperature"); temperature != "" {
+			t, err := strconv.ParseFloat(temperature, 64)
+			if err != nil {
+				rowErr = fmt.Errorf("temperature is not a number: %s", temperature)
+			}
+			raw.Temperature = &t
+		}
+
+		if transparency := value(record, "transparency"); transparency != "" && rowErr == nil {
+			t, err := strconv.Atoi(transparency)
+			if err != nil {
+				rowErr = fmt.Errorf("transparency is not an integer: %s", transparency)
+			}
+			raw.Transparency = &t
+		}
+
+		if spieces := value(record, "spieces"); spieces != "" {
+			raw.Spieces = strings.Split(spieces, ";")
+		}
+
+		if rowErr == nil {
+			raw.Coords, rowErr = parseCoords(value(record, "x"), value(record, "y"), value(record, "z"))
+		}
+
+		for _, name := range measurement.Names() {
+			column := value(record, name)
+			if measurement.IsBase(name) || column == "" || rowErr != nil {
+				continue
+			}
+
+			v, err := strconv.ParseFloat(column, 64)
+			if err != nil {
+				rowErr = fmt.Errorf("%s is not a number: %s", name, column)
+				break
+			}
+			if raw.Measurements == nil {
+				raw.Measurements = make(map[string]float64)
+			}
+			raw.Measurements[name] = v
+		}
+
+		if err := fn(row, raw, rowErr); err != nil {
+			return err
+		}
+	}
+}
+
+// parseCoords returns nil when all coordinates are empty.
+func parseCoords(x, y, z string) (*sensor.Coordinates, error) {
+	if x == "" && y == "" && z == "" {
+		return nil, nil
+	}
+	if x == "" || y == "" || z == "" {
+		return nil, errors.New("x, y and z should be set together")
+	}
+
+	var coords sensor.Coordinates
+	for _, c := range []struct {
+		name  string
+		value string
+		to    *float64
+	}{{"x", x, &coords.X}, {"y", y, &coords.Y}, {"z", z, &coords.Z}} {
+		v, err := strconv.ParseFloat(c.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a number: %s", c.name, c.value)
+		}
+		*c.to = v
+	}
+
+	return &coords, nil
+}
+
+func parseNDJSONReadings(r io.Reader, fn func(row int, raw rawReading, err error) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for row := 1; scanner.Scan(); row++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			row--
+			continue
+		}
+
+		var raw rawReading
+		err := json.Unmarshal([]byte(line), &raw)
+		if err := fn(row, raw, err); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+type feature struct {
+	Type     string `json:"type"`
+	Geometry *struct {
+		Type        string    `json:"type"`
+		Coordinates []float64 `json:"coordinates"`
+	} `json:"geometry"`
+	Properties struct {
+		Group          string   `json:"group"`
+		Index          *int     `json:"index"`
+		Depth          *float64 `json:"depth"`
+		DataOutputRate *int     `json:"data_output_rate"`
+	} `json:"properties"`
+}
+
+func parseLayout(r io.Reader) (featureCollection, error) {
+	var collection featureCollection
+	if err := json.NewDecoder(r).Decode(&collection); err != nil {
+		return collection, fmt.Errorf("cannot parse GeoJSON: %w", err)
+	}
+
+	if collection.Type != "FeatureCollection" {
+		return collection, fmt.Errorf("GeoJSON should be a FeatureCollection, got %q", collection.Type)
+	}
+
+	return collection, nil
+}
@@ -0,0 +1,235 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sensors-generator/config"
+	"sensors-generator/internal/apperror"
+	clients "sensors-generator/pkg/client/interfaces"
+	"sensors-generator/pkg/clock"
+	"sensors-generator/pkg/logging"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type repository struct {
+	client clients.DBClient
+	clk    clock.Clock
+	logger *logging.Logger
+	cfg    *config.Config
+}
+
+// NewPostgresqlRepository stamps rows with time of clk, so they follow the clock of the generator.
+func NewPostgresqlRepository(client *sql.DB, clk clock.Clock,
+	logger *logging.Logger, cfg *config.Config) *repository {
+	return &repository{
+		client: client,
+		clk:    clk,
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+// InsertReadings writes readings and their detected spieces by batches in one transaction,
+// so either the whole file is imported or nothing.
+// Ids are reserved before insert, because order of multi-row RETURNING is not guaranteed.
+func (r *repository) InsertReadings(ctx context.Context, readings []Reading, batchSize int) (int, error) {
+	qIDs := `SELECT nextval('sensor_data_id_seq') FROM generate_series(1, $1)`
+
+	qSensorData := `INSERT INTO sensor_data(id, sensor_id, temperature, transparency, measurements, raw, x, y, z,
+			created_at, updated_at)
+		SELECT id, sensor_id, temperature, transparency, NULLIF(measurements, '{}'), NULLIF(raw, '{}'), x, y, z,
+			created_at, $6::TIMESTAMPTZ
+		FROM unnest($1::INT[], $2::INT[], $3::FLOAT[], $4::INT[], $5::TIMESTAMPTZ[], $7::JSONB[], $8::JSONB[],
+			$9::FLOAT[], $10::FLOAT[], $11::FLOAT[])
+			AS r(id, sensor_id, temperature, transparency, created_at, measurements, raw, x, y, z)`
+
+	qDetectedSpieces := `INSERT INTO detected_spieces(spiece_id, sensor_data_id, created_at)
+		SELECT * FROM unnest($1::INT[], $2::INT[], $3::TIMESTAMPTZ[])`
+
+	if batchSize <= 0 {
+		batchSize = len(readings)
+	}
+
+	tx, err := r.client.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot begin transaction, due to error: %v", err)
+		return 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+	defer tx.Rollback()
+
+	t := r.clk.Now()
+	imported := 0
+
+	for start := 0; start < len(readings); start += batchSize {
+		batch := readings[start:min(start+batchSize, len(readings))]
+
+		rows, err := tx.QueryContext(ctx, qIDs, len(batch))
+		if err != nil {
+			r.logger.LWithContext(ctx).Errorf("Cannot reserve sensor data ids, due to error: %v", err)
+			return 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+
+		ids := make([]int64, 0, len(batch))
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				r.logger.LWithContext(ctx).Errorf("Cannot reserve sensor data ids, due to error: %v", err)
+				return 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+
+		if err := rows.Err(); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Cannot reserve sensor data ids, due to error: %v", err)
+			return 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+
+		sensorIDs := make([]int64, len(batch))
+		temperatures := make([]float64, len(batch))
+		transparencies := make([]int64, len(batch))
+		createdAt := make([]string, len(batch))
+		measurements := make([]string, len(batch))
+		raw := make([]string, len(batch))
+		// NULL positions are readings taken at the position of the sensor.
+		xs := make([]sql.NullFloat64, len(batch))
+		ys := make([]sql.NullFloat64, len(batch))
+		zs := make([]sql.NullFloat64, len(batch))
+
+		spieceIDs := make([]int64, 0)
+		sensorDataIDs := make([]int64, 0)
+		spiecesCreatedAt := make([]string, 0)
+
+		for i, reading := range batch {
+			sensorIDs[i] = int64(reading.SensorID)
+			temperatures[i] = float64(reading.Temperature)
+			transparencies[i] = int64(reading.Transparency)
+			createdAt[i] = reading.CreatedAt.Format(time.RFC3339Nano)
+			if measurements[i], err = jsonObject(reading.Measurements); err != nil {
+				r.logger.LWithContext(ctx).Errorf("Cannot encode measurements, due to error: %v", err)
+				return 0, apperror.ErrInternalSystem
+			}
+			if raw[i], err = jsonObject(reading.Raw); err != nil {
+				r.logger.LWithContext(ctx).Errorf("Cannot encode raw values, due to error: %v", err)
+				return 0, apperror.ErrInternalSystem
+			}
+
+			if reading.Coords != nil {
+				xs[i] = sql.NullFloat64{Float64: reading.Coords.X, Valid: true}
+				ys[i] = sql.NullFloat64{Float64: reading.Coords.Y, Valid: true}
+				zs[i] = sql.NullFloat64{Float64: reading.Coords.Z, Valid: true}
+			}
+
+			for _, s := range reading.Spieces {
+				spieceIDs = append(spieceIDs, int64(s.ID))
+				sensorDataIDs = append(sensorDataIDs, ids[i])
+				spiecesCreatedAt = append(spiecesCreatedAt, createdAt[i])
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, qSensorData, pq.Array(ids), pq.Array(sensorIDs),
+			pq.Array(temperatures), pq.Array(transparencies), pq.Array(createdAt), t,
+			pq.Array(measurements), pq.Array(raw), pq.Array(xs), pq.Array(ys), pq.Array(zs)); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Cannot import sensor data, due to error: %v", err)
+			return 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+
+		if len(spieceIDs) > 0 {
+			if _, err := tx.ExecContext(ctx, qDetectedSpieces, pq.Array(spieceIDs),
+				pq.Array(sensorDataIDs), pq.Array(spiecesCreatedAt)); err != nil {
+				r.logger.LWithContext(ctx).Errorf("Cannot import detected spieces, due to error: %v", err)
+				return 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+			}
+		}
+
+		imported += len(batch)
+		r.logger.LWithContext(ctx).Debugf("Imported %d of %d readings.", imported, len(readings))
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot commit import, due to error: %v", err)
+		return 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return imported, nil
+}
+
+// SaveSensors creates missing groups, updates sensors with SensorID and creates the rest in one transaction.
+func (r *repository) SaveSensors(ctx context.Context, layouts []SensorLayout) (int, int, error) {
+	qGroup := `INSERT INTO sensor_groups(name, created_at, updated_at) VALUES($1, $2, $2)
+		ON CONFLICT (name) DO NOTHING`
+
+	qUpdate := `UPDATE sensors SET x=$1, y=$2, z=$3, data_output_rate=COALESCE($4, data_output_rate), updated_at=$5
+		WHERE id=$6`
+
+	qCreate := `INSERT INTO sensors(group_id, index, x, y, z, data_output_rate, created_at, updated_at)
+		SELECT sg.id, $1::INT, $2::FLOAT, $3::FLOAT, $4::FLOAT, $5::INT, $6::TIMESTAMPTZ, $6::TIMESTAMPTZ
+		FROM sensor_groups sg WHERE sg.name=$7`
+
+	tx, err := r.client.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot begin transaction, due to error: %v", err)
+		return 0, 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+	defer tx.Rollback()
+
+	t := r.clk.Now()
+	groups := make(map[string]bool)
+	created, updated := 0, 0
+
+	for _, layout := range layouts {
+		if layout.SensorID > 0 {
+			var dataOutputRate interface{}
+			if layout.DataOutputRate > 0 {
+				dataOutputRate = int64(layout.DataOutputRate)
+			}
+
+			if _, err := tx.ExecContext(ctx, qUpdate, layout.Coords.X, layout.Coords.Y, layout.Coords.Z,
+				dataOutputRate, t, layout.SensorID); err != nil {
+				r.logger.LWithContext(ctx).Errorf("Cannot update sensor, due to error: %v", err)
+				return 0, 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+			}
+			updated++
+			continue
+		}
+
+		if !groups[layout.CodeName.GroupName] {
+			if _, err := tx.ExecContext(ctx, qGroup, layout.CodeName.GroupName, t); err != nil {
+				r.logger.LWithContext(ctx).Errorf("Cannot create sensor group, due to error: %v", err)
+				return 0, 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+			}
+			groups[layout.CodeName.GroupName] = true
+		}
+
+		if _, err := tx.ExecContext(ctx, qCreate, layout.CodeName.Index, layout.Coords.X, layout.Coords.Y,
+			layout.Coords.Z, int64(layout.DataOutputRate), t, layout.CodeName.GroupName); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Cannot create sensor, due to error: %v", err)
+			return 0, 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+		created++
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot commit sensors import, due to error: %v", err)
+		return 0, 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return created, updated, nil
+}
+
+// jsonObject encodes values for a JSONB array, empty values are '{}' which is stored as NULL.
+func jsonObject(values map[string]float64) (string, error) {
+	if len(values) == 0 {
+		return "{}", nil
+	}
+
+	value, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
@@ -0,0 +1,392 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sensors-generator/config"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/measurement"
+	"slices"
+	"strings"
+	"time"
+)
+
+type service struct {
+	importRepo     IImportRepository
+	sensorService  sensor.ISensorService
+	spieceService  spiece.ISpiecesService
+	historyService IHistoryService
+	logger         *logging.Logger
+	cfg            *config.Config
+}
+
+func NewService(importRepo IImportRepository, sensorService sensor.ISensorService,
+	spieceService spiece.ISpiecesService, historyService IHistoryService,
+	logger *logging.Logger, cfg *config.Config) *service {
+	return &service{
+		importRepo:     importRepo,
+		sensorService:  sensorService,
+		spieceService:  spieceService,
+		historyService: historyService,
+		logger:         logger,
+		cfg:            cfg,
+	}
+}
+
+// ImportReadings validates the whole file before anything is written.
+// Readings are written only if every row is valid and it is not a dry run.
+func (s *service) ImportReadings(ctx context.Context, r io.Reader, format Format, opts Options) (Report, error) {
+	report := Report{DryRun: opts.DryRun, Errors: make([]RowError, 0)}
+
+	sensors, err := s.sensorsByCodename(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	spieces, err := s.spiecesByName(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	readings := make([]Reading, 0)
+	now := time.Now()
+	maxErrors := s.cfg.ImportConfig.MaxErrors
+
+	var limit time.Time
+	if s.historyService != nil {
+		limit = s.historyService.HistoryLimit(now)
+	}
+
+	err = parseReadings(r, format, func(row int, raw rawReading, err error) error {
+		report.Rows = row
+		if s.cfg.ImportConfig.MaxRows > 0 && row > s.cfg.ImportConfig.MaxRows {
+			return apperror.ErrorWithMessage(apperror.ErrBadRequest,
+				fmt.Sprintf("File has more than %d rows.", s.cfg.ImportConfig.MaxRows))
+		}
+
+		if err != nil {
+			report.addError(maxErrors, row, "%v", err)
+			return nil
+		}
+
+		reading, message := validateReading(raw, sensors, spieces, now, limit)
+		if message != "" {
+			report.addError(maxErrors, row, "%s", message)
+			return nil
+		}
+
+		reading.Row = row
+		readings = append(readings, reading)
+		return nil
+	})
+	if err != nil {
+		s.logger.LWithContext(ctx).Errorf("Cannot parse readings, due to error: %v", err)
+		return report, asBadRequest(err)
+	}
+
+	report.Valid = len(readings)
+
+	if report.HasErrors() || opts.DryRun || len(readings) == 0 {
+		s.logger.LWithContext(ctx).Infof("Readings are not imported, rows: %d, valid: %d, dry run: %t.",
+			report.Rows, report.Valid, opts.DryRun)
+		return report, nil
+	}
+
+	from, till := readings[0].CreatedAt, readings[0].CreatedAt
+	for _, reading := range readings {
+		if reading.CreatedAt.Before(from) {
+			from = reading.CreatedAt
+		}
+		if reading.CreatedAt.After(till) {
+			till = reading.CreatedAt
+		}
+	}
+
+	if s.historyService != nil {
+		created, err := s.historyService.EnsurePartitions(ctx, from, till)
+		if err != nil {
+			s.logger.LWithContext(ctx).Errorf("Cannot create partitions for imported readings, due to error: %v", err)
+			return report, err
+		}
+		if len(created) > 0 {
+			s.logger.LWithContext(ctx).Infof("Created partitions for imported readings: %s.", strings.Join(created, ", "))
+		}
+	}
+
+	report.Imported, err = s.importRepo.InsertReadings(ctx, readings, s.cfg.ImportConfig.BatchSize)
+	if err != nil {
+		return report, err
+	}
+
+	// Readings behind rollup watermarks would never be rolled up and retention would delete them.
+	if s.historyService != nil {
+		if err := s.historyService.Reroll(ctx, from); err != nil {
+			s.logger.LWithContext(ctx).Errorf("Cannot roll up imported readings again, due to error: %v", err)
+			return report, err
+		}
+	}
+
+	s.logger.LWithContext(ctx).Infof("Imported %d readings.", report.Imported)
+	return report, nil
+}
+
+// ImportSensors updates coordinates of existing sensors and creates new sensors and groups from GeoJSON points.
+func (s *service) ImportSensors(ctx context.Context, r io.Reader, opts Options) (Report, error) {
+	report := Report{DryRun: opts.DryRun, Errors: make([]RowError, 0)}
+
+	collection, err := parseLayout(r)
+	if err != nil {
+		s.logger.LWithContext(ctx).Errorf("Cannot parse sensors layout, due to error: %v", err)
+		return report, asBadRequest(err)
+	}
+
+	report.Rows = len(collection.Features)
+	if s.cfg.ImportConfig.MaxRows > 0 && report.Rows > s.cfg.ImportConfig.MaxRows {
+		return report, apperror.ErrorWithMessage(apperror.ErrBadRequest,
+			fmt.Sprintf("File has more than %d features.", s.cfg.ImportConfig.MaxRows))
+	}
+
+	sensors, err := s.sensorsByCodename(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	layouts := make([]SensorLayout, 0, len(collection.Features))
+	seen := make(map[sensor.Codename]int)
+
+	for i, f := range collection.Features {
+		row := i + 1
+
+		layout, message := validateFeature(f, sensors)
+		if message == "" {
+			if first, ok := seen[layout.CodeName]; ok {
+				message = fmt.Sprintf("sensor %s %d is already described by feature %d",
+					layout.CodeName.GroupName, layout.CodeName.Index, first)
+			}
+		}
+
+		if message != "" {
+			report.addError(s.cfg.ImportConfig.MaxErrors, row, "%s", message)
+			continue
+		}
+
+		seen[layout.CodeName] = row
+		layout.Row = row
+		layouts = append(layouts, layout)
+	}
+
+	report.Valid = len(layouts)
+
+	if report.HasErrors() || opts.DryRun || len(layouts) == 0 {
+		s.logger.LWithContext(ctx).Infof("Sensors are not imported, features: %d, valid: %d, dry run: %t.",
+			report.Rows, report.Valid, opts.DryRun)
+		return report, nil
+	}
+
+	report.Created, report.Updated, err = s.importRepo.SaveSensors(ctx, layouts)
+	if err != nil {
+		return report, err
+	}
+	report.Imported = report.Created + report.Updated
+
+	s.logger.LWithContext(ctx).Infof("Imported sensors, created: %d, updated: %d.", report.Created, report.Updated)
+	return report, nil
+}
+
+func (s *service) sensorsByCodename(ctx context.Context) (map[sensor.Codename]sensor.Sensor, error) {
+	sensors, err := s.sensorService.GetAll(ctx, sensor.SensorFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	byCodename := make(map[sensor.Codename]sensor.Sensor, len(sensors))
+	for _, sens := range sensors {
+		byCodename[sens.CodeName] = sens
+	}
+
+	return byCodename, nil
+}
+
+func (s *service) spiecesByName(ctx context.Context) (map[string]spiece.Spiece, error) {
+	spieces, err := s.spieceService.GetAll(ctx, spiece.SpieceFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]spiece.Spiece, len(spieces))
+	for _, sp := range spieces {
+		byName[strings.ToLower(sp.Name)] = sp
+	}
+
+	return byName, nil
+}
+
+// validateReading returns a message instead of error, because messages go to the report, not to logs.
+// Readings created before limit are rejected, because their raw readings are not kept, zero limit allows all.
+func validateReading(raw rawReading, sensors map[sensor.Codename]sensor.Sensor,
+	spieces map[string]spiece.Spiece, now, limit time.Time) (Reading, string) {
+	var reading Reading
+
+	if raw.CodeName == "" {
+		return reading, "codename is required"
+	}
+
+	codeName, err := sensor.NewCodenameFromString(raw.CodeName)
+	if err != nil {
+		return reading, fmt.Sprintf("wrong codename %q", raw.CodeName)
+	}
+
+	sens, ok := sensors[codeName]
+	if !ok {
+		return reading, fmt.Sprintf("sensor %q not found", raw.CodeName)
+	}
+
+	if raw.Temperature == nil {
+		return reading, "temperature is required"
+	}
+	if *raw.Temperature < MinTemperature || *raw.Temperature > MaxTemperature {
+		return reading, fmt.Sprintf("temperature %v is out of range [%d, %d]",
+			*raw.Temperature, MinTemperature, MaxTemperature)
+	}
+
+	if raw.Transparency == nil {
+		return reading, "transparency is required"
+	}
+	if *raw.Transparency < 0 || *raw.Transparency > MaxTransparency {
+		return reading, fmt.Sprintf("transparency %d is out of range [0, %d]", *raw.Transparency, MaxTransparency)
+	}
+
+	if raw.CreatedAt == "" {
+		return reading, "created_at is required"
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, raw.CreatedAt)
+	if err != nil {
+		return reading, fmt.Sprintf("created_at %q is not RFC3339 time", raw.CreatedAt)
+	}
+	if createdAt.After(now.Add(allowedClockSkew)) {
+		return reading, fmt.Sprintf("created_at %s is in the future", raw.CreatedAt)
+	}
+	if createdAt.Before(limit) {
+		return reading, fmt.Sprintf("created_at %s is older than retention keeps raw readings, the earliest is %s",
+			raw.CreatedAt, limit.Format(time.RFC3339))
+	}
+
+	if raw.Coords != nil {
+		for _, v := range []float64{raw.Coords.X, raw.Coords.Y, raw.Coords.Z} {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return reading, "coordinates should be finite numbers"
+			}
+		}
+	}
+
+	for name, value := range raw.Measurements {
+		mType, ok := measurement.Lookup(name)
+		if !ok || measurement.IsBase(name) {
+			return reading, fmt.Sprintf("unknown measurement %q", name)
+		}
+		if sens.Measurements != nil && !slices.Contains(sens.Measurements, name) {
+			return reading, fmt.Sprintf("sensor %q does not measure %s", raw.CodeName, name)
+		}
+		if !mType.Contains(value) {
+			return reading, fmt.Sprintf("%s %v is out of range [%v, %v]", name, value, mType.Min, mType.Max)
+		}
+	}
+
+	detected := make([]spiece.Spiece, 0, len(raw.Spieces))
+	for _, name := range raw.Spieces {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		sp, ok := spieces[strings.ToLower(name)]
+		if !ok {
+			return reading, fmt.Sprintf("spiece %q not found", name)
+		}
+		detected = append(detected, sp)
+	}
+
+	return Reading{
+		CodeName:     codeName,
+		SensorID:     sens.ID,
+		Temperature:  float32(*raw.Temperature),
+		Transparency: uint8(*raw.Transparency),
+		Measurements: raw.Measurements,
+		Coords:       raw.Coords,
+		Spieces:      detected,
+		CreatedAt:    createdAt.UTC(),
+	}, ""
+}
+
+// validateFeature takes x and y from the point and z from depth property,
+// so layouts drawn in 2D GIS tools can be imported.
+func validateFeature(f feature, sensors map[sensor.Codename]sensor.Sensor) (SensorLayout, string) {
+	var layout SensorLayout
+
+	if f.Type != "Feature" {
+		return layout, fmt.Sprintf("expected Feature, got %q", f.Type)
+	}
+
+	if f.Geometry == nil || f.Geometry.Type != "Point" {
+		return layout, "geometry should be a Point"
+	}
+
+	if len(f.Geometry.Coordinates) < 2 {
+		return layout, "point should have at least 2 coordinates"
+	}
+
+	if f.Properties.Group == "" || f.Properties.Index == nil {
+		return layout, "group and index properties are required"
+	}
+
+	codeName, err := sensor.NewCodenameFromString(fmt.Sprintf("%s %d", f.Properties.Group, *f.Properties.Index))
+	if err != nil || codeName.Index <= 0 {
+		return layout, fmt.Sprintf("wrong codename %q %d", f.Properties.Group, *f.Properties.Index)
+	}
+
+	if f.Properties.Depth == nil {
+		return layout, "depth property is required"
+	}
+	if *f.Properties.Depth < 0 {
+		return layout, fmt.Sprintf("depth %v should not be negative", *f.Properties.Depth)
+	}
+
+	layout = SensorLayout{
+		CodeName: codeName,
+		Coords: sensor.Coordinates{
+			X: f.Geometry.Coordinates[0],
+			Y: f.Geometry.Coordinates[1],
+			Z: *f.Properties.Depth,
+		},
+	}
+
+	if f.Properties.DataOutputRate != nil {
+		if *f.Properties.DataOutputRate <= 0 {
+			return layout, "data_output_rate should be positive"
+		}
+		// Rate is kept in seconds, the same way as the generator reads it.
+		layout.DataOutputRate = time.Duration(*f.Properties.DataOutputRate)
+	}
+
+	if sens, ok := sensors[codeName]; ok {
+		layout.SensorID = sens.ID
+	} else if layout.DataOutputRate == 0 {
+		return layout, "data_output_rate property is required for new sensors"
+	}
+
+	return layout, ""
+}
+
+func asBadRequest(err error) error {
+	if _, ok := err.(*apperror.AppError); ok {
+		return err
+	}
+
+	return apperror.ErrorWithMessage(apperror.ErrBadRequest, err.Error())
+}
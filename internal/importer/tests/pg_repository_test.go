@@ -0,0 +1,143 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"sensors-generator/internal/importer"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/clock"
+	"sensors-generator/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+func Test_ImportRepository_InsertReadings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logging.Init("trace", true)
+
+	repo := importer.NewPostgresqlRepository(db, clock.Real{}, logging.GetLogger(), nil)
+
+	createdAt := time.Date(2023, time.July, 1, 10, 0, 0, 0, time.UTC)
+	readings := []importer.Reading{
+		{SensorID: 1, Temperature: 12.5, Transparency: 80, CreatedAt: createdAt,
+			Spieces: []spiece.Spiece{{ID: 1}, {ID: 2}}},
+		{SensorID: 2, Temperature: 9, Transparency: 40, CreatedAt: createdAt,
+			Measurements: map[string]float64{"salinity": 34.5}, Raw: map[string]float64{"temperature": 9.2},
+			Coords: &sensor.Coordinates{X: 15, Y: -3.5, Z: 20}},
+		{SensorID: 1, Temperature: 11, Transparency: 81, CreatedAt: createdAt},
+	}
+	createdAtArg := createdAt.Format(time.RFC3339Nano)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT nextval\('sensor_data_id_seq'\) FROM generate_series\(1, \$1\)`).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(10).AddRow(11))
+	mock.ExpectExec(`INSERT INTO sensor_data\(id, sensor_id, temperature, transparency, measurements, raw, x, y, z,\s+created_at, updated_at\)(.+)FROM unnest`).
+		WithArgs(pq.Array([]int64{10, 11}), pq.Array([]int64{1, 2}), pq.Array([]float64{12.5, 9}),
+			pq.Array([]int64{80, 40}), pq.Array([]string{createdAtArg, createdAtArg}), sqlmock.AnyArg(),
+			pq.Array([]string{"{}", `{"salinity":34.5}`}), pq.Array([]string{"{}", `{"temperature":9.2}`}),
+			pq.Array([]sql.NullFloat64{{}, {Float64: 15, Valid: true}}),
+			pq.Array([]sql.NullFloat64{{}, {Float64: -3.5, Valid: true}}),
+			pq.Array([]sql.NullFloat64{{}, {Float64: 20, Valid: true}})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`INSERT INTO detected_spieces\(spiece_id, sensor_data_id, created_at\)(.+)FROM unnest`).
+		WithArgs(pq.Array([]int64{1, 2}), pq.Array([]int64{10, 10}), pq.Array([]string{createdAtArg, createdAtArg})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(`SELECT nextval\('sensor_data_id_seq'\)`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(12))
+	mock.ExpectExec(`INSERT INTO sensor_data`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	imported, err := repo.InsertReadings(context.Background(), readings, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if imported != 3 {
+		t.Errorf("unexpected number of imported readings, got: %d, want: %d", imported, 3)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_ImportRepository_InsertReadings_RollbackOnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := importer.NewPostgresqlRepository(db, clock.Real{}, logging.GetLogger(), nil)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT nextval`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(10))
+	mock.ExpectExec(`INSERT INTO sensor_data`).
+		WillReturnError(&pq.Error{Code: "23503"})
+	mock.ExpectRollback()
+
+	_, err = repo.InsertReadings(context.Background(), []importer.Reading{{SensorID: 100, CreatedAt: time.Now()}}, 10)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_ImportRepository_SaveSensors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := importer.NewPostgresqlRepository(db, clock.Real{}, logging.GetLogger(), nil)
+
+	layouts := []importer.SensorLayout{
+		{SensorID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1},
+			Coords: sensor.Coordinates{X: 1.5, Y: 2, Z: 4}},
+		{CodeName: sensor.Codename{GroupName: "gamma", Index: 1},
+			Coords: sensor.Coordinates{X: 3, Y: 4, Z: 7}, DataOutputRate: 5},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE sensors SET x=\$1, y=\$2, z=\$3(.+)WHERE id=\$6`).
+		WithArgs(1.5, 2.0, 4.0, nil, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO sensor_groups\(name, created_at, updated_at\)(.+)ON CONFLICT \(name\) DO NOTHING`).
+		WithArgs("gamma", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO sensors\(group_id, index, x, y, z, data_output_rate, created_at, updated_at\)(.+)WHERE sg\.name=\$7`).
+		WithArgs(1, 3.0, 4.0, 7.0, int64(5), sqlmock.AnyArg(), "gamma").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	created, updated, err := repo.SaveSensors(context.Background(), layouts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if created != 1 || updated != 1 {
+		t.Errorf("unexpected created and updated sensors, got: %d and %d, want: 1 and 1", created, updated)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,134 @@
+package importer
+
+import (
+	"context"
+	"sensors-generator/internal/importer"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/internal/spiece"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImportRepository struct {
+	mock.Mock
+}
+
+func (m *MockImportRepository) InsertReadings(ctx context.Context, readings []importer.Reading, batchSize int) (int, error) {
+	args := m.Called(ctx, readings, batchSize)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockImportRepository) SaveSensors(ctx context.Context, layouts []importer.SensorLayout) (int, int, error) {
+	args := m.Called(ctx, layouts)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+type MockHistoryService struct {
+	mock.Mock
+}
+
+func (m *MockHistoryService) EnsurePartitions(ctx context.Context, from, till time.Time) ([]string, error) {
+	args := m.Called(ctx, from, till)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockHistoryService) HistoryLimit(now time.Time) time.Time {
+	args := m.Called(now)
+	return args.Get(0).(time.Time)
+}
+
+func (m *MockHistoryService) Reroll(ctx context.Context, from time.Time) error {
+	args := m.Called(ctx, from)
+	return args.Error(0)
+}
+
+type MockSpieceService struct {
+	mock.Mock
+}
+
+func (m *MockSpieceService) GetAll(ctx context.Context, filters spiece.SpieceFilters) ([]spiece.Spiece, error) {
+	args := m.Called(ctx, filters)
+	return args.Get(0).([]spiece.Spiece), args.Error(1)
+}
+
+func (m *MockSpieceService) Create(ctx context.Context, spieces ...spiece.CreateSpieceDTO) error {
+	args := m.Called(ctx, spieces)
+	return args.Error(0)
+}
+
+func (m *MockSpieceService) GetVersion(ctx context.Context) (spiece.Version, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(spiece.Version), args.Error(1)
+}
+
+type MockSensorService struct {
+	mock.Mock
+}
+
+func (m *MockSensorService) GetAll(ctx context.Context, filters sensor.SensorFilters) ([]sensor.Sensor, error) {
+	args := m.Called(ctx, filters)
+	return args.Get(0).([]sensor.Sensor), args.Error(1)
+}
+
+func (m *MockSensorService) Create(ctx context.Context, sensors ...sensor.CreateSensorDTO) error {
+	args := m.Called(ctx, sensors)
+	return args.Error(0)
+}
+
+func (m *MockSensorService) Update(ctx context.Context, codeName sensor.Codename, sensor sensor.UpdateSensorDTO) error {
+	args := m.Called(ctx, codeName, sensor)
+	return args.Error(0)
+}
+
+func (m *MockSensorService) AddSensorToGroup(ctx context.Context, sensorID int, groupID int) error {
+	args := m.Called(ctx, sensorID, groupID)
+	return args.Error(0)
+}
+
+func (m *MockSensorService) GetExtremumForRegion(ctx context.Context, metric string, minCoords, maxCoords sensor.Coordinates, min bool) (float64, error) {
+	args := m.Called(ctx, metric, minCoords, maxCoords, min)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockSensorService) GetAvgForSensor(ctx context.Context, metric string, filters sensor.SensorFilters) (float64, error) {
+	args := m.Called(ctx, metric, filters)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockSensorService) GetAvgForSensors(ctx context.Context, metric string, sensorIDs []int, filters sensor.SensorFilters) (map[int]float64, error) {
+	args := m.Called(ctx, metric, sensorIDs, filters)
+	return args.Get(0).(map[int]float64), args.Error(1)
+}
+
+func (m *MockSensorService) GetCalibrations(ctx context.Context, codeName sensor.Codename) ([]sensor.Calibration, error) {
+	args := m.Called(ctx, codeName)
+	if obj := args.Get(0); obj != nil {
+		return obj.([]sensor.Calibration), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorService) AddCalibration(ctx context.Context, codeName sensor.Codename, calibration sensor.CreateCalibrationDTO) (*sensor.Calibration, error) {
+	args := m.Called(ctx, codeName, calibration)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*sensor.Calibration), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorService) GetMaintenance(ctx context.Context, filters sensor.SensorFilters) ([]sensor.Maintenance, error) {
+	args := m.Called(ctx, filters)
+	if obj := args.Get(0); obj != nil {
+		return obj.([]sensor.Maintenance), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorService) AddMaintenance(ctx context.Context, codeName sensor.Codename, maintenance sensor.CreateMaintenanceDTO) (*sensor.Maintenance, error) {
+	args := m.Called(ctx, codeName, maintenance)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*sensor.Maintenance), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
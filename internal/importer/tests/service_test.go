@@ -0,0 +1,235 @@
+package importer
+
+import (
+	"context"
+	"sensors-generator/config"
+	"sensors-generator/internal/importer"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/logging"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	sensors = []sensor.Sensor{
+		{ID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1}, Measurements: []string{"temperature", "transparency"}},
+		{ID: 2, CodeName: sensor.Codename{GroupName: "beta", Index: 3},
+			Measurements: []string{"temperature", "transparency", "salinity"}},
+	}
+	spieces = []spiece.Spiece{{ID: 1, Name: "Atlantic cod"}, {ID: 2, Name: "Herring"}}
+)
+
+type mocks struct {
+	repo    *MockImportRepository
+	history *MockHistoryService
+}
+
+func newService() (mocks, importer.IImportService) {
+	logging.Init("trace", true)
+
+	cfg := &config.Config{}
+	cfg.ImportConfig.BatchSize = 2
+	cfg.ImportConfig.MaxRows = 10
+	cfg.ImportConfig.MaxErrors = 2
+
+	sensorService := &MockSensorService{}
+	sensorService.On("GetAll", mock.Anything, sensor.SensorFilters{}).Return(sensors, nil)
+	spieceService := &MockSpieceService{}
+	spieceService.On("GetAll", mock.Anything, spiece.SpieceFilters{}).Return(spieces, nil)
+
+	m := mocks{repo: &MockImportRepository{}, history: &MockHistoryService{}}
+	m.history.On("HistoryLimit", mock.Anything).Return(time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC))
+	return m, importer.NewService(m.repo, sensorService, spieceService, m.history, logging.GetLogger(), cfg)
+}
+
+func Test_ImportService_ImportReadings_CSV(t *testing.T) {
+	m, service := newService()
+	ctx := context.Background()
+
+	file := `codename,temperature,transparency,created_at,spieces
+alpha 1,12.5,80,2023-07-01T10:00:00Z,Atlantic cod;herring
+beta 3,9,40,2023-06-30T10:00:00Z,
+alpha 1,11,81,2023-07-02T10:00:00+03:00,
+`
+
+	m.history.On("EnsurePartitions", ctx,
+		time.Date(2023, time.June, 30, 10, 0, 0, 0, time.UTC),
+		time.Date(2023, time.July, 2, 7, 0, 0, 0, time.UTC)).Return([]string{}, nil)
+	m.repo.On("InsertReadings", ctx, mock.MatchedBy(func(readings []importer.Reading) bool {
+		return len(readings) == 3 && readings[0].SensorID == 1 && readings[1].SensorID == 2 &&
+			len(readings[0].Spieces) == 2 && readings[0].Spieces[1].ID == 2 && len(readings[1].Spieces) == 0
+	}), 2).Return(3, nil)
+	m.history.On("Reroll", ctx, time.Date(2023, time.June, 30, 10, 0, 0, 0, time.UTC)).Return(nil)
+
+	report, err := service.ImportReadings(ctx, strings.NewReader(file), importer.FormatCSV, importer.Options{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Rows)
+	assert.Equal(t, 3, report.Imported)
+	assert.Empty(t, report.Errors)
+	m.history.AssertExpectations(t)
+	m.repo.AssertExpectations(t)
+}
+
+func Test_ImportService_ImportReadings_InvalidRowsRejectFile(t *testing.T) {
+	m, service := newService()
+
+	file := `{"codename":"alpha 1","temperature":12.5,"transparency":80,"created_at":"2023-07-01T10:00:00Z"}
+{"codename":"gamma 1","temperature":12.5,"transparency":80,"created_at":"2023-07-01T10:00:00Z"}
+
+{"codename":"alpha 1","temperature":12.5,"transparency":180,"created_at":"2023-07-01T10:00:00Z"}
+{"codename":"alpha 1","temperature":12.5,"transparency":80,"created_at":"2023-07-01"}
+`
+
+	report, err := service.ImportReadings(context.Background(), strings.NewReader(file), importer.FormatNDJSON, importer.Options{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, report.Rows)
+	assert.Equal(t, 1, report.Valid)
+	assert.Equal(t, 0, report.Imported)
+	assert.Equal(t, []importer.RowError{
+		{Row: 2, Message: `sensor "gamma 1" not found`},
+		{Row: 3, Message: "transparency 180 is out of range [0, 100]"},
+	}, report.Errors)
+	assert.True(t, report.ErrorsTruncated)
+	m.repo.AssertNotCalled(t, "InsertReadings", mock.Anything, mock.Anything, mock.Anything)
+	m.history.AssertNotCalled(t, "EnsurePartitions", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_ImportService_ImportReadings_DryRun(t *testing.T) {
+	m, service := newService()
+
+	file := `codename,temperature,transparency,created_at
+alpha 1,12.5,80,2023-07-01T10:00:00Z
+alpha 1,x,80,2023-07-01T10:00:00Z
+`
+
+	report, err := service.ImportReadings(context.Background(), strings.NewReader(file), importer.FormatCSV,
+		importer.Options{DryRun: true})
+
+	assert.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Valid)
+	assert.Equal(t, []importer.RowError{{Row: 2, Message: "temperature is not a number: x"}}, report.Errors)
+	m.repo.AssertNotCalled(t, "InsertReadings", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_ImportService_ImportReadings_Measurements(t *testing.T) {
+	m, service := newService()
+
+	file := `{"codename":"beta 3","temperature":9,"transparency":40,"created_at":"2023-07-01T10:00:00Z","measurements":{"salinity":34.5}}
+{"codename":"alpha 1","temperature":9,"transparency":40,"created_at":"2023-07-01T10:00:00Z","measurements":{"salinity":34.5}}
+{"codename":"beta 3","temperature":9,"transparency":40,"created_at":"2023-07-01T10:00:00Z","measurements":{"salinity":50}}
+`
+
+	report, err := service.ImportReadings(context.Background(), strings.NewReader(file), importer.FormatNDJSON,
+		importer.Options{DryRun: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Valid)
+	assert.Equal(t, []importer.RowError{
+		{Row: 2, Message: `sensor "alpha 1" does not measure salinity`},
+		{Row: 3, Message: "salinity 50 is out of range [0, 45]"},
+	}, report.Errors)
+	m.repo.AssertNotCalled(t, "InsertReadings", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_ImportService_ImportReadings_Coords(t *testing.T) {
+	m, service := newService()
+
+	file := `codename,x,y,z,temperature,transparency,created_at
+alpha 1,15,-3.5,20,12.5,80,2023-07-01T10:00:00Z
+alpha 1,,,,12.5,80,2023-07-01T11:00:00Z
+alpha 1,15,,20,12.5,80,2023-07-01T12:00:00Z
+alpha 1,15,north,20,12.5,80,2023-07-01T13:00:00Z
+`
+
+	report, err := service.ImportReadings(context.Background(), strings.NewReader(file), importer.FormatCSV,
+		importer.Options{DryRun: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Valid)
+	assert.Equal(t, []importer.RowError{
+		{Row: 3, Message: "x, y and z should be set together"},
+		{Row: 4, Message: "y is not a number: north"},
+	}, report.Errors)
+	m.repo.AssertNotCalled(t, "InsertReadings", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_ImportService_ImportReadings_OlderThanRetention(t *testing.T) {
+	m, service := newService()
+
+	file := `codename,temperature,transparency,created_at
+alpha 1,12.5,80,2023-05-31T23:59:00Z
+alpha 1,12.5,80,2023-06-01T00:00:00Z
+`
+
+	report, err := service.ImportReadings(context.Background(), strings.NewReader(file), importer.FormatCSV,
+		importer.Options{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []importer.RowError{{Row: 1,
+		Message: "created_at 2023-05-31T23:59:00Z is older than retention keeps raw readings, the earliest is 2023-06-01T00:00:00Z"}},
+		report.Errors)
+	m.repo.AssertNotCalled(t, "InsertReadings", mock.Anything, mock.Anything, mock.Anything)
+	m.history.AssertNotCalled(t, "Reroll", mock.Anything, mock.Anything)
+}
+
+func Test_ImportService_ImportReadings_BadHeader(t *testing.T) {
+	_, service := newService()
+
+	_, err := service.ImportReadings(context.Background(), strings.NewReader("codename,temperature\n"),
+		importer.FormatCSV, importer.Options{})
+
+	assert.Error(t, err)
+}
+
+func Test_ImportService_ImportSensors(t *testing.T) {
+	m, service := newService()
+	ctx := context.Background()
+
+	file := `{"type":"FeatureCollection","features":[
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[1.5,2]},"properties":{"group":"alpha","index":1,"depth":4}},
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[3,4]},"properties":{"group":"gamma","index":1,"depth":7,"data_output_rate":5}}
+	]}`
+
+	m.repo.On("SaveSensors", ctx, []importer.SensorLayout{
+		{Row: 1, SensorID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1},
+			Coords: sensor.Coordinates{X: 1.5, Y: 2, Z: 4}},
+		{Row: 2, CodeName: sensor.Codename{GroupName: "gamma", Index: 1},
+			Coords: sensor.Coordinates{X: 3, Y: 4, Z: 7}, DataOutputRate: 5},
+	}).Return(1, 1, nil)
+
+	report, err := service.ImportSensors(ctx, strings.NewReader(file), importer.Options{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 2, report.Imported)
+	m.repo.AssertExpectations(t)
+}
+
+func Test_ImportService_ImportSensors_Invalid(t *testing.T) {
+	m, service := newService()
+
+	file := `{"type":"FeatureCollection","features":[
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[1.5,2]},"properties":{"group":"alpha","index":1,"depth":4}},
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[1.5,2]},"properties":{"group":"alpha","index":1,"depth":5}},
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[3,4]},"properties":{"group":"gamma","index":1,"depth":7}}
+	]}`
+
+	report, err := service.ImportSensors(context.Background(), strings.NewReader(file), importer.Options{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Valid)
+	assert.Equal(t, []importer.RowError{
+		{Row: 2, Message: "sensor alpha 1 is already described by feature 1"},
+		{Row: 3, Message: "data_output_rate property is required for new sensors"},
+	}, report.Errors)
+	m.repo.AssertNotCalled(t, "SaveSensors", mock.Anything, mock.Anything)
+}
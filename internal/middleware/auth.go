@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"sensors-generator/internal/apikey"
+	"sensors-generator/internal/apperror"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	APIKeyHeader = "X-API-Key"
+	APIKeyKey    = "api_key"
+)
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, key string) (*apikey.APIKey, error)
+}
+
+// Authenticate reads the key from X-API-Key or "Authorization: Bearer" header
+// and stores the matching api key in the gin context.
+func Authenticate(authenticator Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, ok := requestKey(c.GetHeader(APIKeyHeader), c.GetHeader("Authorization"))
+		if !ok {
+			c.Error(apperror.ErrUnauthorized)
+			c.Abort()
+			return
+		}
+
+		apiKey, err := authenticator.Authenticate(c.Request.Context(), key)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Set(APIKeyKey, apiKey)
+		c.Next()
+	}
+}
+
+// requestKey returns the key of X-API-Key header, or of Authorization header when it has the Bearer scheme.
+// Other schemes are not taken as keys.
+func requestKey(apiKey, authorization string) (string, bool) {
+	if apiKey != "" {
+		return apiKey, true
+	}
+
+	key, ok := strings.CutPrefix(authorization, "Bearer ")
+	return key, ok && key != ""
+}
+
+// Authorize allows the request only if authenticated key has at least the required role.
+func Authorize(required apikey.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, ok := c.Get(APIKeyKey)
+		if !ok {
+			c.Error(apperror.ErrUnauthorized)
+			c.Abort()
+			return
+		}
+
+		apiKey, ok := value.(*apikey.APIKey)
+		if !ok || !apiKey.Role.Allows(required) {
+			c.Error(apperror.ErrForbidden)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,238 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sensors-generator/internal/apikey"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/ratelimit"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type grpcAPIKey struct{}
+
+// GRPCOptions configure gRPC interceptors, they do the same as gin middlewares of the REST api.
+// Metadata keys are lower case header names: x-api-key, authorization, x-request-id.
+type GRPCOptions struct {
+	// Authenticator is nil when auth is disabled.
+	Authenticator Authenticator
+	// Roles are required roles by full method name, other methods need the reader role.
+	Roles map[string]apikey.Role
+	// Limiter is nil when rate limit is disabled. Rules are matched by full method name,
+	// e.g. prefix /sensorgen.v1.ReadingService. IPLimit is checked per peer before authentication.
+	Limiter ratelimit.Limiter
+	Rules   ratelimit.Rules
+	IPLimit ratelimit.Limit
+	// Timeout is set on unary calls, streams are limited by deadlines of clients.
+	Timeout time.Duration
+}
+
+// GRPCUnary returns interceptor which logs calls, checks api key and rate limit
+// and converts AppError to gRPC status.
+func GRPCUnary(opts GRPCOptions, logger *logging.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		ctx, err := opts.before(ctx, info.FullMethod, logger)
+		if err != nil {
+			return nil, logGRPC(ctx, logger, start, err)
+		}
+
+		if opts.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+			defer cancel()
+		}
+
+		resp, err := handler(ctx, req)
+		return resp, logGRPC(ctx, logger, start, err)
+	}
+}
+
+// GRPCStream is GRPCUnary for streaming calls.
+func GRPCStream(opts GRPCOptions, logger *logging.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		ctx, err := opts.before(ss.Context(), info.FullMethod, logger)
+		if err != nil {
+			return logGRPC(ctx, logger, start, err)
+		}
+
+		err = handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		return logGRPC(ctx, logger, start, err)
+	}
+}
+
+// APIKeyFromContext returns the key which authenticated the gRPC call.
+func APIKeyFromContext(ctx context.Context) (*apikey.APIKey, bool) {
+	apiKey, ok := ctx.Value(grpcAPIKey{}).(*apikey.APIKey)
+	return apiKey, ok
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (o GRPCOptions) before(ctx context.Context, method string, logger *logging.Logger) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := firstValue(md, strings.ToLower(RequestIDHeader))
+	if !isValidRequestID(requestID) {
+		requestID = newRequestID()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(RequestIDHeader), requestID))
+
+	ctx = logging.ContextWithRequestID(ctx, requestID)
+	ctx = logging.ContextWithRoute(ctx, method)
+
+	if o.Limiter != nil {
+		if allowed, retryAfter := allow(ctx, o.Limiter, "client:"+grpcPeerKey(ctx), o.IPLimit, logger); !allowed {
+			grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(RetryAfterHeader), retryAfterSeconds(retryAfter)))
+			return ctx, apperror.ErrTooManyRequests
+		}
+	}
+
+	if o.Authenticator != nil {
+		key, ok := requestKey(firstValue(md, strings.ToLower(APIKeyHeader)), firstValue(md, "authorization"))
+		if !ok {
+			return ctx, apperror.ErrUnauthorized
+		}
+
+		apiKey, err := o.Authenticator.Authenticate(ctx, key)
+		if err != nil {
+			return ctx, err
+		}
+
+		required, ok := o.Roles[method]
+		if !ok {
+			required = apikey.RoleReader
+		}
+
+		if !apiKey.Role.Allows(required) {
+			return ctx, apperror.ErrForbidden
+		}
+
+		ctx = context.WithValue(ctx, grpcAPIKey{}, apiKey)
+	}
+
+	if o.Limiter != nil {
+		group, limit := o.Rules.Match(method)
+		if allowed, retryAfter := allow(ctx, o.Limiter, group+":"+grpcClientKey(ctx), limit, logger); !allowed {
+			grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(RetryAfterHeader), retryAfterSeconds(retryAfter)))
+			return ctx, apperror.ErrTooManyRequests
+		}
+	}
+
+	return ctx, nil
+}
+
+// logGRPC logs the handled call and returns its error as gRPC status.
+func logGRPC(ctx context.Context, logger *logging.Logger, start time.Time, err error) error {
+	err = GRPCError(ctx, err)
+	code := status.Code(err)
+
+	entry := logger.LWithContext(ctx).LWithFields(map[string]interface{}{
+		"code":    code.String(),
+		"latency": time.Since(start).String(),
+	})
+
+	switch code {
+	case codes.OK:
+		entry.Info("Call handled.")
+	case codes.Internal, codes.Unknown, codes.DeadlineExceeded:
+		entry.Error("Call handled.")
+	default:
+		entry.Warn("Call handled.")
+	}
+
+	return err
+}
+
+// GRPCError converts AppError to gRPC status with the same message as in REST responses.
+// Errors which are already statuses are kept, others are internal errors.
+func GRPCError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var appError *apperror.AppError
+	if !errors.As(err, &appError) {
+		switch {
+		case errors.Is(err, context.Canceled):
+			return status.Error(codes.Canceled, err.Error())
+		case errors.Is(err, context.DeadlineExceeded), errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return status.Error(codes.DeadlineExceeded, apperror.ErrTimeout.Message)
+		}
+		appError = apperror.ErrInternalSystem
+	}
+
+	return status.Error(grpcCode(appError.TransportCode), appError.Message)
+}
+
+func grpcCode(transportCode int) codes.Code {
+	switch transportCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+func grpcClientKey(ctx context.Context) string {
+	if apiKey, ok := APIKeyFromContext(ctx); ok {
+		return "key:" + strconv.Itoa(apiKey.ID)
+	}
+
+	return grpcPeerKey(ctx)
+}
+
+func grpcPeerKey(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		host := p.Addr.String()
+		if i := strings.LastIndex(host, ":"); i > 0 {
+			host = host[:i]
+		}
+		return "ip:" + host
+	}
+
+	return "ip:unknown"
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
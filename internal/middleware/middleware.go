@@ -1,11 +1,23 @@
 package middleware
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"sensors-generator/internal/apperror"
+	"sensors-generator/pkg/logging"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	RequestIDHeader = "X-Request-ID"
+	RequestIDKey    = "request_id"
+
+	maxRequestIDLength = 128
+)
+
 func HandleErrors() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
@@ -26,3 +38,87 @@ func HandleErrors() gin.HandlerFunc {
 		}
 	}
 }
+
+// RequestID takes X-Request-ID from the request or generates a new one,
+// stores it in the request context and logs every handled request.
+func RequestID(logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = newRequestID()
+		}
+
+		c.Set(RequestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		ctx := logging.ContextWithRequestID(c.Request.Context(), requestID)
+		ctx = logging.ContextWithRoute(ctx, c.FullPath())
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		entry := logger.LWithContext(ctx).LWithFields(map[string]interface{}{
+			"method":  c.Request.Method,
+			"path":    c.Request.URL.Path,
+			"status":  c.Writer.Status(),
+			"latency": time.Since(start).String(),
+		})
+
+		switch {
+		case c.Writer.Status() >= 500:
+			entry.Error("Request handled.")
+		case c.Writer.Status() >= 400:
+			entry.Warn("Request handled.")
+		default:
+			entry.Info("Request handled.")
+		}
+	}
+}
+
+// QueryTimeout sets deadline on the request context, so database queries of the route
+// are cancelled when they run longer than configured. Route keys are gin full paths.
+func QueryTimeout(defaultTimeout time.Duration, routeTimeouts map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := defaultTimeout
+		if routeTimeout, ok := routeTimeouts[c.FullPath()]; ok {
+			timeout = routeTimeout
+		}
+
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// isValidRequestID rejects empty, too long or non printable IDs, so clients cannot break log lines.
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range requestID {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+
+	return true
+}
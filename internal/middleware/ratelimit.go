@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"sensors-generator/internal/apikey"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/ratelimit"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const RetryAfterHeader = "Retry-After"
+
+// RateLimit limits requests per api key, or per client IP for anonymous requests.
+// Each route group from rules has its own bucket. If the limiter store is not
+// available requests are let through, so Redis outage does not stop the API.
+func RateLimit(limiter ratelimit.Limiter, rules ratelimit.Rules, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		group, limit := rules.Match(c.FullPath())
+		rateLimit(c, limiter, group+":"+clientKey(c), limit, logger)
+	}
+}
+
+// RateLimitIP limits requests per client IP on all routes. It goes before Authenticate,
+// so requests without a key or with a wrong one are throttled before the key is looked up.
+func RateLimitIP(limiter ratelimit.Limiter, limit ratelimit.Limit, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rateLimit(c, limiter, "client:ip:"+c.ClientIP(), limit, logger)
+	}
+}
+
+func rateLimit(c *gin.Context, limiter ratelimit.Limiter, key string, limit ratelimit.Limit, logger *logging.Logger) {
+	if allowed, retryAfter := allow(c.Request.Context(), limiter, key, limit, logger); !allowed {
+		c.Header(RetryAfterHeader, retryAfterSeconds(retryAfter))
+		c.Error(apperror.ErrTooManyRequests)
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+// allow takes a token from the bucket of key, errors of the limiter store are logged and let the request through.
+func allow(ctx context.Context, limiter ratelimit.Limiter, key string, limit ratelimit.Limit,
+	logger *logging.Logger) (bool, time.Duration) {
+	if limit.IsUnlimited() {
+		return true, 0
+	}
+
+	allowed, retryAfter, err := limiter.Allow(ctx, key, limit)
+	if err != nil {
+		logger.LWithContext(ctx).Errorf("Cannot check rate limit, due to error: %v", err)
+		return true, 0
+	}
+
+	return allowed, retryAfter
+}
+
+func retryAfterSeconds(retryAfter time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+}
+
+func clientKey(c *gin.Context) string {
+	if value, ok := c.Get(APIKeyKey); ok {
+		if apiKey, ok := value.(*apikey.APIKey); ok {
+			return "key:" + strconv.Itoa(apiKey.ID)
+		}
+	}
+
+	return "ip:" + c.ClientIP()
+}
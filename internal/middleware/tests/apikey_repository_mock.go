@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"context"
+	"sensors-generator/internal/apikey"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) FindAll(ctx context.Context, filters apikey.APIKeyFilters) ([]apikey.APIKey, error) {
+	args := m.Called(ctx, filters)
+	return args.Get(0).([]apikey.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) FindOneByHash(ctx context.Context, hash string) (*apikey.APIKey, error) {
+	args := m.Called(ctx, hash)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*apikey.APIKey), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, apiKey apikey.CreateAPIKeyDTO, prefix, hash string) (int, error) {
+	args := m.Called(ctx, apiKey, prefix, hash)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
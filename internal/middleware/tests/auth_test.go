@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sensors-generator/internal/apikey"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/middleware"
+	"sensors-generator/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	readerKey   = "sg_reader"
+	operatorKey = "sg_operator"
+	revokedKey  = "sg_revoked"
+)
+
+// newAuthenticator returns api key service which knows a reader, an operator and a revoked key.
+func newAuthenticator() (middleware.Authenticator, *MockAPIKeyRepository) {
+	revokedAt := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	repo := &MockAPIKeyRepository{}
+	repo.On("FindOneByHash", mock.Anything, apikey.HashKey(readerKey)).
+		Return(&apikey.APIKey{ID: 1, Role: apikey.RoleReader}, nil)
+	repo.On("FindOneByHash", mock.Anything, apikey.HashKey(operatorKey)).
+		Return(&apikey.APIKey{ID: 2, Role: apikey.RoleOperator}, nil)
+	repo.On("FindOneByHash", mock.Anything, apikey.HashKey(revokedKey)).
+		Return(&apikey.APIKey{ID: 3, Role: apikey.RoleAdmin, RevokedAt: &revokedAt}, nil)
+	repo.On("FindOneByHash", mock.Anything, mock.Anything).Return(nil, apperror.ErrUnauthorized)
+
+	return apikey.NewService(repo, logging.GetLogger(), nil), repo
+}
+
+func newAuthRouter(authenticator middleware.Authenticator) *gin.Engine {
+	router := gin.New()
+	router.Use(middleware.HandleErrors())
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.Group("", middleware.Authenticate(authenticator), middleware.Authorize(apikey.RoleReader)).
+		GET("/read", ok)
+	router.Group("", middleware.Authenticate(authenticator), middleware.Authorize(apikey.RoleOperator)).
+		GET("/operate", ok)
+	return router
+}
+
+func serve(router *gin.Engine, path string, headers map[string]string) int {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func Test_Authenticate(t *testing.T) {
+	logging.Init("trace", true)
+	authenticator, repo := newAuthenticator()
+	router := newAuthRouter(authenticator)
+
+	tests := []struct {
+		name     string
+		path     string
+		headers  map[string]string
+		expected int
+	}{
+		{"missing key", "/read", nil, http.StatusUnauthorized},
+		{"empty bearer", "/read", map[string]string{"Authorization": "Bearer "}, http.StatusUnauthorized},
+		{"other scheme", "/read", map[string]string{"Authorization": "Basic " + readerKey}, http.StatusUnauthorized},
+		{"unknown key", "/read", map[string]string{middleware.APIKeyHeader: "sg_unknown"}, http.StatusUnauthorized},
+		{"revoked key", "/read", map[string]string{middleware.APIKeyHeader: revokedKey}, http.StatusUnauthorized},
+		{"x-api-key", "/read", map[string]string{middleware.APIKeyHeader: readerKey}, http.StatusOK},
+		{"bearer", "/read", map[string]string{"Authorization": "Bearer " + readerKey}, http.StatusOK},
+		{"x-api-key before bearer", "/operate", map[string]string{
+			middleware.APIKeyHeader: readerKey, "Authorization": "Bearer " + operatorKey}, http.StatusForbidden},
+		{"reader on operator route", "/operate", map[string]string{middleware.APIKeyHeader: readerKey}, http.StatusForbidden},
+		{"operator", "/operate", map[string]string{"Authorization": "Bearer " + operatorKey}, http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, serve(router, test.path, test.headers))
+		})
+	}
+
+	// Keys of other schemes are never looked up.
+	repo.AssertNotCalled(t, "FindOneByHash", mock.Anything, apikey.HashKey("Basic "+readerKey))
+}
+
+func Test_Authorize_WithoutAuthenticate(t *testing.T) {
+	router := gin.New()
+	router.Use(middleware.HandleErrors())
+	router.GET("/read", middleware.Authorize(apikey.RoleReader), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	assert.Equal(t, http.StatusUnauthorized, serve(router, "/read", nil))
+}
+
+func Test_GRPCUnary_Auth(t *testing.T) {
+	logging.Init("trace", true)
+	authenticator, _ := newAuthenticator()
+
+	const updateMethod = "/sensorgen.v1.SensorService/UpdateSensor"
+	interceptor := middleware.GRPCUnary(middleware.GRPCOptions{
+		Authenticator: authenticator,
+		Roles:         map[string]apikey.Role{updateMethod: apikey.RoleOperator},
+	}, logging.GetLogger())
+
+	call := func(method string, pairs ...string) (*apikey.APIKey, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+		resp, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				apiKey, _ := middleware.APIKeyFromContext(ctx)
+				return apiKey, nil
+			})
+		apiKey, _ := resp.(*apikey.APIKey)
+		return apiKey, err
+	}
+
+	const readMethod = "/sensorgen.v1.SensorService/GetSensors"
+
+	_, err := call(readMethod)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = call(readMethod, "authorization", "Basic "+readerKey)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = call(readMethod, "x-api-key", revokedKey)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = call(updateMethod, "x-api-key", readerKey)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	apiKey, err := call(readMethod, "x-api-key", readerKey)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, apiKey.ID)
+
+	apiKey, err = call(updateMethod, "authorization", "Bearer "+operatorKey)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, apiKey.ID)
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sensors-generator/internal/middleware"
+	"sensors-generator/pkg/logging"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var generatedRequestID = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// requestID returns X-Request-ID of the response and the ID which the handler found in the request context.
+func requestID(t *testing.T, header string) (string, string) {
+	logging.Init("trace", true)
+	router := gin.New()
+	router.Use(middleware.RequestID(logging.GetLogger()))
+
+	var fromContext string
+	router.GET("/api/v1/sensor", func(c *gin.Context) {
+		fromContext = logging.RequestIDFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/sensor", nil)
+	if header != "" {
+		req.Header.Set(middleware.RequestIDHeader, header)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	return w.Header().Get(middleware.RequestIDHeader), fromContext
+}
+
+func Test_RequestID_Generated(t *testing.T) {
+	echoed, fromContext := requestID(t, "")
+
+	assert.Regexp(t, generatedRequestID, echoed)
+	assert.Equal(t, echoed, fromContext)
+
+	another, _ := requestID(t, "")
+	assert.NotEqual(t, echoed, another)
+}
+
+func Test_RequestID_Echoed(t *testing.T) {
+	echoed, fromContext := requestID(t, "client-42/retry.1")
+
+	assert.Equal(t, "client-42/retry.1", echoed)
+	assert.Equal(t, "client-42/retry.1", fromContext)
+}
+
+func Test_RequestID_InvalidReplaced(t *testing.T) {
+	for _, header := range []string{
+		"two words",
+		"tab\there",
+		"ünïcode",
+		strings.Repeat("a", 129),
+	} {
+		echoed, fromContext := requestID(t, header)
+
+		assert.Regexp(t, generatedRequestID, echoed, header)
+		assert.Equal(t, echoed, fromContext)
+	}
+
+	// The longest allowed ID is kept.
+	longest := strings.Repeat("a", 128)
+	echoed, _ := requestID(t, longest)
+	assert.Equal(t, longest, echoed)
+}
@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sensors-generator/internal/apikey"
+	"sensors-generator/internal/middleware"
+	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/ratelimit"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (bool, time.Duration, error) {
+	return false, 0, errors.New("redis is down")
+}
+
+var limitRules = ratelimit.Rules{
+	Default: ratelimit.Limit{Rate: 1, Burst: 2},
+	Groups: []ratelimit.Rule{
+		{Name: "region", Prefix: "/api/v1/region", Limit: ratelimit.Limit{Rate: 0.25, Burst: 1}},
+		{Name: "heartbeat", Prefix: "/api/heartbeat"},
+	},
+}
+
+func newLimitedRouter(limiter ratelimit.Limiter, handlers ...gin.HandlerFunc) *gin.Engine {
+	router := gin.New()
+	router.Use(middleware.HandleErrors())
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	group := router.Group("", append(handlers, middleware.RateLimit(limiter, limitRules, logging.GetLogger()))...)
+	group.GET("/api/v1/region/:metric/min", ok)
+	group.GET("/api/v1/sensor", ok)
+	group.GET("/api/heartbeat", ok)
+	return router
+}
+
+func get(router *gin.Engine, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func Test_RateLimit_TooManyRequests(t *testing.T) {
+	logging.Init("trace", true)
+	now := time.Unix(1700000000, 0)
+	router := newLimitedRouter(ratelimit.NewMemoryLimiterWithClock(func() time.Time { return now }))
+
+	assert.Equal(t, http.StatusOK, get(router, "/api/v1/region/temperature/min", nil).Code)
+
+	w := get(router, "/api/v1/region/ph/min", nil)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	// The next token of the region group comes in 4 seconds.
+	assert.Equal(t, "4", w.Header().Get(middleware.RetryAfterHeader))
+
+	now = now.Add(1500 * time.Millisecond)
+	w = get(router, "/api/v1/region/ph/min", nil)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "3", w.Header().Get(middleware.RetryAfterHeader))
+
+	now = now.Add(2500 * time.Millisecond)
+	assert.Equal(t, http.StatusOK, get(router, "/api/v1/region/ph/min", nil).Code)
+}
+
+func Test_RateLimit_GroupBuckets(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	router := newLimitedRouter(ratelimit.NewMemoryLimiterWithClock(func() time.Time { return now }))
+
+	// The region bucket is empty, the default one is not.
+	assert.Equal(t, http.StatusOK, get(router, "/api/v1/region/temperature/min", nil).Code)
+	assert.Equal(t, http.StatusTooManyRequests, get(router, "/api/v1/region/temperature/min", nil).Code)
+	assert.Equal(t, http.StatusOK, get(router, "/api/v1/sensor", nil).Code)
+	assert.Equal(t, http.StatusOK, get(router, "/api/v1/sensor", nil).Code)
+	assert.Equal(t, http.StatusTooManyRequests, get(router, "/api/v1/sensor", nil).Code)
+
+	// Groups without limits are not limited.
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, http.StatusOK, get(router, "/api/heartbeat", nil).Code)
+	}
+}
+
+func Test_RateLimit_PerKey(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	authenticator, _ := newAuthenticator()
+	router := newLimitedRouter(ratelimit.NewMemoryLimiterWithClock(func() time.Time { return now }),
+		middleware.Authenticate(authenticator), middleware.Authorize(apikey.RoleReader))
+
+	// Keys from the same IP have their own buckets.
+	reader := map[string]string{middleware.APIKeyHeader: readerKey}
+	operator := map[string]string{middleware.APIKeyHeader: operatorKey}
+	assert.Equal(t, http.StatusOK, get(router, "/api/v1/region/temperature/min", reader).Code)
+	assert.Equal(t, http.StatusTooManyRequests, get(router, "/api/v1/region/temperature/min", reader).Code)
+	assert.Equal(t, http.StatusOK, get(router, "/api/v1/region/temperature/min", operator).Code)
+}
+
+func Test_RateLimit_FailOpen(t *testing.T) {
+	router := newLimitedRouter(failingLimiter{})
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, get(router, "/api/v1/region/temperature/min", nil).Code)
+	}
+}
+
+func Test_RateLimitIP_BeforeAuthenticate(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	limiter := ratelimit.NewMemoryLimiterWithClock(func() time.Time { return now })
+	authenticator, repo := newAuthenticator()
+	router := newLimitedRouter(limiter,
+		middleware.RateLimitIP(limiter, ratelimit.Limit{Rate: 1, Burst: 3}, logging.GetLogger()),
+		middleware.Authenticate(authenticator), middleware.Authorize(apikey.RoleReader))
+
+	wrong := map[string]string{middleware.APIKeyHeader: "sg_wrong"}
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusUnauthorized, get(router, "/api/v1/sensor", wrong).Code)
+	}
+
+	// Requests with wrong keys are throttled and do not reach the api keys table.
+	w := get(router, "/api/v1/sensor", wrong)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get(middleware.RetryAfterHeader))
+	repo.AssertNumberOfCalls(t, "FindOneByHash", 3)
+}
+
+func Test_RateLimitIP_TrustedProxies(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	limiter := ratelimit.NewMemoryLimiterWithClock(func() time.Time { return now })
+
+	newRouter := func(trustedProxies []string) *gin.Engine {
+		router := gin.New()
+		assert.NoError(t, router.SetTrustedProxies(trustedProxies))
+		router.Use(middleware.HandleErrors())
+		router.GET("/api/v1/sensor", middleware.RateLimitIP(limiter, ratelimit.Limit{Rate: 1, Burst: 1},
+			logging.GetLogger()), func(c *gin.Context) { c.Status(http.StatusOK) })
+		return router
+	}
+
+	// Without trusted proxies a forged X-Forwarded-For does not give a new bucket.
+	router := newRouter(nil)
+	assert.Equal(t, http.StatusOK, get(router, "/api/v1/sensor", map[string]string{"X-Forwarded-For": "1.1.1.1"}).Code)
+	assert.Equal(t, http.StatusTooManyRequests,
+		get(router, "/api/v1/sensor", map[string]string{"X-Forwarded-For": "2.2.2.2"}).Code)
+
+	// Behind a trusted proxy clients are told apart by X-Forwarded-For.
+	router = newRouter([]string{"10.0.0.0/8"})
+	assert.Equal(t, http.StatusOK, get(router, "/api/v1/sensor", map[string]string{"X-Forwarded-For": "3.3.3.3"}).Code)
+	assert.Equal(t, http.StatusTooManyRequests,
+		get(router, "/api/v1/sensor", map[string]string{"X-Forwarded-For": "3.3.3.3"}).Code)
+	assert.Equal(t, http.StatusOK, get(router, "/api/v1/sensor", map[string]string{"X-Forwarded-For": "4.4.4.4"}).Code)
+}
+
+func Test_GRPCUnary_RateLimitBeforeAuth(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	authenticator, repo := newAuthenticator()
+	interceptor := middleware.GRPCUnary(middleware.GRPCOptions{
+		Authenticator: authenticator,
+		Limiter:       ratelimit.NewMemoryLimiterWithClock(func() time.Time { return now }),
+		Rules:         limitRules,
+		IPLimit:       ratelimit.Limit{Rate: 1, Burst: 2},
+	}, logging.GetLogger())
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1234}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-api-key", "sg_wrong"))
+	call := func() error {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/sensorgen.v1.SensorService/GetSensors"},
+			func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+		return err
+	}
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(call()))
+	assert.Equal(t, codes.Unauthenticated, status.Code(call()))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call()))
+	repo.AssertNumberOfCalls(t, "FindOneByHash", 2)
+}
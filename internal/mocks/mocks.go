@@ -207,82 +207,122 @@ var (
 	CreateSpieces = []spiece.CreateSpieceDTO{
 		{
 			Name: "Atlantic Bluefin Tuna",
+			Habitat: spiece.Habitat{MinDepth: 0, MaxDepth: 500, MinTemperature: 3, MaxTemperature: 30,
+				MinTransparency: 40, Abundance: 0.3, SchoolSize: 12},
 		},
 
 		{
 			Name: "Atlantic Cod",
+			Habitat: spiece.Habitat{MinDepth: 10, MaxDepth: 600, MinTemperature: 0, MaxTemperature: 20,
+				MinTransparency: 0, Abundance: 0.8, SchoolSize: 6},
 		},
 
 		{
 			Name: "Atlantic Goliath Grouper",
+			Habitat: spiece.Habitat{MinDepth: 1, MaxDepth: 50, MinTemperature: 22, MaxTemperature: 30,
+				MinTransparency: 30, Abundance: 0.2, SchoolSize: 1},
 		},
 
 		{
 			Name: "Banded Butterflyfish",
+			Habitat: spiece.Habitat{MinDepth: 1, MaxDepth: 20, MinTemperature: 22, MaxTemperature: 30,
+				MinTransparency: 60, Abundance: 1, SchoolSize: 2},
 		},
 
 		{
 			Name: "Beluga Sturgeon",
+			Habitat: spiece.Habitat{MinDepth: 0, MaxDepth: 180, MinTemperature: 2, MaxTemperature: 25,
+				MinTransparency: 0, Abundance: 0.2, SchoolSize: 1},
 		},
 
 		{
 			Name: "Blue Marlin",
+			Habitat: spiece.Habitat{MinDepth: 0, MaxDepth: 200, MinTemperature: 21, MaxTemperature: 30,
+				MinTransparency: 60, Abundance: 0.1, SchoolSize: 1},
 		},
 
 		{
 			Name: "Blue Tang",
+			Habitat: spiece.Habitat{MinDepth: 2, MaxDepth: 40, MinTemperature: 24, MaxTemperature: 30,
+				MinTransparency: 60, Abundance: 1.2, SchoolSize: 8},
 		},
 
 		{
 			Name: "Bluebanded Goby",
+			Habitat: spiece.Habitat{MinDepth: 0, MaxDepth: 30, MinTemperature: 13, MaxTemperature: 25,
+				MinTransparency: 30, Abundance: 1.5, SchoolSize: 1},
 		},
 
 		{
 			Name: "Bluehead Wrasse",
+			Habitat: spiece.Habitat{MinDepth: 0, MaxDepth: 25, MinTemperature: 22, MaxTemperature: 30,
+				MinTransparency: 50, Abundance: 1.5, SchoolSize: 10},
 		},
 
 		{
 			Name: "California Grunion",
+			Habitat: spiece.Habitat{MinDepth: 0, MaxDepth: 20, MinTemperature: 13, MaxTemperature: 26,
+				MinTransparency: 20, Abundance: 0.6, SchoolSize: 25},
 		},
 
 		{
 			Name: "Clown Triggerfish",
+			Habitat: spiece.Habitat{MinDepth: 1, MaxDepth: 75, MinTemperature: 22, MaxTemperature: 29,
+				MinTransparency: 60, Abundance: 0.4, SchoolSize: 1},
 		},
 
 		{
 			Name: "Coelacanth",
+			Habitat: spiece.Habitat{MinDepth: 150, MaxDepth: 500, MinTemperature: 14, MaxTemperature: 22,
+				MinTransparency: 0, Abundance: 0.05, SchoolSize: 1},
 		},
 
 		{
 			Name: "Flashlight Fish",
+			Habitat: spiece.Habitat{MinDepth: 0, MaxDepth: 400, MinTemperature: 20, MaxTemperature: 28,
+				MinTransparency: 0, Abundance: 0.3, SchoolSize: 15},
 		},
 
 		{
 			Name: "French Angelfish",
+			Habitat: spiece.Habitat{MinDepth: 2, MaxDepth: 100, MinTemperature: 22, MaxTemperature: 30,
+				MinTransparency: 50, Abundance: 0.6, SchoolSize: 2},
 		},
 
 		{
 			Name: "John Dory",
+			Habitat: spiece.Habitat{MinDepth: 5, MaxDepth: 400, MinTemperature: 10, MaxTemperature: 25,
+				MinTransparency: 20, Abundance: 0.3, SchoolSize: 1},
 		},
 
 		{
 			Name: "Nassau Grouper",
+			Habitat: spiece.Habitat{MinDepth: 1, MaxDepth: 100, MinTemperature: 22, MaxTemperature: 30,
+				MinTransparency: 40, Abundance: 0.3, SchoolSize: 1},
 		},
 
 		{
 			Name: "Ocean Sunfish",
+			Habitat: spiece.Habitat{MinDepth: 0, MaxDepth: 600, MinTemperature: 10, MaxTemperature: 30,
+				MinTransparency: 30, Abundance: 0.1, SchoolSize: 1},
 		},
 
 		{
 			Name: "Pacific Herring",
+			Habitat: spiece.Habitat{MinDepth: 0, MaxDepth: 250, MinTemperature: 1, MaxTemperature: 18,
+				MinTransparency: 10, Abundance: 1, SchoolSize: 30},
 		},
 
 		{
 			Name: "Patagonian Toothfish",
+			Habitat: spiece.Habitat{MinDepth: 70, MaxDepth: 1600, MinTemperature: 1, MaxTemperature: 4,
+				MinTransparency: 0, Abundance: 0.2, SchoolSize: 1},
 		},
 
 		{
 			Name: "Sailfish",
+			Habitat: spiece.Habitat{MinDepth: 0, MaxDepth: 200, MinTemperature: 21, MaxTemperature: 30,
+				MinTransparency: 60, Abundance: 0.15, SchoolSize: 3},
 		},
 	}
 )
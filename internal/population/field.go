@@ -0,0 +1,55 @@
+package population
+
+import (
+	"math"
+	"sensors-generator/internal/sensor"
+	"sync"
+)
+
+type observation struct {
+	coords      sensor.Coordinates
+	temperature float32
+}
+
+// ObservedField interpolates the last temperatures measured by sensors, nearer sensors weigh more.
+// It is safe for concurrent use, sensor goroutines observe while the simulation reads.
+type ObservedField struct {
+	mu           sync.RWMutex
+	observations map[int]observation
+}
+
+func NewObservedField() *ObservedField {
+	return &ObservedField{observations: make(map[int]observation)}
+}
+
+func (f *ObservedField) Observe(sensorID int, coords sensor.Coordinates, temperature float32) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.observations[sensorID] = observation{coords: coords, temperature: temperature}
+}
+
+// TemperatureAt weighs observations by inverse squared distance.
+func (f *ObservedField) TemperatureAt(c sensor.Coordinates) (float32, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if len(f.observations) == 0 {
+		return 0, false
+	}
+
+	var sum, weights float64
+	for _, o := range f.observations {
+		dx, dy, dz := o.coords.X-c.X, o.coords.Y-c.Y, o.coords.Z-c.Z
+		distance2 := dx*dx + dy*dy + dz*dz
+		if distance2 < 1e-9 {
+			return o.temperature, true
+		}
+
+		weight := 1 / distance2
+		sum += weight * float64(o.temperature)
+		weights += weight
+	}
+
+	return float32(sum / weights), !math.IsNaN(sum / weights)
+}
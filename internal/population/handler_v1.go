@@ -0,0 +1,50 @@
+package population
+
+import (
+	"net/http"
+	"sensors-generator/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	schoolsPath = "api/v1/population/schools"
+)
+
+type handler struct {
+	populationService IPopulationService
+	logger            *logging.Logger
+}
+
+func NewHandler(populationService IPopulationService, logger *logging.Logger) *handler {
+	return &handler{
+		populationService: populationService,
+		logger:            logger,
+	}
+}
+
+func (h *handler) Register(router gin.IRouter) {
+	router.GET(schoolsPath, h.GetAll)
+}
+
+// GetAll
+// @Summary Schools
+// @Description Ground truth of simulated schools: position, velocity and size, ordered by id.
+// @Description When the simulation runs in another process, schools of its last checkpoint are returned.
+// @Tags Population
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /api/v1/population/schools [get]
+func (h *handler) GetAll(c *gin.Context) {
+	schools, err := h.populationService.GetAll(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"schools": schools})
+}
@@ -0,0 +1,8 @@
+package population
+
+import "context"
+
+type IPopulationRepository interface {
+	FindAll(ctx context.Context) ([]School, error)
+	Save(ctx context.Context, schools []School) error
+}
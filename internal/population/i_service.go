@@ -0,0 +1,16 @@
+package population
+
+import (
+	"context"
+	"sensors-generator/internal/sensor"
+	"time"
+)
+
+type IPopulationService interface {
+	Run(ctx context.Context)
+	Restore(ctx context.Context, now time.Time) error
+	Checkpoint(ctx context.Context) error
+	Observe(sensorID int, coords sensor.Coordinates, temperature float32)
+	Detect(coords sensor.Coordinates) []Detection
+	GetAll(ctx context.Context) ([]School, error)
+}
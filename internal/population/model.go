@@ -0,0 +1,49 @@
+package population
+
+import (
+	"sensors-generator/internal/sensor"
+	"sensors-generator/internal/spiece"
+	"time"
+)
+
+// School is a group of fish of one spiece which moves through the sensor field.
+type School struct {
+	ID       int                `json:"id"`
+	SpieceID int                `json:"spiece_id"`
+	Spiece   string             `json:"spiece"`
+	Size     int                `json:"size"`
+	Position sensor.Coordinates `json:"position"`
+	Velocity Velocity           `json:"velocity"`
+	// Phase shifts the seasonal migration path of the school, so schools of a spiece do not move together.
+	Phase     float64   `json:"phase"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Velocity is horizontal, meters per second.
+type Velocity struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Bounds of the sensor field, schools are kept inside them.
+type Bounds struct {
+	Min sensor.Coordinates `json:"min"`
+	Max sensor.Coordinates `json:"max"`
+}
+
+// Detection is a part of a school seen by a sensor.
+type Detection struct {
+	SchoolID int
+	Spiece   spiece.Spiece
+	Fish     int
+}
+
+// Params of the simulation.
+type Params struct {
+	// SchoolsPerSpiece is how many schools of every schooling spiece live in the field.
+	SchoolsPerSpiece int
+	// Speed is the mean speed of schools, meters per second.
+	Speed float64
+	// DetectionRadius is the distance a sensor sees a school from.
+	DetectionRadius float64
+}
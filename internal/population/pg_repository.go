@@ -0,0 +1,104 @@
+package population
+
+import (
+	"context"
+	"database/sql"
+	"sensors-generator/config"
+	"sensors-generator/internal/apperror"
+	clients "sensors-generator/pkg/client/interfaces"
+	"sensors-generator/pkg/logging"
+
+	"github.com/lib/pq"
+)
+
+type repository struct {
+	client clients.DBClient
+	logger *logging.Logger
+	cfg    *config.Config
+}
+
+func NewPostgresqlRepository(client *sql.DB,
+	logger *logging.Logger, cfg *config.Config) *repository {
+	return &repository{
+		client: client,
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+// FindAll returns schools of the last checkpoint.
+func (r *repository) FindAll(ctx context.Context) ([]School, error) {
+	q := `SELECT sc.id, sc.spiece_id, s.name, sc.size, sc.x, sc.y, sc.z, sc.vx, sc.vy, sc.phase, sc.updated_at
+		FROM schools AS sc
+		JOIN spieces s ON s.id=sc.spiece_id
+		ORDER BY sc.id`
+
+	rows, err := r.client.QueryContext(ctx, q)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to get schools, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+	defer rows.Close()
+
+	schools := make([]School, 0)
+
+	for rows.Next() {
+		var school School
+		if err := rows.Scan(&school.ID, &school.SpieceID, &school.Spiece, &school.Size,
+			&school.Position.X, &school.Position.Y, &school.Position.Z,
+			&school.Velocity.X, &school.Velocity.Y, &school.Phase, &school.UpdatedAt); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Failed to fetch row, due to error: %v", err)
+			return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+		schools = append(schools, school)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to iterate rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return schools, nil
+}
+
+// Save replaces the checkpoint with schools in one transaction, schools which died out are deleted.
+func (r *repository) Save(ctx context.Context, schools []School) error {
+	tx, err := r.client.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to begin transaction, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	ids := make([]int, 0, len(schools))
+	for _, school := range schools {
+		ids = append(ids, school.ID)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schools WHERE NOT (id = ANY($1))`, pq.Array(ids)); err != nil {
+		tx.Rollback()
+		r.logger.LWithContext(ctx).Errorf("Failed to delete schools, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	q := `INSERT INTO schools(id, spiece_id, size, x, y, z, vx, vy, phase, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO UPDATE SET size=EXCLUDED.size, x=EXCLUDED.x, y=EXCLUDED.y, z=EXCLUDED.z,
+			vx=EXCLUDED.vx, vy=EXCLUDED.vy, updated_at=EXCLUDED.updated_at`
+
+	for _, school := range schools {
+		if _, err := tx.ExecContext(ctx, q, school.ID, school.SpieceID, school.Size,
+			school.Position.X, school.Position.Y, school.Position.Z,
+			school.Velocity.X, school.Velocity.Y, school.Phase, school.UpdatedAt); err != nil {
+			tx.Rollback()
+			r.logger.LWithContext(ctx).Errorf("Failed to save school, due to error: %v", err)
+			return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to commit transaction, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return nil
+}
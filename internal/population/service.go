@@ -0,0 +1,227 @@
+package population
+
+import (
+	"context"
+	"math/rand"
+	"sensors-generator/config"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/clock"
+	"sensors-generator/pkg/logging"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxCatchUp bounds how long the simulation catches up after a restart, older checkpoints move
+// schools as if the simulation was stopped maxCatchUp ago.
+const maxCatchUp = 24 * time.Hour
+
+type service struct {
+	populationRepo IPopulationRepository
+	spieceService  spiece.ISpiecesService
+	sensorService  sensor.ISensorService
+	field          *ObservedField
+	clock          clock.Clock
+	logger         *logging.Logger
+	cfg            *config.Config
+
+	mu         sync.Mutex
+	simulation *Simulation
+	// schools is taken after every change of the simulation, so workers detect schools without mu.
+	schools atomic.Pointer[Snapshot]
+}
+
+func NewService(populationRepo IPopulationRepository, spieceService spiece.ISpiecesService,
+	sensorService sensor.ISensorService, clk clock.Clock, logger *logging.Logger, cfg *config.Config) *service {
+	return &service{
+		populationRepo: populationRepo,
+		spieceService:  spieceService,
+		sensorService:  sensorService,
+		field:          NewObservedField(),
+		clock:          clk,
+		logger:         logger,
+		cfg:            cfg,
+	}
+}
+
+// Run restores the simulation, steps it every configured step and saves a checkpoint
+// every checkpoint_every and when ctx is done. Schools move in the time of the clock,
+// so accelerated or advanced time is caught up with several steps.
+func (s *service) Run(ctx context.Context) {
+	populationCfg := s.cfg.PopulationConfig
+
+	if err := s.Restore(ctx, s.clock.Now()); err != nil {
+		s.logger.Errorf("Failed to restore population, due to error: %v", err)
+		return
+	}
+
+	stepTicker := time.NewTicker(populationCfg.Step)
+	defer stepTicker.Stop()
+	checkpointTicker := time.NewTicker(populationCfg.CheckpointEvery)
+	defer checkpointTicker.Stop()
+
+	last := s.clock.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := s.Checkpoint(context.Background()); err != nil {
+				s.logger.Errorf("Failed to save population, due to error: %v", err)
+			}
+			return
+		case <-stepTicker.C:
+			now := s.clock.Now()
+			if last.Before(now.Add(-maxCatchUp)) {
+				last = now.Add(-maxCatchUp)
+			}
+
+			s.mu.Lock()
+			for ; !last.Add(populationCfg.Step).After(now); last = last.Add(populationCfg.Step) {
+				s.simulation.Step(last.Add(populationCfg.Step), populationCfg.Step, s.field)
+			}
+			s.schools.Store(s.simulation.Snapshot())
+			s.mu.Unlock()
+		case <-checkpointTicker.C:
+			if err := s.sync(ctx); err != nil {
+				s.logger.Errorf("Failed to update spieces and sensors of population, due to error: %v", err)
+			}
+			if err := s.Checkpoint(ctx); err != nil {
+				s.logger.Errorf("Failed to save population, due to error: %v", err)
+			}
+		}
+	}
+}
+
+// Restore resumes schools of the last checkpoint. Schools are moved by the time the simulation was stopped,
+// then new schools are spawned for spieces which have less schools than configured.
+func (s *service) Restore(ctx context.Context, now time.Time) error {
+	populationCfg := s.cfg.PopulationConfig
+
+	schools, err := s.populationRepo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	spieces, sensors, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	seed := now.UnixNano()
+	if populationCfg.Seed != 0 {
+		seed = populationCfg.Seed
+	}
+
+	simulation := NewSimulation(Params{
+		SchoolsPerSpiece: populationCfg.SchoolsPerSpiece,
+		Speed:            populationCfg.Speed,
+		DetectionRadius:  populationCfg.DetectionRadius,
+	}, NewBounds(sensors, 2*populationCfg.DetectionRadius), spieces, schools, rand.New(rand.NewSource(seed)))
+
+	var stoppedAt time.Time
+	for _, school := range schools {
+		if school.UpdatedAt.After(stoppedAt) {
+			stoppedAt = school.UpdatedAt
+		}
+	}
+
+	steps := 0
+	if !stoppedAt.IsZero() {
+		if stoppedAt.Before(now.Add(-maxCatchUp)) {
+			stoppedAt = now.Add(-maxCatchUp)
+		}
+		for t := stoppedAt.Add(populationCfg.Step); !t.After(now); t = t.Add(populationCfg.Step) {
+			simulation.Step(t, populationCfg.Step, s.field)
+			steps++
+		}
+	}
+
+	spawned := simulation.Populate(now)
+	s.logger.Infof("Population is restored, schools: %d, caught up steps: %d, spawned: %d.",
+		len(simulation.Schools()), steps, spawned)
+
+	s.mu.Lock()
+	s.simulation = simulation
+	s.schools.Store(simulation.Snapshot())
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Checkpoint saves schools, it does nothing before Restore.
+func (s *service) Checkpoint(ctx context.Context) error {
+	s.mu.Lock()
+	if s.simulation == nil {
+		s.mu.Unlock()
+		return nil
+	}
+	schools := s.simulation.Schools()
+	s.mu.Unlock()
+
+	s.logger.LWithContext(ctx).Debugf("Save %d schools.", len(schools))
+	return s.populationRepo.Save(ctx, schools)
+}
+
+// Observe records the temperature measured by a sensor, schools swim towards suitable temperatures.
+func (s *service) Observe(sensorID int, coords sensor.Coordinates, temperature float32) {
+	s.field.Observe(sensorID, coords, temperature)
+}
+
+// Detect returns schools seen by a sensor at coords, nothing before Restore.
+// Schools are taken from the last snapshot, so workers do not wait for steps of the simulation.
+func (s *service) Detect(coords sensor.Coordinates) []Detection {
+	schools := s.schools.Load()
+	if schools == nil {
+		return nil
+	}
+	return schools.Detect(coords)
+}
+
+// GetAll returns schools of the running simulation, otherwise schools of the last checkpoint,
+// e.g. when the generator runs as a separate command.
+func (s *service) GetAll(ctx context.Context) ([]School, error) {
+	s.mu.Lock()
+	simulation := s.simulation
+	var schools []School
+	if simulation != nil {
+		schools = simulation.Schools()
+	}
+	s.mu.Unlock()
+
+	if simulation != nil {
+		return schools, nil
+	}
+
+	s.logger.LWithContext(ctx).Debug("Get schools.")
+	return s.populationRepo.FindAll(ctx)
+}
+
+// sync applies changes of spieces and sensors to the running simulation.
+func (s *service) sync(ctx context.Context) error {
+	spieces, sensors, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.simulation.SetSpieces(spieces)
+	s.simulation.SetBounds(NewBounds(sensors, 2*s.cfg.PopulationConfig.DetectionRadius))
+	s.schools.Store(s.simulation.Snapshot())
+	return nil
+}
+
+func (s *service) load(ctx context.Context) ([]spiece.Spiece, []sensor.Sensor, error) {
+	spieces, err := s.spieceService.GetAll(ctx, spiece.SpieceFilters{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sensors, err := s.sensorService.GetAll(ctx, sensor.SensorFilters{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return spieces, sensors, nil
+}
@@ -0,0 +1,360 @@
+package population
+
+import (
+	"math"
+	"math/rand"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/internal/spiece"
+	"sort"
+	"time"
+)
+
+const (
+	// migrationWeight is the part of the speed a school spends following its seasonal path.
+	migrationWeight = 0.6
+	// migrationRadius is the radius of seasonal paths relative to the size of the field.
+	migrationRadius = 0.4
+	// turnTime is how long a school takes to turn to the desired velocity, seconds.
+	turnTime = 60.0
+	// noise is the random part of the velocity relative to the speed.
+	noise = 0.3
+	// maxSpeed bounds the speed of a school relative to the mean speed.
+	maxSpeed = 3.0
+	// depthNoise is how many meters the depth of a school wanders in a second.
+	depthNoise = 0.05
+	// growthPerHour is the largest relative change of the school size in an hour,
+	// schools grow in suitable water and shrink in unsuitable one.
+	growthPerHour = 0.05
+	// maxGrowth bounds the size of a school relative to SchoolSize of its spiece.
+	maxGrowth = 2
+
+	year = 365.25 * 24 * time.Hour
+)
+
+// TemperatureField gives the water temperature at a point, ok is false when nothing is known there.
+type TemperatureField interface {
+	TemperatureAt(c sensor.Coordinates) (temperature float32, ok bool)
+}
+
+// Simulation moves schools of schooling spieces through the sensor field. Every school circles the field
+// once a year along its seasonal path, drifts randomly and swims towards water of suitable temperature.
+// Schools grow and shrink with suitability of the water, a school which dies out is replaced
+// by a new one coming from the edge of the field. Simulation is not safe for concurrent use.
+type Simulation struct {
+	params  Params
+	bounds  Bounds
+	spieces map[int]spiece.Spiece
+	schools []School
+	rand    *rand.Rand
+	nextID  int
+}
+
+// NewSimulation resumes schools of a checkpoint, schools of unknown spieces are dropped.
+func NewSimulation(params Params, bounds Bounds, spieces []spiece.Spiece, schools []School, rand *rand.Rand) *Simulation {
+	s := &Simulation{
+		params:  params,
+		bounds:  bounds,
+		schools: append([]School(nil), schools...),
+		rand:    rand,
+		nextID:  1,
+	}
+
+	for _, school := range schools {
+		if school.ID >= s.nextID {
+			s.nextID = school.ID + 1
+		}
+	}
+
+	s.SetSpieces(spieces)
+	return s
+}
+
+// Schools returns a copy of schools ordered by id.
+func (s *Simulation) Schools() []School {
+	schools := append([]School(nil), s.schools...)
+	sort.Slice(schools, func(i, j int) bool { return schools[i].ID < schools[j].ID })
+	return schools
+}
+
+func (s *Simulation) Bounds() Bounds {
+	return s.bounds
+}
+
+// SetBounds changes the field when sensors are added or moved, schools outside it are moved inside.
+func (s *Simulation) SetBounds(bounds Bounds) {
+	s.bounds = bounds
+	for i := range s.schools {
+		school := &s.schools[i]
+		school.Position.X = clamp(school.Position.X, bounds.Min.X, bounds.Max.X)
+		school.Position.Y = clamp(school.Position.Y, bounds.Min.Y, bounds.Max.Y)
+		if minZ, maxZ, ok := s.depthRange(s.spieces[school.SpieceID]); ok {
+			school.Position.Z = clamp(school.Position.Z, minZ, maxZ)
+		}
+	}
+}
+
+// SetSpieces replaces spieces when the catalogue changes, schools of removed spieces are dropped.
+func (s *Simulation) SetSpieces(spieces []spiece.Spiece) {
+	s.spieces = make(map[int]spiece.Spiece, len(spieces))
+	for _, sp := range spieces {
+		s.spieces[sp.ID] = sp
+	}
+
+	schools := s.schools[:0]
+	for _, school := range s.schools {
+		if sp, ok := s.spieces[school.SpieceID]; ok {
+			school.Spiece = sp.Name
+			schools = append(schools, school)
+		}
+	}
+	s.schools = schools
+}
+
+// Populate spawns schools anywhere in the field, so every schooling spiece which lives at depths
+// of the field has SchoolsPerSpiece schools. It returns the number of spawned schools.
+func (s *Simulation) Populate(now time.Time) int {
+	return s.populate(now, false)
+}
+
+// Step moves schools by dt. Field may be nil, schools do not respond to temperature then.
+func (s *Simulation) Step(now time.Time, dt time.Duration, field TemperatureField) {
+	seconds := dt.Seconds()
+	if seconds <= 0 {
+		return
+	}
+
+	alive := s.schools[:0]
+	for _, school := range s.schools {
+		s.move(&school, now, seconds, field)
+		if school.Size > 0 {
+			alive = append(alive, school)
+		}
+	}
+	s.schools = alive
+
+	// Dead schools are replaced by schools coming from outside of the field.
+	s.populate(now, true)
+}
+
+// Detect returns schools which a sensor at the point sees, nearer schools are seen better.
+func (s *Simulation) Detect(c sensor.Coordinates) []Detection {
+	return s.Snapshot().Detect(c)
+}
+
+// Snapshot is a copy of schools taken from the simulation, it is never changed,
+// so it is safe for concurrent use.
+type Snapshot struct {
+	radius  float64
+	spieces map[int]spiece.Spiece
+	schools []School
+}
+
+// Snapshot copies schools. Spieces are shared, SetSpieces replaces the map instead of changing it.
+func (s *Simulation) Snapshot() *Snapshot {
+	return &Snapshot{
+		radius:  s.params.DetectionRadius,
+		spieces: s.spieces,
+		schools: s.Schools(),
+	}
+}
+
+// Detect returns schools of the snapshot which a sensor at the point sees, nearer schools are seen better.
+func (s *Snapshot) Detect(c sensor.Coordinates) []Detection {
+	radius := s.radius
+	detections := make([]Detection, 0)
+
+	for _, school := range s.schools {
+		distance := math.Hypot(school.Position.X-c.X, school.Position.Y-c.Y)
+		if distance >= radius || math.Abs(school.Position.Z-c.Z) >= radius {
+			continue
+		}
+
+		fish := int(math.Round(float64(school.Size) * (1 - distance/radius)))
+		if fish > 0 {
+			detections = append(detections, Detection{SchoolID: school.ID, Spiece: s.spieces[school.SpieceID], Fish: fish})
+		}
+	}
+
+	return detections
+}
+
+func (s *Simulation) move(school *School, now time.Time, seconds float64, field TemperatureField) {
+	sp := s.spieces[school.SpieceID]
+	speed := s.params.Speed
+	pos := school.Position
+
+	// Seasonal path is an ellipse around the center of the field, the school goes round it once a year.
+	season := 2*math.Pi*math.Mod(float64(now.UnixNano()), float64(year))/float64(year) + school.Phase
+	target := sensor.Coordinates{
+		X: (s.bounds.Min.X+s.bounds.Max.X)/2 + migrationRadius*(s.bounds.Max.X-s.bounds.Min.X)*math.Cos(season),
+		Y: (s.bounds.Min.Y+s.bounds.Max.Y)/2 + migrationRadius*(s.bounds.Max.Y-s.bounds.Min.Y)*math.Sin(season),
+	}
+	desiredX, desiredY := unit(target.X-pos.X, target.Y-pos.Y)
+	desiredX, desiredY = desiredX*speed*migrationWeight, desiredY*speed*migrationWeight
+
+	suitability, known := s.suitability(sp, pos, field)
+	if known {
+		// The worse the water is, the harder the school swims up the gradient of suitability.
+		d := s.params.DetectionRadius
+		east, _ := s.suitability(sp, sensor.Coordinates{X: pos.X + d, Y: pos.Y, Z: pos.Z}, field)
+		west, _ := s.suitability(sp, sensor.Coordinates{X: pos.X - d, Y: pos.Y, Z: pos.Z}, field)
+		north, _ := s.suitability(sp, sensor.Coordinates{X: pos.X, Y: pos.Y + d, Z: pos.Z}, field)
+		south, _ := s.suitability(sp, sensor.Coordinates{X: pos.X, Y: pos.Y - d, Z: pos.Z}, field)
+
+		gradientX, gradientY := unit(east-west, north-south)
+		desiredX += gradientX * speed * (1 - suitability)
+		desiredY += gradientY * speed * (1 - suitability)
+	}
+
+	turn := math.Min(1, seconds/turnTime)
+	school.Velocity.X += (desiredX-school.Velocity.X)*turn + s.rand.NormFloat64()*speed*noise*math.Sqrt(turn)
+	school.Velocity.Y += (desiredY-school.Velocity.Y)*turn + s.rand.NormFloat64()*speed*noise*math.Sqrt(turn)
+	if v := math.Hypot(school.Velocity.X, school.Velocity.Y); v > speed*maxSpeed {
+		school.Velocity.X, school.Velocity.Y = school.Velocity.X*speed*maxSpeed/v, school.Velocity.Y*speed*maxSpeed/v
+	}
+
+	school.Position.X, school.Velocity.X = reflect(pos.X+school.Velocity.X*seconds, school.Velocity.X, s.bounds.Min.X, s.bounds.Max.X)
+	school.Position.Y, school.Velocity.Y = reflect(pos.Y+school.Velocity.Y*seconds, school.Velocity.Y, s.bounds.Min.Y, s.bounds.Max.Y)
+	if minZ, maxZ, ok := s.depthRange(sp); ok {
+		school.Position.Z = clamp(pos.Z+s.rand.NormFloat64()*depthNoise*math.Sqrt(seconds), minZ, maxZ)
+	}
+
+	if known {
+		growth := float64(school.Size) * growthPerHour * (2*suitability - 1) * seconds / time.Hour.Seconds()
+		school.Size += int(math.Floor(growth))
+		if s.rand.Float64() < growth-math.Floor(growth) {
+			school.Size++
+		}
+		school.Size = int(clamp(float64(school.Size), 0, float64(maxGrowth*sp.SchoolSize)))
+	}
+
+	school.UpdatedAt = now
+}
+
+// suitability of the water temperature for the spiece, known is false when the temperature is not known.
+func (s *Simulation) suitability(sp spiece.Spiece, c sensor.Coordinates, field TemperatureField) (float64, bool) {
+	if field == nil {
+		return 0, false
+	}
+
+	temperature, ok := field.TemperatureAt(c)
+	if !ok {
+		return 0, false
+	}
+
+	// Depth is kept inside the band and transparency does not move schools.
+	return sp.Suitability(c.Z, temperature, math.MaxUint8), true
+}
+
+func (s *Simulation) populate(now time.Time, atEdge bool) int {
+	counts := make(map[int]int)
+	for _, school := range s.schools {
+		counts[school.SpieceID]++
+	}
+
+	ids := make([]int, 0, len(s.spieces))
+	for id := range s.spieces {
+		ids = append(ids, id)
+	}
+	// Spieces are spawned in the same order, so seeded simulations repeat.
+	sort.Ints(ids)
+
+	spawned := 0
+	for _, id := range ids {
+		sp := s.spieces[id]
+		minZ, maxZ, ok := s.depthRange(sp)
+		if sp.SchoolSize <= 1 || !ok {
+			continue
+		}
+
+		for n := counts[id]; n < s.params.SchoolsPerSpiece; n++ {
+			s.schools = append(s.schools, s.spawn(sp, now, minZ, maxZ, atEdge))
+			spawned++
+		}
+	}
+
+	return spawned
+}
+
+func (s *Simulation) spawn(sp spiece.Spiece, now time.Time, minZ, maxZ float64, atEdge bool) School {
+	b := s.bounds
+	pos := sensor.Coordinates{
+		X: b.Min.X + s.rand.Float64()*(b.Max.X-b.Min.X),
+		Y: b.Min.Y + s.rand.Float64()*(b.Max.Y-b.Min.Y),
+		Z: minZ + s.rand.Float64()*(maxZ-minZ),
+	}
+
+	if atEdge {
+		switch s.rand.Intn(4) {
+		case 0:
+			pos.X = b.Min.X
+		case 1:
+			pos.X = b.Max.X
+		case 2:
+			pos.Y = b.Min.Y
+		default:
+			pos.Y = b.Max.Y
+		}
+	}
+
+	school := School{
+		ID:        s.nextID,
+		SpieceID:  sp.ID,
+		Spiece:    sp.Name,
+		Size:      (sp.SchoolSize+1)/2 + s.rand.Intn(sp.SchoolSize/2+1),
+		Position:  pos,
+		Phase:     s.rand.Float64() * 2 * math.Pi,
+		UpdatedAt: now,
+	}
+	s.nextID++
+
+	return school
+}
+
+// depthRange is the part of the depth band of the spiece inside the field, ok is false when they do not meet.
+func (s *Simulation) depthRange(sp spiece.Spiece) (minZ, maxZ float64, ok bool) {
+	minZ = math.Max(sp.MinDepth, s.bounds.Min.Z)
+	maxZ = math.Min(sp.MaxDepth, s.bounds.Max.Z)
+	return minZ, maxZ, minZ <= maxZ
+}
+
+// NewBounds returns bounds of sensors widened by margin, depth does not go above the surface.
+func NewBounds(sensors []sensor.Sensor, margin float64) Bounds {
+	if len(sensors) == 0 {
+		return Bounds{}
+	}
+
+	b := Bounds{Min: sensors[0].Coords, Max: sensors[0].Coords}
+	for _, sens := range sensors[1:] {
+		b.Min.X, b.Max.X = math.Min(b.Min.X, sens.Coords.X), math.Max(b.Max.X, sens.Coords.X)
+		b.Min.Y, b.Max.Y = math.Min(b.Min.Y, sens.Coords.Y), math.Max(b.Max.Y, sens.Coords.Y)
+		b.Min.Z, b.Max.Z = math.Min(b.Min.Z, sens.Coords.Z), math.Max(b.Max.Z, sens.Coords.Z)
+	}
+
+	b.Min.X, b.Min.Y, b.Min.Z = b.Min.X-margin, b.Min.Y-margin, math.Max(0, b.Min.Z-margin)
+	b.Max.X, b.Max.Y, b.Max.Z = b.Max.X+margin, b.Max.Y+margin, b.Max.Z+margin
+	return b
+}
+
+func unit(x, y float64) (float64, float64) {
+	length := math.Hypot(x, y)
+	if length == 0 {
+		return 0, 0
+	}
+	return x / length, y / length
+}
+
+// reflect bounces the position off the bounds, the velocity turns back.
+func reflect(position, velocity, min, max float64) (float64, float64) {
+	switch {
+	case position < min:
+		return math.Min(2*min-position, max), math.Abs(velocity)
+	case position > max:
+		return math.Max(2*max-position, min), -math.Abs(velocity)
+	}
+	return position, velocity
+}
+
+func clamp(value, min, max float64) float64 {
+	return math.Max(min, math.Min(max, value))
+}
@@ -0,0 +1,71 @@
+package population
+
+import (
+	"context"
+	"sensors-generator/internal/population"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/pkg/logging"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PopulationRepository_FindAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logging.Init("trace", true)
+	repo := population.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	mock.ExpectQuery("SELECT sc.id, sc.spiece_id, s.name, sc.size, sc.x, sc.y, sc.z, sc.vx, sc.vy, sc.phase, sc.updated_at FROM schools").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "spiece_id", "name", "size", "x", "y", "z", "vx", "vy", "phase", "updated_at"}).
+			AddRow(1, herring.ID, herring.Name, 20, 10.5, 20.5, 5, 0.1, -0.2, 1.5, now))
+
+	schools, err := repo.FindAll(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []population.School{{
+		ID:        1,
+		SpieceID:  herring.ID,
+		Spiece:    herring.Name,
+		Size:      20,
+		Position:  sensor.Coordinates{X: 10.5, Y: 20.5, Z: 5},
+		Velocity:  population.Velocity{X: 0.1, Y: -0.2},
+		Phase:     1.5,
+		UpdatedAt: now,
+	}}, schools)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_PopulationRepository_Save(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := population.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	schools := []population.School{
+		{ID: 1, SpieceID: herring.ID, Size: 20, Position: sensor.Coordinates{X: 10, Y: 20, Z: 5}, UpdatedAt: now},
+		{ID: 3, SpieceID: herring.ID, Size: 15, Position: sensor.Coordinates{X: 30, Y: 40, Z: 6}, UpdatedAt: now},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM schools").WithArgs(pq.Array([]int{1, 3})).WillReturnResult(sqlmock.NewResult(0, 2))
+	for _, school := range schools {
+		mock.ExpectExec("INSERT INTO schools").
+			WithArgs(school.ID, school.SpieceID, school.Size, school.Position.X, school.Position.Y, school.Position.Z,
+				0.0, 0.0, 0.0, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.Save(context.Background(), schools))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,84 @@
+package population
+
+import (
+	"context"
+	"sensors-generator/config"
+	"sensors-generator/internal/population"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/clock"
+	"sensors-generator/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.PopulationConfig.Enabled = true
+	cfg.PopulationConfig.Step = 5 * time.Second
+	cfg.PopulationConfig.SchoolsPerSpiece = 2
+	cfg.PopulationConfig.Speed = 0.2
+	cfg.PopulationConfig.DetectionRadius = 10
+	cfg.PopulationConfig.Seed = 1
+	return cfg
+}
+
+func Test_PopulationService_Restore(t *testing.T) {
+	logging.Init("trace", true)
+	ctx := context.Background()
+
+	stoppedAt := now.Add(-time.Hour)
+	checkpoint := []population.School{
+		{ID: 4, SpieceID: herring.ID, Size: 20, Position: sensor.Coordinates{X: 50, Y: 50, Z: 5}, UpdatedAt: stoppedAt},
+		// The spiece was deleted.
+		{ID: 9, SpieceID: 100, Size: 20, Position: sensor.Coordinates{X: 50, Y: 50, Z: 5}, UpdatedAt: stoppedAt},
+	}
+
+	repo := &MockPopulationRepository{}
+	repo.On("FindAll", ctx).Return(checkpoint, nil)
+	repo.On("Save", ctx).Return(nil)
+	spieceService := &MockSpieceService{}
+	spieceService.On("GetAll", ctx, spiece.SpieceFilters{}).Return([]spiece.Spiece{herring, goby}, nil)
+	sensorService := &MockSensorService{}
+	sensorService.On("GetAll", ctx, sensor.SensorFilters{}).Return([]sensor.Sensor{
+		{ID: 1, Coords: sensor.Coordinates{X: 20, Y: 20, Z: 5}},
+		{ID: 2, Coords: sensor.Coordinates{X: 100, Y: 80, Z: 10}},
+	}, nil)
+
+	service := population.NewService(repo, spieceService, sensorService, clock.Real{}, logging.GetLogger(), newConfig())
+
+	assert.Empty(t, service.Detect(sensor.Coordinates{X: 50, Y: 50, Z: 5}))
+	assert.NoError(t, service.Restore(ctx, now))
+
+	schools, err := service.GetAll(ctx)
+	assert.NoError(t, err)
+	// The school of the checkpoint caught up for an hour, one school was spawned.
+	assert.Len(t, schools, 2)
+	assert.Equal(t, 4, schools[0].ID)
+	assert.Equal(t, herring.Name, schools[0].Spiece)
+	assert.Equal(t, now, schools[0].UpdatedAt)
+	assert.NotEqual(t, checkpoint[0].Position, schools[0].Position)
+	assert.Equal(t, 10, schools[1].ID)
+
+	assert.NoError(t, service.Checkpoint(ctx))
+	assert.Equal(t, [][]population.School{schools}, repo.Saved)
+}
+
+func Test_PopulationService_GetAll_Checkpoint(t *testing.T) {
+	ctx := context.Background()
+
+	checkpoint := []population.School{{ID: 1, SpieceID: herring.ID, Spiece: herring.Name, Size: 20}}
+	repo := &MockPopulationRepository{}
+	repo.On("FindAll", ctx).Return(checkpoint, nil)
+
+	service := population.NewService(repo, &MockSpieceService{}, &MockSensorService{}, clock.Real{}, logging.GetLogger(), newConfig())
+
+	// The simulation runs in another process, schools of its checkpoint are returned.
+	schools, err := service.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, checkpoint, schools)
+	assert.NoError(t, service.Checkpoint(ctx))
+	assert.Empty(t, repo.Saved)
+}
@@ -0,0 +1,181 @@
+package population
+
+import (
+	"math"
+	"math/rand"
+	"sensors-generator/internal/population"
+	"sensors-generator/internal/sensor"
+	"sensors-generator/internal/spiece"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	herring = spiece.Spiece{ID: 1, Name: "Pacific Herring", Habitat: spiece.Habitat{MinDepth: 0, MaxDepth: 250,
+		MinTemperature: 1, MaxTemperature: 18, Abundance: 1, SchoolSize: 30}}
+	goby = spiece.Spiece{ID: 2, Name: "Bluebanded Goby", Habitat: spiece.Habitat{MinDepth: 0, MaxDepth: 30,
+		MinTemperature: 13, MaxTemperature: 25, Abundance: 1, SchoolSize: 1}}
+	coelacanth = spiece.Spiece{ID: 3, Name: "Coelacanth", Habitat: spiece.Habitat{MinDepth: 150, MaxDepth: 500,
+		MinTemperature: 14, MaxTemperature: 22, Abundance: 1, SchoolSize: 10}}
+
+	params = population.Params{SchoolsPerSpiece: 3, Speed: 0.2, DetectionRadius: 10}
+	bounds = population.Bounds{Min: sensor.Coordinates{X: 0, Y: 0, Z: 0}, Max: sensor.Coordinates{X: 200, Y: 200, Z: 20}}
+	now    = time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// uniformField has the same temperature everywhere.
+type uniformField float32
+
+func (f uniformField) TemperatureAt(c sensor.Coordinates) (float32, bool) {
+	return float32(f), true
+}
+
+func Test_Simulation_Populate(t *testing.T) {
+	simulation := population.NewSimulation(params, bounds, []spiece.Spiece{herring, goby, coelacanth}, nil,
+		rand.New(rand.NewSource(1)))
+
+	spawned := simulation.Populate(now)
+
+	// Goby is solitary and coelacanth lives deeper than the field.
+	assert.Equal(t, 3, spawned)
+	for i, school := range simulation.Schools() {
+		assert.Equal(t, i+1, school.ID)
+		assert.Equal(t, herring.ID, school.SpieceID)
+		assert.Equal(t, herring.Name, school.Spiece)
+		assert.GreaterOrEqual(t, school.Size, 15)
+		assert.LessOrEqual(t, school.Size, 30)
+		assert.LessOrEqual(t, school.Position.Z, 20.0)
+	}
+
+	assert.Zero(t, simulation.Populate(now))
+}
+
+func Test_Simulation_Detect(t *testing.T) {
+	school := population.School{ID: 7, SpieceID: herring.ID, Size: 20, Position: sensor.Coordinates{X: 100, Y: 100, Z: 5}}
+	simulation := population.NewSimulation(params, bounds, []spiece.Spiece{herring}, []population.School{school},
+		rand.New(rand.NewSource(1)))
+
+	assert.Equal(t, []population.Detection{{SchoolID: 7, Spiece: herring, Fish: 20}},
+		simulation.Detect(sensor.Coordinates{X: 100, Y: 100, Z: 5}))
+	assert.Equal(t, []population.Detection{{SchoolID: 7, Spiece: herring, Fish: 10}},
+		simulation.Detect(sensor.Coordinates{X: 103, Y: 104, Z: 8}))
+	assert.Empty(t, simulation.Detect(sensor.Coordinates{X: 110, Y: 100, Z: 5}))
+	assert.Empty(t, simulation.Detect(sensor.Coordinates{X: 100, Y: 100, Z: 16}))
+}
+
+func Test_Simulation_Snapshot(t *testing.T) {
+	school := population.School{ID: 7, SpieceID: herring.ID, Size: 20, Position: sensor.Coordinates{X: 100, Y: 100, Z: 5}}
+	simulation := population.NewSimulation(params, bounds, []spiece.Spiece{herring}, []population.School{school},
+		rand.New(rand.NewSource(1)))
+
+	snapshot := simulation.Snapshot()
+	simulation.Step(now.Add(time.Hour), time.Hour, nil)
+	simulation.SetSpieces(nil)
+
+	// Changes of the simulation do not reach the snapshot.
+	assert.Empty(t, simulation.Detect(sensor.Coordinates{X: 100, Y: 100, Z: 5}))
+	assert.Equal(t, []population.Detection{{SchoolID: 7, Spiece: herring, Fish: 20}},
+		snapshot.Detect(sensor.Coordinates{X: 100, Y: 100, Z: 5}))
+}
+
+func Test_Simulation_NeighboursDetectSameSchool(t *testing.T) {
+	simulation := population.NewSimulation(population.Params{SchoolsPerSpiece: 1, Speed: 0.2, DetectionRadius: 10},
+		bounds, []spiece.Spiece{herring}, nil, rand.New(rand.NewSource(3)))
+	simulation.Populate(now)
+
+	// Sensors every 20 meters, a reading every 30 seconds for 6 hours.
+	sensors := make([]sensor.Coordinates, 0)
+	for x := 10.0; x < 200; x += 20 {
+		for y := 10.0; y < 200; y += 20 {
+			sensors = append(sensors, sensor.Coordinates{X: x, Y: y, Z: simulation.Schools()[0].Position.Z})
+		}
+	}
+
+	var last *sensor.Coordinates
+	detectedBy := make(map[sensor.Coordinates]bool)
+	for step, at := 0, now; step < 720; step, at = step+1, at.Add(30*time.Second) {
+		simulation.Step(at, 30*time.Second, nil)
+
+		for i := range sensors {
+			if len(simulation.Detect(sensors[i])) == 0 {
+				continue
+			}
+
+			// The school moves slowly, the next sensor which sees it is a neighbour of the previous one.
+			if last != nil && *last != sensors[i] {
+				assert.LessOrEqual(t, math.Hypot(last.X-sensors[i].X, last.Y-sensors[i].Y), 30.0)
+			}
+			last = &sensors[i]
+			detectedBy[sensors[i]] = true
+		}
+	}
+
+	assert.Greater(t, len(detectedBy), 3)
+	school := simulation.Schools()[0]
+	assert.Equal(t, 1, school.ID)
+	assert.True(t, school.Position.X >= 0 && school.Position.X <= 200)
+	assert.True(t, school.Position.Y >= 0 && school.Position.Y <= 200)
+}
+
+func Test_Simulation_SwimsToSuitableTemperature(t *testing.T) {
+	field := population.NewObservedField()
+	field.Observe(1, sensor.Coordinates{X: 0, Y: 100, Z: 5}, 5)
+	field.Observe(2, sensor.Coordinates{X: 200, Y: 100, Z: 5}, 30)
+
+	school := population.School{ID: 1, SpieceID: herring.ID, Size: 20, Position: sensor.Coordinates{X: 180, Y: 100, Z: 5}}
+	simulation := population.NewSimulation(params, bounds, []spiece.Spiece{herring}, []population.School{school},
+		rand.New(rand.NewSource(1)))
+
+	temperature, _ := field.TemperatureAt(school.Position)
+	assert.Greater(t, temperature, float32(20))
+
+	for at := now; at.Before(now.Add(3 * time.Hour)); at = at.Add(10 * time.Second) {
+		simulation.Step(at, 10*time.Second, field)
+	}
+
+	temperature, _ = field.TemperatureAt(simulation.Schools()[0].Position)
+	assert.Less(t, temperature, float32(19))
+}
+
+func Test_Simulation_SchoolDiesOut(t *testing.T) {
+	school := population.School{ID: 5, SpieceID: herring.ID, Size: 20, Position: sensor.Coordinates{X: 100, Y: 100, Z: 5}}
+	simulation := population.NewSimulation(population.Params{SchoolsPerSpiece: 1, Speed: 0.2, DetectionRadius: 10},
+		bounds, []spiece.Spiece{herring}, []population.School{school}, rand.New(rand.NewSource(1)))
+
+	// Herring does not survive in warm water, a new school comes from the edge of the field.
+	for at := now; at.Before(now.Add(10 * 24 * time.Hour)); at = at.Add(time.Minute) {
+		simulation.Step(at, time.Minute, uniformField(30))
+	}
+
+	schools := simulation.Schools()
+	assert.Len(t, schools, 1)
+	assert.Greater(t, schools[0].ID, 5)
+}
+
+func Test_Simulation_SameSeed(t *testing.T) {
+	run := func() []population.School {
+		simulation := population.NewSimulation(params, bounds, []spiece.Spiece{herring}, nil, rand.New(rand.NewSource(42)))
+		simulation.Populate(now)
+		for at := now; at.Before(now.Add(time.Hour)); at = at.Add(5 * time.Second) {
+			simulation.Step(at, 5*time.Second, uniformField(10))
+		}
+		return simulation.Schools()
+	}
+
+	assert.Equal(t, run(), run())
+}
+
+func Test_NewBounds(t *testing.T) {
+	sensors := []sensor.Sensor{
+		{Coords: sensor.Coordinates{X: 16, Y: 27.88, Z: 8}},
+		{Coords: sensor.Coordinates{X: 213.45, Y: 21.88, Z: 2}},
+		{Coords: sensor.Coordinates{X: 55.33, Y: 68.24, Z: 13}},
+	}
+
+	assert.Equal(t, population.Bounds{
+		Min: sensor.Coordinates{X: -4, Y: 1.879999999999999, Z: 0},
+		Max: sensor.Coordinates{X: 233.45, Y: 88.24, Z: 33},
+	}, population.NewBounds(sensors, 20))
+}
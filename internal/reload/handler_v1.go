@@ -0,0 +1,69 @@
+package reload
+
+import (
+	"net/http"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	configPath = "api/v1/admin/config"
+	reloadPath = "/reload"
+)
+
+type handler struct {
+	watcher IConfigWatcher
+	logger  *logging.Logger
+}
+
+func NewHandler(watcher IConfigWatcher, logger *logging.Logger) *handler {
+	return &handler{
+		watcher: watcher,
+		logger:  logger,
+	}
+}
+
+func (h *handler) Register(router gin.IRouter) {
+	config := router.Group(configPath)
+	{
+		config.GET("", h.Status)
+		config.POST(reloadPath, h.Reload)
+	}
+}
+
+// Status
+// @Summary Config status
+// @Description Config file, last reload and settings which were changed but need a restart to take effect.
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Success 200
+// @Failure 401
+// @Failure 403
+// @Router /api/v1/admin/config [get]
+func (h *handler) Status(c *gin.Context) {
+	c.JSON(http.StatusOK, h.watcher.Status())
+}
+
+// Reload
+// @Summary Reload config
+// @Description Reads the config again, the same as SIGHUP. Log level and format, cors, cache ttl
+// @Description and sensors of the generator are applied live, other changes are listed in need_restart.
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /api/v1/admin/config/reload [post]
+func (h *handler) Reload(c *gin.Context) {
+	report, err := h.watcher.Reload()
+	if err != nil {
+		h.logger.LWithContext(c.Request.Context()).Errorf("Cannot reload config, due to error: %v", err)
+		c.Error(apperror.ErrorWithMessage(apperror.ErrValidation, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
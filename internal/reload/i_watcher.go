@@ -0,0 +1,8 @@
+package reload
+
+import "sensors-generator/config"
+
+type IConfigWatcher interface {
+	Reload() (config.ReloadReport, error)
+	Status() config.WatchStatus
+}
@@ -0,0 +1,18 @@
+package retention
+
+import (
+	"context"
+	"time"
+)
+
+type IRetentionRepository interface {
+	FindWatermark(ctx context.Context, level Level) (time.Time, error)
+	LowerWatermark(ctx context.Context, level Level, till time.Time) error
+	RollupHourly(ctx context.Context, from, till time.Time) error
+	RollupDaily(ctx context.Context, from, till time.Time) error
+	DeleteRawBatch(ctx context.Context, before time.Time, limit int) (int64, error)
+	DeleteHourlyBefore(ctx context.Context, before time.Time) (int64, error)
+	FindPartitions(ctx context.Context, table string) ([]Partition, error)
+	CreatePartition(ctx context.Context, partition Partition) error
+	DropPartition(ctx context.Context, partition Partition) error
+}
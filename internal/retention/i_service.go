@@ -0,0 +1,15 @@
+package retention
+
+import (
+	"context"
+	"time"
+)
+
+type IRetentionService interface {
+	Run(ctx context.Context)
+	RunOnce(ctx context.Context, now time.Time) (Report, error)
+	EnsurePartitions(ctx context.Context, from, till time.Time) ([]string, error)
+	Premake(ctx context.Context, now time.Time) (time.Time, []string, error)
+	HistoryLimit(now time.Time) time.Time
+	Reroll(ctx context.Context, from time.Time) error
+}
@@ -0,0 +1,54 @@
+package retention
+
+import "time"
+
+type Level string
+
+const (
+	LevelHourly Level = "hourly"
+	LevelDaily  Level = "daily"
+)
+
+const day = 24 * time.Hour
+
+// Report describes one run of the retention job.
+type Report struct {
+	HourlyTill    time.Time
+	DailyTill     time.Time
+	DeletedRaw    int64
+	DeletedHourly int64
+	RawCutoff     time.Time
+	HourlyCutoff  time.Time
+
+	CreatedPartitions []string
+	DroppedPartitions []string
+}
+
+// Plan tells which sources can serve aggregate query of the range.
+type Plan int
+
+const (
+	// PlanRaw reads raw readings only.
+	PlanRaw Plan = iota
+	// PlanHourly reads hourly rollups before the hourly watermark and raw readings after it.
+	PlanHourly
+	// PlanDaily additionally reads daily rollups before the daily watermark.
+	PlanDaily
+)
+
+// PlanFor picks the coarsest rollups which buckets fit into [from, till).
+// Zero bounds mean the range is open from that side.
+func PlanFor(from, till time.Time) Plan {
+	switch {
+	case isAligned(from, day) && isAligned(till, day):
+		return PlanDaily
+	case isAligned(from, time.Hour) && isAligned(till, time.Hour):
+		return PlanHourly
+	default:
+		return PlanRaw
+	}
+}
+
+func isAligned(t time.Time, d time.Duration) bool {
+	return t.IsZero() || t.Equal(t.UTC().Truncate(d))
+}
@@ -0,0 +1,94 @@
+package retention
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+type PartitionInterval string
+
+const (
+	IntervalMonth PartitionInterval = "month"
+	IntervalDay   PartitionInterval = "day"
+)
+
+// PartitionedTables are partitioned by created_at of the reading with the same bounds.
+var PartitionedTables = []string{"sensor_data", "detected_spieces"}
+
+var partitionSuffixPattern = regexp.MustCompile(`^_p(\d{6}|\d{8})$`)
+
+// Partition is a range partition of the table with bounds [From, Till).
+type Partition struct {
+	Table string
+	Name  string
+	From  time.Time
+	Till  time.Time
+}
+
+func NewPartitionIntervalFromString(interval string) (PartitionInterval, error) {
+	switch PartitionInterval(interval) {
+	case IntervalMonth, IntervalDay:
+		return PartitionInterval(interval), nil
+	case "":
+		return IntervalMonth, nil
+	default:
+		return "", fmt.Errorf("unknown partition interval: %s", interval)
+	}
+}
+
+// PartitionFor returns partition of the interval which contains t.
+// Names are <table>_pYYYYMM for monthly and <table>_pYYYYMMDD for daily partitions.
+func PartitionFor(table string, interval PartitionInterval, t time.Time) Partition {
+	t = t.UTC()
+
+	if interval == IntervalDay {
+		from := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return Partition{
+			Table: table,
+			Name:  fmt.Sprintf("%s_p%s", table, from.Format("20060102")),
+			From:  from,
+			Till:  from.AddDate(0, 0, 1),
+		}
+	}
+
+	from := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return Partition{
+		Table: table,
+		Name:  fmt.Sprintf("%s_p%s", table, from.Format("200601")),
+		From:  from,
+		Till:  from.AddDate(0, 1, 0),
+	}
+}
+
+// ParsePartition restores bounds from the partition name, false is returned for foreign tables.
+func ParsePartition(table, name string) (Partition, bool) {
+	if len(name) <= len(table) || name[:len(table)] != table {
+		return Partition{}, false
+	}
+
+	matches := partitionSuffixPattern.FindStringSubmatch(name[len(table):])
+	if matches == nil {
+		return Partition{}, false
+	}
+
+	interval, layout := IntervalMonth, "200601"
+	if len(matches[1]) == 8 {
+		interval, layout = IntervalDay, "20060102"
+	}
+
+	from, err := time.Parse(layout, matches[1])
+	if err != nil {
+		return Partition{}, false
+	}
+
+	return PartitionFor(table, interval, from), true
+}
+
+func (p Partition) Overlaps(other Partition) bool {
+	return p.From.Before(other.Till) && other.From.Before(p.Till)
+}
+
+func (p Partition) Covers(other Partition) bool {
+	return !p.From.After(other.From) && !p.Till.Before(other.Till)
+}
@@ -0,0 +1,283 @@
+package retention
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sensors-generator/config"
+	"sensors-generator/internal/apperror"
+	clients "sensors-generator/pkg/client/interfaces"
+	"sensors-generator/pkg/logging"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type repository struct {
+	client clients.DBClient
+	logger *logging.Logger
+	cfg    *config.Config
+}
+
+func NewPostgresqlRepository(client *sql.DB,
+	logger *logging.Logger, cfg *config.Config) *repository {
+	return &repository{
+		client: client,
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+// FindWatermark returns zero time when the level was never rolled up.
+func (r *repository) FindWatermark(ctx context.Context, level Level) (time.Time, error) {
+	q := `SELECT rolled_up_till FROM rollup_state WHERE name=$1`
+
+	var watermark time.Time
+	if err := r.client.QueryRowContext(ctx, q, level).Scan(&watermark); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		r.logger.LWithContext(ctx).Errorf("Failed to get %s watermark, due to error: %v", level, err)
+		return time.Time{}, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return watermark, nil
+}
+
+// LowerWatermark moves the watermark of the level back to till, a watermark which is already earlier is kept.
+func (r *repository) LowerWatermark(ctx context.Context, level Level, till time.Time) error {
+	q := `UPDATE rollup_state SET rolled_up_till=$2, updated_at=$3 WHERE name=$1 AND rolled_up_till > $2`
+
+	if _, err := r.client.ExecContext(ctx, q, level, till, time.Now()); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to lower %s watermark, due to error: %v", level, err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return nil
+}
+
+// RollupHourly aggregates raw readings of [from, till) and moves hourly watermark to till in one transaction.
+func (r *repository) RollupHourly(ctx context.Context, from, till time.Time) error {
+	sensorDataQ := `INSERT INTO sensor_data_hourly(sensor_id, bucket, temperature_min, temperature_max, temperature_avg,
+			transparency_min, transparency_max, transparency_avg, readings_count)
+		SELECT sensor_id, date_trunc('hour', created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS bucket,
+			MIN(temperature), MAX(temperature), AVG(temperature),
+			MIN(transparency), MAX(transparency), AVG(transparency), COUNT(*)
+		FROM sensor_data
+		WHERE created_at >= $1 AND created_at < $2
+		GROUP BY sensor_id, bucket
+		ON CONFLICT (sensor_id, bucket) DO UPDATE SET temperature_min=EXCLUDED.temperature_min,
+			temperature_max=EXCLUDED.temperature_max, temperature_avg=EXCLUDED.temperature_avg,
+			transparency_min=EXCLUDED.transparency_min, transparency_max=EXCLUDED.transparency_max,
+			transparency_avg=EXCLUDED.transparency_avg, readings_count=EXCLUDED.readings_count`
+
+	spiecesQ := `INSERT INTO detected_spieces_hourly(group_id, spiece_id, bucket, detections_count)
+		SELECT sens.group_id, ds.spiece_id, date_trunc('hour', sd.created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS bucket,
+			COUNT(*)
+		FROM detected_spieces ds
+		JOIN sensor_data sd ON sd.id=ds.sensor_data_id AND sd.created_at=ds.created_at
+		JOIN sensors sens ON sens.id=sd.sensor_id
+		WHERE ds.created_at >= $1 AND ds.created_at < $2
+		GROUP BY sens.group_id, ds.spiece_id, bucket
+		ON CONFLICT (group_id, spiece_id, bucket) DO UPDATE SET detections_count=EXCLUDED.detections_count`
+
+	// Readings store other measurements by name, every metric gets its own row.
+	measurementsQ := `INSERT INTO measurements_hourly(sensor_id, bucket, metric, value_min, value_max, value_avg, readings_count)
+		SELECT sd.sensor_id, date_trunc('hour', sd.created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS bucket, m.key,
+			MIN(m.value::FLOAT), MAX(m.value::FLOAT), AVG(m.value::FLOAT), COUNT(*)
+		FROM sensor_data sd, jsonb_each_text(sd.measurements) m
+		WHERE sd.created_at >= $1 AND sd.created_at < $2 AND sd.measurements IS NOT NULL
+		GROUP BY sd.sensor_id, bucket, m.key
+		ON CONFLICT (sensor_id, metric, bucket) DO UPDATE SET value_min=EXCLUDED.value_min,
+			value_max=EXCLUDED.value_max, value_avg=EXCLUDED.value_avg, readings_count=EXCLUDED.readings_count`
+
+	return r.rollup(ctx, LevelHourly, till, func(tx *sql.Tx) error {
+		for _, q := range []string{sensorDataQ, spiecesQ, measurementsQ} {
+			if _, err := tx.ExecContext(ctx, q, from, till); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RollupDaily aggregates hourly rollups of [from, till) and moves daily watermark to till in one transaction.
+func (r *repository) RollupDaily(ctx context.Context, from, till time.Time) error {
+	sensorDataQ := `INSERT INTO sensor_data_daily(sensor_id, bucket, temperature_min, temperature_max, temperature_avg,
+			transparency_min, transparency_max, transparency_avg, readings_count)
+		SELECT sensor_id, date_trunc('day', bucket AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS day,
+			MIN(temperature_min), MAX(temperature_max), SUM(temperature_avg * readings_count) / SUM(readings_count),
+			MIN(transparency_min), MAX(transparency_max), SUM(transparency_avg * readings_count) / SUM(readings_count),
+			SUM(readings_count)
+		FROM sensor_data_hourly
+		WHERE bucket >= $1 AND bucket < $2
+		GROUP BY sensor_id, day
+		ON CONFLICT (sensor_id, bucket) DO UPDATE SET temperature_min=EXCLUDED.temperature_min,
+			temperature_max=EXCLUDED.temperature_max, temperature_avg=EXCLUDED.temperature_avg,
+			transparency_min=EXCLUDED.transparency_min, transparency_max=EXCLUDED.transparency_max,
+			transparency_avg=EXCLUDED.transparency_avg, readings_count=EXCLUDED.readings_count`
+
+	spiecesQ := `INSERT INTO detected_spieces_daily(group_id, spiece_id, bucket, detections_count)
+		SELECT group_id, spiece_id, date_trunc('day', bucket AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS day,
+			SUM(detections_count)
+		FROM detected_spieces_hourly
+		WHERE bucket >= $1 AND bucket < $2
+		GROUP BY group_id, spiece_id, day
+		ON CONFLICT (group_id, spiece_id, bucket) DO UPDATE SET detections_count=EXCLUDED.detections_count`
+
+	measurementsQ := `INSERT INTO measurements_daily(sensor_id, bucket, metric, value_min, value_max, value_avg, readings_count)
+		SELECT sensor_id, date_trunc('day', bucket AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS day, metric,
+			MIN(value_min), MAX(value_max), SUM(value_avg * readings_count) / SUM(readings_count), SUM(readings_count)
+		FROM measurements_hourly
+		WHERE bucket >= $1 AND bucket < $2
+		GROUP BY sensor_id, day, metric
+		ON CONFLICT (sensor_id, metric, bucket) DO UPDATE SET value_min=EXCLUDED.value_min,
+			value_max=EXCLUDED.value_max, value_avg=EXCLUDED.value_avg, readings_count=EXCLUDED.readings_count`
+
+	return r.rollup(ctx, LevelDaily, till, func(tx *sql.Tx) error {
+		for _, q := range []string{sensorDataQ, spiecesQ, measurementsQ} {
+			if _, err := tx.ExecContext(ctx, q, from, till); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *repository) rollup(ctx context.Context, level Level, till time.Time, fn func(tx *sql.Tx) error) error {
+	tx, err := r.client.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to begin transaction, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		r.logger.LWithContext(ctx).Errorf("Failed to roll up %s data, due to error: %v", level, err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	q := `INSERT INTO rollup_state(name, rolled_up_till, updated_at) VALUES($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE SET rolled_up_till=EXCLUDED.rolled_up_till, updated_at=EXCLUDED.updated_at`
+
+	if _, err := tx.ExecContext(ctx, q, level, till, time.Now()); err != nil {
+		tx.Rollback()
+		r.logger.LWithContext(ctx).Errorf("Failed to move %s watermark, due to error: %v", level, err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to commit transaction, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return nil
+}
+
+// DeleteRawBatch deletes up to limit oldest readings created before the time together with their detected spieces.
+func (r *repository) DeleteRawBatch(ctx context.Context, before time.Time, limit int) (int64, error) {
+	q := `WITH batch AS (
+			SELECT id, created_at FROM sensor_data WHERE created_at < $1 ORDER BY created_at LIMIT $2
+		), deleted_spieces AS (
+			DELETE FROM detected_spieces WHERE created_at < $1 AND (sensor_data_id, created_at) IN (SELECT id, created_at FROM batch)
+		)
+		DELETE FROM sensor_data WHERE created_at < $1 AND (id, created_at) IN (SELECT id, created_at FROM batch)`
+
+	result, err := r.client.ExecContext(ctx, q, before, limit)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to delete raw data, due to error: %v", err)
+		return 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return result.RowsAffected()
+}
+
+// DeleteHourlyBefore deletes hourly rollups which buckets start before the time.
+func (r *repository) DeleteHourlyBefore(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+
+	for _, q := range []string{
+		`DELETE FROM sensor_data_hourly WHERE bucket < $1`,
+		`DELETE FROM detected_spieces_hourly WHERE bucket < $1`,
+		`DELETE FROM measurements_hourly WHERE bucket < $1`,
+	} {
+		result, err := r.client.ExecContext(ctx, q, before)
+		if err != nil {
+			r.logger.LWithContext(ctx).Errorf("Failed to delete hourly rollups, due to error: %v", err)
+			return deleted, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return deleted, err
+		}
+		deleted += affected
+	}
+
+	return deleted, nil
+}
+
+// FindPartitions returns partitions of the table created by the migration or the maintenance job.
+func (r *repository) FindPartitions(ctx context.Context, table string) ([]Partition, error) {
+	q := `SELECT c.relname FROM pg_inherits i
+		JOIN pg_class c ON c.oid=i.inhrelid
+		JOIN pg_class p ON p.oid=i.inhparent
+		WHERE p.relname=$1
+		ORDER BY c.relname`
+
+	rows, err := r.client.QueryContext(ctx, q, table)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to get partitions, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+	defer rows.Close()
+
+	partitions := make([]Partition, 0)
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Failed to fetch row, due to error: %v", err)
+			return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+
+		partition, ok := ParsePartition(table, name)
+		if !ok {
+			r.logger.LWithContext(ctx).Warnf("Skip partition %s with unknown name format.", name)
+			continue
+		}
+		partitions = append(partitions, partition)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to iterate rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return partitions, nil
+}
+
+func (r *repository) CreatePartition(ctx context.Context, partition Partition) error {
+	q := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM (%s) TO (%s)`,
+		pq.QuoteIdentifier(partition.Name), pq.QuoteIdentifier(partition.Table),
+		pq.QuoteLiteral(partition.From.Format(time.RFC3339)), pq.QuoteLiteral(partition.Till.Format(time.RFC3339)))
+
+	if _, err := r.client.ExecContext(ctx, q); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to create partition %s, due to error: %v", partition.Name, err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return nil
+}
+
+func (r *repository) DropPartition(ctx context.Context, partition Partition) error {
+	q := fmt.Sprintf(`DROP TABLE IF EXISTS %s`, pq.QuoteIdentifier(partition.Name))
+
+	if _, err := r.client.ExecContext(ctx, q); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to drop partition %s, due to error: %v", partition.Name, err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return nil
+}
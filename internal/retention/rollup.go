@@ -0,0 +1,155 @@
+package retention
+
+import (
+	"fmt"
+	"sensors-generator/pkg/measurement"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	hourlyWatermark = `COALESCE((SELECT rolled_up_till FROM rollup_state WHERE name='hourly'), '-infinity')`
+	dailyWatermark  = `COALESCE((SELECT rolled_up_till FROM rollup_state WHERE name='daily'), '-infinity')`
+)
+
+// SensorDataSource returns derived table for aggregate queries over sensor data of [from, till).
+// It has columns sensor_id, temperature_min, temperature_max, temperature_sum,
+// transparency_min, transparency_max, transparency_sum and readings_count,
+// so average is SUM(temperature_sum) / SUM(readings_count).
+// Placeholders start from argsCounter, returned args should be appended to query args in order.
+func SensorDataSource(plan Plan, from, till time.Time, argsCounter int) (string, []interface{}) {
+	rollupColumns := `sensor_id, temperature_min, temperature_max, temperature_avg * readings_count AS temperature_sum,
+			transparency_min, transparency_max, transparency_avg * readings_count AS transparency_sum, readings_count`
+	rawColumns := `sensor_id, temperature AS temperature_min, temperature AS temperature_max, temperature AS temperature_sum,
+			transparency AS transparency_min, transparency AS transparency_max, transparency AS transparency_sum, 1 AS readings_count`
+
+	return source(plan, from, till, argsCounter, sourceTables{
+		daily:  fmt.Sprintf(`SELECT %s FROM sensor_data_daily`, rollupColumns),
+		hourly: fmt.Sprintf(`SELECT %s FROM sensor_data_hourly`, rollupColumns),
+		raw:    fmt.Sprintf(`SELECT %s FROM sensor_data`, rawColumns),
+		rawAt:  "created_at",
+	})
+}
+
+// MetricSource returns derived table of one measurement for aggregate queries over sensor data of [from, till).
+// It has columns sensor_id, value_min, value_max, value_sum and readings_count, readings without
+// the measurement are not counted. The metric should be a known measurement type.
+// Arguments are the same as for SensorDataSource.
+func MetricSource(metric string, plan Plan, from, till time.Time, argsCounter int) (string, []interface{}) {
+	if measurement.IsBase(metric) {
+		rollupColumns := fmt.Sprintf(`sensor_id, %[1]s_min AS value_min, %[1]s_max AS value_max,
+			%[1]s_avg * readings_count AS value_sum, readings_count`, metric)
+		rawColumns := fmt.Sprintf(`sensor_id, %[1]s AS value_min, %[1]s AS value_max, %[1]s AS value_sum,
+			1 AS readings_count`, metric)
+
+		return source(plan, from, till, argsCounter, sourceTables{
+			daily:  fmt.Sprintf(`SELECT %s FROM sensor_data_daily`, rollupColumns),
+			hourly: fmt.Sprintf(`SELECT %s FROM sensor_data_hourly`, rollupColumns),
+			raw:    fmt.Sprintf(`SELECT %s FROM sensor_data`, rawColumns),
+			rawAt:  "created_at",
+		})
+	}
+
+	// Other measurements have rows of their own in rollups and are stored by name in raw readings.
+	name := pq.QuoteLiteral(metric)
+	rollupColumns := `sensor_id, value_min, value_max, value_avg * readings_count AS value_sum, readings_count`
+	value := rawValue(metric)
+
+	return source(plan, from, till, argsCounter, sourceTables{
+		daily: fmt.Sprintf(`SELECT %s FROM (SELECT * FROM measurements_daily WHERE metric=%s) AS m`,
+			rollupColumns, name),
+		hourly: fmt.Sprintf(`SELECT %s FROM (SELECT * FROM measurements_hourly WHERE metric=%s) AS m`,
+			rollupColumns, name),
+		raw: fmt.Sprintf(`SELECT sensor_id, %[1]s AS value_min, %[1]s AS value_max, %[1]s AS value_sum,
+			(%[1]s IS NOT NULL)::INT AS readings_count FROM sensor_data`, value),
+		rawAt: "created_at",
+	})
+}
+
+// DetectedSpiecesSource returns derived table with columns group_id, spiece_id and detections_count
+// for detections of [from, till). Arguments are the same as for SensorDataSource.
+func DetectedSpiecesSource(plan Plan, from, till time.Time, argsCounter int) (string, []interface{}) {
+	return source(plan, from, till, argsCounter, sourceTables{
+		daily:  `SELECT group_id, spiece_id, detections_count FROM detected_spieces_daily`,
+		hourly: `SELECT group_id, spiece_id, detections_count FROM detected_spieces_hourly`,
+		raw: `SELECT sens.group_id, ds.spiece_id, 1 AS detections_count FROM detected_spieces ds
+			JOIN sensor_data sd ON sd.id=ds.sensor_data_id AND sd.created_at=ds.created_at
+			JOIN sensors sens ON sens.id=sd.sensor_id`,
+		rawAt: "ds.created_at",
+	})
+}
+
+// MetricReadings returns derived table of raw readings of one measurement with columns sensor_id, x, y, z,
+// value_min and value_max. Position columns are NULL for readings taken at the position of the sensor,
+// value columns are NULL for readings without the measurement. Rollups have no positions,
+// so readings of mobile sensors are aggregated only while they are kept.
+func MetricReadings(metric string) string {
+	return fmt.Sprintf(`SELECT sensor_id, x, y, z, %[1]s AS value_min, %[1]s AS value_max FROM sensor_data`,
+		rawValue(metric))
+}
+
+// rawValue is the value of the metric in a row of sensor_data.
+func rawValue(metric string) string {
+	if measurement.IsBase(metric) {
+		return metric
+	}
+	return fmt.Sprintf(`(measurements->>%s)::FLOAT`, pq.QuoteLiteral(metric))
+}
+
+type sourceTables struct {
+	daily  string
+	hourly string
+	raw    string
+	rawAt  string
+}
+
+func source(plan Plan, from, till time.Time, argsCounter int, tables sourceTables) (string, []interface{}) {
+	args := []interface{}{}
+	var fromArg, tillArg string
+
+	if !from.IsZero() {
+		fromArg = fmt.Sprintf("$%d", argsCounter)
+		args = append(args, from)
+		argsCounter++
+	}
+
+	if !till.IsZero() {
+		tillArg = fmt.Sprintf("$%d", argsCounter)
+		args = append(args, till)
+	}
+
+	// Buckets and raw readings are taken from half-open [from, till), so a reading at till
+	// is not counted both by raw readings and by the bucket which starts at till.
+	bounds := func(column string, conditions ...string) string {
+		if fromArg != "" {
+			conditions = append(conditions, fmt.Sprintf("%s >= %s", column, fromArg))
+		}
+		if tillArg != "" {
+			conditions = append(conditions, fmt.Sprintf("%s < %s", column, tillArg))
+		}
+		if len(conditions) == 0 {
+			return ""
+		}
+		return "\n\t\tWHERE " + strings.Join(conditions, " AND ")
+	}
+
+	parts := make([]string, 0, 3)
+
+	switch plan {
+	case PlanDaily:
+		parts = append(parts,
+			tables.daily+bounds("bucket", "bucket < "+dailyWatermark),
+			tables.hourly+bounds("bucket", "bucket >= "+dailyWatermark, "bucket < "+hourlyWatermark),
+			tables.raw+bounds(tables.rawAt, tables.rawAt+" >= "+hourlyWatermark))
+	case PlanHourly:
+		parts = append(parts,
+			tables.hourly+bounds("bucket", "bucket < "+hourlyWatermark),
+			tables.raw+bounds(tables.rawAt, tables.rawAt+" >= "+hourlyWatermark))
+	default:
+		parts = append(parts, tables.raw+bounds(tables.rawAt))
+	}
+
+	return strings.Join(parts, "\n\t\tUNION ALL\n\t\t"), args
+}
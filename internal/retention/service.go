@@ -0,0 +1,290 @@
+package retention
+
+import (
+	"context"
+	"sensors-generator/config"
+	"sensors-generator/pkg/logging"
+	"time"
+)
+
+const (
+	defaultInterval  = 10 * time.Minute
+	defaultBatchSize = 5000
+)
+
+type service struct {
+	retentionRepo IRetentionRepository
+	logger        *logging.Logger
+	cfg           *config.Config
+}
+
+func NewService(retentionRepo IRetentionRepository,
+	logger *logging.Logger, cfg *config.Config) *service {
+	return &service{
+		retentionRepo: retentionRepo,
+		logger:        logger,
+		cfg:           cfg,
+	}
+}
+
+// Run calls RunOnce every configured interval until ctx is done.
+func (s *service) Run(ctx context.Context) {
+	interval := s.cfg.RetentionConfig.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunOnce(ctx, time.Now()); err != nil {
+			s.logger.Errorf("Retention job failed, due to error: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce creates future partitions, rolls up closed hours and days, then deletes raw readings
+// and hourly rollups older than retention. Nothing is deleted before it is rolled up into the next level.
+// Partitions which are fully expired are dropped, the rest of expired readings is deleted in batches.
+func (s *service) RunOnce(ctx context.Context, now time.Time) (Report, error) {
+	retentionCfg := s.cfg.RetentionConfig
+	report := Report{}
+
+	if s.cfg.PartitionConfig.Enabled {
+		_, created, err := s.Premake(ctx, now)
+		report.CreatedPartitions = created
+		if err != nil {
+			return report, err
+		}
+	}
+
+	if !retentionCfg.Enabled {
+		return report, nil
+	}
+
+	hourlyFrom, err := s.retentionRepo.FindWatermark(ctx, LevelHourly)
+	if err != nil {
+		return report, err
+	}
+
+	report.HourlyTill = now.Add(-retentionCfg.RollupDelay).Truncate(time.Hour)
+	if report.HourlyTill.After(hourlyFrom) {
+		s.logger.Debugf("Roll up hourly data from %v till %v.", hourlyFrom, report.HourlyTill)
+		if err := s.retentionRepo.RollupHourly(ctx, hourlyFrom, report.HourlyTill); err != nil {
+			return report, err
+		}
+	} else {
+		report.HourlyTill = hourlyFrom
+	}
+
+	dailyFrom, err := s.retentionRepo.FindWatermark(ctx, LevelDaily)
+	if err != nil {
+		return report, err
+	}
+
+	report.DailyTill = report.HourlyTill.Truncate(day)
+	if report.DailyTill.After(dailyFrom) {
+		s.logger.Debugf("Roll up daily data from %v till %v.", dailyFrom, report.DailyTill)
+		if err := s.retentionRepo.RollupDaily(ctx, dailyFrom, report.DailyTill); err != nil {
+			return report, err
+		}
+	} else {
+		report.DailyTill = dailyFrom
+	}
+
+	if retentionCfg.RawDays > 0 {
+		report.RawCutoff = earliest(now.AddDate(0, 0, -retentionCfg.RawDays), report.HourlyTill)
+
+		if s.cfg.PartitionConfig.Enabled {
+			dropped, err := s.dropPartitions(ctx, report.RawCutoff)
+			report.DroppedPartitions = dropped
+			if err != nil {
+				return report, err
+			}
+		}
+
+		batchSize := retentionCfg.BatchSize
+		if batchSize <= 0 {
+			batchSize = defaultBatchSize
+		}
+
+		for ctx.Err() == nil {
+			deleted, err := s.retentionRepo.DeleteRawBatch(ctx, report.RawCutoff, batchSize)
+			if err != nil {
+				return report, err
+			}
+
+			report.DeletedRaw += deleted
+			if deleted < int64(batchSize) {
+				break
+			}
+		}
+	}
+
+	if retentionCfg.HourlyDays > 0 {
+		report.HourlyCutoff = earliest(now.AddDate(0, 0, -retentionCfg.HourlyDays), report.DailyTill)
+
+		deleted, err := s.retentionRepo.DeleteHourlyBefore(ctx, report.HourlyCutoff)
+		if err != nil {
+			return report, err
+		}
+		report.DeletedHourly = deleted
+	}
+
+	s.logger.Infof("Retention job finished, dropped %d partitions, deleted %d raw readings and %d hourly rollups.",
+		len(report.DroppedPartitions), report.DeletedRaw, report.DeletedHourly)
+
+	return report, ctx.Err()
+}
+
+// HistoryLimit returns the earliest time readings of the past can be written at. Raw readings of earlier hours
+// and hourly rollups of earlier days may be deleted already, so rolling up such buckets again would replace
+// their rollups with new readings only. Zero time means there is no limit.
+func (s *service) HistoryLimit(now time.Time) time.Time {
+	retentionCfg := s.cfg.RetentionConfig
+	if !retentionCfg.Enabled {
+		return time.Time{}
+	}
+
+	var limit time.Time
+	if retentionCfg.RawDays > 0 {
+		limit = ceil(now.AddDate(0, 0, -retentionCfg.RawDays), time.Hour)
+	}
+	if retentionCfg.HourlyDays > 0 {
+		if hourly := ceil(now.AddDate(0, 0, -retentionCfg.HourlyDays), day); hourly.After(limit) {
+			limit = hourly
+		}
+	}
+
+	return limit
+}
+
+// Reroll lowers watermarks to the hour and the day of from after readings of the past are written,
+// e.g. by import or backfill. The next run rolls their buckets up again together with readings
+// which are already there, till then aggregate queries read them raw.
+func (s *service) Reroll(ctx context.Context, from time.Time) error {
+	if err := s.retentionRepo.LowerWatermark(ctx, LevelHourly, from.Truncate(time.Hour)); err != nil {
+		return err
+	}
+	return s.retentionRepo.LowerWatermark(ctx, LevelDaily, from.Truncate(day))
+}
+
+// Premake makes sure that partitions for the interval of now and premake next intervals exist
+// and returns when the last of them ends. The generator calls it with the time of its clock,
+// which may run ahead of the real time of the retention job.
+func (s *service) Premake(ctx context.Context, now time.Time) (time.Time, []string, error) {
+	interval, err := NewPartitionIntervalFromString(s.cfg.PartitionConfig.Interval)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	till := now
+	for i := 0; i < s.cfg.PartitionConfig.Premake; i++ {
+		till = PartitionFor("", interval, till).Till
+	}
+
+	created, err := s.EnsurePartitions(ctx, now, till)
+	return PartitionFor("", interval, till).Till, created, err
+}
+
+// EnsurePartitions creates missing partitions of the configured interval for readings created in [from, till].
+// Interval which overlaps existing partition of other interval is skipped, unless it is fully covered.
+func (s *service) EnsurePartitions(ctx context.Context, from, till time.Time) ([]string, error) {
+	interval, err := NewPartitionIntervalFromString(s.cfg.PartitionConfig.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]string, 0)
+
+	for _, table := range PartitionedTables {
+		existing, err := s.retentionRepo.FindPartitions(ctx, table)
+		if err != nil {
+			return created, err
+		}
+
+		for t := from; !t.After(till); {
+			partition := PartitionFor(table, interval, t)
+			t = partition.Till
+
+			if isCovered, overlapped := coverage(existing, partition); isCovered {
+				continue
+			} else if overlapped != "" {
+				s.logger.Warnf("Partition %s overlaps %s, skip it.", partition.Name, overlapped)
+				continue
+			}
+
+			s.logger.Infof("Create partition %s.", partition.Name)
+			if err := s.retentionRepo.CreatePartition(ctx, partition); err != nil {
+				return created, err
+			}
+			existing = append(existing, partition)
+			created = append(created, partition.Name)
+		}
+	}
+
+	return created, nil
+}
+
+// dropPartitions drops partitions which contain only readings created before cutoff.
+func (s *service) dropPartitions(ctx context.Context, cutoff time.Time) ([]string, error) {
+	dropped := make([]string, 0)
+
+	for _, table := range PartitionedTables {
+		partitions, err := s.retentionRepo.FindPartitions(ctx, table)
+		if err != nil {
+			return dropped, err
+		}
+
+		for _, partition := range partitions {
+			if partition.Till.After(cutoff) {
+				continue
+			}
+
+			s.logger.Infof("Drop expired partition %s.", partition.Name)
+			if err := s.retentionRepo.DropPartition(ctx, partition); err != nil {
+				return dropped, err
+			}
+			dropped = append(dropped, partition.Name)
+		}
+	}
+
+	return dropped, nil
+}
+
+// coverage returns true if one of partitions covers p, otherwise name of overlapped partition if any.
+func coverage(partitions []Partition, p Partition) (bool, string) {
+	overlapped := ""
+	for _, partition := range partitions {
+		if partition.Covers(p) {
+			return true, ""
+		}
+		if partition.Overlaps(p) {
+			overlapped = partition.Name
+		}
+	}
+
+	return false, overlapped
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// ceil rounds t up to a multiple of d.
+func ceil(t time.Time, d time.Duration) time.Time {
+	if truncated := t.Truncate(d); truncated.Before(t) {
+		return truncated.Add(d)
+	}
+	return t
+}
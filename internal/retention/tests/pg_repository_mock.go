@@ -0,0 +1,58 @@
+package retention
+
+import (
+	"context"
+	"sensors-generator/internal/retention"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRetentionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRetentionRepository) FindWatermark(ctx context.Context, level retention.Level) (time.Time, error) {
+	args := m.Called(ctx, level)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockRetentionRepository) LowerWatermark(ctx context.Context, level retention.Level, till time.Time) error {
+	args := m.Called(ctx, level, till)
+	return args.Error(0)
+}
+
+func (m *MockRetentionRepository) RollupHourly(ctx context.Context, from, till time.Time) error {
+	args := m.Called(ctx, from, till)
+	return args.Error(0)
+}
+
+func (m *MockRetentionRepository) RollupDaily(ctx context.Context, from, till time.Time) error {
+	args := m.Called(ctx, from, till)
+	return args.Error(0)
+}
+
+func (m *MockRetentionRepository) DeleteRawBatch(ctx context.Context, before time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRetentionRepository) DeleteHourlyBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRetentionRepository) FindPartitions(ctx context.Context, table string) ([]retention.Partition, error) {
+	args := m.Called(ctx, table)
+	return args.Get(0).([]retention.Partition), args.Error(1)
+}
+
+func (m *MockRetentionRepository) CreatePartition(ctx context.Context, partition retention.Partition) error {
+	args := m.Called(ctx, partition)
+	return args.Error(0)
+}
+
+func (m *MockRetentionRepository) DropPartition(ctx context.Context, partition retention.Partition) error {
+	args := m.Called(ctx, partition)
+	return args.Error(0)
+}
@@ -0,0 +1,169 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"sensors-generator/internal/retention"
+	"sensors-generator/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RetentionRepository_RollupHourly(t *testing.T) {
+	logging.Init("trace", true)
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := retention.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	from := time.Date(2023, time.July, 1, 10, 0, 0, 0, time.UTC)
+	till := time.Date(2023, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO sensor_data_hourly(.+)FROM sensor_data(.+)ON CONFLICT`).
+		WithArgs(from, till).WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectExec(`INSERT INTO detected_spieces_hourly(.+)FROM detected_spieces ds(.+)ON CONFLICT`).
+		WithArgs(from, till).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(`INSERT INTO measurements_hourly(.+)FROM sensor_data sd, jsonb_each_text\(sd.measurements\) m(.+)ON CONFLICT`).
+		WithArgs(from, till).WillReturnResult(sqlmock.NewResult(0, 6))
+	mock.ExpectExec(`INSERT INTO rollup_state`).
+		WithArgs(retention.LevelHourly, till, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.RollupHourly(context.Background(), from, till))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_RetentionRepository_RollupDaily_Rollback(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := retention.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	from := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	till := time.Date(2023, time.July, 3, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO sensor_data_daily(.+)FROM sensor_data_hourly`).
+		WithArgs(from, till).WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectExec(`INSERT INTO detected_spieces_daily(.+)FROM detected_spieces_hourly`).
+		WithArgs(from, till).WillReturnError(errors.New("conn reset"))
+	mock.ExpectRollback()
+
+	assert.Error(t, repo.RollupDaily(context.Background(), from, till))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_RetentionRepository_FindWatermark_NotRolledUp(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := retention.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	mock.ExpectQuery(`SELECT rolled_up_till FROM rollup_state WHERE name=\$1`).
+		WithArgs(retention.LevelDaily).
+		WillReturnRows(sqlmock.NewRows([]string{"rolled_up_till"}))
+
+	watermark, err := repo.FindWatermark(context.Background(), retention.LevelDaily)
+
+	assert.NoError(t, err)
+	assert.True(t, watermark.IsZero())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_RetentionRepository_LowerWatermark(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := retention.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+	till := time.Date(2023, time.July, 2, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`UPDATE rollup_state SET rolled_up_till=\$2, updated_at=\$3 WHERE name=\$1 AND rolled_up_till > \$2`).
+		WithArgs(retention.LevelHourly, till, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.LowerWatermark(context.Background(), retention.LevelHourly, till))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_RetentionRepository_DeleteRawBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := retention.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	before := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`WITH batch AS \(\s*SELECT id, created_at FROM sensor_data WHERE created_at < \$1 ORDER BY created_at LIMIT \$2`+
+		`(.+)DELETE FROM detected_spieces(.+)DELETE FROM sensor_data WHERE created_at < \$1 AND \(id, created_at\) IN`).
+		WithArgs(before, 1000).
+		WillReturnResult(sqlmock.NewResult(0, 1000))
+
+	deleted, err := repo.DeleteRawBatch(context.Background(), before, 1000)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_RetentionRepository_CreatePartition(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := retention.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	partition := retention.PartitionFor("sensor_data", retention.IntervalDay, time.Date(2023, time.July, 1, 15, 0, 0, 0, time.UTC))
+
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS "sensor_data_p20230701" PARTITION OF "sensor_data" ` +
+		`FOR VALUES FROM \('2023-07-01T00:00:00Z'\) TO \('2023-07-02T00:00:00Z'\)`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, repo.CreatePartition(context.Background(), partition))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_RetentionRepository_FindPartitions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := retention.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	mock.ExpectQuery(`SELECT c\.relname FROM pg_inherits`).
+		WithArgs("sensor_data").
+		WillReturnRows(sqlmock.NewRows([]string{"relname"}).
+			AddRow("sensor_data_p202306").
+			AddRow("sensor_data_p20230701").
+			AddRow("sensor_data_archive"))
+
+	partitions, err := repo.FindPartitions(context.Background(), "sensor_data")
+
+	assert.NoError(t, err)
+	assert.Len(t, partitions, 2)
+	assert.Equal(t, time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC), partitions[0].Till)
+	assert.Equal(t, time.Date(2023, time.July, 2, 0, 0, 0, 0, time.UTC), partitions[1].Till)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
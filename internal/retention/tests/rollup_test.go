@@ -0,0 +1,59 @@
+package retention
+
+import (
+	"sensors-generator/internal/retention"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PlanFor(t *testing.T) {
+	midnight := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, retention.PlanDaily, retention.PlanFor(time.Time{}, time.Time{}))
+	assert.Equal(t, retention.PlanDaily, retention.PlanFor(midnight, midnight.AddDate(0, 0, 2)))
+	assert.Equal(t, retention.PlanHourly, retention.PlanFor(midnight.Add(3*time.Hour), time.Time{}))
+	assert.Equal(t, retention.PlanRaw, retention.PlanFor(midnight, midnight.Add(90*time.Minute)))
+}
+
+func Test_SensorDataSource_Raw(t *testing.T) {
+	from := time.Date(2023, time.July, 1, 0, 30, 0, 0, time.UTC)
+
+	q, args := retention.SensorDataSource(retention.PlanRaw, from, time.Time{}, 3)
+
+	assert.Equal(t, []interface{}{from}, args)
+	assert.Contains(t, q, "WHERE created_at >= $3")
+	assert.NotContains(t, q, "rollup_state")
+	assert.False(t, strings.Contains(q, "UNION ALL"))
+}
+
+func Test_MetricSource(t *testing.T) {
+	q, args := retention.MetricSource("temperature", retention.PlanDaily, time.Time{}, time.Time{}, 2)
+
+	assert.Empty(t, args)
+	assert.Contains(t, q, "temperature_avg * readings_count AS value_sum")
+	assert.Contains(t, q, "FROM sensor_data_daily")
+
+	q, _ = retention.MetricSource("salinity", retention.PlanDaily, time.Time{}, time.Time{}, 2)
+
+	assert.Contains(t, q, "FROM (SELECT * FROM measurements_daily WHERE metric='salinity') AS m")
+	assert.Contains(t, q, "FROM (SELECT * FROM measurements_hourly WHERE metric='salinity') AS m")
+	assert.Contains(t, q, "((measurements->>'salinity')::FLOAT IS NOT NULL)::INT AS readings_count FROM sensor_data")
+	assert.Equal(t, 2, strings.Count(q, "UNION ALL"))
+}
+
+func Test_ParsePartition(t *testing.T) {
+	partition, ok := retention.ParsePartition("sensor_data", "sensor_data_p202312")
+
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC), partition.From)
+	assert.Equal(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), partition.Till)
+
+	_, ok = retention.ParsePartition("sensor_data", "sensor_data_hourly")
+	assert.False(t, ok)
+
+	_, ok = retention.ParsePartition("sensor_data", "sensor_data_p2023121")
+	assert.False(t, ok)
+}
@@ -0,0 +1,233 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"sensors-generator/config"
+	"sensors-generator/internal/retention"
+	"sensors-generator/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.RetentionConfig.Enabled = true
+	cfg.RetentionConfig.RollupDelay = 5 * time.Minute
+	cfg.RetentionConfig.RawDays = 30
+	cfg.RetentionConfig.HourlyDays = 365
+	cfg.RetentionConfig.BatchSize = 2
+	return cfg
+}
+
+func Test_RetentionService_RunOnce(t *testing.T) {
+	logging.Init("trace", true)
+	repo := &MockRetentionRepository{}
+	service := retention.NewService(repo, logging.GetLogger(), newConfig())
+
+	ctx := context.Background()
+	now := time.Date(2023, time.July, 10, 14, 3, 0, 0, time.UTC)
+	hourlyFrom := time.Date(2023, time.July, 10, 11, 0, 0, 0, time.UTC)
+	hourlyTill := time.Date(2023, time.July, 10, 13, 0, 0, 0, time.UTC)
+	dailyFrom := time.Date(2023, time.July, 9, 0, 0, 0, 0, time.UTC)
+	dailyTill := time.Date(2023, time.July, 10, 0, 0, 0, 0, time.UTC)
+	rawCutoff := now.AddDate(0, 0, -30)
+	hourlyCutoff := now.AddDate(0, 0, -365)
+
+	repo.On("FindWatermark", ctx, retention.LevelHourly).Return(hourlyFrom, nil)
+	repo.On("RollupHourly", ctx, hourlyFrom, hourlyTill).Return(nil)
+	repo.On("FindWatermark", ctx, retention.LevelDaily).Return(dailyFrom, nil)
+	repo.On("RollupDaily", ctx, dailyFrom, dailyTill).Return(nil)
+	repo.On("DeleteRawBatch", ctx, rawCutoff, 2).Return(int64(2), nil).Twice()
+	repo.On("DeleteRawBatch", ctx, rawCutoff, 2).Return(int64(1), nil).Once()
+	repo.On("DeleteHourlyBefore", ctx, hourlyCutoff).Return(int64(7), nil)
+
+	report, err := service.RunOnce(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, hourlyTill, report.HourlyTill)
+	assert.Equal(t, dailyTill, report.DailyTill)
+	assert.Equal(t, int64(5), report.DeletedRaw)
+	assert.Equal(t, int64(7), report.DeletedHourly)
+	repo.AssertExpectations(t)
+}
+
+func Test_RetentionService_RunOnce_NeverDeletesNotRolledUpData(t *testing.T) {
+	repo := &MockRetentionRepository{}
+	service := retention.NewService(repo, logging.GetLogger(), newConfig())
+
+	ctx := context.Background()
+	now := time.Date(2023, time.July, 10, 14, 3, 0, 0, time.UTC)
+	hourlyWatermark := time.Date(2023, time.May, 1, 5, 0, 0, 0, time.UTC)
+	dailyWatermark := time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	// Hourly rollup fails, so nothing is moved and deletion stops at the old watermark.
+	repo.On("FindWatermark", ctx, retention.LevelHourly).Return(hourlyWatermark, nil)
+	repo.On("RollupHourly", ctx, hourlyWatermark, mock.Anything).Return(errors.New("conn reset"))
+
+	_, err := service.RunOnce(ctx, now)
+
+	assert.Error(t, err)
+	repo.AssertNotCalled(t, "DeleteRawBatch", mock.Anything, mock.Anything, mock.Anything)
+
+	repo = &MockRetentionRepository{}
+	cfg := newConfig()
+	cfg.RetentionConfig.RollupDelay = 60 * 24 * time.Hour
+	cfg.RetentionConfig.HourlyDays = 0
+	service = retention.NewService(repo, logging.GetLogger(), cfg)
+
+	// Raw retention is 30 days, but only 60 days old data is rolled up, so deletion stops at the watermark.
+	hourlyTill := time.Date(2023, time.May, 11, 14, 0, 0, 0, time.UTC)
+	repo.On("FindWatermark", ctx, retention.LevelHourly).Return(hourlyWatermark, nil)
+	repo.On("RollupHourly", ctx, hourlyWatermark, hourlyTill).Return(nil)
+	repo.On("FindWatermark", ctx, retention.LevelDaily).Return(dailyWatermark, nil)
+	repo.On("RollupDaily", ctx, dailyWatermark, hourlyTill.Truncate(24*time.Hour)).Return(nil)
+	repo.On("DeleteRawBatch", ctx, hourlyTill, 2).Return(int64(0), nil)
+
+	report, err := service.RunOnce(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, hourlyTill, report.RawCutoff)
+	repo.AssertNotCalled(t, "DeleteHourlyBefore", mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+}
+
+func Test_RetentionService_RunOnce_Partitions(t *testing.T) {
+	repo := &MockRetentionRepository{}
+	cfg := newConfig()
+	cfg.RetentionConfig.HourlyDays = 0
+	cfg.PartitionConfig.Enabled = true
+	cfg.PartitionConfig.Interval = "month"
+	cfg.PartitionConfig.Premake = 2
+	service := retention.NewService(repo, logging.GetLogger(), cfg)
+
+	ctx := context.Background()
+	now := time.Date(2023, time.July, 10, 14, 3, 0, 0, time.UTC)
+	hourlyTill := time.Date(2023, time.July, 10, 14, 0, 0, 0, time.UTC).Add(-time.Hour)
+	rawCutoff := now.AddDate(0, 0, -30)
+
+	partition := func(table string, month time.Month) retention.Partition {
+		return retention.PartitionFor(table, retention.IntervalMonth, time.Date(2023, month, 1, 0, 0, 0, 0, time.UTC))
+	}
+
+	for _, table := range retention.PartitionedTables {
+		repo.On("FindPartitions", ctx, table).Return([]retention.Partition{
+			partition(table, time.May), partition(table, time.June), partition(table, time.July),
+		}, nil)
+		repo.On("CreatePartition", ctx, partition(table, time.August)).Return(nil)
+		repo.On("CreatePartition", ctx, partition(table, time.September)).Return(nil)
+		repo.On("DropPartition", ctx, partition(table, time.May)).Return(nil)
+	}
+
+	repo.On("FindWatermark", ctx, retention.LevelHourly).Return(hourlyTill, nil)
+	repo.On("FindWatermark", ctx, retention.LevelDaily).Return(hourlyTill.Truncate(24*time.Hour), nil)
+	repo.On("DeleteRawBatch", ctx, rawCutoff, 2).Return(int64(1), nil)
+
+	report, err := service.RunOnce(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sensor_data_p202308", "sensor_data_p202309",
+		"detected_spieces_p202308", "detected_spieces_p202309"}, report.CreatedPartitions)
+	assert.Equal(t, []string{"sensor_data_p202305", "detected_spieces_p202305"}, report.DroppedPartitions)
+	repo.AssertNotCalled(t, "DropPartition", ctx, partition("sensor_data", time.June))
+	repo.AssertExpectations(t)
+}
+
+func Test_RetentionService_RunOnce_PartitionsOnly(t *testing.T) {
+	repo := &MockRetentionRepository{}
+	cfg := &config.Config{}
+	cfg.PartitionConfig.Enabled = true
+	cfg.PartitionConfig.Interval = "day"
+	service := retention.NewService(repo, logging.GetLogger(), cfg)
+
+	ctx := context.Background()
+	now := time.Date(2023, time.July, 10, 14, 3, 0, 0, time.UTC)
+
+	// Daily partition inside existing monthly one is not needed.
+	repo.On("FindPartitions", ctx, mock.Anything).Return([]retention.Partition{
+		retention.PartitionFor("sensor_data", retention.IntervalMonth, now),
+		retention.PartitionFor("detected_spieces", retention.IntervalMonth, now),
+	}, nil)
+
+	report, err := service.RunOnce(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Empty(t, report.CreatedPartitions)
+	repo.AssertNotCalled(t, "FindWatermark", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "CreatePartition", mock.Anything, mock.Anything)
+}
+
+func Test_RetentionService_Premake(t *testing.T) {
+	repo := &MockRetentionRepository{}
+	cfg := &config.Config{}
+	cfg.PartitionConfig.Enabled = true
+	cfg.PartitionConfig.Interval = "month"
+	cfg.PartitionConfig.Premake = 1
+	service := retention.NewService(repo, logging.GetLogger(), cfg)
+
+	ctx := context.Background()
+	// Time of an accelerated clock, far ahead of the real time.
+	now := time.Date(2031, time.December, 31, 23, 0, 0, 0, time.UTC)
+
+	repo.On("FindPartitions", ctx, mock.Anything).Return([]retention.Partition{}, nil)
+	repo.On("CreatePartition", ctx, mock.Anything).Return(nil)
+
+	till, created, err := service.Premake(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2032, time.February, 1, 0, 0, 0, 0, time.UTC), till)
+	assert.Equal(t, []string{"sensor_data_p203112", "sensor_data_p203201",
+		"detected_spieces_p203112", "detected_spieces_p203201"}, created)
+}
+
+func Test_RetentionService_EnsurePartitions(t *testing.T) {
+	repo := &MockRetentionRepository{}
+	cfg := &config.Config{}
+	cfg.PartitionConfig.Interval = "month"
+	service := retention.NewService(repo, logging.GetLogger(), cfg)
+
+	ctx := context.Background()
+	from := time.Date(2021, time.January, 20, 0, 0, 0, 0, time.UTC)
+	till := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	repo.On("FindPartitions", ctx, mock.Anything).Return([]retention.Partition{}, nil)
+	repo.On("CreatePartition", ctx, mock.Anything).Return(nil)
+
+	created, err := service.EnsurePartitions(ctx, from, till)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sensor_data_p202101", "sensor_data_p202102", "sensor_data_p202103",
+		"detected_spieces_p202101", "detected_spieces_p202102", "detected_spieces_p202103"}, created)
+}
+
+func Test_RetentionService_HistoryLimit(t *testing.T) {
+	cfg := newConfig()
+	service := retention.NewService(&MockRetentionRepository{}, logging.GetLogger(), cfg)
+	now := time.Date(2023, time.July, 10, 14, 3, 0, 0, time.UTC)
+
+	// Raw readings of hours before the 30 days may be deleted already.
+	assert.Equal(t, time.Date(2023, time.June, 10, 15, 0, 0, 0, time.UTC), service.HistoryLimit(now))
+
+	cfg.RetentionConfig.RawDays = 0
+	assert.Equal(t, time.Date(2022, time.July, 11, 0, 0, 0, 0, time.UTC), service.HistoryLimit(now))
+
+	cfg.RetentionConfig.Enabled = false
+	assert.True(t, service.HistoryLimit(now).IsZero())
+}
+
+func Test_RetentionService_Reroll(t *testing.T) {
+	repo := &MockRetentionRepository{}
+	service := retention.NewService(repo, logging.GetLogger(), newConfig())
+
+	ctx := context.Background()
+	from := time.Date(2023, time.July, 2, 10, 30, 0, 0, time.UTC)
+
+	repo.On("LowerWatermark", ctx, retention.LevelHourly, time.Date(2023, time.July, 2, 10, 0, 0, 0, time.UTC)).Return(nil)
+	repo.On("LowerWatermark", ctx, retention.LevelDaily, time.Date(2023, time.July, 2, 0, 0, 0, 0, time.UTC)).Return(nil)
+
+	assert.NoError(t, service.Reroll(ctx, from))
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,177 @@
+package sensor
+
+import (
+	"context"
+	"sensors-generator/internal/apperror"
+	sensorgenv1 "sensors-generator/pkg/api/sensorgen/v1"
+	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/measurement"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type grpcServer struct {
+	sensorgenv1.UnimplementedSensorServiceServer
+	sensorService ISensorService
+	logger        *logging.Logger
+}
+
+// NewGRPCServer serves the same calls as the REST handler.
+func NewGRPCServer(sensorService ISensorService, logger *logging.Logger) *grpcServer {
+	return &grpcServer{
+		sensorService: sensorService,
+		logger:        logger,
+	}
+}
+
+func (s *grpcServer) Register(server grpc.ServiceRegistrar) {
+	sensorgenv1.RegisterSensorServiceServer(server, s)
+}
+
+func (s *grpcServer) ListSensors(ctx context.Context, req *sensorgenv1.ListSensorsRequest) (*sensorgenv1.ListSensorsResponse, error) {
+	sensors, err := s.sensorService.GetAll(ctx, SensorFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &sensorgenv1.ListSensorsResponse{Sensors: make([]*sensorgenv1.Sensor, 0, len(sensors))}
+	for _, sensor := range sensors {
+		resp.Sensors = append(resp.Sensors, &sensorgenv1.Sensor{
+			Codename:       CodenameToProto(sensor.CodeName),
+			Coordinates:    CoordsToProto(sensor.Coords),
+			DataOutputRate: int64(sensor.DataOutputRate),
+			CreatedAt:      timestamppb.New(sensor.CreatedAt),
+			UpdatedAt:      timestamppb.New(sensor.UpdatedAt),
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *grpcServer) GetMinInRegion(ctx context.Context, req *sensorgenv1.RegionMetricRequest) (*sensorgenv1.MetricResponse, error) {
+	return s.regionExtremum(ctx, req.GetMetric(), req.GetMin(), req.GetMax(), true)
+}
+
+func (s *grpcServer) GetMaxInRegion(ctx context.Context, req *sensorgenv1.RegionMetricRequest) (*sensorgenv1.MetricResponse, error) {
+	return s.regionExtremum(ctx, req.GetMetric(), req.GetMin(), req.GetMax(), false)
+}
+
+func (s *grpcServer) regionExtremum(ctx context.Context, metric string, minCoords, maxCoords *sensorgenv1.Coordinates,
+	min bool) (*sensorgenv1.MetricResponse, error) {
+	if minCoords == nil || maxCoords == nil {
+		return nil, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Min and max coordinates are required.")
+	}
+
+	value, err := s.sensorService.GetExtremumForRegion(ctx, metric, CoordsFromProto(minCoords), CoordsFromProto(maxCoords), min)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sensorgenv1.MetricResponse{Metric: metric, Value: value}, nil
+}
+
+func (s *grpcServer) GetAvgForSensor(ctx context.Context, req *sensorgenv1.SensorMetricRequest) (*sensorgenv1.MetricResponse, error) {
+	value, err := s.avgForSensor(ctx, req.GetMetric(), req.GetCodename(), req.GetRange())
+	if err != nil {
+		return nil, err
+	}
+
+	return &sensorgenv1.MetricResponse{Metric: req.GetMetric(), Value: value}, nil
+}
+
+func (s *grpcServer) GetMinRegionTemperature(ctx context.Context, req *sensorgenv1.RegionRequest) (*sensorgenv1.TemperatureResponse, error) {
+	return s.regionTemperature(ctx, req, true)
+}
+
+func (s *grpcServer) GetMaxRegionTemperature(ctx context.Context, req *sensorgenv1.RegionRequest) (*sensorgenv1.TemperatureResponse, error) {
+	return s.regionTemperature(ctx, req, false)
+}
+
+func (s *grpcServer) regionTemperature(ctx context.Context, req *sensorgenv1.RegionRequest, min bool) (*sensorgenv1.TemperatureResponse, error) {
+	resp, err := s.regionExtremum(ctx, measurement.Temperature, req.GetMin(), req.GetMax(), min)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sensorgenv1.TemperatureResponse{Temperature: float32(resp.GetValue())}, nil
+}
+
+func (s *grpcServer) GetAvgSensorTemperature(ctx context.Context, req *sensorgenv1.SensorTemperatureRequest) (*sensorgenv1.TemperatureResponse, error) {
+	temperature, err := s.avgForSensor(ctx, measurement.Temperature, req.GetCodename(), req.GetRange())
+	if err != nil {
+		return nil, err
+	}
+
+	return &sensorgenv1.TemperatureResponse{Temperature: float32(temperature)}, nil
+}
+
+func (s *grpcServer) avgForSensor(ctx context.Context, metric string, codename *sensorgenv1.Codename,
+	timeRange *sensorgenv1.TimeRange) (float64, error) {
+	codeName, err := CodenameFromProto(codename)
+	if err != nil {
+		return 0, err
+	}
+
+	filters := SensorFilters{CodeName: codeName}
+	filters.FromDate, filters.TillDate = TimeRangeFromProto(timeRange)
+
+	return s.sensorService.GetAvgForSensor(ctx, metric, filters)
+}
+
+func (s *grpcServer) UpdateSensor(ctx context.Context, req *sensorgenv1.UpdateSensorRequest) (*emptypb.Empty, error) {
+	codeName, err := CodenameFromProto(req.GetCodename())
+	if err != nil {
+		return nil, err
+	}
+
+	var dto UpdateSensorDTO
+	if req.Coordinates != nil {
+		coords := CoordsFromProto(req.Coordinates)
+		dto.Coords = &coords
+	}
+	if req.DataOutputRate != nil {
+		rate := time.Duration(req.GetDataOutputRate())
+		dto.DataOutputRate = &rate
+	}
+
+	if err := s.sensorService.Update(ctx, codeName, dto); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// CodenameFromProto checks codename the same way as NewCodenameFromString.
+func CodenameFromProto(codename *sensorgenv1.Codename) (Codename, error) {
+	if codename.GetGroupName() == "" || codename.GetIndex() < 0 {
+		return Codename{}, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Wrong codename.")
+	}
+
+	return Codename{GroupName: codename.GetGroupName(), Index: int(codename.GetIndex())}, nil
+}
+
+func CodenameToProto(codename Codename) *sensorgenv1.Codename {
+	return &sensorgenv1.Codename{GroupName: codename.GroupName, Index: int32(codename.Index)}
+}
+
+func CoordsFromProto(coords *sensorgenv1.Coordinates) Coordinates {
+	return Coordinates{X: coords.GetX(), Y: coords.GetY(), Z: coords.GetZ()}
+}
+
+func CoordsToProto(coords Coordinates) *sensorgenv1.Coordinates {
+	return &sensorgenv1.Coordinates{X: coords.X, Y: coords.Y, Z: coords.Z}
+}
+
+// TimeRangeFromProto returns zero times for unset bounds, so they do not filter.
+func TimeRangeFromProto(r *sensorgenv1.TimeRange) (from, till time.Time) {
+	if r.GetFrom() != nil {
+		from = r.GetFrom().AsTime()
+	}
+	if r.GetTill() != nil {
+		till = r.GetTill().AsTime()
+	}
+	return from, till
+}
@@ -1,10 +1,10 @@
 package sensor
 
 import (
-	"context"
 	"net/http"
 	"sensors-generator/internal/apperror"
 	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/measurement"
 	"strconv"
 	"time"
 
@@ -12,11 +12,14 @@ import (
 )
 
 const (
-	sensorPath         = "api/v1/sensor/:codeName"
-	regionPath         = "api/v1/region"
-	temperatureMinPath = "/temperature/min"
-	temperatureMaxPath = "/temperature/max"
-	temperatureAvgPath = "/temperature/average"
+	sensorPath      = "api/v1/sensor/:codeName"
+	regionPath      = "api/v1/region"
+	measurementPath = "api/v1/measurements"
+	metricMinPath   = "/:metric/min"
+	metricMaxPath   = "/:metric/max"
+	metricAvgPath   = "/:metric/average"
+	calibrationPath = "/calibrations"
+	maintenancePath = "/maintenance"
 )
 
 type handler struct {
@@ -31,22 +34,35 @@ func NewHandler(sensorService ISensorService, logger *logging.Logger) *handler {
 	}
 }
 
-func (h *handler) Register(router *gin.Engine) {
+func (h *handler) Register(router gin.IRouter) {
 	region := router.Group(regionPath)
 	{
-		region.GET(temperatureMaxPath, h.MaxTemperature)
-		region.GET(temperatureMinPath, h.MinTemperature)
+		region.GET(metricMaxPath, h.Max)
+		region.GET(metricMinPath, h.Min)
 	}
 
 	sensor := router.Group(sensorPath)
 	{
-		sensor.GET(temperatureAvgPath, h.AvgTemperature)
+		sensor.GET(metricAvgPath, h.Avg)
+		sensor.GET(calibrationPath, h.GetCalibrations)
+		sensor.GET(maintenancePath, h.GetMaintenance)
 	}
+
+	router.GET(measurementPath, h.Measurements)
+}
+
+// RegisterManagement registers routes which change sensors.
+func (h *handler) RegisterManagement(router gin.IRouter) {
+	router.PATCH(sensorPath, h.UpdateSensor)
+	router.POST(sensorPath+calibrationPath, h.AddCalibration)
+	router.POST(sensorPath+maintenancePath, h.AddMaintenance)
 }
 
-// MinTemperature
-// @Summary Min Temperature
+// Min
+// @Summary Min of a metric in region
 // @Tags Sensors
+// @Security ApiKeyAuth
+// @Param metric path string true "Measurement type, e.g. temperature or salinity"
 // @Param xMin query string true "Minimum value for x coordinate"
 // @Param yMin query string true "Minimum value for y coordinate"
 // @Param zMin query string true "Minimum value for z coordinate"
@@ -55,10 +71,12 @@ func (h *handler) Register(router *gin.Engine) {
 // @Param zMax query string true "Maximum value for z coordinate"
 // @Success 200
 // @Failure 400
+// @Failure 401
+// @Failure 403
 // @Failure 500
-// @Router /api/v1/region/temperature/min [get]
-func (h *handler) MinTemperature(c *gin.Context) {
-	h.logger.Info("MIN TEMPERATURE.")
+// @Router /api/v1/region/{metric}/min [get]
+func (h *handler) Min(c *gin.Context) {
+	metric := c.Param("metric")
 
 	xMin := c.Query("xMin")
 	yMin := c.Query("yMin")
@@ -82,19 +100,21 @@ func (h *handler) MinTemperature(c *gin.Context) {
 		return
 	}
 
-	minTemperature, err := h.sensorService.GetExtremumTemperatureForRegion(context.Background(),
+	minValue, err := h.sensorService.GetExtremumForRegion(c.Request.Context(), metric,
 		minCoords, maxCoords, true)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"min_temperature": minTemperature})
+	c.JSON(http.StatusOK, gin.H{"min_" + metric: minValue})
 }
 
-// MaxTemperature
-// @Summary Max Temperature
+// Max
+// @Summary Max of a metric in region
 // @Tags Sensors
+// @Security ApiKeyAuth
+// @Param metric path string true "Measurement type, e.g. temperature or salinity"
 // @Param xMin query string true "Minimum value for x coordinate"
 // @Param yMin query string true "Minimum value for y coordinate"
 // @Param zMin query string true "Minimum value for z coordinate"
@@ -103,10 +123,12 @@ func (h *handler) MinTemperature(c *gin.Context) {
 // @Param zMax query string true "Maximum value for z coordinate"
 // @Success 200
 // @Failure 400
+// @Failure 401
+// @Failure 403
 // @Failure 500
-// @Router /api/v1/region/temperature/max [get]
-func (h *handler) MaxTemperature(c *gin.Context) {
-	h.logger.Info("MAX TEMPERATURE.")
+// @Router /api/v1/region/{metric}/max [get]
+func (h *handler) Max(c *gin.Context) {
+	metric := c.Param("metric")
 
 	xMin := c.Query("xMin")
 	yMin := c.Query("yMin")
@@ -130,28 +152,32 @@ func (h *handler) MaxTemperature(c *gin.Context) {
 		return
 	}
 
-	maxTemperature, err := h.sensorService.GetExtremumTemperatureForRegion(context.Background(),
+	maxValue, err := h.sensorService.GetExtremumForRegion(c.Request.Context(), metric,
 		minCoords, maxCoords, false)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"max_temperature": maxTemperature})
+	c.JSON(http.StatusOK, gin.H{"max_" + metric: maxValue})
 }
 
-// AvgTemperature
-// @Summary Average Temperature
+// Avg
+// @Summary Average of a metric for sensor
 // @Tags Sensors
+// @Security ApiKeyAuth
 // @Param codeName path string true "Name of the group"
+// @Param metric path string true "Measurement type, e.g. temperature or salinity"
 // @Param from query int false "from"
 // @Param till query int false "till"
 // @Success 200
 // @Failure 400
+// @Failure 401
+// @Failure 403
 // @Failure 500
-// @Router /api/v1/sensor/{codeName}/temperature/average [get]
-func (h *handler) AvgTemperature(c *gin.Context) {
-	h.logger.Info("AVERAGE TEMPERATURE.")
+// @Router /api/v1/sensor/{codeName}/{metric}/average [get]
+func (h *handler) Avg(c *gin.Context) {
+	metric := c.Param("metric")
 
 	codeNameQ := c.Param("codeName")
 	codeName, err := NewCodenameFromString(codeNameQ)
@@ -184,11 +210,217 @@ func (h *handler) AvgTemperature(c *gin.Context) {
 		filters.TillDate = time.Unix(int64(tillTS), 0)
 	}
 
-	avgTemperature, err := h.sensorService.GetAvgTemperatureForSensor(context.Background(), filters)
+	avgValue, err := h.sensorService.GetAvgForSensor(c.Request.Context(), metric, filters)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"avg_" + metric: avgValue})
+}
+
+// Measurements
+// @Summary Measurement types
+// @Description Types which sensors can measure, with units and valid ranges. Names are metrics of aggregations.
+// @Tags Sensors
+// @Security ApiKeyAuth
+// @Success 200
+// @Failure 401
+// @Failure 403
+// @Router /api/v1/measurements [get]
+func (h *handler) Measurements(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"measurements": measurement.Types()})
+}
+
+// UpdateSensor
+// @Summary Update sensor
+// @Tags Sensors
+// @Security ApiKeyAuth
+// @Param codeName path string true "Codename of the sensor"
+// @Param sensor body UpdateSensorDTO true "Fields to update"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/sensor/{codeName} [patch]
+func (h *handler) UpdateSensor(c *gin.Context) {
+	codeName, err := NewCodenameFromString(c.Param("codeName"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto UpdateSensorDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.LWithContext(c.Request.Context()).Errorf("Cannot parse body, due to error: %v", err)
+		c.Error(apperror.ErrBadRequest)
+		return
+	}
+
+	if err := h.sensorService.Update(c.Request.Context(), codeName, dto); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetCalibrations
+// @Summary Calibrations of sensor
+// @Description Calibrations ordered by calibrated_at, a calibration applies till the next one of the same metric.
+// @Tags Sensors
+// @Security ApiKeyAuth
+// @Produce json
+// @Param codeName path string true "Codename of the sensor"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /api/v1/sensor/{codeName}/calibrations [get]
+func (h *handler) GetCalibrations(c *gin.Context) {
+	codeName, err := NewCodenameFromString(c.Param("codeName"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	calibrations, err := h.sensorService.GetCalibrations(c.Request.Context(), codeName)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"calibrations": calibrations})
+}
+
+// AddCalibration
+// @Summary Add calibration of sensor
+// @Description Readings from calibrated_at are written as raw * gain + offset, raw values are kept.
+// @Description The generator applies the calibration after the next refresh.
+// @Tags Sensors
+// @Security ApiKeyAuth
+// @Param codeName path string true "Codename of the sensor"
+// @Param calibration body CreateCalibrationDTO true "Calibration"
+// @Success 201
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/sensor/{codeName}/calibrations [post]
+func (h *handler) AddCalibration(c *gin.Context) {
+	codeName, err := NewCodenameFromString(c.Param("codeName"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto CreateCalibrationDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.LWithContext(c.Request.Context()).Errorf("Cannot parse body, due to error: %v", err)
+		c.Error(apperror.ErrBadRequest)
+		return
+	}
+
+	calibration, err := h.sensorService.AddCalibration(c.Request.Context(), codeName, dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, calibration)
+}
+
+// GetMaintenance
+// @Summary Maintenance of sensor
+// @Description Maintenance windows ordered by start.
+// @Tags Sensors
+// @Security ApiKeyAuth
+// @Produce json
+// @Param codeName path string true "Codename of the sensor"
+// @Param from query int false "Keep maintenance which ends after the timestamp"
+// @Param till query int false "Keep maintenance which starts before the timestamp"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /api/v1/sensor/{codeName}/maintenance [get]
+func (h *handler) GetMaintenance(c *gin.Context) {
+	codeName, err := NewCodenameFromString(c.Param("codeName"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	filters := SensorFilters{CodeName: codeName}
+
+	if from, ok := c.GetQuery("from"); ok {
+		fromTS, err := strconv.Atoi(from)
+		if err != nil {
+			h.logger.Errorf("Cannot parse string, due to error: %v", err)
+			c.Error(apperror.ErrBadRequest)
+			return
+		}
+		filters.FromDate = time.Unix(int64(fromTS), 0)
+	}
+
+	if till, ok := c.GetQuery("till"); ok {
+		tillTS, err := strconv.Atoi(till)
+		if err != nil {
+			h.logger.Errorf("Cannot parse string, due to error: %v", err)
+			c.Error(apperror.ErrBadRequest)
+			return
+		}
+		filters.TillDate = time.Unix(int64(tillTS), 0)
+	}
+
+	maintenance, err := h.sensorService.GetMaintenance(c.Request.Context(), filters)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"maintenance": maintenance})
+}
+
+// AddMaintenance
+// @Summary Add maintenance of sensor
+// @Description Readings taken from starts_at till ends_at are flagged with maintenance, including written ones.
+// @Description Kind is inspection, cleaning, repair, replacement, firmware_update or calibration.
+// @Tags Sensors
+// @Security ApiKeyAuth
+// @Param codeName path string true "Codename of the sensor"
+// @Param maintenance body CreateMaintenanceDTO true "Maintenance"
+// @Success 201
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/sensor/{codeName}/maintenance [post]
+func (h *handler) AddMaintenance(c *gin.Context) {
+	codeName, err := NewCodenameFromString(c.Param("codeName"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto CreateMaintenanceDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logger.LWithContext(c.Request.Context()).Errorf("Cannot parse body, due to error: %v", err)
+		c.Error(apperror.ErrBadRequest)
+		return
+	}
+
+	maintenance, err := h.sensorService.AddMaintenance(c.Request.Context(), codeName, dto)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"avg_temperature": avgTemperature})
+	c.JSON(http.StatusCreated, maintenance)
 }
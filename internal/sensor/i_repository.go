@@ -6,8 +6,13 @@ type ISensorRepository interface {
 	FindAll(ctx context.Context, filters SensorFilters) ([]Sensor, error)
 	FindOneByID(ctx context.Context, id int, filters SensorFilters) (*Sensor, error)
 	Create(ctx context.Context, sensor CreateSensorDTO) error
+	Update(ctx context.Context, codeName Codename, sensor UpdateSensorDTO) error
 	AddSensorToGroup(ctx context.Context, sensorID int, groupID int) error
-	FindMaxTemperatureForRegion(ctx context.Context, minCoords, maxCoords Coordinates) (float32, error)
-	FindMinTemperatureForRegion(ctx context.Context, minCoords, maxCoords Coordinates) (float32, error)
-	FindAvgTemperatureForSensor(ctx context.Context, filters SensorFilters) (float32, error)
+	FindExtremumForRegion(ctx context.Context, metric string, minCoords, maxCoords Coordinates, min bool) (float64, error)
+	FindAvgForSensor(ctx context.Context, metric string, filters SensorFilters) (float64, error)
+	FindAvgForSensors(ctx context.Context, metric string, sensorIDs []int, filters SensorFilters) (map[int]float64, error)
+	FindCalibrations(ctx context.Context, codeName Codename) ([]Calibration, error)
+	CreateCalibration(ctx context.Context, codeName Codename, calibration CreateCalibrationDTO) (*Calibration, error)
+	FindMaintenance(ctx context.Context, filters SensorFilters) ([]Maintenance, error)
+	CreateMaintenance(ctx context.Context, codeName Codename, maintenance CreateMaintenanceDTO) (*Maintenance, error)
 }
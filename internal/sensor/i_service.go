@@ -5,7 +5,13 @@ import "context"
 type ISensorService interface {
 	GetAll(ctx context.Context, filters SensorFilters) ([]Sensor, error)
 	Create(ctx context.Context, sensors ...CreateSensorDTO) error
+	Update(ctx context.Context, codeName Codename, sensor UpdateSensorDTO) error
 	AddSensorToGroup(ctx context.Context, sensorID int, groupID int) error
-	GetExtremumTemperatureForRegion(ctx context.Context, minCoords, maxCoords Coordinates, min bool) (float32, error)
-	GetAvgTemperatureForSensor(ctx context.Context, filters SensorFilters) (float32, error)
+	GetExtremumForRegion(ctx context.Context, metric string, minCoords, maxCoords Coordinates, min bool) (float64, error)
+	GetAvgForSensor(ctx context.Context, metric string, filters SensorFilters) (float64, error)
+	GetAvgForSensors(ctx context.Context, metric string, sensorIDs []int, filters SensorFilters) (map[int]float64, error)
+	GetCalibrations(ctx context.Context, codeName Codename) ([]Calibration, error)
+	AddCalibration(ctx context.Context, codeName Codename, calibration CreateCalibrationDTO) (*Calibration, error)
+	GetMaintenance(ctx context.Context, filters SensorFilters) ([]Maintenance, error)
+	AddMaintenance(ctx context.Context, codeName Codename, maintenance CreateMaintenanceDTO) (*Maintenance, error)
 }
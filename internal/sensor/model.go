@@ -1,10 +1,13 @@
 package sensor
 
 import (
+	"math"
 	"regexp"
 	"sensors-generator/internal/apperror"
 	"sensors-generator/internal/spiece"
 	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/measurement"
+	"sensors-generator/pkg/trajectory"
 	"strconv"
 	"strings"
 	"time"
@@ -27,14 +30,92 @@ type Sensor struct {
 	Coords         Coordinates     `json:"coordinates"`
 	DataOutputRate time.Duration   `json:"data_output_rate"`
 	Spieces        []spiece.Spiece `json:"spieces"`
-	CreatedAt      time.Time       `json:"created_at"`
-	UpdatedAt      time.Time       `json:"updated_at"`
+	Measurements   []string        `json:"measurements"`
+	// Trajectory of a mobile sensor, static sensors have none and stay at Coords.
+	Trajectory  *trajectory.Trajectory `json:"trajectory,omitempty"`
+	Model       string                 `json:"model,omitempty"`
+	Firmware    string                 `json:"firmware,omitempty"`
+	InstalledAt *time.Time             `json:"installed_at,omitempty"`
+	// Calibrations are ordered by CalibratedAt.
+	Calibrations []Calibration `json:"calibrations,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
 }
 
 type CreateSensorDTO struct {
 	CodeName       Codename      `json:"codename"`
 	Coords         Coordinates   `json:"coordinates"`
 	DataOutputRate time.Duration `json:"data_output_rate"`
+	Measurements   []string      `json:"measurements,omitempty"`
+	// Trajectory makes the sensor mobile, Coords is where it starts.
+	Trajectory  *trajectory.Trajectory `json:"trajectory,omitempty"`
+	Model       string                 `json:"model,omitempty"`
+	Firmware    string                 `json:"firmware,omitempty"`
+	InstalledAt *time.Time             `json:"installed_at,omitempty"`
+}
+
+type UpdateSensorDTO struct {
+	Coords         *Coordinates   `json:"coordinates"`
+	DataOutputRate *time.Duration `json:"data_output_rate" swaggertype:"integer"`
+	Measurements   []string       `json:"measurements"`
+	// Trajectory of kind static makes the sensor static.
+	Trajectory  *trajectory.Trajectory `json:"trajectory"`
+	Model       *string                `json:"model"`
+	Firmware    *string                `json:"firmware"`
+	InstalledAt *time.Time             `json:"installed_at"`
+}
+
+// Calibration corrects readings of one measurement from CalibratedAt till the next calibration
+// of the same measurement, calibrated value is raw*Gain + Offset.
+type Calibration struct {
+	ID           int       `json:"id"`
+	Metric       string    `json:"metric"`
+	Offset       float64   `json:"offset"`
+	Gain         float64   `json:"gain"`
+	CalibratedAt time.Time `json:"calibrated_at"`
+	Note         string    `json:"note,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type CreateCalibrationDTO struct {
+	Metric string  `json:"metric" example:"temperature"`
+	Offset float64 `json:"offset" example:"-0.12"`
+	// Gain is 1 when it is not set.
+	Gain *float64 `json:"gain" example:"1.002"`
+	// CalibratedAt is now when it is not set.
+	CalibratedAt time.Time `json:"calibrated_at"`
+	Note         string    `json:"note"`
+}
+
+type MaintenanceKind string
+
+const (
+	MaintenanceInspection     MaintenanceKind = "inspection"
+	MaintenanceCleaning       MaintenanceKind = "cleaning"
+	MaintenanceRepair         MaintenanceKind = "repair"
+	MaintenanceReplacement    MaintenanceKind = "replacement"
+	MaintenanceFirmwareUpdate MaintenanceKind = "firmware_update"
+	MaintenanceCalibration    MaintenanceKind = "calibration"
+)
+
+var maintenanceKinds = []MaintenanceKind{MaintenanceInspection, MaintenanceCleaning, MaintenanceRepair,
+	MaintenanceReplacement, MaintenanceFirmwareUpdate, MaintenanceCalibration}
+
+// Maintenance is a window when the sensor was serviced, its readings of the window are flagged.
+type Maintenance struct {
+	ID        int             `json:"id"`
+	Kind      MaintenanceKind `json:"kind"`
+	StartsAt  time.Time       `json:"starts_at"`
+	EndsAt    time.Time       `json:"ends_at"`
+	Note      string          `json:"note,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type CreateMaintenanceDTO struct {
+	Kind     MaintenanceKind `json:"kind" example:"cleaning"`
+	StartsAt time.Time       `json:"starts_at"`
+	EndsAt   time.Time       `json:"ends_at"`
+	Note     string          `json:"note"`
 }
 
 type SensorFilters struct {
@@ -43,6 +124,70 @@ type SensorFilters struct {
 	TillDate time.Time
 }
 
+// Apply returns the calibrated value.
+func (c Calibration) Apply(value float64) float64 {
+	return value*c.Gain + c.Offset
+}
+
+// Calibrate corrects the value of the metric by the calibration which is valid at the time,
+// it returns false and the value as is when the metric was not calibrated by then.
+func (s *Sensor) Calibrate(metric string, value float64, at time.Time) (float64, bool) {
+	for i := len(s.Calibrations) - 1; i >= 0; i-- {
+		c := s.Calibrations[i]
+		if c.Metric == metric && !c.CalibratedAt.After(at) {
+			calibrated := c.Apply(value)
+			if mType, ok := measurement.Lookup(metric); ok {
+				calibrated = mType.Clamp(calibrated)
+			}
+			return calibrated, true
+		}
+	}
+
+	return value, false
+}
+
+// Validate checks the metric and the gain, zero gain would lose the readings.
+func (dto CreateCalibrationDTO) Validate() error {
+	if _, ok := measurement.Lookup(dto.Metric); !ok {
+		return apperror.ErrorWithMessage(apperror.ErrValidation,
+			"Unknown metric, known are "+strings.Join(measurement.Names(), ", ")+".")
+	}
+
+	if math.IsNaN(dto.Offset) || math.IsInf(dto.Offset, 0) {
+		return apperror.ErrorWithMessage(apperror.ErrValidation, "Offset should be a number.")
+	}
+
+	if dto.Gain != nil && !(*dto.Gain > 0 && !math.IsInf(*dto.Gain, 0)) {
+		return apperror.ErrorWithMessage(apperror.ErrValidation, "Gain should be positive.")
+	}
+
+	return nil
+}
+
+func (dto CreateMaintenanceDTO) Validate() error {
+	known := false
+	kinds := make([]string, 0, len(maintenanceKinds))
+	for _, kind := range maintenanceKinds {
+		known = known || kind == dto.Kind
+		kinds = append(kinds, string(kind))
+	}
+
+	if !known {
+		return apperror.ErrorWithMessage(apperror.ErrValidation,
+			"Unknown kind, known are "+strings.Join(kinds, ", ")+".")
+	}
+
+	if dto.StartsAt.IsZero() || dto.EndsAt.IsZero() {
+		return apperror.ErrorWithMessage(apperror.ErrValidation, "starts_at and ends_at are required.")
+	}
+
+	if !dto.StartsAt.Before(dto.EndsAt) {
+		return apperror.ErrorWithMessage(apperror.ErrValidation, "starts_at should be before ends_at.")
+	}
+
+	return nil
+}
+
 func NewCoordsFromString(x, y, z string) (Coordinates, error) {
 	var X, Y, Z float64
 	X, err := strconv.ParseFloat(x, 64)
@@ -3,12 +3,19 @@ package sensor
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sensors-generator/config"
 	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/retention"
 	clients "sensors-generator/pkg/client/interfaces"
 	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/measurement"
+	"sensors-generator/pkg/trajectory"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type repository struct {
@@ -26,33 +33,99 @@ func NewPostgresqlRepository(client *sql.DB,
 	}
 }
 
+// FindAll returns sensors with their calibrations, so readings can be calibrated without more queries.
 func (r *repository) FindAll(ctx context.Context, filters SensorFilters) ([]Sensor, error) {
-	q := `SELECT s.id, sg.name, s.index, s.x, s.y, s.z, s.data_output_rate, s.created_at, s.updated_at FROM sensors as s
+	q := `SELECT s.id, sg.name, s.index, s.x, s.y, s.z, s.data_output_rate, s.measurements, s.trajectory,
+			s.model, s.firmware, s.installed_at, s.created_at, s.updated_at FROM sensors as s
 		JOIN sensor_groups sg ON s.group_id=sg.id`
 
 	rows, err := r.client.QueryContext(ctx, q)
-	defer rows.Close()
 	if err != nil {
-		r.logger.Errorf("Failed to get rows, due to error: %v", err)
-		return nil, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Sensors not found.")
+		r.logger.LWithContext(ctx).Errorf("Failed to get rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Sensors not found."))
 	}
+	defer rows.Close()
 
 	sensors := make([]Sensor, 0)
 
 	for rows.Next() {
 		var sensor Sensor
+		var model, firmware sql.NullString
+		var installedAt sql.NullTime
+		var path []byte
 		if err := rows.Scan(&sensor.ID, &sensor.CodeName.GroupName, &sensor.CodeName.Index, &sensor.Coords.X,
-			&sensor.Coords.Y, &sensor.Coords.Z, &sensor.DataOutputRate, &sensor.CreatedAt, &sensor.UpdatedAt); err != nil {
-			r.logger.Errorf("Failed to fetch row, due to error: %v", err)
-			return nil, apperror.ErrInternalSystem
+			&sensor.Coords.Y, &sensor.Coords.Z, &sensor.DataOutputRate, pq.Array(&sensor.Measurements), &path,
+			&model, &firmware, &installedAt, &sensor.CreatedAt, &sensor.UpdatedAt); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Failed to fetch row, due to error: %v", err)
+			return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+
+		if len(path) > 0 {
+			if err := json.Unmarshal(path, &sensor.Trajectory); err != nil {
+				r.logger.LWithContext(ctx).Errorf("Cannot decode trajectory, due to error: %v", err)
+				return nil, apperror.ErrInternalSystem
+			}
+		}
+
+		sensor.Model, sensor.Firmware = model.String, firmware.String
+		if installedAt.Valid {
+			sensor.InstalledAt = &installedAt.Time
 		}
 
 		sensors = append(sensors, sensor)
 	}
 
+	if err := rows.Err(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to iterate rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	calibrations, err := r.findCalibrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sensors {
+		sensors[i].Calibrations = calibrations[sensors[i].ID]
+	}
+
 	return sensors, nil
 }
 
+// findCalibrations returns calibrations of all sensors by sensor id, ordered by calibrated_at.
+func (r *repository) findCalibrations(ctx context.Context) (map[int][]Calibration, error) {
+	q := `SELECT sensor_id, id, metric, value_offset, gain, calibrated_at, note, created_at FROM calibrations
+		ORDER BY sensor_id, calibrated_at, id`
+
+	rows, err := r.client.QueryContext(ctx, q)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to get calibrations, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+	defer rows.Close()
+
+	calibrations := make(map[int][]Calibration)
+
+	for rows.Next() {
+		var sensorID int
+		var calibration Calibration
+		if err := rows.Scan(&sensorID, &calibration.ID, &calibration.Metric, &calibration.Offset, &calibration.Gain,
+			&calibration.CalibratedAt, &calibration.Note, &calibration.CreatedAt); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Failed to fetch row, due to error: %v", err)
+			return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+
+		calibrations[sensorID] = append(calibrations[sensorID], calibration)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to iterate rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return calibrations, nil
+}
+
 func (r *repository) FindOneByID(ctx context.Context, id int, filters SensorFilters) (*Sensor, error) {
 	return nil, nil
 }
@@ -62,128 +135,358 @@ func (r *repository) Create(ctx context.Context, sensor CreateSensorDTO) error {
 	var groupID int
 	err := r.client.QueryRowContext(ctx, query, sensor.CodeName.GroupName).Scan(&groupID)
 	if err != nil {
-		r.logger.Errorf("Failed to retrieve group ID, due to error: %v", err)
-		return apperror.ErrInternalSystem
+		r.logger.LWithContext(ctx).Errorf("Failed to retrieve group ID, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	insertQuery := `INSERT INTO sensors (group_id, index, x, y, z, data_output_rate, measurements, trajectory,
+			model, firmware, installed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), NULLIF($10, ''), $11, $12, $13)`
+
+	measurements := sensor.Measurements
+	if len(measurements) == 0 {
+		measurements = measurement.Default
 	}
 
-	insertQuery := `INSERT INTO sensors (group_id, index, x, y, z, data_output_rate, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	path, err := trajectoryJSON(sensor.Trajectory)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot encode trajectory, due to error: %v", err)
+		return apperror.ErrInternalSystem
+	}
 
 	t := time.Now()
 
 	if _, err := r.client.ExecContext(ctx, insertQuery, groupID, sensor.CodeName.Index,
-		sensor.Coords.X, sensor.Coords.Y, sensor.Coords.Z, sensor.DataOutputRate,
-		t, t); err != nil {
-		r.logger.Errorf("Failed create sensor, due to error: %v", err)
-		return apperror.ErrInternalSystem
+		sensor.Coords.X, sensor.Coords.Y, sensor.Coords.Z, sensor.DataOutputRate, pq.Array(measurements), path,
+		sensor.Model, sensor.Firmware, sensor.InstalledAt, t, t); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed create sensor, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
 	}
 
 	return nil
 }
 
-func (r *repository) AddSensorToGroup(ctx context.Context, sensorID int, groupID int) error {
-	q := `UPDATE sensors SET group_id=$1 WHERE id=$2`
+func (r *repository) Update(ctx context.Context, codeName Codename, sensor UpdateSensorDTO) error {
+	q := `UPDATE sensors AS sens SET x=COALESCE($1, sens.x), y=COALESCE($2, sens.y), z=COALESCE($3, sens.z),
+		data_output_rate=COALESCE($4, sens.data_output_rate), measurements=COALESCE($5, sens.measurements),
+		trajectory=NULLIF(COALESCE($6::JSONB, sens.trajectory), 'null'::JSONB),
+		model=COALESCE($7, sens.model), firmware=COALESCE($8, sens.firmware),
+		installed_at=COALESCE($9, sens.installed_at), updated_at=$10
+		FROM sensor_groups sg
+		WHERE sg.id=sens.group_id AND sg.name=$11 AND sens.index=$12`
+
+	var x, y, z, dataOutputRate, measurements, path, installedAt interface{}
+	if sensor.Coords != nil {
+		x, y, z = sensor.Coords.X, sensor.Coords.Y, sensor.Coords.Z
+	}
+	if sensor.DataOutputRate != nil {
+		dataOutputRate = int64(*sensor.DataOutputRate)
+	}
+	if sensor.Measurements != nil {
+		measurements = pq.Array(sensor.Measurements)
+	}
+	if sensor.InstalledAt != nil {
+		installedAt = *sensor.InstalledAt
+	}
+	// Trajectory of kind static is written as JSON null, which clears the trajectory.
+	if sensor.Trajectory != nil {
+		path = "null"
+		if sensor.Trajectory.Kind != trajectory.KindStatic {
+			encoded, err := trajectoryJSON(sensor.Trajectory)
+			if err != nil {
+				r.logger.LWithContext(ctx).Errorf("Cannot encode trajectory, due to error: %v", err)
+				return apperror.ErrInternalSystem
+			}
+			path = encoded
+		}
+	}
 
-	if _, err := r.client.ExecContext(ctx, q, groupID, sensorID); err != nil {
-		r.logger.Errorf("Failed to add sensor to another group, due to error: %v", err)
-		return apperror.ErrInternalSystem
+	result, err := r.client.ExecContext(ctx, q, x, y, z, dataOutputRate, measurements, path,
+		sensor.Model, sensor.Firmware, installedAt, time.Now(), codeName.GroupName, codeName.Index)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to update sensor, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return apperror.ErrorWithMessage(apperror.ErrNotFound, "Sensor not found.")
 	}
 
 	return nil
 }
 
-func (r *repository) FindMaxTemperatureForRegion(ctx context.Context, minCoords, maxCoords Coordinates) (float32, error) {
-	q := `SELECT MAX(sd.temperature) FROM sensors as sens
-		JOIN sensor_data sd ON sens.id=sd.sensor_id
-		WHERE sens.x < $1 AND sens.x > $2 AND sens.y < $3 AND sens.y > $4 AND sens.z < $5 AND sens.z > $6`
-
-	var temperature float32
+func (r *repository) AddSensorToGroup(ctx context.Context, sensorID int, groupID int) error {
+	q := `UPDATE sensors SET group_id=$1 WHERE id=$2`
 
-	if err := r.client.QueryRow(q, maxCoords.X, minCoords.X,
-		maxCoords.Y, minCoords.Y, maxCoords.Z, minCoords.Z).Scan(&temperature); err != nil {
-		r.logger.Errorf("Cannot measure max temperature, due to error: %v", err)
-		return 0.0, apperror.ErrorWithMessage(apperror.ErrInternalSystem, "No data was found.")
+	if _, err := r.client.ExecContext(ctx, q, groupID, sensorID); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to add sensor to another group, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
 	}
 
-	return temperature, nil
+	return nil
 }
 
-func (r *repository) FindMinTemperatureForRegion(ctx context.Context, minCoords, maxCoords Coordinates) (float32, error) {
-	q := `SELECT MIN(sd.temperature) FROM sensors as sens
-		JOIN sensor_data sd ON sens.id=sd.sensor_id
-		WHERE sens.x < $1 AND sens.x > $2 AND sens.y < $3 AND sens.y > $4 AND sens.z < $5 AND sens.z > $6`
+// FindExtremumForRegion reads rollups of static sensors for the rolled up history and raw readings after it.
+// Mobile sensors are in the region while positions of their readings are, so only their raw readings are read.
+// The metric should be a known measurement type.
+func (r *repository) FindExtremumForRegion(ctx context.Context, metric string, minCoords, maxCoords Coordinates,
+	min bool) (float64, error) {
+	source, _ := retention.MetricSource(metric, retention.PlanDaily, time.Time{}, time.Time{}, 1)
 
-	var temperature float32
+	extremum, aggregate := "max", "MAX(sd.value_max)"
+	if min {
+		extremum, aggregate = "min", "MIN(sd.value_min)"
+	}
 
-	if err := r.client.QueryRow(q, maxCoords.X, minCoords.X,
-		maxCoords.Y, minCoords.Y, maxCoords.Z, minCoords.Z).Scan(&temperature); err != nil {
-		r.logger.Errorf("Cannot measure min temperature, due to error: %v", err)
-		return 0.0, apperror.ErrorWithMessage(apperror.ErrInternalSystem, "No data was found.")
+	q := fmt.Sprintf(`SELECT %s FROM (
+			SELECT sd.value_min, sd.value_max FROM sensors as sens
+			JOIN (%s) sd ON sens.id=sd.sensor_id
+			WHERE sens.trajectory IS NULL
+				AND sens.x < $1 AND sens.x > $2 AND sens.y < $3 AND sens.y > $4 AND sens.z < $5 AND sens.z > $6
+			UNION ALL
+			SELECT sd.value_min, sd.value_max FROM sensors as sens
+			JOIN (%s) sd ON sens.id=sd.sensor_id
+			WHERE sens.trajectory IS NOT NULL
+				AND COALESCE(sd.x, sens.x) < $1 AND COALESCE(sd.x, sens.x) > $2
+				AND COALESCE(sd.y, sens.y) < $3 AND COALESCE(sd.y, sens.y) > $4
+				AND COALESCE(sd.z, sens.z) < $5 AND COALESCE(sd.z, sens.z) > $6
+		) sd`, aggregate, source, retention.MetricReadings(metric))
+
+	var value float64
+
+	if err := r.client.QueryRowContext(ctx, q, maxCoords.X, minCoords.X,
+		maxCoords.Y, minCoords.Y, maxCoords.Z, minCoords.Z).Scan(&value); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot measure %s %s, due to error: %v", extremum, metric, err)
+		return 0.0, apperror.FromDBError(ctx, err, apperror.ErrorWithMessage(apperror.ErrInternalSystem, "No data was found."))
 	}
 
-	return temperature, nil
+	return value, nil
 }
 
-func (r *repository) FindAvgTemperatureForSensor(ctx context.Context, filters SensorFilters) (float32, error) {
-	q := `SELECT AVG(sd.temperature) FROM sensors AS sens
-		JOIN sensor_data sd ON sens.id=sd.sensor_id`
-
+// FindAvgForSensor reads rollups when from and till are aligned to hours (or days),
+// otherwise only raw readings are used, which may be already deleted by retention.
+// The metric should be a known measurement type.
+func (r *repository) FindAvgForSensor(ctx context.Context, metric string, filters SensorFilters) (float64, error) {
 	args := []interface{}{}
 	argsCounter := 1
+	where := ""
 
 	if !filters.CodeName.IsEmpty() {
-		q += "\n" + `JOIN sensor_groups sg ON sg.id=sens.group_id`
-		if argsCounter == 1 {
-			q += "\n" + ` WHERE`
-		} else {
-			q += ` AND`
-		}
-		q += fmt.Sprintf(` sg.name=$%d AND sens.index=$%d`, argsCounter, argsCounter+1)
+		where = fmt.Sprintf("\n"+`JOIN sensor_groups sg ON sg.id=sens.group_id
+		WHERE sg.name=$%d AND sens.index=$%d`, argsCounter, argsCounter+1)
 		args = append(args, filters.CodeName.GroupName)
 		args = append(args, filters.CodeName.Index)
 		argsCounter += 2
 	}
 
-	if !filters.FromDate.IsZero() {
-		if argsCounter == 1 {
-			q += "\n" + ` WHERE`
-		} else {
-			q += ` AND`
+	plan := retention.PlanFor(filters.FromDate, filters.TillDate)
+	source, sourceArgs := retention.MetricSource(metric, plan, filters.FromDate, filters.TillDate, argsCounter)
+	args = append(args, sourceArgs...)
+
+	q := fmt.Sprintf(`SELECT SUM(sd.value_sum) / SUM(sd.readings_count) FROM sensors AS sens
+		JOIN (%s) sd ON sens.id=sd.sensor_id`, source) + where
+
+	var value float64
+
+	if err := r.client.QueryRowContext(ctx, q, args...).Scan(&value); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot measure average %s, due to error: %v", metric, err)
+		return 0.0, apperror.FromDBError(ctx, err, apperror.ErrorWithMessage(apperror.ErrInternalSystem, "No sensor data was found."))
+	}
+
+	return value, nil
+}
+
+// FindAvgForSensors measures average of the metric for every sensor with one query,
+// sensors without the measurement in the range are missing in the result.
+func (r *repository) FindAvgForSensors(ctx context.Context, metric string, sensorIDs []int, filters SensorFilters) (map[int]float64, error) {
+	plan := retention.PlanFor(filters.FromDate, filters.TillDate)
+	source, sourceArgs := retention.MetricSource(metric, plan, filters.FromDate, filters.TillDate, 2)
+	args := append([]interface{}{pq.Array(sensorIDs)}, sourceArgs...)
+
+	q := fmt.Sprintf(`SELECT sd.sensor_id, SUM(sd.value_sum) / SUM(sd.readings_count) FROM (%s) sd
+		WHERE sd.sensor_id = ANY($1)
+		GROUP BY sd.sensor_id
+		HAVING SUM(sd.readings_count) > 0`, source)
+
+	rows, err := r.client.QueryContext(ctx, q, args...)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot measure average %s, due to error: %v", metric, err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+	defer rows.Close()
+
+	values := make(map[int]float64, len(sensorIDs))
+
+	for rows.Next() {
+		var sensorID int
+		var value float64
+		if err := rows.Scan(&sensorID, &value); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Failed to fetch row, due to error: %v", err)
+			return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+		values[sensorID] = value
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to iterate rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return values, nil
+}
+
+// FindCalibrations returns calibrations of the sensor ordered by calibrated_at.
+func (r *repository) FindCalibrations(ctx context.Context, codeName Codename) ([]Calibration, error) {
+	q := `SELECT c.id, c.metric, c.value_offset, c.gain, c.calibrated_at, c.note, c.created_at FROM calibrations AS c
+		JOIN sensors sens ON sens.id=c.sensor_id
+		JOIN sensor_groups sg ON sg.id=sens.group_id
+		WHERE sg.name=$1 AND sens.index=$2
+		ORDER BY c.calibrated_at, c.id`
+
+	rows, err := r.client.QueryContext(ctx, q, codeName.GroupName, codeName.Index)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to get calibrations, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+	defer rows.Close()
+
+	calibrations := make([]Calibration, 0)
+
+	for rows.Next() {
+		var calibration Calibration
+		if err := rows.Scan(&calibration.ID, &calibration.Metric, &calibration.Offset, &calibration.Gain,
+			&calibration.CalibratedAt, &calibration.Note, &calibration.CreatedAt); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Failed to fetch row, due to error: %v", err)
+			return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+
+		calibrations = append(calibrations, calibration)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to iterate rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return calibrations, nil
+}
+
+// CreateCalibration stores the calibration of the sensor, gain should be set.
+func (r *repository) CreateCalibration(ctx context.Context, codeName Codename, calibration CreateCalibrationDTO) (*Calibration, error) {
+	q := `INSERT INTO calibrations (sensor_id, metric, value_offset, gain, calibrated_at, note, created_at)
+		SELECT sens.id, $1, $2, $3, $4, $5, $6 FROM sensors AS sens
+		JOIN sensor_groups sg ON sg.id=sens.group_id
+		WHERE sg.name=$7 AND sens.index=$8
+		RETURNING id`
+
+	created := Calibration{
+		Metric:       calibration.Metric,
+		Offset:       calibration.Offset,
+		Gain:         *calibration.Gain,
+		CalibratedAt: calibration.CalibratedAt,
+		Note:         calibration.Note,
+		CreatedAt:    time.Now(),
+	}
+
+	if err := r.client.QueryRowContext(ctx, q, created.Metric, created.Offset, created.Gain, created.CalibratedAt,
+		created.Note, created.CreatedAt, codeName.GroupName, codeName.Index).Scan(&created.ID); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to create calibration, due to error: %v", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrorWithMessage(apperror.ErrNotFound, "Sensor not found.")
 		}
-		q += fmt.Sprintf(` sd.created_at >= $%d`, argsCounter)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return &created, nil
+}
+
+// FindMaintenance returns maintenance of the sensor of filters which overlaps from and till, ordered by start.
+func (r *repository) FindMaintenance(ctx context.Context, filters SensorFilters) ([]Maintenance, error) {
+	q := `SELECT m.id, m.kind, m.starts_at, m.ends_at, m.note, m.created_at FROM maintenance AS m
+		JOIN sensors sens ON sens.id=m.sensor_id
+		JOIN sensor_groups sg ON sg.id=sens.group_id
+		WHERE sg.name=$1 AND sens.index=$2`
+
+	args := []interface{}{filters.CodeName.GroupName, filters.CodeName.Index}
+	argsCounter := 3
+
+	if !filters.FromDate.IsZero() {
+		q += fmt.Sprintf(` AND m.ends_at > $%d`, argsCounter)
 		args = append(args, filters.FromDate)
 		argsCounter++
 	}
 
 	if !filters.TillDate.IsZero() {
-		if argsCounter == 1 {
-			q += "\n" + ` WHERE`
-		} else {
-			q += ` AND`
-		}
-		q += fmt.Sprintf(` sd.created_at <= $%d`, argsCounter)
+		q += fmt.Sprintf(` AND m.starts_at < $%d`, argsCounter)
 		args = append(args, filters.TillDate)
-		argsCounter++
 	}
 
-	// if !filters.FromDate.IsZero() && !filters.TillDate.IsZero() {
-	// 	if argsCounter == 1 {
-	// 		q += "\n" + ` WHERE`
-	// 	} else {
-	// 		q += ` AND`
-	// 	}
-	// 	q += fmt.Sprintf(` sd.created_at BETWEEN $%d AND $%d`, argsCounter, argsCounter+1)
-	// 	args = append(args, filters.FromDate)
-	// 	args = append(args, filters.TillDate)
-	// 	argsCounter += 2
-	// }
+	q += "\n\t\tORDER BY m.starts_at, m.id"
+
+	rows, err := r.client.QueryContext(ctx, q, args...)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to get maintenance, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+	defer rows.Close()
+
+	maintenance := make([]Maintenance, 0)
+
+	for rows.Next() {
+		var m Maintenance
+		if err := rows.Scan(&m.ID, &m.Kind, &m.StartsAt, &m.EndsAt, &m.Note, &m.CreatedAt); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Failed to fetch row, due to error: %v", err)
+			return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+
+		maintenance = append(maintenance, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to iterate rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return maintenance, nil
+}
+
+func (r *repository) CreateMaintenance(ctx context.Context, codeName Codename, maintenance CreateMaintenanceDTO) (*Maintenance, error) {
+	q := `INSERT INTO maintenance (sensor_id, kind, starts_at, ends_at, note, created_at)
+		SELECT sens.id, $1, $2, $3, $4, $5 FROM sensors AS sens
+		JOIN sensor_groups sg ON sg.id=sens.group_id
+		WHERE sg.name=$6 AND sens.index=$7
+		RETURNING id`
+
+	created := Maintenance{
+		Kind:      maintenance.Kind,
+		StartsAt:  maintenance.StartsAt,
+		EndsAt:    maintenance.EndsAt,
+		Note:      maintenance.Note,
+		CreatedAt: time.Now(),
+	}
+
+	if err := r.client.QueryRowContext(ctx, q, created.Kind, created.StartsAt, created.EndsAt, created.Note,
+		created.CreatedAt, codeName.GroupName, codeName.Index).Scan(&created.ID); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to create maintenance, due to error: %v", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrorWithMessage(apperror.ErrNotFound, "Sensor not found.")
+		}
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return &created, nil
+}
 
-	var temperature float32
+// trajectoryJSON returns nil for static sensors, so the column is NULL.
+func trajectoryJSON(t *trajectory.Trajectory) (interface{}, error) {
+	if t == nil {
+		return nil, nil
+	}
 
-	if err := r.client.QueryRowContext(ctx, q, args...).Scan(&temperature); err != nil {
-		r.logger.Errorf("Cannot measure average temperature, due to error: %v", err)
-		return 0.0, apperror.ErrorWithMessage(apperror.ErrInternalSystem, "No sensor data was found.")
+	encoded, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
 	}
 
-	return temperature, nil
+	return string(encoded), nil
 }
@@ -3,7 +3,11 @@ package sensor
 import (
 	"context"
 	"sensors-generator/config"
+	"sensors-generator/internal/apperror"
 	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/measurement"
+	"sensors-generator/pkg/trajectory"
+	"time"
 )
 
 type service struct {
@@ -22,38 +26,147 @@ func NewService(sensorRepo ISensorRepository,
 }
 
 func (s *service) GetAll(ctx context.Context, filters SensorFilters) ([]Sensor, error) {
-	s.logger.Info("GET ALL SENSORS.")
+	s.logger.LWithContext(ctx).Debug("Get all sensors.")
 	return s.sensorRepo.FindAll(ctx, filters)
 }
 
 func (s *service) Create(ctx context.Context, sensors ...CreateSensorDTO) error {
-	s.logger.Info("CREATE SENSORS.")
+	s.logger.LWithContext(ctx).Debug("Create sensors.")
 	for _, sensor := range sensors {
+		if sensor.DataOutputRate <= 0 {
+			return apperror.ErrorWithMessage(apperror.ErrValidation, "Data output rate should be > 0.")
+		}
+
+		if sensor.Measurements != nil {
+			if err := measurement.Validate(sensor.Measurements); err != nil {
+				return apperror.ErrorWithMessage(apperror.ErrValidation, err.Error())
+			}
+			sensor.Measurements = measurement.WithDefault(sensor.Measurements)
+		}
+
+		if sensor.Trajectory != nil {
+			if err := sensor.Trajectory.Validate(); err != nil {
+				return apperror.ErrorWithMessage(apperror.ErrValidation, err.Error())
+			}
+			if sensor.Trajectory.Kind == trajectory.KindStatic {
+				sensor.Trajectory = nil
+			}
+		}
+
 		if err := s.sensorRepo.Create(ctx, sensor); err != nil {
 			return err
 		}
 	}
 
-	s.logger.Info("Sensors was created successfully.")
+	s.logger.LWithContext(ctx).Info("Sensors was created successfully.")
 	return nil
 }
 
+func (s *service) Update(ctx context.Context, codeName Codename, sensor UpdateSensorDTO) error {
+	s.logger.LWithContext(ctx).Debug("Update sensor.")
+
+	if sensor.Coords == nil && sensor.DataOutputRate == nil && sensor.Measurements == nil && sensor.Trajectory == nil &&
+		sensor.Model == nil && sensor.Firmware == nil && sensor.InstalledAt == nil {
+		return apperror.ErrorWithMessage(apperror.ErrValidation, "Nothing to update.")
+	}
+
+	if sensor.DataOutputRate != nil && *sensor.DataOutputRate <= 0 {
+		return apperror.ErrorWithMessage(apperror.ErrValidation, "Data output rate should be > 0.")
+	}
+
+	if sensor.Measurements != nil {
+		if err := measurement.Validate(sensor.Measurements); err != nil {
+			return apperror.ErrorWithMessage(apperror.ErrValidation, err.Error())
+		}
+		sensor.Measurements = measurement.WithDefault(sensor.Measurements)
+	}
+
+	if sensor.Trajectory != nil {
+		if err := sensor.Trajectory.Validate(); err != nil {
+			return apperror.ErrorWithMessage(apperror.ErrValidation, err.Error())
+		}
+	}
+
+	return s.sensorRepo.Update(ctx, codeName, sensor)
+}
+
 func (s *service) AddSensorToGroup(ctx context.Context, sensorID int, groupID int) error {
-	s.logger.Info("ADD SENSOR TO GROUP")
+	s.logger.LWithContext(ctx).Debug("Add sensor to group.")
 	return s.sensorRepo.AddSensorToGroup(ctx, sensorID, groupID)
 }
 
-func (s *service) GetExtremumTemperatureForRegion(ctx context.Context, minCoords, maxCoords Coordinates, min bool) (float32, error) {
+func (s *service) GetExtremumForRegion(ctx context.Context, metric string, minCoords, maxCoords Coordinates, min bool) (float64, error) {
+	if _, ok := measurement.Lookup(metric); !ok {
+		return 0, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Unknown metric.")
+	}
+
 	if min {
-		s.logger.Info("GET MIN TEMPERATURE FOR REGION.")
-		return s.sensorRepo.FindMinTemperatureForRegion(ctx, minCoords, maxCoords)
+		s.logger.LWithContext(ctx).Debugf("Get min %s for region.", metric)
+	} else {
+		s.logger.LWithContext(ctx).Debugf("Get max %s for region.", metric)
+	}
+	return s.sensorRepo.FindExtremumForRegion(ctx, metric, minCoords, maxCoords, min)
+}
+
+func (s *service) GetAvgForSensor(ctx context.Context, metric string, filters SensorFilters) (float64, error) {
+	if _, ok := measurement.Lookup(metric); !ok {
+		return 0, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Unknown metric.")
+	}
+
+	s.logger.LWithContext(ctx).Debugf("Get average %s for sensor.", metric)
+	return s.sensorRepo.FindAvgForSensor(ctx, metric, filters)
+}
+
+// GetAvgForSensors ignores codename of filters, sensors are selected by ids.
+func (s *service) GetAvgForSensors(ctx context.Context, metric string, sensorIDs []int, filters SensorFilters) (map[int]float64, error) {
+	if _, ok := measurement.Lookup(metric); !ok {
+		return nil, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Unknown metric.")
+	}
+
+	s.logger.LWithContext(ctx).Debugf("Get average %s for sensors.", metric)
+	return s.sensorRepo.FindAvgForSensors(ctx, metric, sensorIDs, filters)
+}
+
+// GetCalibrations returns calibrations of the sensor ordered by calibrated_at.
+func (s *service) GetCalibrations(ctx context.Context, codeName Codename) ([]Calibration, error) {
+	s.logger.LWithContext(ctx).Debug("Get calibrations of sensor.")
+	return s.sensorRepo.FindCalibrations(ctx, codeName)
+}
+
+// AddCalibration records the calibration, the generator applies it to readings after the next refresh.
+func (s *service) AddCalibration(ctx context.Context, codeName Codename, calibration CreateCalibrationDTO) (*Calibration, error) {
+	s.logger.LWithContext(ctx).Debug("Add calibration of sensor.")
+
+	if err := calibration.Validate(); err != nil {
+		return nil, err
+	}
+
+	if calibration.Gain == nil {
+		gain := 1.0
+		calibration.Gain = &gain
+	}
+
+	if calibration.CalibratedAt.IsZero() {
+		calibration.CalibratedAt = time.Now()
 	}
 
-	s.logger.Info("GET MAX TEMPERATURE FOR REGION.")
-	return s.sensorRepo.FindMaxTemperatureForRegion(ctx, minCoords, maxCoords)
+	return s.sensorRepo.CreateCalibration(ctx, codeName, calibration)
 }
 
-func (s *service) GetAvgTemperatureForSensor(ctx context.Context, filters SensorFilters) (float32, error) {
-	s.logger.Info("GET AVERAGE TEMPERATURE FOR REGION.")
-	return s.sensorRepo.FindAvgTemperatureForSensor(ctx, filters)
+// GetMaintenance returns maintenance of the sensor which overlaps the window of filters, ordered by start.
+func (s *service) GetMaintenance(ctx context.Context, filters SensorFilters) ([]Maintenance, error) {
+	s.logger.LWithContext(ctx).Debug("Get maintenance of sensor.")
+	return s.sensorRepo.FindMaintenance(ctx, filters)
+}
+
+// AddMaintenance records the maintenance window, readings of the window are flagged when they are queried,
+// including readings which were written before.
+func (s *service) AddMaintenance(ctx context.Context, codeName Codename, maintenance CreateMaintenanceDTO) (*Maintenance, error) {
+	s.logger.LWithContext(ctx).Debug("Add maintenance of sensor.")
+
+	if err := maintenance.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.sensorRepo.CreateMaintenance(ctx, codeName, maintenance)
 }
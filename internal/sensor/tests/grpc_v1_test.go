@@ -0,0 +1,113 @@
+package sensor
+
+import (
+	"context"
+	"sensors-generator/internal/sensor"
+	sensorgenv1 "sensors-generator/pkg/api/sensorgen/v1"
+	"sensors-generator/pkg/logging"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_GRPCServer_GetAvgSensorTemperature(t *testing.T) {
+	logging.Init("trace", true)
+	mockSensorService := &MockSensorService{}
+	server := sensor.NewGRPCServer(mockSensorService, logging.GetLogger())
+
+	from := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	mockSensorService.On("GetAvgForSensor", mock.Anything, "temperature", sensor.SensorFilters{
+		CodeName: sensor.Codename{GroupName: "alpha", Index: 1},
+		FromDate: from,
+	}).Return(12.5, nil)
+
+	resp, err := server.GetAvgSensorTemperature(context.Background(), &sensorgenv1.SensorTemperatureRequest{
+		Codename: &sensorgenv1.Codename{GroupName: "alpha", Index: 1},
+		Range:    &sensorgenv1.TimeRange{From: timestamppb.New(from)},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, float32(12.5), resp.Temperature)
+	mockSensorService.AssertExpectations(t)
+
+	_, err = server.GetAvgSensorTemperature(context.Background(), &sensorgenv1.SensorTemperatureRequest{})
+	assert.Error(t, err)
+}
+
+func Test_GRPCServer_RegionTemperature(t *testing.T) {
+	logging.Init("trace", true)
+	mockSensorService := &MockSensorService{}
+	server := sensor.NewGRPCServer(mockSensorService, logging.GetLogger())
+
+	minCoords := sensor.Coordinates{X: 1, Y: 2, Z: 3}
+	maxCoords := sensor.Coordinates{X: 10, Y: 20, Z: 30}
+	mockSensorService.On("GetExtremumForRegion", mock.Anything, "temperature", minCoords, maxCoords, false).
+		Return(20.0, nil)
+
+	resp, err := server.GetMaxRegionTemperature(context.Background(), &sensorgenv1.RegionRequest{
+		Min: sensor.CoordsToProto(minCoords),
+		Max: sensor.CoordsToProto(maxCoords),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, float32(20), resp.Temperature)
+
+	_, err = server.GetMinRegionTemperature(context.Background(), &sensorgenv1.RegionRequest{Min: sensor.CoordsToProto(minCoords)})
+	assert.Error(t, err)
+}
+
+func Test_GRPCServer_MetricAggregations(t *testing.T) {
+	logging.Init("trace", true)
+	mockSensorService := &MockSensorService{}
+	server := sensor.NewGRPCServer(mockSensorService, logging.GetLogger())
+
+	minCoords := sensor.Coordinates{X: 1, Y: 2, Z: 3}
+	maxCoords := sensor.Coordinates{X: 10, Y: 20, Z: 30}
+	mockSensorService.On("GetExtremumForRegion", mock.Anything, "salinity", minCoords, maxCoords, true).
+		Return(31.5, nil)
+	mockSensorService.On("GetAvgForSensor", mock.Anything, "oxygen", sensor.SensorFilters{
+		CodeName: sensor.Codename{GroupName: "alpha", Index: 1},
+	}).Return(7.25, nil)
+
+	resp, err := server.GetMinInRegion(context.Background(), &sensorgenv1.RegionMetricRequest{
+		Min:    sensor.CoordsToProto(minCoords),
+		Max:    sensor.CoordsToProto(maxCoords),
+		Metric: "salinity",
+	})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(&sensorgenv1.MetricResponse{Metric: "salinity", Value: 31.5}, resp))
+
+	resp, err = server.GetAvgForSensor(context.Background(), &sensorgenv1.SensorMetricRequest{
+		Codename: &sensorgenv1.Codename{GroupName: "alpha", Index: 1},
+		Metric:   "oxygen",
+	})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(&sensorgenv1.MetricResponse{Metric: "oxygen", Value: 7.25}, resp))
+	mockSensorService.AssertExpectations(t)
+
+	_, err = server.GetMaxInRegion(context.Background(), &sensorgenv1.RegionMetricRequest{Metric: "salinity"})
+	assert.Error(t, err)
+}
+
+func Test_GRPCServer_UpdateSensor(t *testing.T) {
+	logging.Init("trace", true)
+	mockSensorService := &MockSensorService{}
+	server := sensor.NewGRPCServer(mockSensorService, logging.GetLogger())
+
+	rate := time.Duration(30)
+	mockSensorService.On("Update", mock.Anything, sensor.Codename{GroupName: "alpha", Index: 2},
+		sensor.UpdateSensorDTO{DataOutputRate: &rate}).Return(nil)
+
+	_, err := server.UpdateSensor(context.Background(), &sensorgenv1.UpdateSensorRequest{
+		Codename:       &sensorgenv1.Codename{GroupName: "alpha", Index: 2},
+		DataOutputRate: proto.Int64(30),
+	})
+
+	require.NoError(t, err)
+	mockSensorService.AssertExpectations(t)
+}
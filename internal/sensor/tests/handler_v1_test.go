@@ -6,7 +6,10 @@ import (
 	"net/http/httptest"
 	"sensors-generator/internal/sensor"
 	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/measurement"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -18,11 +21,11 @@ func Test_Handler_MinTemperature(t *testing.T) {
 	mockSensorService := &MockSensorService{}
 	handler := sensor.NewHandler(mockSensorService, logging.GetLogger())
 
-	mockMinTemperature := float32(10.5)
+	mockMinTemperature := 10.5
 	mockMinCoords := sensor.Coordinates{X: 1.0, Y: 2.0, Z: 3.0}
 	mockMaxCoords := sensor.Coordinates{X: 10.0, Y: 20.0, Z: 30.0}
 
-	mockSensorService.On("GetExtremumTemperatureForRegion", mock.Anything, mockMinCoords, mockMaxCoords, true).
+	mockSensorService.On("GetExtremumForRegion", mock.Anything, "temperature", mockMinCoords, mockMaxCoords, true).
 		Return(mockMinTemperature, nil)
 
 	req, err := http.NewRequest("GET", "/min_temperature?xMin=1&yMin=2&zMin=3&xMax=10&yMax=20&zMax=30", nil)
@@ -34,20 +37,21 @@ func Test_Handler_MinTemperature(t *testing.T) {
 
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
+	c.Params = gin.Params{{Key: "metric", Value: "temperature"}}
 
-	handler.MinTemperature(c)
+	handler.Min(c)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
 	}
 
-	var response map[string]float32
+	var response map[string]float64
 	err = json.Unmarshal(w.Body.Bytes(), &response)
 	if err != nil {
 		t.Fatalf("Failed to parse JSON response: %v", err)
 	}
 
-	expectedResponse := map[string]float32{"min_temperature": mockMinTemperature}
+	expectedResponse := map[string]float64{"min_temperature": mockMinTemperature}
 	assert.Equal(t, expectedResponse, response)
 }
 
@@ -58,18 +62,19 @@ func Test_Handler_MaxTemperature(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request, _ = http.NewRequest("GET", "/max_temperature?xMin=1&yMin=2&zMin=3&xMax=4&yMax=5&zMax=6", nil)
+	c.Params = gin.Params{{Key: "metric", Value: "temperature"}}
 
 	expectedMinCoords := sensor.Coordinates{X: 1, Y: 2, Z: 3}
 	expectedMaxCoords := sensor.Coordinates{X: 4, Y: 5, Z: 6}
-	expectedTemperature := float32(25.5)
-	mockService.On("GetExtremumTemperatureForRegion", mock.Anything, expectedMinCoords, expectedMaxCoords, false).
+	expectedTemperature := 25.5
+	mockService.On("GetExtremumForRegion", mock.Anything, "temperature", expectedMinCoords, expectedMaxCoords, false).
 		Return(expectedTemperature, nil)
 
-	handler.MaxTemperature(c)
+	handler.Max(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response map[string]float32
+	var response map[string]float64
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	if err != nil {
 		t.Fatalf("Failed to parse JSON response: %v", err)
@@ -77,3 +82,109 @@ func Test_Handler_MaxTemperature(t *testing.T) {
 
 	assert.Equal(t, expectedTemperature, response["max_temperature"])
 }
+
+func Test_Handler_Avg(t *testing.T) {
+	mockService := &MockSensorService{}
+	handler := sensor.NewHandler(mockService, logging.GetLogger())
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/alpha%201/salinity/average?from=1688169600&till=1688256000", nil)
+	c.Params = gin.Params{{Key: "codeName", Value: "alpha 1"}, {Key: "metric", Value: "salinity"}}
+
+	filters := sensor.SensorFilters{
+		CodeName: sensor.Codename{GroupName: "alpha", Index: 1},
+		FromDate: time.Unix(1688169600, 0),
+		TillDate: time.Unix(1688256000, 0),
+	}
+	mockService.On("GetAvgForSensor", mock.Anything, "salinity", filters).Return(34.6, nil)
+
+	handler.Avg(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"avg_salinity": 34.6}`, w.Body.String())
+}
+
+func Test_Handler_Measurements(t *testing.T) {
+	handler := sensor.NewHandler(&MockSensorService{}, logging.GetLogger())
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/measurements", nil)
+
+	handler.Measurements(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Measurements []measurement.Type `json:"measurements"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v", err)
+	}
+	assert.Equal(t, measurement.Types(), response.Measurements)
+}
+
+func Test_Handler_AddCalibration(t *testing.T) {
+	mockService := &MockSensorService{}
+	handler := sensor.NewHandler(mockService, logging.GetLogger())
+
+	codeName := sensor.Codename{GroupName: "alpha", Index: 1}
+	calibratedAt := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	gain := 1.01
+	dto := sensor.CreateCalibrationDTO{Metric: "salinity", Offset: -0.1, Gain: &gain, CalibratedAt: calibratedAt}
+
+	mockService.On("AddCalibration", mock.Anything, codeName, dto).
+		Return(&sensor.Calibration{ID: 1, Metric: "salinity", Offset: -0.1, Gain: gain, CalibratedAt: calibratedAt}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/sensor/alpha%201/calibrations",
+		strings.NewReader(`{"metric":"salinity","offset":-0.1,"gain":1.01,"calibrated_at":"2023-07-01T00:00:00Z"}`))
+	c.Params = gin.Params{{Key: "codeName", Value: "alpha 1"}}
+
+	handler.AddCalibration(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response sensor.Calibration
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v", err)
+	}
+	assert.Equal(t, gain, response.Gain)
+	mockService.AssertExpectations(t)
+}
+
+func Test_Handler_GetMaintenance(t *testing.T) {
+	mockService := &MockSensorService{}
+	handler := sensor.NewHandler(mockService, logging.GetLogger())
+
+	filters := sensor.SensorFilters{
+		CodeName: sensor.Codename{GroupName: "alpha", Index: 1},
+		FromDate: time.Unix(1688169600, 0),
+	}
+	startsAt := time.Date(2023, time.July, 2, 10, 0, 0, 0, time.UTC)
+
+	mockService.On("GetMaintenance", mock.Anything, filters).Return([]sensor.Maintenance{
+		{ID: 1, Kind: sensor.MaintenanceRepair, StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour)},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/sensor/alpha%201/maintenance?from=1688169600", nil)
+	c.Params = gin.Params{{Key: "codeName", Value: "alpha 1"}}
+
+	handler.GetMaintenance(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Maintenance []sensor.Maintenance `json:"maintenance"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v", err)
+	}
+	assert.Len(t, response.Maintenance, 1)
+	assert.Equal(t, sensor.MaintenanceRepair, response.Maintenance[0].Kind)
+	mockService.AssertExpectations(t)
+}
@@ -29,22 +29,59 @@ func (m *MockSensorRepository) Create(ctx context.Context, sensor sensor.CreateS
 	return args.Error(0)
 }
 
+func (m *MockSensorRepository) Update(ctx context.Context, codeName sensor.Codename, sensor sensor.UpdateSensorDTO) error {
+	args := m.Called(ctx, codeName, sensor)
+	return args.Error(0)
+}
+
 func (m *MockSensorRepository) AddSensorToGroup(ctx context.Context, sensorID int, groupID int) error {
 	args := m.Called(ctx, sensorID, groupID)
 	return args.Error(0)
 }
 
-func (m *MockSensorRepository) FindMaxTemperatureForRegion(ctx context.Context, minCoords, maxCoords sensor.Coordinates) (float32, error) {
-	args := m.Called(ctx, minCoords, maxCoords)
-	return float32(args.Get(0).(float32)), args.Error(1)
+func (m *MockSensorRepository) FindExtremumForRegion(ctx context.Context, metric string, minCoords, maxCoords sensor.Coordinates, min bool) (float64, error) {
+	args := m.Called(ctx, metric, minCoords, maxCoords, min)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockSensorRepository) FindAvgForSensor(ctx context.Context, metric string, filters sensor.SensorFilters) (float64, error) {
+	args := m.Called(ctx, metric, filters)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockSensorRepository) FindAvgForSensors(ctx context.Context, metric string, sensorIDs []int, filters sensor.SensorFilters) (map[int]float64, error) {
+	args := m.Called(ctx, metric, sensorIDs, filters)
+	return args.Get(0).(map[int]float64), args.Error(1)
 }
 
-func (m *MockSensorRepository) FindMinTemperatureForRegion(ctx context.Context, minCoords, maxCoords sensor.Coordinates) (float32, error) {
-	args := m.Called(ctx, minCoords, maxCoords)
-	return float32(args.Get(0).(float32)), args.Error(1)
+func (m *MockSensorRepository) FindCalibrations(ctx context.Context, codeName sensor.Codename) ([]sensor.Calibration, error) {
+	args := m.Called(ctx, codeName)
+	if obj := args.Get(0); obj != nil {
+		return obj.([]sensor.Calibration), args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
-func (m *MockSensorRepository) FindAvgTemperatureForSensor(ctx context.Context, filters sensor.SensorFilters) (float32, error) {
+func (m *MockSensorRepository) CreateCalibration(ctx context.Context, codeName sensor.Codename, calibration sensor.CreateCalibrationDTO) (*sensor.Calibration, error) {
+	args := m.Called(ctx, codeName, calibration)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*sensor.Calibration), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorRepository) FindMaintenance(ctx context.Context, filters sensor.SensorFilters) ([]sensor.Maintenance, error) {
 	args := m.Called(ctx, filters)
-	return float32(args.Get(0).(float32)), args.Error(1)
+	if obj := args.Get(0); obj != nil {
+		return obj.([]sensor.Maintenance), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorRepository) CreateMaintenance(ctx context.Context, codeName sensor.Codename, maintenance sensor.CreateMaintenanceDTO) (*sensor.Maintenance, error) {
+	args := m.Called(ctx, codeName, maintenance)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*sensor.Maintenance), args.Error(1)
+	}
+	return nil, args.Error(1)
 }
@@ -2,12 +2,16 @@ package sensor
 
 import (
 	"context"
+	"reflect"
+	"sensors-generator/internal/apperror"
 	"sensors-generator/internal/sensor"
 	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/trajectory"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 )
 
 func Test_SensorRepository_Create(t *testing.T) {
@@ -22,6 +26,7 @@ func Test_SensorRepository_Create(t *testing.T) {
 			Z: 4,
 		},
 		DataOutputRate: 15,
+		Model:          "SBE 37-SM",
 	}
 
 	db, mock, err := sqlmock.New()
@@ -40,7 +45,8 @@ func Test_SensorRepository_Create(t *testing.T) {
 
 	mock.ExpectExec(`INSERT INTO sensors`).WithArgs(
 		1, mockDTO.CodeName.Index, mockDTO.Coords.X, mockDTO.Coords.Y,
-		mockDTO.Coords.Z, mockDTO.DataOutputRate, sqlmock.AnyArg(), sqlmock.AnyArg(),
+		mockDTO.Coords.Z, mockDTO.DataOutputRate, pq.Array([]string{"temperature", "transparency"}),
+		nil, "SBE 37-SM", "", nil, sqlmock.AnyArg(), sqlmock.AnyArg(),
 	).WillReturnResult(sqlmock.NewResult(1, 1))
 
 	if err := repo.Create(context.Background(), mockDTO); err != nil {
@@ -76,7 +82,7 @@ func Test_SensorRepository_AddSensorToGroup(t *testing.T) {
 	}
 }
 
-func Test_SensorRepository_FindMaxTemperatureForRegion(t *testing.T) {
+func Test_SensorRepository_FindExtremumForRegion_Max(t *testing.T) {
 	minCoords := sensor.Coordinates{X: 10.0, Y: 20.0, Z: 5.0}
 	maxCoords := sensor.Coordinates{X: 20.0, Y: 30.0, Z: 15.0}
 
@@ -88,13 +94,17 @@ func Test_SensorRepository_FindMaxTemperatureForRegion(t *testing.T) {
 
 	repo := sensor.NewPostgresqlRepository(db, logging.GetLogger(), nil)
 
-	expectedTemperature := float32(25.0)
+	expectedTemperature := 25.0
 	mockRows := sqlmock.NewRows([]string{"max_temperature"}).AddRow(expectedTemperature)
-	mock.ExpectQuery(`SELECT MAX\(sd\.temperature\) FROM sensors as sens(.+)`).WithArgs(
+	// Static sensors are read from rollups, mobile ones from positions of raw readings.
+	mock.ExpectQuery(`SELECT MAX\(sd\.value_max\) FROM \((.+)FROM sensors as sens(.+)temperature_max AS value_max`+
+		`(.+)FROM sensor_data_daily(.+)FROM sensor_data_hourly(.+)FROM sensor_data WHERE(.+)sens\.trajectory IS NULL`+
+		`(.+)UNION ALL(.+)SELECT sensor_id, x, y, z, temperature AS value_min, temperature AS value_max FROM sensor_data\)`+
+		`(.+)sens\.trajectory IS NOT NULL AND COALESCE\(sd\.x, sens\.x\) < \$1`).WithArgs(
 		maxCoords.X, minCoords.X, maxCoords.Y, minCoords.Y, maxCoords.Z, minCoords.Z,
 	).WillReturnRows(mockRows)
 
-	temperature, err := repo.FindMaxTemperatureForRegion(context.Background(), minCoords, maxCoords)
+	temperature, err := repo.FindExtremumForRegion(context.Background(), "temperature", minCoords, maxCoords, false)
 	if err != nil {
 		t.Errorf("unexpected error: %s", err)
 	}
@@ -108,7 +118,7 @@ func Test_SensorRepository_FindMaxTemperatureForRegion(t *testing.T) {
 	}
 }
 
-func Test_SensorRepository_FindMinTemperatureForRegion(t *testing.T) {
+func Test_SensorRepository_FindExtremumForRegion_Min(t *testing.T) {
 	minCoords := sensor.Coordinates{
 		X: 10.0,
 		Y: 20.0,
@@ -121,7 +131,7 @@ func Test_SensorRepository_FindMinTemperatureForRegion(t *testing.T) {
 		Z: 10.0,
 	}
 
-	expectedTemperature := float32(15.5)
+	expectedTemperature := 15.5
 
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -131,11 +141,11 @@ func Test_SensorRepository_FindMinTemperatureForRegion(t *testing.T) {
 
 	repo := sensor.NewPostgresqlRepository(db, logging.GetLogger(), nil)
 
-	mock.ExpectQuery(`SELECT MIN\(sd\.temperature\) FROM sensors as sens`).
+	mock.ExpectQuery(`SELECT MIN\(sd\.value_min\) FROM \((.+)FROM sensors as sens(.+)temperature_min AS value_min(.+)FROM sensor_data_daily`).
 		WithArgs(maxCoords.X, minCoords.X, maxCoords.Y, minCoords.Y, maxCoords.Z, minCoords.Z).
 		WillReturnRows(sqlmock.NewRows([]string{"min"}).AddRow(expectedTemperature))
 
-	temperature, err := repo.FindMinTemperatureForRegion(context.Background(), minCoords, maxCoords)
+	temperature, err := repo.FindExtremumForRegion(context.Background(), "temperature", minCoords, maxCoords, true)
 	if err != nil {
 		t.Errorf("error was not expected while finding min temperature: %s", err)
 	}
@@ -149,7 +159,7 @@ func Test_SensorRepository_FindMinTemperatureForRegion(t *testing.T) {
 	}
 }
 
-func Test_SensorRepository_FindAvgTemperatureForSensor(t *testing.T) {
+func Test_SensorRepository_FindAvgForSensor(t *testing.T) {
 	mockFilters := sensor.SensorFilters{
 		CodeName: sensor.Codename{
 			GroupName: "alpha",
@@ -159,7 +169,7 @@ func Test_SensorRepository_FindAvgTemperatureForSensor(t *testing.T) {
 		TillDate: time.Date(2023, time.July, 31, 23, 59, 59, 0, time.UTC),
 	}
 
-	expectedTemperature := float32(25.5)
+	expectedTemperature := 25.5
 
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -169,12 +179,13 @@ func Test_SensorRepository_FindAvgTemperatureForSensor(t *testing.T) {
 
 	repo := sensor.NewPostgresqlRepository(db, logging.GetLogger(), nil)
 
-	mock.ExpectQuery(`SELECT AVG\(sd\.temperature\) FROM sensors AS sens`).
+	mock.ExpectQuery(`SELECT SUM\(sd\.value_sum\) / SUM\(sd\.readings_count\) FROM sensors AS sens
+		JOIN \(SELECT sensor_id, temperature AS value_min(.+)FROM sensor_data WHERE created_at >= \$3 AND created_at < \$4\) sd`).
 		WithArgs(mockFilters.CodeName.GroupName, mockFilters.CodeName.Index,
 			mockFilters.FromDate, mockFilters.TillDate).
 		WillReturnRows(sqlmock.NewRows([]string{"avg"}).AddRow(expectedTemperature))
 
-	temperature, err := repo.FindAvgTemperatureForSensor(context.Background(), mockFilters)
+	temperature, err := repo.FindAvgForSensor(context.Background(), "temperature", mockFilters)
 	if err != nil {
 		t.Errorf("error was not expected while finding average temperature: %s", err)
 	}
@@ -187,3 +198,307 @@ func Test_SensorRepository_FindAvgTemperatureForSensor(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func Test_SensorRepository_FindExtremumForRegion_Timeout(t *testing.T) {
+	minCoords := sensor.Coordinates{X: 10.0, Y: 20.0, Z: 5.0}
+	maxCoords := sensor.Coordinates{X: 30.0, Y: 40.0, Z: 15.0}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := sensor.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	mock.ExpectQuery("SELECT MAX").
+		WillDelayFor(100 * time.Millisecond).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(25.5))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = repo.FindExtremumForRegion(ctx, "temperature", minCoords, maxCoords, false)
+	if err != apperror.ErrTimeout {
+		t.Errorf("unexpected error, got: %v, want: %v", err, apperror.ErrTimeout)
+	}
+}
+
+func Test_SensorRepository_FindAvgForSensor_DailyRollups(t *testing.T) {
+	mockFilters := sensor.SensorFilters{
+		CodeName: sensor.Codename{GroupName: "alpha", Index: 1},
+		FromDate: time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC),
+		TillDate: time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := sensor.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	mock.ExpectQuery(`SELECT SUM\(sd\.value_sum\) / SUM\(sd\.readings_count\) FROM sensors AS sens`+
+		`(.+)FROM sensor_data_daily WHERE bucket < (.+) AND bucket >= \$3 AND bucket < \$4`+
+		`(.+)FROM sensor_data_hourly WHERE bucket >= (.+) AND bucket >= \$3 AND bucket < \$4`+
+		`(.+)FROM sensor_data WHERE created_at >= (.+) AND created_at >= \$3 AND created_at < \$4\) sd`+
+		`(.+)WHERE sg\.name=\$1 AND sens\.index=\$2`).
+		WithArgs(mockFilters.CodeName.GroupName, mockFilters.CodeName.Index,
+			mockFilters.FromDate, mockFilters.TillDate).
+		WillReturnRows(sqlmock.NewRows([]string{"avg"}).AddRow(21.5))
+
+	temperature, err := repo.FindAvgForSensor(context.Background(), "temperature", mockFilters)
+	if err != nil {
+		t.Errorf("error was not expected while finding average temperature: %s", err)
+	}
+
+	if temperature != 21.5 {
+		t.Errorf("expected temperature %f, but got %f", 21.5, temperature)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_SensorRepository_FindAvgForSensor_Measurement(t *testing.T) {
+	mockFilters := sensor.SensorFilters{
+		CodeName: sensor.Codename{GroupName: "alpha", Index: 1},
+		FromDate: time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC),
+		TillDate: time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := sensor.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	mock.ExpectQuery(`SELECT SUM\(sd\.value_sum\) / SUM\(sd\.readings_count\) FROM sensors AS sens`+
+		`(.+)FROM measurements_daily WHERE metric='dissolved_oxygen'\) AS m WHERE bucket < (.+) AND bucket >= \$3 AND bucket < \$4`+
+		`(.+)FROM measurements_hourly WHERE metric='dissolved_oxygen'\) AS m WHERE bucket >= (.+)`+
+		`(.+)\(measurements->>'dissolved_oxygen'\)::FLOAT(.+)FROM sensor_data WHERE created_at >= (.+)`+
+		`(.+)WHERE sg\.name=\$1 AND sens\.index=\$2`).
+		WithArgs(mockFilters.CodeName.GroupName, mockFilters.CodeName.Index,
+			mockFilters.FromDate, mockFilters.TillDate).
+		WillReturnRows(sqlmock.NewRows([]string{"avg"}).AddRow(7.25))
+
+	oxygen, err := repo.FindAvgForSensor(context.Background(), "dissolved_oxygen", mockFilters)
+	if err != nil {
+		t.Errorf("error was not expected while finding average dissolved oxygen: %s", err)
+	}
+
+	if oxygen != 7.25 {
+		t.Errorf("expected dissolved oxygen %f, but got %f", 7.25, oxygen)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_SensorRepository_FindAvgForSensors(t *testing.T) {
+	mockFilters := sensor.SensorFilters{
+		FromDate: time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC),
+		TillDate: time.Date(2023, time.July, 31, 23, 59, 59, 0, time.UTC),
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := sensor.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	mock.ExpectQuery(`SELECT sd\.sensor_id, SUM\(sd\.value_sum\) / SUM\(sd\.readings_count\) FROM `+
+		`\(SELECT sensor_id(.+)FROM sensor_data WHERE created_at >= \$2 AND created_at < \$3\) sd`+
+		`(.+)WHERE sd\.sensor_id = ANY\(\$1\)(.+)GROUP BY sd\.sensor_id(.+)HAVING SUM\(sd\.readings_count\) > 0`).
+		WithArgs(pq.Array([]int{1, 2, 3}), mockFilters.FromDate, mockFilters.TillDate).
+		WillReturnRows(sqlmock.NewRows([]string{"sensor_id", "avg"}).AddRow(1, 25.5).AddRow(3, 20))
+
+	temperatures, err := repo.FindAvgForSensors(context.Background(), "temperature", []int{1, 2, 3}, mockFilters)
+	if err != nil {
+		t.Errorf("error was not expected while finding average temperatures: %s", err)
+	}
+
+	expected := map[int]float64{1: 25.5, 3: 20}
+	if !reflect.DeepEqual(temperatures, expected) {
+		t.Errorf("expected temperatures %v, but got %v", expected, temperatures)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_SensorRepository_FindAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := sensor.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	createdAt := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	installedAt := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT s\.id, sg\.name(.+)s\.model, s\.firmware, s\.installed_at(.+)FROM sensors as s`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "index", "x", "y", "z", "data_output_rate",
+			"measurements", "trajectory", "model", "firmware", "installed_at", "created_at", "updated_at"}).
+			AddRow(1, "alpha", 1, 1.0, 2.0, 3.0, 15, "{temperature,transparency}", nil, "SBE 37-SM", "6.1.2",
+				installedAt, createdAt, createdAt).
+			AddRow(2, "alpha", 2, 4.0, 5.0, 6.0, 15, "{temperature,transparency}",
+				`{"kind":"random_walk","step":20,"radius":100}`, nil, nil, nil, createdAt, createdAt))
+	mock.ExpectQuery(`SELECT sensor_id, id, metric, value_offset, gain, calibrated_at, note, created_at FROM calibrations` +
+		`(.+)ORDER BY sensor_id, calibrated_at, id`).
+		WillReturnRows(sqlmock.NewRows([]string{"sensor_id", "id", "metric", "value_offset", "gain",
+			"calibrated_at", "note", "created_at"}).
+			AddRow(1, 1, "temperature", -0.1, 1.0, createdAt, "", createdAt).
+			AddRow(1, 2, "salinity", 0.0, 1.01, createdAt, "lab", createdAt))
+
+	sensors, err := repo.FindAll(context.Background(), sensor.SensorFilters{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sensors) != 2 {
+		t.Fatalf("unexpected number of sensors, got: %d, want: %d", len(sensors), 2)
+	}
+
+	if sensors[0].Model != "SBE 37-SM" || sensors[0].Firmware != "6.1.2" || !sensors[0].InstalledAt.Equal(installedAt) {
+		t.Errorf("unexpected metadata: %+v", sensors[0])
+	}
+
+	if sensors[1].Model != "" || sensors[1].InstalledAt != nil {
+		t.Errorf("unexpected metadata: %+v", sensors[1])
+	}
+
+	if sensors[0].Trajectory != nil || sensors[1].Trajectory == nil || sensors[1].Trajectory.Step != 20 {
+		t.Errorf("unexpected trajectories: %+v and %+v", sensors[0].Trajectory, sensors[1].Trajectory)
+	}
+
+	if len(sensors[0].Calibrations) != 2 || sensors[0].Calibrations[1].Gain != 1.01 || sensors[1].Calibrations != nil {
+		t.Errorf("unexpected calibrations: %+v and %+v", sensors[0].Calibrations, sensors[1].Calibrations)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_SensorRepository_CreateCalibration(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := sensor.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	gain := 1.002
+	calibratedAt := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	dto := sensor.CreateCalibrationDTO{Metric: "temperature", Offset: -0.12, Gain: &gain, CalibratedAt: calibratedAt}
+
+	mock.ExpectQuery(`INSERT INTO calibrations \(sensor_id, metric, value_offset, gain, calibrated_at, note, created_at\)`+
+		`(.+)WHERE sg\.name=\$7 AND sens\.index=\$8(.+)RETURNING id`).
+		WithArgs("temperature", -0.12, gain, calibratedAt, "", sqlmock.AnyArg(), "alpha", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectQuery(`INSERT INTO calibrations`).
+		WithArgs("temperature", -0.12, gain, calibratedAt, "", sqlmock.AnyArg(), "alpha", 9).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	calibration, err := repo.CreateCalibration(context.Background(), sensor.Codename{GroupName: "alpha", Index: 1}, dto)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calibration.ID != 3 || calibration.Gain != gain || !calibration.CalibratedAt.Equal(calibratedAt) {
+		t.Errorf("unexpected calibration: %+v", calibration)
+	}
+
+	_, err = repo.CreateCalibration(context.Background(), sensor.Codename{GroupName: "alpha", Index: 9}, dto)
+	if err != apperror.ErrNotFound {
+		t.Errorf("unexpected error, got: %v, want: %v", err, apperror.ErrNotFound)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_SensorRepository_FindMaintenance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := sensor.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	filters := sensor.SensorFilters{
+		CodeName: sensor.Codename{GroupName: "alpha", Index: 1},
+		FromDate: time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC),
+	}
+	startsAt := time.Date(2023, time.July, 2, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT m\.id, m\.kind, m\.starts_at, m\.ends_at, m\.note, m\.created_at FROM maintenance AS m`+
+		`(.+)WHERE sg\.name=\$1 AND sens\.index=\$2 AND m\.ends_at > \$3(.+)ORDER BY m\.starts_at, m\.id`).
+		WithArgs("alpha", 1, filters.FromDate).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "kind", "starts_at", "ends_at", "note", "created_at"}).
+			AddRow(1, "cleaning", startsAt, startsAt.Add(time.Hour), "biofouling", startsAt))
+
+	maintenance, err := repo.FindMaintenance(context.Background(), filters)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(maintenance) != 1 || maintenance[0].Kind != sensor.MaintenanceCleaning || maintenance[0].Note != "biofouling" {
+		t.Errorf("unexpected maintenance: %+v", maintenance)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_SensorRepository_Update_Trajectory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := sensor.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+	codeName := sensor.Codename{GroupName: "alpha", Index: 1}
+
+	mock.ExpectExec(`UPDATE sensors AS sens(.+)trajectory=NULLIF\(COALESCE\(\$6::JSONB, sens\.trajectory\), 'null'::JSONB\)`).
+		WithArgs(nil, nil, nil, nil, nil, `{"kind":"random_walk","step":20}`, nil, nil, nil, sqlmock.AnyArg(), "alpha", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// A static trajectory clears the trajectory.
+	mock.ExpectExec(`UPDATE sensors AS sens`).
+		WithArgs(nil, nil, nil, nil, nil, "null", nil, nil, nil, sqlmock.AnyArg(), "alpha", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.Update(context.Background(), codeName, sensor.UpdateSensorDTO{
+		Trajectory: &trajectory.Trajectory{Kind: trajectory.KindRandomWalk, Step: 20},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = repo.Update(context.Background(), codeName, sensor.UpdateSensorDTO{
+		Trajectory: &trajectory.Trajectory{Kind: trajectory.KindStatic},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -21,17 +21,59 @@ func (m *MockSensorService) Create(ctx context.Context, sensors ...sensor.Create
 	return args.Error(0)
 }
 
+func (m *MockSensorService) Update(ctx context.Context, codeName sensor.Codename, sensor sensor.UpdateSensorDTO) error {
+	args := m.Called(ctx, codeName, sensor)
+	return args.Error(0)
+}
+
 func (m *MockSensorService) AddSensorToGroup(ctx context.Context, sensorID int, groupID int) error {
 	args := m.Called(ctx, sensorID, groupID)
 	return args.Error(0)
 }
 
-func (m *MockSensorService) GetExtremumTemperatureForRegion(ctx context.Context, minCoords, maxCoords sensor.Coordinates, min bool) (float32, error) {
-	args := m.Called(ctx, minCoords, maxCoords, min)
-	return float32(args.Get(0).(float32)), args.Error(1)
+func (m *MockSensorService) GetExtremumForRegion(ctx context.Context, metric string, minCoords, maxCoords sensor.Coordinates, min bool) (float64, error) {
+	args := m.Called(ctx, metric, minCoords, maxCoords, min)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockSensorService) GetAvgForSensor(ctx context.Context, metric string, filters sensor.SensorFilters) (float64, error) {
+	args := m.Called(ctx, metric, filters)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockSensorService) GetAvgForSensors(ctx context.Context, metric string, sensorIDs []int, filters sensor.SensorFilters) (map[int]float64, error) {
+	args := m.Called(ctx, metric, sensorIDs, filters)
+	return args.Get(0).(map[int]float64), args.Error(1)
 }
 
-func (m *MockSensorService) GetAvgTemperatureForSensor(ctx context.Context, filters sensor.SensorFilters) (float32, error) {
+func (m *MockSensorService) GetCalibrations(ctx context.Context, codeName sensor.Codename) ([]sensor.Calibration, error) {
+	args := m.Called(ctx, codeName)
+	if obj := args.Get(0); obj != nil {
+		return obj.([]sensor.Calibration), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorService) AddCalibration(ctx context.Context, codeName sensor.Codename, calibration sensor.CreateCalibrationDTO) (*sensor.Calibration, error) {
+	args := m.Called(ctx, codeName, calibration)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*sensor.Calibration), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorService) GetMaintenance(ctx context.Context, filters sensor.SensorFilters) ([]sensor.Maintenance, error) {
 	args := m.Called(ctx, filters)
-	return float32(args.Get(0).(float32)), args.Error(1)
+	if obj := args.Get(0); obj != nil {
+		return obj.([]sensor.Maintenance), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSensorService) AddMaintenance(ctx context.Context, codeName sensor.Codename, maintenance sensor.CreateMaintenanceDTO) (*sensor.Maintenance, error) {
+	args := m.Called(ctx, codeName, maintenance)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*sensor.Maintenance), args.Error(1)
+	}
+	return nil, args.Error(1)
 }
@@ -2,9 +2,11 @@ package sensor
 
 import (
 	"context"
+	"sensors-generator/internal/apperror"
 	"sensors-generator/internal/sensor"
 	"sensors-generator/internal/spiece"
 	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/trajectory"
 	"testing"
 	"time"
 
@@ -118,6 +120,20 @@ func Test_SensorService_Create(t *testing.T) {
 	repo.AssertCalled(t, "Create", ctx, sensors[1])
 }
 
+func Test_SensorService_Create_DataOutputRate(t *testing.T) {
+	repo := &MockSensorRepository{}
+
+	service := sensor.NewService(repo, logging.GetLogger(), nil)
+
+	ctx := context.Background()
+	for _, rate := range []time.Duration{0, -10} {
+		dto := sensor.CreateSensorDTO{CodeName: sensor.Codename{GroupName: "alpha", Index: 1}, DataOutputRate: rate}
+		assert.ErrorIs(t, service.Create(ctx, dto), apperror.ErrValidation)
+	}
+
+	repo.AssertNumberOfCalls(t, "Create", 0)
+}
+
 func Test_SensorService_AddSensorToGroup(t *testing.T) {
 	repo := &MockSensorRepository{}
 
@@ -136,7 +152,7 @@ func Test_SensorService_AddSensorToGroup(t *testing.T) {
 	repo.AssertCalled(t, "AddSensorToGroup", ctx, sensorID, groupID)
 }
 
-func Test_SensorService_GetExtremumTemperatureForRegion(t *testing.T) {
+func Test_SensorService_GetExtremumForRegion(t *testing.T) {
 	repo := &MockSensorRepository{}
 
 	service := sensor.NewService(repo, logging.GetLogger(), nil)
@@ -144,23 +160,23 @@ func Test_SensorService_GetExtremumTemperatureForRegion(t *testing.T) {
 	ctx := context.Background()
 	minCoords := sensor.Coordinates{X: 0, Y: 0, Z: 0}
 	maxCoords := sensor.Coordinates{X: 10, Y: 10, Z: 10}
-	minTemperature := float32(25.5)
-	maxTemperature := float32(30.0)
+	minTemperature := 25.5
+	maxTemperature := 30.0
 
-	repo.On("FindMinTemperatureForRegion", ctx, minCoords, maxCoords).Return(minTemperature, nil)
-	temp, err := service.GetExtremumTemperatureForRegion(ctx, minCoords, maxCoords, true)
+	repo.On("FindExtremumForRegion", ctx, "temperature", minCoords, maxCoords, true).Return(minTemperature, nil)
+	temp, err := service.GetExtremumForRegion(ctx, "temperature", minCoords, maxCoords, true)
 	assert.NoError(t, err)
 	assert.Equal(t, minTemperature, temp)
 
-	repo.On("FindMaxTemperatureForRegion", ctx, minCoords, maxCoords).Return(maxTemperature, nil)
-	temp, err = service.GetExtremumTemperatureForRegion(ctx, minCoords, maxCoords, false)
+	repo.On("FindExtremumForRegion", ctx, "temperature", minCoords, maxCoords, false).Return(maxTemperature, nil)
+	temp, err = service.GetExtremumForRegion(ctx, "temperature", minCoords, maxCoords, false)
 	assert.NoError(t, err)
 	assert.Equal(t, maxTemperature, temp)
 
 	repo.AssertExpectations(t)
 }
 
-func Test_SensorService_GetAvgTemperatureForSensor(t *testing.T) {
+func Test_SensorService_GetAvgForSensor(t *testing.T) {
 	repo := &MockSensorRepository{}
 
 	service := sensor.NewService(repo, logging.GetLogger(), nil)
@@ -171,12 +187,185 @@ func Test_SensorService_GetAvgTemperatureForSensor(t *testing.T) {
 		FromDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
 		TillDate: time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC),
 	}
-	avgTemperature := float32(27.8)
+	avgSalinity := 34.8
 
-	repo.On("FindAvgTemperatureForSensor", ctx, filters).Return(avgTemperature, nil)
-	temp, err := service.GetAvgTemperatureForSensor(ctx, filters)
+	repo.On("FindAvgForSensor", ctx, "salinity", filters).Return(avgSalinity, nil)
+	salinity, err := service.GetAvgForSensor(ctx, "salinity", filters)
 	assert.NoError(t, err)
-	assert.Equal(t, avgTemperature, temp)
+	assert.Equal(t, avgSalinity, salinity)
 
 	repo.AssertExpectations(t)
 }
+
+func Test_SensorService_UnknownMetric(t *testing.T) {
+	repo := &MockSensorRepository{}
+
+	service := sensor.NewService(repo, logging.GetLogger(), nil)
+
+	ctx := context.Background()
+
+	_, err := service.GetAvgForSensor(ctx, "turbidity", sensor.SensorFilters{})
+	assert.ErrorIs(t, err, apperror.ErrBadRequest)
+
+	_, err = service.GetExtremumForRegion(ctx, "turbidity", sensor.Coordinates{}, sensor.Coordinates{X: 1, Y: 1, Z: 1}, true)
+	assert.ErrorIs(t, err, apperror.ErrBadRequest)
+
+	_, err = service.GetAvgForSensors(ctx, "turbidity", []int{1}, sensor.SensorFilters{})
+	assert.ErrorIs(t, err, apperror.ErrBadRequest)
+
+	repo.AssertExpectations(t)
+}
+
+func Test_SensorService_Create_Measurements(t *testing.T) {
+	repo := &MockSensorRepository{}
+
+	service := sensor.NewService(repo, logging.GetLogger(), nil)
+
+	ctx := context.Background()
+	dto := sensor.CreateSensorDTO{
+		CodeName:       sensor.Codename{GroupName: "alpha", Index: 1},
+		DataOutputRate: 10,
+		Measurements:   []string{"salinity", "temperature", "ph"},
+	}
+
+	// Temperature and transparency are reported by every sensor.
+	expected := dto
+	expected.Measurements = []string{"temperature", "transparency", "salinity", "ph"}
+	repo.On("Create", ctx, expected).Return(nil)
+
+	assert.NoError(t, service.Create(ctx, dto))
+
+	dto.Measurements = []string{"salinity", "turbidity"}
+	assert.ErrorIs(t, service.Create(ctx, dto), apperror.ErrValidation)
+
+	dto.Measurements = []string{"salinity", "salinity"}
+	assert.ErrorIs(t, service.Create(ctx, dto), apperror.ErrValidation)
+
+	repo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func Test_SensorService_AddCalibration(t *testing.T) {
+	repo := &MockSensorRepository{}
+
+	service := sensor.NewService(repo, logging.GetLogger(), nil)
+
+	ctx := context.Background()
+	codeName := sensor.Codename{GroupName: "alpha", Index: 1}
+	calibratedAt := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	gain := 1.0
+
+	// Gain defaults to one.
+	expected := sensor.CreateCalibrationDTO{Metric: "salinity", Offset: 0.2, Gain: &gain, CalibratedAt: calibratedAt}
+	repo.On("CreateCalibration", ctx, codeName, expected).
+		Return(&sensor.Calibration{ID: 1, Metric: "salinity", Offset: 0.2, Gain: 1, CalibratedAt: calibratedAt}, nil)
+
+	calibration, err := service.AddCalibration(ctx, codeName,
+		sensor.CreateCalibrationDTO{Metric: "salinity", Offset: 0.2, CalibratedAt: calibratedAt})
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, calibration.Gain)
+
+	_, err = service.AddCalibration(ctx, codeName, sensor.CreateCalibrationDTO{Metric: "turbidity"})
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+
+	negative := -1.0
+	_, err = service.AddCalibration(ctx, codeName, sensor.CreateCalibrationDTO{Metric: "salinity", Gain: &negative})
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+
+	repo.AssertNumberOfCalls(t, "CreateCalibration", 1)
+}
+
+func Test_SensorService_AddMaintenance(t *testing.T) {
+	repo := &MockSensorRepository{}
+
+	service := sensor.NewService(repo, logging.GetLogger(), nil)
+
+	ctx := context.Background()
+	codeName := sensor.Codename{GroupName: "alpha", Index: 1}
+	startsAt := time.Date(2023, time.July, 1, 10, 0, 0, 0, time.UTC)
+
+	_, err := service.AddMaintenance(ctx, codeName, sensor.CreateMaintenanceDTO{
+		Kind: sensor.MaintenanceCleaning, StartsAt: startsAt, EndsAt: startsAt.Add(-time.Hour),
+	})
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+
+	_, err = service.AddMaintenance(ctx, codeName, sensor.CreateMaintenanceDTO{
+		Kind: "painting", StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour),
+	})
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+
+	repo.AssertExpectations(t)
+}
+
+func Test_SensorService_Update_Metadata(t *testing.T) {
+	repo := &MockSensorRepository{}
+
+	service := sensor.NewService(repo, logging.GetLogger(), nil)
+
+	ctx := context.Background()
+	codeName := sensor.Codename{GroupName: "alpha", Index: 1}
+	firmware := "6.1.2"
+	dto := sensor.UpdateSensorDTO{Firmware: &firmware}
+
+	repo.On("Update", ctx, codeName, dto).Return(nil)
+
+	assert.NoError(t, service.Update(ctx, codeName, dto))
+	assert.ErrorIs(t, service.Update(ctx, codeName, sensor.UpdateSensorDTO{}), apperror.ErrValidation)
+
+	repo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func Test_Sensor_Calibrate(t *testing.T) {
+	july := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	sens := sensor.Sensor{
+		Calibrations: []sensor.Calibration{
+			{Metric: "temperature", Offset: 1, Gain: 1, CalibratedAt: july},
+			{Metric: "temperature", Offset: 0, Gain: 2, CalibratedAt: july.AddDate(0, 1, 0)},
+			{Metric: "ph", Offset: 10, Gain: 1, CalibratedAt: july},
+		},
+	}
+
+	_, ok := sens.Calibrate("temperature", 10, july.Add(-time.Hour))
+	assert.False(t, ok)
+
+	value, ok := sens.Calibrate("temperature", 10, july.AddDate(0, 0, 1))
+	assert.True(t, ok)
+	assert.Equal(t, 11.0, value)
+
+	value, _ = sens.Calibrate("temperature", 10, july.AddDate(0, 2, 0))
+	assert.Equal(t, 20.0, value)
+
+	// Calibrated values stay in the range of the measurement.
+	value, _ = sens.Calibrate("ph", 8, july)
+	assert.Equal(t, 14.0, value)
+}
+
+func Test_SensorService_Create_Trajectory(t *testing.T) {
+	repo := &MockSensorRepository{}
+
+	service := sensor.NewService(repo, logging.GetLogger(), nil)
+
+	ctx := context.Background()
+	dto := sensor.CreateSensorDTO{
+		CodeName:       sensor.Codename{GroupName: "alpha", Index: 1},
+		DataOutputRate: 10,
+		Trajectory:     &trajectory.Trajectory{Kind: trajectory.KindStatic},
+	}
+
+	// A static sensor is created without trajectory.
+	expected := dto
+	expected.Trajectory = nil
+	repo.On("Create", ctx, expected).Return(nil)
+
+	assert.NoError(t, service.Create(ctx, dto))
+
+	dto.Trajectory = &trajectory.Trajectory{Kind: trajectory.KindWaypoints, Speed: 0.5}
+	assert.ErrorIs(t, service.Create(ctx, dto), apperror.ErrValidation)
+
+	err := service.Update(ctx, dto.CodeName, sensor.UpdateSensorDTO{
+		Trajectory: &trajectory.Trajectory{Kind: trajectory.KindRandomWalk, Step: -1},
+	})
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+
+	repo.AssertNumberOfCalls(t, "Create", 1)
+	repo.AssertNumberOfCalls(t, "Update", 0)
+}
@@ -0,0 +1,83 @@
+package sensordata
+
+import (
+	"context"
+	"sync"
+)
+
+// subscriberBuffer is how many readings wait for a slow subscriber before new ones are dropped.
+const subscriberBuffer = 256
+
+// Delivery is a published reading. Dropped counts readings which the subscriber missed before it.
+type Delivery struct {
+	SensorData SensorData
+	Dropped    int64
+}
+
+type subscriber struct {
+	filters SensorDataFilters
+	ch      chan Delivery
+	dropped int64
+}
+
+// broker passes published readings to subscribers. Publish never blocks,
+// so a slow subscriber cannot slow down the generator.
+type broker struct {
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+func newBroker() *broker {
+	return &broker{subscribers: make(map[*subscriber]struct{})}
+}
+
+func (b *broker) publish(sensorData SensorData) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		if !sub.matches(sensorData) {
+			continue
+		}
+
+		select {
+		case sub.ch <- Delivery{SensorData: sensorData, Dropped: sub.dropped}:
+			sub.dropped = 0
+		default:
+			sub.dropped++
+		}
+	}
+}
+
+// subscribe returns channel which is closed when ctx is done.
+func (b *broker) subscribe(ctx context.Context, filters SensorDataFilters) <-chan Delivery {
+	sub := &subscriber{filters: filters, ch: make(chan Delivery, subscriberBuffer)}
+
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		delete(b.subscribers, sub)
+		close(sub.ch)
+		b.mu.Unlock()
+	}()
+
+	return sub.ch
+}
+
+// matches checks codename and group filters, time filters do not apply to live readings.
+func (s *subscriber) matches(sensorData SensorData) bool {
+	if !s.filters.CodeName.IsEmpty() && s.filters.CodeName != sensorData.CodeName {
+		return false
+	}
+
+	if s.filters.GroupName != "" && s.filters.GroupName != sensorData.CodeName.GroupName {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,88 @@
+package sensordata
+
+import (
+	"sensors-generator/internal/sensor"
+	"sensors-generator/internal/spiece"
+	sensorgenv1 "sensors-generator/pkg/api/sensorgen/v1"
+	"sensors-generator/pkg/logging"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type grpcServer struct {
+	sensorgenv1.UnimplementedReadingServiceServer
+	sensorDataService ISensorDataService
+	logger            *logging.Logger
+}
+
+func NewGRPCServer(sensorDataService ISensorDataService, logger *logging.Logger) *grpcServer {
+	return &grpcServer{
+		sensorDataService: sensorDataService,
+		logger:            logger,
+	}
+}
+
+func (s *grpcServer) Register(server grpc.ServiceRegistrar) {
+	sensorgenv1.RegisterReadingServiceServer(server, s)
+}
+
+func (s *grpcServer) ExportReadings(req *sensorgenv1.ExportReadingsRequest, stream sensorgenv1.ReadingService_ExportReadingsServer) error {
+	filters, err := filtersFromProto(req.GetCodename(), req.GetGroupName())
+	if err != nil {
+		return err
+	}
+	filters.FromDate, filters.TillDate = sensor.TimeRangeFromProto(req.GetRange())
+
+	return s.sensorDataService.Iterate(stream.Context(), filters, func(sensorData SensorData) error {
+		return stream.Send(readingToProto(sensorData, 0))
+	})
+}
+
+// SubscribeReadings sends readings till the client cancels the call or the server stops.
+func (s *grpcServer) SubscribeReadings(req *sensorgenv1.SubscribeReadingsRequest, stream sensorgenv1.ReadingService_SubscribeReadingsServer) error {
+	filters, err := filtersFromProto(req.GetCodename(), req.GetGroupName())
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	for delivery := range s.sensorDataService.Subscribe(ctx, filters) {
+		if delivery.Dropped > 0 {
+			s.logger.LWithContext(ctx).Warnf("Subscriber is slow, %d readings were dropped.", delivery.Dropped)
+		}
+
+		if err := stream.Send(readingToProto(delivery.SensorData, delivery.Dropped)); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+func filtersFromProto(codename *sensorgenv1.Codename, groupName string) (SensorDataFilters, error) {
+	filters := SensorDataFilters{GroupName: groupName}
+
+	if codename != nil {
+		codeName, err := sensor.CodenameFromProto(codename)
+		if err != nil {
+			return filters, err
+		}
+		filters.CodeName = codeName
+	}
+
+	return filters, nil
+}
+
+func readingToProto(sensorData SensorData, dropped int64) *sensorgenv1.Reading {
+	return &sensorgenv1.Reading{
+		Id:           int64(sensorData.ID),
+		Codename:     sensor.CodenameToProto(sensorData.CodeName),
+		Coordinates:  sensor.CoordsToProto(sensorData.Coords),
+		Temperature:  sensorData.Temperature,
+		Transparency: uint32(sensorData.Transparency),
+		Spieces:      spiece.ToProto(sensorData.DetectedSpieces),
+		CreatedAt:    timestamppb.New(sensorData.CreatedAt),
+		Dropped:      dropped,
+	}
+}
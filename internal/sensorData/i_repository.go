@@ -7,7 +7,10 @@ import (
 
 type ISensorDataRepository interface {
 	FindAll(ctx context.Context, filters SensorDataFilters) ([]SensorData, error)
+	Iterate(ctx context.Context, filters SensorDataFilters, fn func(SensorData) error) error
+	FindLatestForSensors(ctx context.Context, sensorIDs []int, filters SensorDataFilters) ([]SensorData, error)
 	FindOneByID(ctx context.Context, id int, filters SensorDataFilters) (*SensorData, error)
 	Create(ctx context.Context, sensorData CreateSensorDataDTO) (int, error)
 	AddDetectedSpiece(ctx context.Context, sensorDataID int, spiece spiece.Spiece) error
+	CreateWithSpieces(ctx context.Context, sensorData CreateSensorDataDTO, spieces []spiece.Spiece) (int, error)
 }
@@ -6,7 +6,13 @@ import (
 )
 
 type ISensorDataService interface {
+	GetAll(ctx context.Context, filters SensorDataFilters) ([]SensorData, error)
+	Iterate(ctx context.Context, filters SensorDataFilters, fn func(SensorData) error) error
+	GetLatestForSensors(ctx context.Context, sensorIDs []int, filters SensorDataFilters) ([]SensorData, error)
 	GetOneByID(ctx context.Context, id int, filters SensorDataFilters) (*SensorData, error)
 	Create(ctx context.Context, sensorData ...CreateSensorDataDTO) ([]int, error)
 	AddDetectedSpieces(ctx context.Context, sensorDataID int, spieces ...spiece.Spiece) error
+	CreateWithSpieces(ctx context.Context, sensorData CreateSensorDataDTO, spieces ...spiece.Spiece) (int, error)
+	Publish(sensorData SensorData)
+	Subscribe(ctx context.Context, filters SensorDataFilters) <-chan Delivery
 }
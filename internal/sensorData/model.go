@@ -1,15 +1,26 @@
 package sensordata
 
 import (
+	"math"
+	"sensors-generator/internal/sensor"
 	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/measurement"
 	"time"
 )
 
 type SensorData struct {
-	ID              int
-	SensorID        int
-	Temperature     float32
-	Transparency    uint8
+	ID       int
+	SensorID int
+	CodeName sensor.Codename
+	// Coords is the position of the sensor when the reading was taken.
+	Coords       sensor.Coordinates
+	Temperature  float32
+	Transparency uint8
+	Measurements map[string]float64
+	// Raw are values before calibration by measurement name, only calibrated measurements are there.
+	Raw map[string]float64
+	// Maintenance is true when the reading was taken during maintenance of the sensor.
+	Maintenance     bool
 	DetectedSpieces []spiece.Spiece
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
@@ -19,7 +30,96 @@ type CreateSensorDataDTO struct {
 	SensorID     int     `json:"sensor_id"`
 	Temperature  float32 `json:"temperature"`
 	Transparency uint8   `json:"transparency"`
+	// Measurements are values of other measurement types the sensor carries, by name.
+	Measurements map[string]float64 `json:"measurements,omitempty"`
+	// Raw are values before calibration, see Calibrated.
+	Raw map[string]float64 `json:"raw,omitempty"`
+	// Coords is the position of the sensor when the reading was taken, nil means the position of the sensor.
+	Coords *sensor.Coordinates `json:"coordinates,omitempty"`
+	// CreatedAt is the time of measurement, zero means now.
+	CreatedAt time.Time `json:"created_at"`
 }
 
+// SensorDataFilters select readings for FindAll. Zero values do not filter.
 type SensorDataFilters struct {
+	CodeName  sensor.Codename
+	GroupName string
+	FromDate  time.Time
+	TillDate  time.Time
+	Limit     int
+	// Raw returns values before calibration instead of calibrated ones.
+	Raw bool
+}
+
+// Calibrated applies calibrations of the sensor which are valid at the time of the reading,
+// values before calibration are kept in Raw. The dto is not changed.
+func (dto CreateSensorDataDTO) Calibrated(sens *sensor.Sensor) CreateSensorDataDTO {
+	if len(sens.Calibrations) == 0 {
+		return dto
+	}
+
+	at := dto.CreatedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	raw := make(map[string]float64)
+
+	if value, ok := sens.Calibrate(measurement.Temperature, float64(dto.Temperature), at); ok {
+		raw[measurement.Temperature] = float64(dto.Temperature)
+		dto.Temperature = float32(value)
+	}
+
+	if value, ok := sens.Calibrate(measurement.Transparency, float64(dto.Transparency), at); ok {
+		raw[measurement.Transparency] = float64(dto.Transparency)
+		dto.Transparency = uint8(math.Round(value))
+	}
+
+	if dto.Measurements != nil {
+		measurements := make(map[string]float64, len(dto.Measurements))
+		for name, value := range dto.Measurements {
+			if calibrated, ok := sens.Calibrate(name, value, at); ok {
+				raw[name] = value
+				value = calibrated
+			}
+			measurements[name] = value
+		}
+		dto.Measurements = measurements
+	}
+
+	if len(raw) > 0 {
+		dto.Raw = raw
+	}
+
+	return dto
+}
+
+// Uncalibrated returns the reading with values before calibration.
+func (sd SensorData) Uncalibrated() SensorData {
+	if len(sd.Raw) == 0 {
+		return sd
+	}
+
+	measurements := make(map[string]float64, len(sd.Measurements))
+	for name, value := range sd.Measurements {
+		measurements[name] = value
+	}
+
+	for name, value := range sd.Raw {
+		switch name {
+		case measurement.Temperature:
+			sd.Temperature = float32(value)
+		case measurement.Transparency:
+			sd.Transparency = uint8(value)
+		default:
+			measurements[name] = value
+		}
+	}
+
+	if len(measurements) > 0 {
+		sd.Measurements = measurements
+	}
+	sd.Raw = nil
+
+	return sd
 }
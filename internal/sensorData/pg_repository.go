@@ -3,31 +3,231 @@ package sensordata
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"sensors-generator/config"
 	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/sensor"
 	"sensors-generator/internal/spiece"
 	clients "sensors-generator/pkg/client/interfaces"
+	"sensors-generator/pkg/clock"
 	"sensors-generator/pkg/logging"
-	"time"
+	"strings"
+
+	"github.com/lib/pq"
 )
 
+// maintenanceFlag selects whether the reading sd of the sensor sens was taken during its maintenance.
+const maintenanceFlag = `EXISTS(SELECT 1 FROM maintenance m
+				WHERE m.sensor_id=sens.id AND m.starts_at <= sd.created_at AND m.ends_at > sd.created_at)`
+
 type repository struct {
 	client clients.DBClient
+	clk    clock.Clock
 	logger *logging.Logger
 	cfg    *config.Config
 }
 
-func NewPostgresqlRepository(client *sql.DB,
+// NewPostgresqlRepository stamps rows with time of clk, so they follow the clock of the generator.
+func NewPostgresqlRepository(client *sql.DB, clk clock.Clock,
 	logger *logging.Logger, cfg *config.Config) *repository {
 	return &repository{
 		client: client,
+		clk:    clk,
 		logger: logger,
 		cfg:    cfg,
 	}
 }
 
 func (r *repository) FindAll(ctx context.Context, filters SensorDataFilters) ([]SensorData, error) {
-	return nil, nil
+	sensorData := make([]SensorData, 0)
+
+	if err := r.Iterate(ctx, filters, func(sd SensorData) error {
+		sensorData = append(sensorData, sd)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return sensorData, nil
+}
+
+// Iterate streams readings ordered by created_at with codename, coordinates and detected spieces of the sensor.
+// Error returned by fn stops iteration and is returned as is.
+func (r *repository) Iterate(ctx context.Context, filters SensorDataFilters, fn func(SensorData) error) error {
+	q := `SELECT sd.id, sens.id, sg.name, sens.index,
+			COALESCE(sd.x, sens.x), COALESCE(sd.y, sens.y), COALESCE(sd.z, sens.z),
+			sd.temperature, sd.transparency, sd.measurements, sd.raw, ` + maintenanceFlag + `, sd.created_at, sd.updated_at,
+			COALESCE(array_agg(s.id ORDER BY s.id) FILTER (WHERE s.id IS NOT NULL), '{}'),
+			COALESCE(array_agg(s.name ORDER BY s.id) FILTER (WHERE s.id IS NOT NULL), '{}')
+		FROM sensor_data AS sd
+		JOIN sensors sens ON sd.sensor_id=sens.id
+		JOIN sensor_groups sg ON sg.id=sens.group_id
+		LEFT JOIN detected_spieces ds ON ds.sensor_data_id=sd.id AND ds.created_at=sd.created_at
+		LEFT JOIN spieces s ON s.id=ds.spiece_id`
+
+	conditions := make([]string, 0)
+	args := []interface{}{}
+	argsCounter := 1
+
+	if !filters.CodeName.IsEmpty() {
+		conditions = append(conditions, fmt.Sprintf(`sg.name=$%d AND sens.index=$%d`, argsCounter, argsCounter+1))
+		args = append(args, filters.CodeName.GroupName, filters.CodeName.Index)
+		argsCounter += 2
+	}
+
+	if filters.GroupName != "" {
+		conditions = append(conditions, fmt.Sprintf(`sg.name=$%d`, argsCounter))
+		args = append(args, filters.GroupName)
+		argsCounter++
+	}
+
+	if !filters.FromDate.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(`sd.created_at >= $%d`, argsCounter))
+		args = append(args, filters.FromDate)
+		argsCounter++
+	}
+
+	if !filters.TillDate.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(`sd.created_at <= $%d`, argsCounter))
+		args = append(args, filters.TillDate)
+		argsCounter++
+	}
+
+	if len(conditions) > 0 {
+		q += "\n\t\tWHERE " + strings.Join(conditions, " AND ")
+	}
+
+	q += "\n\t\t" + `GROUP BY sd.id, sd.created_at, sens.id, sg.name
+		ORDER BY sd.created_at, sd.id`
+
+	if filters.Limit > 0 {
+		q += fmt.Sprintf(` LIMIT $%d`, argsCounter)
+		args = append(args, filters.Limit)
+	}
+
+	rows, err := r.client.QueryContext(ctx, q, args...)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to get sensor data, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+	defer rows.Close()
+
+	return r.scanRows(ctx, rows, fn)
+}
+
+// FindLatestForSensors returns up to filters.Limit latest readings of every sensor with one query,
+// ordered by sensor and then from the newest reading. Codename and group of filters are ignored.
+func (r *repository) FindLatestForSensors(ctx context.Context, sensorIDs []int, filters SensorDataFilters) ([]SensorData, error) {
+	conditions := []string{"sensor_id=ids.sensor_id"}
+	args := []interface{}{pq.Array(sensorIDs)}
+	argsCounter := 2
+
+	if !filters.FromDate.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(`created_at >= $%d`, argsCounter))
+		args = append(args, filters.FromDate)
+		argsCounter++
+	}
+
+	if !filters.TillDate.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(`created_at <= $%d`, argsCounter))
+		args = append(args, filters.TillDate)
+		argsCounter++
+	}
+
+	limit := ""
+	if filters.Limit > 0 {
+		limit = fmt.Sprintf(` LIMIT $%d`, argsCounter)
+		args = append(args, filters.Limit)
+	}
+
+	q := fmt.Sprintf(`SELECT sd.id, sens.id, sg.name, sens.index,
+			COALESCE(sd.x, sens.x), COALESCE(sd.y, sens.y), COALESCE(sd.z, sens.z),
+			sd.temperature, sd.transparency, sd.measurements, sd.raw, %s, sd.created_at, sd.updated_at,
+			COALESCE(array_agg(s.id ORDER BY s.id) FILTER (WHERE s.id IS NOT NULL), '{}'),
+			COALESCE(array_agg(s.name ORDER BY s.id) FILTER (WHERE s.id IS NOT NULL), '{}')
+		FROM unnest($1::INT[]) AS ids(sensor_id)
+		CROSS JOIN LATERAL (SELECT id, sensor_id, x, y, z, temperature, transparency, measurements, raw,
+				created_at, updated_at FROM sensor_data
+			WHERE %s
+			ORDER BY created_at DESC, id DESC%s) sd
+		JOIN sensors sens ON sd.sensor_id=sens.id
+		JOIN sensor_groups sg ON sg.id=sens.group_id
+		LEFT JOIN detected_spieces ds ON ds.sensor_data_id=sd.id AND ds.created_at=sd.created_at
+		LEFT JOIN spieces s ON s.id=ds.spiece_id
+		GROUP BY sd.id, sd.created_at, sd.x, sd.y, sd.z, sd.temperature, sd.transparency, sd.measurements, sd.raw,
+			sd.updated_at, sens.id, sg.name
+		ORDER BY sens.id, sd.created_at DESC, sd.id DESC`, maintenanceFlag, strings.Join(conditions, " AND "), limit)
+
+	rows, err := r.client.QueryContext(ctx, q, args...)
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to get latest sensor data, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+	defer rows.Close()
+
+	sensorData := make([]SensorData, 0)
+
+	if err := r.scanRows(ctx, rows, func(sd SensorData) error {
+		sensorData = append(sensorData, sd)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return sensorData, nil
+}
+
+// scanRows reads rows selected with codename, coordinates and aggregated detected spieces.
+func (r *repository) scanRows(ctx context.Context, rows *sql.Rows, fn func(SensorData) error) error {
+	for rows.Next() {
+		var sensorData SensorData
+		var updatedAt sql.NullTime
+		var spieceIDs []int64
+		var spieceNames []string
+		var measurements, raw []byte
+
+		if err := rows.Scan(&sensorData.ID, &sensorData.SensorID, &sensorData.CodeName.GroupName,
+			&sensorData.CodeName.Index, &sensorData.Coords.X, &sensorData.Coords.Y, &sensorData.Coords.Z,
+			&sensorData.Temperature, &sensorData.Transparency, &measurements, &raw, &sensorData.Maintenance,
+			&sensorData.CreatedAt, &updatedAt, pq.Array(&spieceIDs), pq.Array(&spieceNames)); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Failed to fetch row, due to error: %v", err)
+			return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+		}
+
+		if len(measurements) > 0 {
+			if err := json.Unmarshal(measurements, &sensorData.Measurements); err != nil {
+				r.logger.LWithContext(ctx).Errorf("Cannot decode measurements, due to error: %v", err)
+				return apperror.ErrInternalSystem
+			}
+		}
+
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &sensorData.Raw); err != nil {
+				r.logger.LWithContext(ctx).Errorf("Cannot decode raw values, due to error: %v", err)
+				return apperror.ErrInternalSystem
+			}
+		}
+
+		sensorData.UpdatedAt = updatedAt.Time
+		sensorData.DetectedSpieces = make([]spiece.Spiece, 0, len(spieceIDs))
+		for i := range spieceIDs {
+			sensorData.DetectedSpieces = append(sensorData.DetectedSpieces,
+				spiece.Spiece{ID: int(spieceIDs[i]), Name: spieceNames[i]})
+		}
+
+		if err := fn(sensorData); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to iterate rows, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return nil
 }
 
 func (r *repository) FindOneByID(ctx context.Context, id int, filters SensorDataFilters) (*SensorData, error) {
@@ -36,20 +236,33 @@ func (r *repository) FindOneByID(ctx context.Context, id int, filters SensorData
 		WHERE sd.id=$1`
 
 	qDetectedSpieces := `SELECT s.id, s.name, s.created_at, s.updated_at FROM sensor_data AS sd
-	JOIN detected_spieces ds ON sd.id=ds.sensor_data_id
+	JOIN detected_spieces ds ON sd.id=ds.sensor_data_id AND sd.created_at=ds.created_at
 	JOIN spieces s ON ds.spiece_id=s.id
 	WHERE sd.id=$1`
 
 	tx, err := r.client.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot begin transaction, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
 
 	var sensorData SensorData
 
-	err = tx.QueryRowContext(ctx, q, id).Scan(&sensorData.ID, &sensorData.SensorID,
-		&sensorData.Temperature, &sensorData.Transparency, &sensorData.CreatedAt, &sensorData.UpdatedAt)
+	if err := tx.QueryRowContext(ctx, q, id).Scan(&sensorData.ID, &sensorData.SensorID,
+		&sensorData.Temperature, &sensorData.Transparency, &sensorData.CreatedAt, &sensorData.UpdatedAt); err != nil {
+		tx.Rollback()
+		r.logger.LWithContext(ctx).Errorf("Cannot find sensor data, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrorWithMessage(apperror.ErrNotFound, "Sensor data not found."))
+	}
 
 	sensorData.DetectedSpieces = make([]spiece.Spiece, 0)
 
-	rows, err := tx.QueryContext(context.Background(), qDetectedSpieces, id)
+	rows, err := tx.QueryContext(ctx, qDetectedSpieces, id)
+	if err != nil {
+		tx.Rollback()
+		r.logger.LWithContext(ctx).Errorf("Cannot find detected spieces, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
 	defer rows.Close()
 
 	for rows.Next() {
@@ -57,52 +270,142 @@ func (r *repository) FindOneByID(ctx context.Context, id int, filters SensorData
 		if err := rows.Scan(&detectedSpiece.ID, &detectedSpiece.Name,
 			&detectedSpiece.CreatedAt, &detectedSpiece.UpdatedAt); err != nil {
 			tx.Rollback()
-			r.logger.Errorf("Cannot find detected spiece, due to error: %v", err)
-			return nil, nil
+			r.logger.LWithContext(ctx).Errorf("Cannot find detected spiece, due to error: %v", err)
+			return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
 		}
 		sensorData.DetectedSpieces = append(sensorData.DetectedSpieces, detectedSpiece)
 	}
 
-	switch err {
-	case nil:
-		tx.Commit()
-		break
-
-	default:
+	if err := rows.Err(); err != nil {
 		tx.Rollback()
-		r.logger.Errorf("Cannot find sensor data, due to error: %v", err)
-		return nil, nil
+		r.logger.LWithContext(ctx).Errorf("Cannot find detected spieces, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
 	}
 
+	tx.Commit()
+
 	return &sensorData, nil
 }
 
 func (r *repository) Create(ctx context.Context, sensorData CreateSensorDataDTO) (int, error) {
-	q := `INSERT INTO sensor_data(sensor_id, temperature, transparency, created_at, updated_at)
-		VALUES($1, $2, $3, $4, $5)
+	q := `INSERT INTO sensor_data(sensor_id, temperature, transparency, measurements, raw, x, y, z, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id`
 
-	t := time.Now()
+	t := r.clk.Now()
+	createdAt := sensorData.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = t
+	}
+	x, y, z := position(sensorData.Coords)
 
 	var id int
 
 	if err := r.client.QueryRowContext(ctx, q, sensorData.SensorID, sensorData.Temperature,
-		sensorData.Transparency, t, t).Scan(&id); err != nil {
-		r.logger.Errorf("Cannot create sensor data, due to error: %v", err)
-		return 0, apperror.ErrInternalSystem
+		sensorData.Transparency, measurementsJSON(sensorData.Measurements), measurementsJSON(sensorData.Raw),
+		x, y, z, createdAt, t).Scan(&id); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot create sensor data, due to error: %v", err)
+		return 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return id, nil
+}
+
+// CreateWithSpieces inserts the reading and its detected spieces in one transaction,
+// so a failed write leaves neither of them.
+func (r *repository) CreateWithSpieces(ctx context.Context, sensorData CreateSensorDataDTO, spieces []spiece.Spiece) (int, error) {
+	q := `INSERT INTO sensor_data(sensor_id, temperature, transparency, measurements, raw, x, y, z, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`
+
+	qDetectedSpiece := `INSERT INTO detected_spieces(spiece_id, sensor_data_id, created_at)
+		VALUES($1, $2, $3)`
+
+	t := r.clk.Now()
+	createdAt := sensorData.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = t
+	}
+	x, y, z := position(sensorData.Coords)
+
+	tx, err := r.client.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot begin transaction, due to error: %v", err)
+		return 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	var id int
+
+	if err := tx.QueryRowContext(ctx, q, sensorData.SensorID, sensorData.Temperature,
+		sensorData.Transparency, measurementsJSON(sensorData.Measurements), measurementsJSON(sensorData.Raw),
+		x, y, z, createdAt, t).Scan(&id); err != nil {
+		tx.Rollback()
+		r.logger.LWithContext(ctx).Errorf("Cannot create sensor data, due to error: %v", err)
+		return 0, createError(ctx, err)
+	}
+
+	for _, detectedSpiece := range spieces {
+		if _, err := tx.ExecContext(ctx, qDetectedSpiece, detectedSpiece.ID, id, createdAt); err != nil {
+			tx.Rollback()
+			r.logger.LWithContext(ctx).Errorf("Failed to detect spiece, due to error: %v", err)
+			return 0, createError(ctx, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot commit transaction, due to error: %v", err)
+		return 0, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
 	}
 
 	return id, nil
 }
 
+// createError tells a reading of a deleted sensor or spiece and a reading which breaks constraints
+// or has no partition, they fail the same way when retried, from other errors.
+func createError(ctx context.Context, err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code == "23503":
+			return apperror.ErrorWithMessage(apperror.ErrNotFound, "Sensor or spiece of the reading not found.")
+		case pqErr.Code.Class() == "22" || pqErr.Code.Class() == "23":
+			return apperror.ErrorWithMessage(apperror.ErrValidation, "Reading cannot be stored: "+pqErr.Message)
+		}
+	}
+
+	return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+}
+
+// AddDetectedSpiece copies created_at of the reading, so detection lands into the same partition.
 func (r *repository) AddDetectedSpiece(ctx context.Context, sensorDataID int, spiece spiece.Spiece) error {
-	q := `INSERT INTO detected_spieces(spiece_id, sensor_data_id)
-		VALUES($1, $2)`
+	q := `INSERT INTO detected_spieces(spiece_id, sensor_data_id, created_at)
+		SELECT $1, sd.id, sd.created_at FROM sensor_data sd WHERE sd.id=$2`
 
 	if _, err := r.client.ExecContext(ctx, q, spiece.ID, sensorDataID); err != nil {
-		r.logger.Errorf("Failed to detect spiece, due to error: %v", err)
-		return apperror.ErrInternalSystem
+		r.logger.LWithContext(ctx).Errorf("Failed to detect spiece, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
 	}
 
 	return nil
 }
+
+// position returns NULL coordinates for readings taken at the position of the sensor.
+func position(coords *sensor.Coordinates) (x, y, z interface{}) {
+	if coords == nil {
+		return nil, nil, nil
+	}
+	return coords.X, coords.Y, coords.Z
+}
+
+// measurementsJSON returns the jsonb value of measurements, readings without them keep NULL.
+func measurementsJSON(measurements map[string]float64) interface{} {
+	if len(measurements) == 0 {
+		return nil
+	}
+
+	value, err := json.Marshal(measurements)
+	if err != nil {
+		return nil
+	}
+	return string(value)
+}
@@ -9,6 +9,7 @@ import (
 
 type service struct {
 	sensorDataRepo ISensorDataRepository
+	broker         *broker
 	logger         *logging.Logger
 	cfg            *config.Config
 }
@@ -17,13 +18,14 @@ func NewService(sensorDataRepo ISensorDataRepository,
 	logger *logging.Logger, cfg *config.Config) *service {
 	return &service{
 		sensorDataRepo: sensorDataRepo,
+		broker:         newBroker(),
 		logger:         logger,
 		cfg:            cfg,
 	}
 }
 
 func (s *service) Create(ctx context.Context, sensorData ...CreateSensorDataDTO) ([]int, error) {
-	s.logger.Info("CREATE SENSOR DATA.")
+	s.logger.LWithContext(ctx).Debug("Create sensor data.")
 	ids := make([]int, 0)
 	for _, sd := range sensorData {
 		id, err := s.sensorDataRepo.Create(ctx, sd)
@@ -34,23 +36,93 @@ func (s *service) Create(ctx context.Context, sensorData ...CreateSensorDataDTO)
 		ids = append(ids, id)
 	}
 
-	s.logger.Info("Sensor data created successfully.")
+	s.logger.LWithContext(ctx).Debug("Sensor data created successfully.")
 	return ids, nil
 }
 
 func (s *service) AddDetectedSpieces(ctx context.Context, sensorDataID int, spieces ...spiece.Spiece) error {
-	s.logger.Info("ADD DETECTED SPIECES.")
+	s.logger.LWithContext(ctx).Debug("Add detected spieces.")
 	for _, spiece := range spieces {
 		if err := s.sensorDataRepo.AddDetectedSpiece(ctx, sensorDataID, spiece); err != nil {
 			return err
 		}
 	}
 
-	s.logger.Info("Detected spieces was added successfully.")
+	s.logger.LWithContext(ctx).Debug("Detected spieces was added successfully.")
 	return nil
 }
 
+// CreateWithSpieces creates the reading and its detected spieces at once, on error nothing is created.
+func (s *service) CreateWithSpieces(ctx context.Context, sensorData CreateSensorDataDTO, spieces ...spiece.Spiece) (int, error) {
+	s.logger.LWithContext(ctx).Debug("Create sensor data with detected spieces.")
+	id, err := s.sensorDataRepo.CreateWithSpieces(ctx, sensorData, spieces)
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.LWithContext(ctx).Debug("Sensor data created successfully.")
+	return id, nil
+}
+
 func (s *service) GetOneByID(ctx context.Context, id int, filters SensorDataFilters) (*SensorData, error) {
-	s.logger.Info("GET SENSOR DATA.")
+	s.logger.LWithContext(ctx).Debug("Get sensor data.")
 	return s.sensorDataRepo.FindOneByID(ctx, id, filters)
 }
+
+// GetAll returns calibrated readings unless filters.Raw is set.
+func (s *service) GetAll(ctx context.Context, filters SensorDataFilters) ([]SensorData, error) {
+	s.logger.LWithContext(ctx).Debug("Get sensor data.")
+	sensorData, err := s.sensorDataRepo.FindAll(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return uncalibrated(filters, sensorData), nil
+}
+
+// GetLatestForSensors returns up to filters.Limit latest readings of every sensor.
+func (s *service) GetLatestForSensors(ctx context.Context, sensorIDs []int, filters SensorDataFilters) ([]SensorData, error) {
+	s.logger.LWithContext(ctx).Debug("Get latest sensor data of sensors.")
+	sensorData, err := s.sensorDataRepo.FindLatestForSensors(ctx, sensorIDs, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return uncalibrated(filters, sensorData), nil
+}
+
+// Iterate calls fn for every reading without loading all of them into memory.
+func (s *service) Iterate(ctx context.Context, filters SensorDataFilters, fn func(SensorData) error) error {
+	s.logger.LWithContext(ctx).Debug("Iterate sensor data.")
+	if !filters.Raw {
+		return s.sensorDataRepo.Iterate(ctx, filters, fn)
+	}
+
+	return s.sensorDataRepo.Iterate(ctx, filters, func(sd SensorData) error {
+		return fn(sd.Uncalibrated())
+	})
+}
+
+// uncalibrated replaces calibrated values of readings with raw ones when filters ask for them.
+func uncalibrated(filters SensorDataFilters, sensorData []SensorData) []SensorData {
+	if !filters.Raw {
+		return sensorData
+	}
+
+	for i := range sensorData {
+		sensorData[i] = sensorData[i].Uncalibrated()
+	}
+	return sensorData
+}
+
+// Publish passes the written reading to subscribers of live readings.
+func (s *service) Publish(sensorData SensorData) {
+	s.broker.publish(sensorData)
+}
+
+// Subscribe returns published readings which match codename and group of filters
+// till ctx is done, then the channel is closed.
+func (s *service) Subscribe(ctx context.Context, filters SensorDataFilters) <-chan Delivery {
+	s.logger.LWithContext(ctx).Debug("Subscribe to sensor data.")
+	return s.broker.subscribe(ctx, filters)
+}
@@ -17,6 +17,11 @@ func (m *MockSensorDataRepository) FindAll(ctx context.Context, filters sensorda
 	return args.Get(0).([]sensordata.SensorData), args.Error(1)
 }
 
+func (m *MockSensorDataRepository) Iterate(ctx context.Context, filters sensordata.SensorDataFilters, fn func(sensordata.SensorData) error) error {
+	args := m.Called(ctx, filters, fn)
+	return args.Error(0)
+}
+
 func (m *MockSensorDataRepository) FindOneByID(ctx context.Context, id int, filters sensordata.SensorDataFilters) (*sensordata.SensorData, error) {
 	args := m.Called(ctx, id, filters)
 	if obj := args.Get(0); obj != nil {
@@ -34,3 +39,13 @@ func (m *MockSensorDataRepository) AddDetectedSpiece(ctx context.Context, sensor
 	args := m.Called(ctx, sensorDataID, spiece)
 	return args.Error(0)
 }
+
+func (m *MockSensorDataRepository) CreateWithSpieces(ctx context.Context, sensorData sensordata.CreateSensorDataDTO, spieces []spiece.Spiece) (int, error) {
+	args := m.Called(ctx, sensorData, spieces)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSensorDataRepository) FindLatestForSensors(ctx context.Context, sensorIDs []int, filters sensordata.SensorDataFilters) ([]sensordata.SensorData, error) {
+	args := m.Called(ctx, sensorIDs, filters)
+	return args.Get(0).([]sensordata.SensorData), args.Error(1)
+}
@@ -2,13 +2,18 @@ package sensordata
 
 import (
 	"context"
+	"errors"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/sensor"
 	sensordata "sensors-generator/internal/sensorData"
 	"sensors-generator/internal/spiece"
+	"sensors-generator/pkg/clock"
 	"sensors-generator/pkg/logging"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 )
 
 func Test_SensorDataRepository_FindOneByID(t *testing.T) {
@@ -23,15 +28,15 @@ func Test_SensorDataRepository_FindOneByID(t *testing.T) {
 
 	logging.Init("trace", true)
 
-	repo := sensordata.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+	repo := sensordata.NewPostgresqlRepository(db, clock.Real{}, logging.GetLogger(), nil)
 
 	mock.ExpectBegin()
 	mock.ExpectQuery("SELECT sd.id, sens.id, sd.temperature, sd.transparency, sd.created_at, sd.updated_at FROM sensor_data AS sd JOIN sensors sens ON sd.sensor_id=sens.id WHERE sd.id=?").
 		WithArgs(mockSensorDataID).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "sensor_id", "temperature", "transparency", "created_at", "updated_at"}).
-			AddRow(mockSensorDataID, mockSensorID, 25.5, 0.8, time.Now(), time.Now()))
+			AddRow(mockSensorDataID, mockSensorID, 25.5, 80, time.Now(), time.Now()))
 
-	mock.ExpectQuery("SELECT s.id, s.name, s.created_at, s.updated_at FROM sensor_data AS sd JOIN detected_spieces ds ON sd.id=ds.sensor_data_id JOIN spieces s ON ds.spiece_id=s.id WHERE sd.id=?").
+	mock.ExpectQuery("SELECT s.id, s.name, s.created_at, s.updated_at FROM sensor_data AS sd JOIN detected_spieces ds ON sd.id=ds.sensor_data_id AND sd.created_at=ds.created_at JOIN spieces s ON ds.spiece_id=s.id WHERE sd.id=?").
 		WithArgs(mockSensorDataID).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
 			AddRow(1, "Species1", time.Now(), time.Now()).
@@ -79,10 +84,14 @@ func Test_SensorDataRepository_Create(t *testing.T) {
 	}
 	defer db.Close()
 
-	repo := sensordata.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+	// Rows are stamped with time of the generator clock, not wall time.
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	repo := sensordata.NewPostgresqlRepository(db, clock.NewStepped(now), logging.GetLogger(), nil)
 
-	mock.ExpectQuery("INSERT INTO sensor_data\\(sensor_id, temperature, transparency, created_at, updated_at\\) VALUES\\(\\$1, \\$2, \\$3, \\$4, \\$5\\) RETURNING id").
-		WithArgs(mockSensorData.SensorID, mockSensorData.Temperature, mockSensorData.Transparency, sqlmock.AnyArg(), sqlmock.AnyArg()).
+	mock.ExpectQuery("INSERT INTO sensor_data\\(sensor_id, temperature, transparency, measurements, raw, x, y, z, created_at, updated_at\\) "+
+		"VALUES\\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10\\) RETURNING id").
+		WithArgs(mockSensorData.SensorID, mockSensorData.Temperature, mockSensorData.Transparency, nil, nil,
+			nil, nil, nil, now, now).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
 	id, err := repo.Create(context.Background(), mockSensorData)
@@ -100,6 +109,136 @@ func Test_SensorDataRepository_Create(t *testing.T) {
 	}
 }
 
+func Test_SensorDataRepository_CreateWithSpieces(t *testing.T) {
+	createdAt := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	mockSensorData := sensordata.CreateSensorDataDTO{
+		SensorID:     1,
+		Temperature:  25.5,
+		Transparency: 8,
+		Measurements: map[string]float64{"salinity": 34.5},
+		Raw:          map[string]float64{"temperature": 25.7},
+		Coords:       &sensor.Coordinates{X: 12.5, Y: 40, Z: 8},
+		CreatedAt:    createdAt,
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := sensordata.NewPostgresqlRepository(db, clock.Real{}, logging.GetLogger(), nil)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO sensor_data\\(sensor_id, temperature, transparency, measurements, raw, x, y, z, created_at, updated_at\\) "+
+		"VALUES\\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10\\) RETURNING id").
+		WithArgs(mockSensorData.SensorID, mockSensorData.Temperature, mockSensorData.Transparency, `{"salinity":34.5}`,
+			`{"temperature":25.7}`, 12.5, 40.0, 8.0, createdAt, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectExec("INSERT INTO detected_spieces\\(spiece_id, sensor_data_id, created_at\\) VALUES\\(\\$1, \\$2, \\$3\\)").
+		WithArgs(100, 7, createdAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO detected_spieces").
+		WithArgs(101, 7, createdAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	id, err := repo.CreateWithSpieces(context.Background(), mockSensorData, []spiece.Spiece{{ID: 100}, {ID: 101}})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if id != 7 {
+		t.Errorf("unexpected sensor data ID, got: %d, want: %d", id, 7)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_SensorDataRepository_CreateWithSpieces_Rollback(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := sensordata.NewPostgresqlRepository(db, clock.Real{}, logging.GetLogger(), nil)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO sensor_data").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectExec("INSERT INTO detected_spieces").
+		WillReturnError(&pq.Error{Code: "08006"})
+	mock.ExpectRollback()
+
+	id, err := repo.CreateWithSpieces(context.Background(), sensordata.CreateSensorDataDTO{SensorID: 1}, []spiece.Spiece{{ID: 100}})
+
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if id != 0 {
+		t.Errorf("unexpected sensor data ID, got: %d, want: %d", id, 0)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_SensorDataRepository_CreateWithSpieces_SensorNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := sensordata.NewPostgresqlRepository(db, clock.Real{}, logging.GetLogger(), nil)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO sensor_data").
+		WillReturnError(&pq.Error{Code: "23503"})
+	mock.ExpectRollback()
+
+	_, err = repo.CreateWithSpieces(context.Background(), sensordata.CreateSensorDataDTO{SensorID: 100}, nil)
+
+	if !errors.Is(err, apperror.ErrNotFound) {
+		t.Errorf("unexpected error, got: %v, want: %v", err, apperror.ErrNotFound)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_SensorDataRepository_CreateWithSpieces_NoPartition(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := sensordata.NewPostgresqlRepository(db, clock.Real{}, logging.GetLogger(), nil)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO sensor_data").
+		WillReturnError(&pq.Error{Code: "23514", Message: `no partition of relation "sensor_data" found for row`})
+	mock.ExpectRollback()
+
+	_, err = repo.CreateWithSpieces(context.Background(), sensordata.CreateSensorDataDTO{SensorID: 1}, nil)
+
+	if !errors.Is(err, apperror.ErrValidation) {
+		t.Errorf("unexpected error, got: %v, want: %v", err, apperror.ErrValidation)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func Test_SensorDataRepository_AddDetectedSpiece(t *testing.T) {
 	mockSensorDataID := 1
 	mockSpieceID := 100
@@ -110,9 +249,9 @@ func Test_SensorDataRepository_AddDetectedSpiece(t *testing.T) {
 	}
 	defer db.Close()
 
-	repo := sensordata.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+	repo := sensordata.NewPostgresqlRepository(db, clock.Real{}, logging.GetLogger(), nil)
 
-	mock.ExpectExec("INSERT INTO detected_spieces\\(spiece_id, sensor_data_id\\) VALUES\\(\\$1, \\$2\\)").
+	mock.ExpectExec("INSERT INTO detected_spieces\\(spiece_id, sensor_data_id, created_at\\) SELECT \\$1, sd\\.id, sd\\.created_at FROM sensor_data sd WHERE sd\\.id=\\$2").
 		WithArgs(mockSpieceID, mockSensorDataID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -126,3 +265,116 @@ func Test_SensorDataRepository_AddDetectedSpiece(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func Test_SensorDataRepository_FindAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := sensordata.NewPostgresqlRepository(db, clock.Real{}, logging.GetLogger(), nil)
+
+	filters := sensordata.SensorDataFilters{
+		GroupName: "alpha",
+		FromDate:  time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC),
+		Limit:     10,
+	}
+	createdAt := time.Date(2023, time.July, 1, 10, 0, 0, 0, time.UTC)
+
+	// Positions of readings fall back to the position of the sensor.
+	mock.ExpectQuery(`SELECT sd\.id, sens\.id, sg\.name, sens\.index,(.+)COALESCE\(sd\.x, sens\.x\), COALESCE\(sd\.y, sens\.y\)`+
+		`(.+)sd\.raw, EXISTS\(SELECT 1 FROM maintenance m(.+)FROM sensor_data AS sd`+
+		`(.+)WHERE sg\.name=\$1 AND sd\.created_at >= \$2(.+)ORDER BY sd\.created_at, sd\.id LIMIT \$3`).
+		WithArgs(filters.GroupName, filters.FromDate, filters.Limit).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sensor_id", "name", "index", "x", "y", "z",
+			"temperature", "transparency", "measurements", "raw", "maintenance", "created_at", "updated_at",
+			"spiece_ids", "spiece_names"}).
+			AddRow(1, 2, "alpha", 1, 1.5, 2.0, -3.0, 12.5, 80, `{"ph": 8.02}`, `{"temperature": 12.3}`, false,
+				createdAt, nil, "{1,2}", `{"Atlantic cod",Herring}`).
+			AddRow(2, 2, "alpha", 1, 1.5, 2.0, -3.0, 12.0, 81, nil, nil, true, createdAt, createdAt, "{}", "{}"))
+
+	sensorData, err := repo.FindAll(context.Background(), filters)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sensorData) != 2 {
+		t.Fatalf("expected 2 readings, got %d", len(sensorData))
+	}
+
+	if sensorData[0].CodeName.GroupName != "alpha" || sensorData[0].Coords.Z != -3.0 {
+		t.Errorf("unexpected sensor of reading: %+v", sensorData[0])
+	}
+
+	if len(sensorData[0].DetectedSpieces) != 2 || sensorData[0].DetectedSpieces[0].Name != "Atlantic cod" {
+		t.Errorf("unexpected detected spieces: %+v", sensorData[0].DetectedSpieces)
+	}
+
+	if len(sensorData[1].DetectedSpieces) != 0 {
+		t.Errorf("unexpected detected spieces: %+v", sensorData[1].DetectedSpieces)
+	}
+
+	if sensorData[0].Measurements["ph"] != 8.02 || sensorData[1].Measurements != nil {
+		t.Errorf("unexpected measurements: %v and %v", sensorData[0].Measurements, sensorData[1].Measurements)
+	}
+
+	if sensorData[0].Raw["temperature"] != 12.3 || sensorData[1].Raw != nil {
+		t.Errorf("unexpected raw values: %v and %v", sensorData[0].Raw, sensorData[1].Raw)
+	}
+
+	if sensorData[0].Maintenance || !sensorData[1].Maintenance {
+		t.Errorf("unexpected maintenance flags: %v and %v", sensorData[0].Maintenance, sensorData[1].Maintenance)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_SensorDataRepository_FindLatestForSensors(t *testing.T) {
+	mockFilters := sensordata.SensorDataFilters{
+		FromDate: time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC),
+		Limit:    2,
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logging.Init("trace", true)
+
+	repo := sensordata.NewPostgresqlRepository(db, clock.Real{}, logging.GetLogger(), nil)
+
+	mock.ExpectQuery(`FROM unnest\(\$1::INT\[\]\) AS ids\(sensor_id\)(.+)CROSS JOIN LATERAL `+
+		`\(SELECT (.+) FROM sensor_data(.+)WHERE sensor_id=ids\.sensor_id AND created_at >= \$2(.+)`+
+		`ORDER BY created_at DESC, id DESC LIMIT \$3\) sd`).
+		WithArgs(pq.Array([]int{1, 2}), mockFilters.FromDate, mockFilters.Limit).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sensor_id", "name", "index", "x", "y", "z",
+			"temperature", "transparency", "measurements", "raw", "maintenance", "created_at", "updated_at",
+			"spiece_ids", "spiece_names"}).
+			AddRow(11, 1, "alpha", 1, 1.0, 2.0, 3.0, 25.5, 80, nil, nil, false, time.Now(), nil, "{1}", "{Tuna}").
+			AddRow(10, 1, "alpha", 1, 1.0, 2.0, 3.0, 24.5, 80, nil, nil, false, time.Now(), nil, "{}", "{}").
+			AddRow(20, 2, "alpha", 2, 4.0, 5.0, 6.0, 20.0, 70, nil, nil, false, time.Now(), nil, "{}", "{}"))
+
+	readings, err := repo.FindLatestForSensors(context.Background(), []int{1, 2}, mockFilters)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(readings) != 3 {
+		t.Fatalf("unexpected number of readings, got: %d, want: %d", len(readings), 3)
+	}
+	if readings[0].SensorID != 1 || readings[2].SensorID != 2 {
+		t.Errorf("unexpected sensors of readings, got: %d and %d", readings[0].SensorID, readings[2].SensorID)
+	}
+	if len(readings[0].DetectedSpieces) != 1 || readings[0].DetectedSpieces[0].Name != "Tuna" {
+		t.Errorf("unexpected detected spieces: %v", readings[0].DetectedSpieces)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -2,6 +2,8 @@ package sensordata
 
 import (
 	"context"
+	"sensors-generator/internal/apperror"
+	"sensors-generator/internal/sensor"
 	sensordata "sensors-generator/internal/sensorData"
 	"sensors-generator/internal/spiece"
 	"sensors-generator/pkg/logging"
@@ -88,3 +90,150 @@ func TestGetOneByID(t *testing.T) {
 
 	repo.AssertExpectations(t)
 }
+
+func Test_SensorDataService_Subscribe(t *testing.T) {
+	logging.Init("trace", true)
+	service := sensordata.NewService(&MockSensorDataRepository{}, logging.GetLogger(), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	readings := service.Subscribe(ctx, sensordata.SensorDataFilters{GroupName: "alpha"})
+
+	alpha := sensordata.SensorData{ID: 1, CodeName: sensor.Codename{GroupName: "alpha", Index: 1}}
+	beta := sensordata.SensorData{ID: 2, CodeName: sensor.Codename{GroupName: "beta", Index: 1}}
+	service.Publish(beta)
+	service.Publish(alpha)
+
+	delivery := <-readings
+	assert.Equal(t, 1, delivery.SensorData.ID)
+	assert.Zero(t, delivery.Dropped)
+
+	cancel()
+	for range readings {
+		t.Fatal("no readings are expected after cancel")
+	}
+}
+
+func Test_SensorDataService_Subscribe_SlowSubscriberDropsReadings(t *testing.T) {
+	logging.Init("trace", true)
+	service := sensordata.NewService(&MockSensorDataRepository{}, logging.GetLogger(), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	readings := service.Subscribe(ctx, sensordata.SensorDataFilters{})
+
+	// Nobody reads, so readings over the buffer are dropped instead of blocking Publish.
+	for i := 1; i <= 300; i++ {
+		service.Publish(sensordata.SensorData{ID: i})
+	}
+
+	for len(readings) > 0 {
+		<-readings
+	}
+	service.Publish(sensordata.SensorData{ID: 301})
+
+	delivery := <-readings
+	assert.Equal(t, 301, delivery.SensorData.ID)
+	assert.Equal(t, int64(300-256), delivery.Dropped)
+}
+
+func Test_SensorDataService_CreateWithSpieces(t *testing.T) {
+	ctx := context.Background()
+	reading := sensordata.CreateSensorDataDTO{SensorID: 1, Temperature: 25.5, Transparency: 90}
+	spieces := []spiece.Spiece{{ID: 1, Name: "Species1"}}
+
+	t.Run("Success", func(t *testing.T) {
+		repo := &MockSensorDataRepository{}
+		repo.On("CreateWithSpieces", ctx, reading, spieces).Return(42, nil)
+
+		service := sensordata.NewService(repo, logging.GetLogger(), nil)
+		id, err := service.CreateWithSpieces(ctx, reading, spieces...)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 42, id)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		repo := &MockSensorDataRepository{}
+		repo.On("CreateWithSpieces", ctx, reading, spieces).Return(0, apperror.ErrInternalSystem)
+
+		service := sensordata.NewService(repo, logging.GetLogger(), nil)
+		id, err := service.CreateWithSpieces(ctx, reading, spieces...)
+
+		assert.ErrorIs(t, err, apperror.ErrInternalSystem)
+		assert.Equal(t, 0, id)
+		repo.AssertExpectations(t)
+	})
+}
+
+func Test_SensorDataService_GetAll_Raw(t *testing.T) {
+	reading := sensordata.SensorData{
+		ID:           1,
+		Temperature:  12.4,
+		Transparency: 80,
+		Measurements: map[string]float64{"salinity": 34.6, "ph": 8.05},
+		Raw:          map[string]float64{"temperature": 12.1, "transparency": 78, "salinity": 34.2},
+	}
+
+	repo := &MockSensorDataRepository{}
+	repo.On("FindAll", mock.Anything, mock.Anything).Return([]sensordata.SensorData{reading}, nil)
+	service := sensordata.NewService(repo, logging.GetLogger(), nil)
+
+	calibrated, err := service.GetAll(context.Background(), sensordata.SensorDataFilters{})
+	assert.NoError(t, err)
+	assert.Equal(t, reading, calibrated[0])
+
+	raw, err := service.GetAll(context.Background(), sensordata.SensorDataFilters{Raw: true})
+	assert.NoError(t, err)
+	assert.Equal(t, float32(12.1), raw[0].Temperature)
+	assert.Equal(t, uint8(78), raw[0].Transparency)
+	assert.Equal(t, map[string]float64{"salinity": 34.2, "ph": 8.05}, raw[0].Measurements)
+	assert.Nil(t, raw[0].Raw)
+	// Calibrated reading of the repository is not changed.
+	assert.Equal(t, 34.6, reading.Measurements["salinity"])
+}
+
+func Test_CreateSensorDataDTO_Calibrated(t *testing.T) {
+	calibratedAt := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	sens := &sensor.Sensor{
+		ID: 1,
+		Calibrations: []sensor.Calibration{
+			{Metric: "temperature", Offset: 1, Gain: 1, CalibratedAt: calibratedAt},
+			{Metric: "salinity", Offset: 0, Gain: 1.01, CalibratedAt: calibratedAt},
+			{Metric: "temperature", Offset: -0.5, Gain: 1, CalibratedAt: calibratedAt.Add(time.Hour)},
+			{Metric: "transparency", Offset: 0.6, Gain: 1, CalibratedAt: calibratedAt.Add(time.Hour)},
+		},
+	}
+	dto := sensordata.CreateSensorDataDTO{
+		SensorID:     1,
+		Temperature:  10,
+		Transparency: 80,
+		Measurements: map[string]float64{"salinity": 34, "ph": 8.1},
+	}
+
+	t.Run("BeforeCalibration", func(t *testing.T) {
+		dto.CreatedAt = calibratedAt.Add(-time.Minute)
+		assert.Equal(t, dto, dto.Calibrated(sens))
+	})
+
+	t.Run("FirstCalibration", func(t *testing.T) {
+		dto.CreatedAt = calibratedAt.Add(time.Minute)
+		calibrated := dto.Calibrated(sens)
+
+		assert.Equal(t, float32(11), calibrated.Temperature)
+		assert.Equal(t, uint8(80), calibrated.Transparency)
+		assert.InDelta(t, 34.34, calibrated.Measurements["salinity"], 1e-9)
+		assert.Equal(t, 8.1, calibrated.Measurements["ph"])
+		assert.Equal(t, map[string]float64{"temperature": 10, "salinity": 34}, calibrated.Raw)
+		assert.Equal(t, 34.0, dto.Measurements["salinity"])
+	})
+
+	t.Run("LatestCalibration", func(t *testing.T) {
+		dto.CreatedAt = calibratedAt.Add(2 * time.Hour)
+		calibrated := dto.Calibrated(sens)
+
+		assert.Equal(t, float32(9.5), calibrated.Temperature)
+		assert.Equal(t, uint8(81), calibrated.Transparency)
+		assert.Equal(t, 80.0, calibrated.Raw["transparency"])
+	})
+}
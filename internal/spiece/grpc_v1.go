@@ -0,0 +1,43 @@
+package spiece
+
+import (
+	"context"
+	sensorgenv1 "sensors-generator/pkg/api/sensorgen/v1"
+	"sensors-generator/pkg/logging"
+
+	"google.golang.org/grpc"
+)
+
+type grpcServer struct {
+	sensorgenv1.UnimplementedSpieceServiceServer
+	spieceService ISpiecesService
+	logger        *logging.Logger
+}
+
+func NewGRPCServer(spieceService ISpiecesService, logger *logging.Logger) *grpcServer {
+	return &grpcServer{
+		spieceService: spieceService,
+		logger:        logger,
+	}
+}
+
+func (s *grpcServer) Register(server grpc.ServiceRegistrar) {
+	sensorgenv1.RegisterSpieceServiceServer(server, s)
+}
+
+func (s *grpcServer) ListSpieces(ctx context.Context, req *sensorgenv1.ListSpiecesRequest) (*sensorgenv1.ListSpiecesResponse, error) {
+	spieces, err := s.spieceService.GetAll(ctx, SpieceFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &sensorgenv1.ListSpiecesResponse{Spieces: ToProto(spieces)}, nil
+}
+
+func ToProto(spieces []Spiece) []*sensorgenv1.Spiece {
+	result := make([]*sensorgenv1.Spiece, 0, len(spieces))
+	for _, spiece := range spieces {
+		result = append(result, &sensorgenv1.Spiece{Id: int32(spiece.ID), Name: spiece.Name})
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package spiece
+
+import "math"
+
+// temperatureMargin is how many degrees outside the range the spiece is still met, though rarely.
+const temperatureMargin = 1.5
+
+// Suitability returns from 0 to 1 how well conditions of a reading suit the spiece.
+// It is 1 inside the depth band and temperature range with enough transparency,
+// outside them it falls off smoothly, so edges of the habitat are not sharp.
+func (h Habitat) Suitability(depth float64, temperature float32, transparency uint8) float64 {
+	// Wide depth bands have wide margins, a spiece living from 0 to 200 meters is met a bit deeper too.
+	depthMargin := math.Max(1, (h.MaxDepth-h.MinDepth)/10)
+
+	suitability := falloff(depth, h.MinDepth, h.MaxDepth, depthMargin) *
+		falloff(float64(temperature), float64(h.MinTemperature), float64(h.MaxTemperature), temperatureMargin)
+
+	if transparency < h.MinTransparency {
+		suitability *= float64(transparency) / float64(h.MinTransparency)
+	}
+
+	return suitability
+}
+
+// falloff is 1 inside [min, max] and a gaussian of the distance to the range outside it.
+func falloff(value, min, max, margin float64) float64 {
+	var distance float64
+	switch {
+	case value < min:
+		distance = min - value
+	case value > max:
+		distance = value - max
+	default:
+		return 1
+	}
+
+	return math.Exp(-(distance / margin) * (distance / margin))
+}
@@ -6,4 +6,5 @@ type ISpiecesRepository interface {
 	FindAll(ctx context.Context, filters SpieceFilters) ([]Spiece, error)
 	FindOneByID(ctx context.Context, id int, filters SpieceFilters) (*Spiece, error)
 	Create(ctx context.Context, spiece CreateSpieceDTO) error
+	FindVersion(ctx context.Context) (Version, error)
 }
@@ -5,4 +5,5 @@ import "context"
 type ISpiecesService interface {
 	GetAll(ctx context.Context, filters SpieceFilters) ([]Spiece, error)
 	Create(ctx context.Context, spieces ...CreateSpieceDTO) error
+	GetVersion(ctx context.Context) (Version, error)
 }
@@ -3,17 +3,46 @@ package spiece
 import "time"
 
 type Spiece struct {
-	ID        int       `json:"id"`
-	Name      string    `json:"name"`
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Habitat
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// Habitat describes where the spiece lives and how often it is met there.
+// Readings keep only id and name of detected spieces, so habitat fields are omitted from them.
+type Habitat struct {
+	// MinDepth and MaxDepth bound the depth band in meters, it is compared with Z of the sensor.
+	MinDepth float64 `json:"min_depth,omitempty"`
+	MaxDepth float64 `json:"max_depth,omitempty"`
+	// MinTemperature and MaxTemperature bound the temperatures the spiece tolerates.
+	MinTemperature float32 `json:"min_temperature,omitempty"`
+	MaxTemperature float32 `json:"max_temperature,omitempty"`
+	// MinTransparency is the lowest transparency the spiece tolerates.
+	MinTransparency uint8 `json:"min_transparency,omitempty"`
+	// Abundance is the mean number of schools met by one reading in the best conditions.
+	Abundance float64 `json:"abundance,omitempty"`
+	// SchoolSize is the largest number of fish detected in one school, 1 for solitary spieces.
+	SchoolSize int `json:"school_size,omitempty"`
+}
+
 type CreateSpieceDTO struct {
 	Name string `json:"name"`
+	Habitat
 }
 
 type SpieceFilters struct {
 	N         int
 	GroupName string
 }
+
+// Version changes when spieces are created or updated, the generator reloads cached spieces then.
+type Version struct {
+	Count     int
+	UpdatedAt time.Time
+}
+
+func (v Version) Equal(other Version) bool {
+	return v.Count == other.Count && v.UpdatedAt.Equal(other.UpdatedAt)
+}
@@ -32,7 +32,8 @@ func (r *repository) FindAll(ctx context.Context, filters SpieceFilters) ([]Spie
 	argsCounter := 1
 
 	if len(filters.GroupName) > 0 {
-		q += `SELECT s.id, s.name, s.created_at, s.updated_at FROM sensors as sens
+		q += `SELECT s.id, s.name, s.min_depth, s.max_depth, s.min_temperature, s.max_temperature,
+				s.min_transparency, s.abundance, s.school_size, s.created_at, s.updated_at FROM sensors as sens
 			JOIN sensor_data sd ON sens.id=sd.sensor_id
 			JOIN detected_spieces ds ON sd.id=ds.sensor_data_id
 			JOIN spieces s ON s.id=ds.spiece_id
@@ -41,7 +42,8 @@ func (r *repository) FindAll(ctx context.Context, filters SpieceFilters) ([]Spie
 		args = append(args, filters.GroupName)
 		argsCounter++
 	} else {
-		q += `SELECT id, name, created_at, updated_at FROM spieces`
+		q += `SELECT id, name, min_depth, max_depth, min_temperature, max_temperature,
+				min_transparency, abundance, school_size, created_at, updated_at FROM spieces`
 	}
 
 	if filters.N > 0 {
@@ -52,49 +54,76 @@ func (r *repository) FindAll(ctx context.Context, filters SpieceFilters) ([]Spie
 
 	var spieces []Spiece
 
-	rows, err := r.client.Query(q, args...)
-	defer rows.Close()
+	rows, err := r.client.QueryContext(ctx, q, args...)
 	if err != nil {
-		r.logger.Errorf("Cannot find spieces, due to error: %v", err)
-		return nil, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Spieces not found.")
+		r.logger.LWithContext(ctx).Errorf("Cannot find spieces, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Spieces not found."))
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var spiece Spiece
-		if err := rows.Scan(&spiece.ID, &spiece.Name,
-			&spiece.CreatedAt, &spiece.UpdatedAt); err != nil {
-			r.logger.Errorf("Cannot scan spieces row.")
-			return nil, apperror.ErrInternalSystem
+		if err := rows.Scan(scanDests(&spiece)...); err != nil {
+			r.logger.LWithContext(ctx).Errorf("Cannot scan spieces row.")
+			return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
 		}
 		spieces = append(spieces, spiece)
 	}
 
+	if err := rows.Err(); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Failed to iterate rows, due to error: %v", err)
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
 	return spieces, nil
 }
 
 func (r *repository) FindOneByID(ctx context.Context, id int, filters SpieceFilters) (*Spiece, error) {
-	q := `SELECT id, name, created_at, updated_at FROM spieces WHERE id=$1`
+	q := `SELECT id, name, min_depth, max_depth, min_temperature, max_temperature,
+			min_transparency, abundance, school_size, created_at, updated_at FROM spieces WHERE id=$1`
 	var spiece Spiece
 
-	if err := r.client.QueryRow(q, id).Scan(&spiece.ID, &spiece.Name,
-		&spiece.CreatedAt, &spiece.UpdatedAt); err != nil {
-		r.logger.Errorf("Cannot scan spieces row.")
-		return nil, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Spiece not found.")
+	if err := r.client.QueryRowContext(ctx, q, id).Scan(scanDests(&spiece)...); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot scan spieces row.")
+		return nil, apperror.FromDBError(ctx, err, apperror.ErrorWithMessage(apperror.ErrBadRequest, "Spiece not found."))
 	}
 
 	return &spiece, nil
 }
 
 func (r *repository) Create(ctx context.Context, spiece CreateSpieceDTO) error {
-	q := `INSERT INTO spieces(name, created_at, updated_at)
-		VALUES($1, $2, $3)`
+	q := `INSERT INTO spieces(name, min_depth, max_depth, min_temperature, max_temperature,
+			min_transparency, abundance, school_size, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
 	t := time.Now()
+	h := spiece.Habitat
 
-	if _, err := r.client.ExecContext(ctx, q, spiece.Name, t, t); err != nil {
-		r.logger.Errorf("Cannot create spiece, due to error: %v", err)
-		return apperror.ErrInternalSystem
+	if _, err := r.client.ExecContext(ctx, q, spiece.Name, h.MinDepth, h.MaxDepth, h.MinTemperature,
+		h.MaxTemperature, h.MinTransparency, h.Abundance, h.SchoolSize, t, t); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot create spiece, due to error: %v", err)
+		return apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
 	}
 
 	return nil
 }
+
+// FindVersion is cheap to call often, unlike FindAll.
+func (r *repository) FindVersion(ctx context.Context) (Version, error) {
+	q := `SELECT COUNT(*), COALESCE(MAX(GREATEST(created_at, updated_at)), 'epoch') FROM spieces`
+	var version Version
+
+	if err := r.client.QueryRowContext(ctx, q).Scan(&version.Count, &version.UpdatedAt); err != nil {
+		r.logger.LWithContext(ctx).Errorf("Cannot get version of spieces, due to error: %v", err)
+		return Version{}, apperror.FromDBError(ctx, err, apperror.ErrInternalSystem)
+	}
+
+	return version, nil
+}
+
+// scanDests returns destinations of the spiece columns in the order they are selected.
+func scanDests(spiece *Spiece) []interface{} {
+	return []interface{}{&spiece.ID, &spiece.Name, &spiece.MinDepth, &spiece.MaxDepth,
+		&spiece.MinTemperature, &spiece.MaxTemperature, &spiece.MinTransparency,
+		&spiece.Abundance, &spiece.SchoolSize, &spiece.CreatedAt, &spiece.UpdatedAt}
+}
@@ -22,18 +22,22 @@ func NewService(spieceRepo ISpiecesRepository,
 }
 
 func (s *service) GetAll(ctx context.Context, filters SpieceFilters) ([]Spiece, error) {
-	s.logger.Info("GET SPIECES.")
+	s.logger.LWithContext(ctx).Debug("Get spieces.")
 	return s.spieceRepo.FindAll(ctx, filters)
 }
 
 func (s *service) Create(ctx context.Context, spieces ...CreateSpieceDTO) error {
-	s.logger.Info("CREATE SPIECES.")
+	s.logger.LWithContext(ctx).Debug("Create spieces.")
 	for _, spiece := range spieces {
 		if err := s.spieceRepo.Create(ctx, spiece); err != nil {
 			return err
 		}
 	}
 
-	s.logger.Info("Spieces was created successfully.")
+	s.logger.LWithContext(ctx).Info("Spieces was created successfully.")
 	return nil
 }
+
+func (s *service) GetVersion(ctx context.Context) (Version, error) {
+	return s.spieceRepo.FindVersion(ctx)
+}
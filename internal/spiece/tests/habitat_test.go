@@ -0,0 +1,20 @@
+package spiece
+
+import (
+	"sensors-generator/internal/spiece"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Habitat_Suitability(t *testing.T) {
+	herring := spiece.Habitat{MinDepth: 0, MaxDepth: 200, MinTemperature: 1, MaxTemperature: 18, MinTransparency: 20}
+
+	assert.Equal(t, 1.0, herring.Suitability(10, 12, 60))
+	// Edges of the habitat are not sharp.
+	assert.InDelta(t, 0.64, herring.Suitability(10, 19, 60), 0.01)
+	assert.Less(t, herring.Suitability(10, 25, 60), 0.001)
+	assert.Less(t, herring.Suitability(300, 12, 60), 0.01)
+	assert.InDelta(t, 0.5, herring.Suitability(10, 12, 10), 0.001)
+	assert.Equal(t, 0.0, herring.Suitability(10, 12, 0))
+}
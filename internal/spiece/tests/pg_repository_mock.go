@@ -28,3 +28,9 @@ func (m *MockSpieceRepository) Create(ctx context.Context, spiece spiece.CreateS
 
 	return args.Error(0)
 }
+
+func (m *MockSpieceRepository) FindVersion(ctx context.Context) (spiece.Version, error) {
+	args := m.Called(ctx)
+
+	return args.Get(0).(spiece.Version), args.Error(1)
+}
@@ -22,11 +22,12 @@ func Test_SpieceRepository_FindAll(t *testing.T) {
 	repo := spiece.NewPostgresqlRepository(db, logging.GetLogger(), nil)
 
 	groupName := "alpha"
-	mockRows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
-		AddRow(1, "Species1", time.Now(), time.Now()).
-		AddRow(2, "Species2", time.Now(), time.Now())
+	mockRows := sqlmock.NewRows([]string{"id", "name", "min_depth", "max_depth", "min_temperature", "max_temperature",
+		"min_transparency", "abundance", "school_size", "created_at", "updated_at"}).
+		AddRow(1, "Species1", 0, 200, 2, 18, 10, 1.5, 30, time.Now(), time.Now()).
+		AddRow(2, "Species2", 10, 600, 0, 20, 0, 0.8, 5, time.Now(), time.Now())
 
-	mock.ExpectQuery("SELECT s.id, s.name, s.created_at, s.updated_at FROM sensors as sens JOIN sensor_data sd ON sens.id=sd.sensor_id JOIN detected_spieces ds ON sd.id=ds.sensor_data_id JOIN spieces s ON s.id=ds.spiece_id WHERE sens.group_name=?").
+	mock.ExpectQuery("SELECT s.id, s.name, s.min_depth, s.max_depth, s.min_temperature, s.max_temperature, s.min_transparency, s.abundance, s.school_size, s.created_at, s.updated_at FROM sensors as sens JOIN sensor_data sd ON sens.id=sd.sensor_id JOIN detected_spieces ds ON sd.id=ds.sensor_data_id JOIN spieces s ON s.id=ds.spiece_id WHERE sens.group_name=?").
 		WithArgs(groupName).
 		WillReturnRows(mockRows)
 
@@ -61,10 +62,11 @@ func Test_SpieceRepository_FindOneByID(t *testing.T) {
 	repo := spiece.NewPostgresqlRepository(db, logging.GetLogger(), nil)
 
 	spieceID := 1
-	mockRows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
-		AddRow(spieceID, "Species1", time.Now(), time.Now())
+	mockRows := sqlmock.NewRows([]string{"id", "name", "min_depth", "max_depth", "min_temperature", "max_temperature",
+		"min_transparency", "abundance", "school_size", "created_at", "updated_at"}).
+		AddRow(spieceID, "Species1", 0, 200, 2, 18, 10, 1.5, 30, time.Now(), time.Now())
 
-	mock.ExpectQuery("SELECT id, name, created_at, updated_at FROM spieces WHERE id=?").
+	mock.ExpectQuery("SELECT id, name, min_depth, max_depth, min_temperature, max_temperature, min_transparency, abundance, school_size, created_at, updated_at FROM spieces WHERE id=?").
 		WithArgs(spieceID).
 		WillReturnRows(mockRows)
 
@@ -79,6 +81,9 @@ func Test_SpieceRepository_FindOneByID(t *testing.T) {
 	if spiece.Name != "Species1" {
 		t.Errorf("unexpected species name, got: %s, want: %s", spiece.Name, "Species1")
 	}
+	if spiece.MaxDepth != 200 || spiece.SchoolSize != 30 {
+		t.Errorf("unexpected habitat, got: %+v", spiece.Habitat)
+	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -95,12 +100,15 @@ func Test_SpieceRepository_Create(t *testing.T) {
 	repo := spiece.NewPostgresqlRepository(db, logging.GetLogger(), nil)
 
 	spieceName := "Species1"
+	habitat := spiece.Habitat{MinDepth: 0, MaxDepth: 200, MinTemperature: 2, MaxTemperature: 18,
+		MinTransparency: 10, Abundance: 1.5, SchoolSize: 30}
 
 	mock.ExpectExec("INSERT INTO spieces").
-		WithArgs(spieceName, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(spieceName, 0.0, 200.0, float32(2), float32(18), uint8(10), 1.5, 30,
+			sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = repo.Create(context.Background(), spiece.CreateSpieceDTO{Name: spieceName})
+	err = repo.Create(context.Background(), spiece.CreateSpieceDTO{Name: spieceName, Habitat: habitat})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -109,3 +117,30 @@ func Test_SpieceRepository_Create(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func Test_SpieceRepository_FindVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := spiece.NewPostgresqlRepository(db, logging.GetLogger(), nil)
+
+	updatedAt := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\), COALESCE\\(MAX\\(GREATEST\\(created_at, updated_at\\)\\), 'epoch'\\) FROM spieces").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "updated_at"}).AddRow(20, updatedAt))
+
+	version, err := repo.FindVersion(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !version.Equal(spiece.Version{Count: 20, UpdatedAt: updatedAt}) {
+		t.Errorf("unexpected version, got: %+v", version)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS measurements_daily;
+DROP TABLE IF EXISTS measurements_hourly;
+
+ALTER TABLE sensor_data
+    DROP COLUMN IF EXISTS measurements;
+
+ALTER TABLE sensors
+    DROP COLUMN IF EXISTS measurements;
@@ -0,0 +1,38 @@
+-- Sensors declare measurements they carry. Temperature and transparency keep their columns,
+-- other measurements of a reading are stored in sensor_data.measurements by name.
+ALTER TABLE sensors
+    ADD COLUMN IF NOT EXISTS measurements TEXT[] NOT NULL DEFAULT '{temperature,transparency}';
+
+ALTER TABLE sensor_data
+    ADD COLUMN IF NOT EXISTS measurements JSONB;
+
+-- Rollups of other measurements, one row per metric.
+CREATE TABLE IF NOT EXISTS measurements_hourly
+(
+    sensor_id INT NOT NULL,
+    bucket TIMESTAMPTZ NOT NULL,
+    metric VARCHAR(32) NOT NULL,
+    value_min FLOAT NOT NULL,
+    value_max FLOAT NOT NULL,
+    value_avg FLOAT NOT NULL,
+    readings_count INT NOT NULL,
+    PRIMARY KEY (sensor_id, metric, bucket),
+    CONSTRAINT fk_sensor
+        FOREIGN KEY(sensor_id)
+        REFERENCES sensors(id)
+);
+
+CREATE TABLE IF NOT EXISTS measurements_daily
+(
+    sensor_id INT NOT NULL,
+    bucket TIMESTAMPTZ NOT NULL,
+    metric VARCHAR(32) NOT NULL,
+    value_min FLOAT NOT NULL,
+    value_max FLOAT NOT NULL,
+    value_avg FLOAT NOT NULL,
+    readings_count INT NOT NULL,
+    PRIMARY KEY (sensor_id, metric, bucket),
+    CONSTRAINT fk_sensor
+        FOREIGN KEY(sensor_id)
+        REFERENCES sensors(id)
+);
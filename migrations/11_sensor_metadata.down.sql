@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS maintenance;
+DROP TABLE IF EXISTS calibrations;
+
+ALTER TABLE sensor_data
+    DROP COLUMN IF EXISTS raw;
+
+ALTER TABLE sensors
+    DROP COLUMN IF EXISTS installed_at,
+    DROP COLUMN IF EXISTS firmware,
+    DROP COLUMN IF EXISTS model;
@@ -0,0 +1,51 @@
+-- Hardware of a sensor, all of it is optional.
+ALTER TABLE sensors
+    ADD COLUMN IF NOT EXISTS model VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS firmware VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS installed_at TIMESTAMPTZ;
+
+-- Values of a reading before calibration by measurement name, NULL when no calibration was applied.
+ALTER TABLE sensor_data
+    ADD COLUMN IF NOT EXISTS raw JSONB;
+
+-- A calibration applies to readings from calibrated_at till the next calibration of the same metric,
+-- calibrated value is raw * gain + value_offset.
+CREATE TABLE IF NOT EXISTS calibrations
+(
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    sensor_id INT NOT NULL,
+    metric VARCHAR(32) NOT NULL,
+    value_offset DOUBLE PRECISION NOT NULL DEFAULT 0,
+    gain DOUBLE PRECISION NOT NULL DEFAULT 1,
+    calibrated_at TIMESTAMPTZ NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_sensor
+        FOREIGN KEY(sensor_id)
+        REFERENCES sensors(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS calibrations_sensor_idx ON calibrations (sensor_id, calibrated_at);
+
+-- Readings taken from starts_at till ends_at are flagged.
+CREATE TABLE IF NOT EXISTS maintenance
+(
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    sensor_id INT NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_sensor
+        FOREIGN KEY(sensor_id)
+        REFERENCES sensors(id)
+        ON DELETE CASCADE,
+    CONSTRAINT chk_kind
+        CHECK (kind IN ('inspection', 'cleaning', 'repair', 'replacement', 'firmware_update', 'calibration')),
+    CONSTRAINT chk_window
+        CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX IF NOT EXISTS maintenance_sensor_idx ON maintenance (sensor_id, starts_at);
@@ -0,0 +1,7 @@
+ALTER TABLE sensor_data
+    DROP COLUMN IF EXISTS z,
+    DROP COLUMN IF EXISTS y,
+    DROP COLUMN IF EXISTS x;
+
+ALTER TABLE sensors
+    DROP COLUMN IF EXISTS trajectory;
@@ -0,0 +1,9 @@
+-- Trajectory of a mobile sensor, NULL for static sensors which stay at x, y, z.
+ALTER TABLE sensors
+    ADD COLUMN IF NOT EXISTS trajectory JSONB;
+
+-- Position of the sensor when the reading was taken, NULL means the position of the sensor (x, y, z of sensors).
+ALTER TABLE sensor_data
+    ADD COLUMN IF NOT EXISTS x FLOAT,
+    ADD COLUMN IF NOT EXISTS y FLOAT,
+    ADD COLUMN IF NOT EXISTS z FLOAT;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS detected_spieces;
+DROP TABLE IF EXISTS sensor_data;
+DROP TABLE IF EXISTS sensors;
+DROP TABLE IF EXISTS sensor_groups;
+DROP TABLE IF EXISTS spieces;
@@ -1,5 +1,3 @@
-BEGIN;
-
 CREATE TABLE IF NOT EXISTS spieces
 (
     id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
@@ -54,5 +52,3 @@ CREATE TABLE IF NOT EXISTS detected_spieces
         FOREIGN KEY(spiece_id)
         REFERENCES spieces(id)
 );
-
-END;
\ No newline at end of file
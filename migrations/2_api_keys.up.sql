@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    role VARCHAR(32) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT (now() AT TIME ZONE 'utc-3'),
+    revoked_at TIMESTAMPTZ,
+    CONSTRAINT chk_role
+        CHECK (role IN ('reader', 'operator', 'admin'))
+);
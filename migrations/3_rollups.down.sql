@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS detected_spieces_sensor_data_id_idx;
+DROP INDEX IF EXISTS sensor_data_created_at_idx;
+DROP TABLE IF EXISTS rollup_state;
+DROP TABLE IF EXISTS detected_spieces_daily;
+DROP TABLE IF EXISTS detected_spieces_hourly;
+DROP TABLE IF EXISTS sensor_data_daily;
+DROP TABLE IF EXISTS sensor_data_hourly;
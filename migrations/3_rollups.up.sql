@@ -0,0 +1,74 @@
+CREATE TABLE IF NOT EXISTS sensor_data_hourly
+(
+    sensor_id INT NOT NULL,
+    bucket TIMESTAMPTZ NOT NULL,
+    temperature_min FLOAT NOT NULL,
+    temperature_max FLOAT NOT NULL,
+    temperature_avg FLOAT NOT NULL,
+    transparency_min INT NOT NULL,
+    transparency_max INT NOT NULL,
+    transparency_avg FLOAT NOT NULL,
+    readings_count INT NOT NULL,
+    PRIMARY KEY (sensor_id, bucket),
+    CONSTRAINT fk_sensor
+        FOREIGN KEY(sensor_id)
+        REFERENCES sensors(id)
+);
+
+CREATE TABLE IF NOT EXISTS sensor_data_daily
+(
+    sensor_id INT NOT NULL,
+    bucket TIMESTAMPTZ NOT NULL,
+    temperature_min FLOAT NOT NULL,
+    temperature_max FLOAT NOT NULL,
+    temperature_avg FLOAT NOT NULL,
+    transparency_min INT NOT NULL,
+    transparency_max INT NOT NULL,
+    transparency_avg FLOAT NOT NULL,
+    readings_count INT NOT NULL,
+    PRIMARY KEY (sensor_id, bucket),
+    CONSTRAINT fk_sensor
+        FOREIGN KEY(sensor_id)
+        REFERENCES sensors(id)
+);
+
+CREATE TABLE IF NOT EXISTS detected_spieces_hourly
+(
+    group_id INT NOT NULL,
+    spiece_id INT NOT NULL,
+    bucket TIMESTAMPTZ NOT NULL,
+    detections_count INT NOT NULL,
+    PRIMARY KEY (group_id, spiece_id, bucket),
+    CONSTRAINT fk_group
+        FOREIGN KEY(group_id)
+        REFERENCES sensor_groups(id),
+    CONSTRAINT fk_spiece
+        FOREIGN KEY(spiece_id)
+        REFERENCES spieces(id)
+);
+
+CREATE TABLE IF NOT EXISTS detected_spieces_daily
+(
+    group_id INT NOT NULL,
+    spiece_id INT NOT NULL,
+    bucket TIMESTAMPTZ NOT NULL,
+    detections_count INT NOT NULL,
+    PRIMARY KEY (group_id, spiece_id, bucket),
+    CONSTRAINT fk_group
+        FOREIGN KEY(group_id)
+        REFERENCES sensor_groups(id),
+    CONSTRAINT fk_spiece
+        FOREIGN KEY(spiece_id)
+        REFERENCES spieces(id)
+);
+
+-- Raw data older than rolled_up_till is already included into the rollups of the level.
+CREATE TABLE IF NOT EXISTS rollup_state
+(
+    name VARCHAR(32) PRIMARY KEY,
+    rolled_up_till TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS sensor_data_created_at_idx ON sensor_data(created_at);
+CREATE INDEX IF NOT EXISTS detected_spieces_sensor_data_id_idx ON detected_spieces(sensor_data_id);
@@ -0,0 +1,48 @@
+ALTER TABLE sensor_data RENAME TO sensor_data_partitioned;
+ALTER TABLE detected_spieces RENAME TO detected_spieces_partitioned;
+ALTER SEQUENCE sensor_data_id_seq RENAME TO sensor_data_partitioned_id_seq;
+ALTER SEQUENCE detected_spieces_id_seq RENAME TO detected_spieces_partitioned_id_seq;
+ALTER INDEX sensor_data_pkey RENAME TO sensor_data_partitioned_pkey;
+ALTER INDEX detected_spieces_pkey RENAME TO detected_spieces_partitioned_pkey;
+ALTER INDEX sensor_data_created_at_idx RENAME TO sensor_data_partitioned_created_at_idx;
+ALTER INDEX detected_spieces_sensor_data_id_idx RENAME TO detected_spieces_partitioned_sensor_data_id_idx;
+
+CREATE TABLE sensor_data
+(
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    sensor_id INT NOT NULL,
+    temperature FLOAT NOT NULL,
+    transparency INT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT (now() AT TIME ZONE 'utc-3'),
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_sensor
+        FOREIGN KEY(sensor_id)
+        REFERENCES sensors(id)
+);
+
+CREATE TABLE detected_spieces
+(
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    spiece_id INT NOT NULL,
+    sensor_data_id INT NOT NULL,
+    CONSTRAINT fk_spiece
+        FOREIGN KEY(spiece_id)
+        REFERENCES spieces(id)
+);
+
+INSERT INTO sensor_data(id, sensor_id, temperature, transparency, created_at, updated_at)
+OVERRIDING SYSTEM VALUE
+SELECT id, sensor_id, temperature, transparency, created_at, updated_at FROM sensor_data_partitioned;
+
+INSERT INTO detected_spieces(id, spiece_id, sensor_data_id)
+OVERRIDING SYSTEM VALUE
+SELECT id, spiece_id, sensor_data_id FROM detected_spieces_partitioned;
+
+SELECT setval(pg_get_serial_sequence('sensor_data', 'id'), COALESCE((SELECT MAX(id) FROM sensor_data), 0) + 1, false);
+SELECT setval(pg_get_serial_sequence('detected_spieces', 'id'), COALESCE((SELECT MAX(id) FROM detected_spieces), 0) + 1, false);
+
+DROP TABLE detected_spieces_partitioned;
+DROP TABLE sensor_data_partitioned;
+
+CREATE INDEX sensor_data_created_at_idx ON sensor_data(created_at);
+CREATE INDEX detected_spieces_sensor_data_id_idx ON detected_spieces(sensor_data_id);
@@ -0,0 +1,94 @@
+-- Partition bounds and names are calculated in UTC, so they match partitions created by the maintenance job.
+SET LOCAL timezone = 'UTC';
+
+ALTER TABLE sensor_data RENAME TO sensor_data_legacy;
+ALTER TABLE detected_spieces RENAME TO detected_spieces_legacy;
+
+CREATE TABLE sensor_data
+(
+    id INT NOT NULL,
+    sensor_id INT NOT NULL,
+    temperature FLOAT NOT NULL,
+    transparency INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_sensor
+        FOREIGN KEY(sensor_id)
+        REFERENCES sensors(id)
+) PARTITION BY RANGE (created_at);
+
+-- created_at of the reading is copied, so detections are partitioned and dropped together with readings.
+CREATE TABLE detected_spieces
+(
+    id INT NOT NULL,
+    spiece_id INT NOT NULL,
+    sensor_data_id INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_spiece
+        FOREIGN KEY(spiece_id)
+        REFERENCES spieces(id)
+) PARTITION BY RANGE (created_at);
+
+DO $$
+DECLARE
+    month_start TIMESTAMPTZ;
+    month_name TEXT;
+BEGIN
+    FOR month_start IN
+        SELECT generate_series(
+            date_trunc('month', LEAST(COALESCE((SELECT MIN(created_at) FROM sensor_data_legacy), now()), now())),
+            date_trunc('month', GREATEST(COALESCE((SELECT MAX(created_at) FROM sensor_data_legacy), now()), now())) + INTERVAL '1 month',
+            INTERVAL '1 month')
+    LOOP
+        month_name := to_char(month_start, 'YYYYMM');
+        EXECUTE format('CREATE TABLE %I PARTITION OF sensor_data FOR VALUES FROM (%L) TO (%L)',
+            'sensor_data_p' || month_name, month_start, month_start + INTERVAL '1 month');
+        EXECUTE format('CREATE TABLE %I PARTITION OF detected_spieces FOR VALUES FROM (%L) TO (%L)',
+            'detected_spieces_p' || month_name, month_start, month_start + INTERVAL '1 month');
+    END LOOP;
+END $$;
+
+INSERT INTO sensor_data(id, sensor_id, temperature, transparency, created_at, updated_at)
+SELECT id, sensor_id, temperature, transparency, COALESCE(created_at, updated_at, now()), updated_at
+FROM sensor_data_legacy;
+
+-- detected_spieces had no foreign key to sensor_data, detections of missing readings have no created_at
+-- to be partitioned by. They are not dropped silently, the migration fails and they have to be deleted first.
+DO $$
+DECLARE
+    orphans BIGINT;
+BEGIN
+    SELECT COUNT(*) INTO orphans
+    FROM detected_spieces_legacy ds
+    LEFT JOIN sensor_data sd ON sd.id=ds.sensor_data_id
+    WHERE sd.id IS NULL;
+
+    IF orphans > 0 THEN
+        RAISE EXCEPTION '% detected spieces refer to missing sensor data', orphans
+            USING HINT = 'Delete them with: DELETE FROM detected_spieces ds WHERE NOT EXISTS '
+                || '(SELECT 1 FROM sensor_data sd WHERE sd.id=ds.sensor_data_id)';
+    END IF;
+END $$;
+
+INSERT INTO detected_spieces(id, spiece_id, sensor_data_id, created_at)
+SELECT ds.id, ds.spiece_id, ds.sensor_data_id, sd.created_at
+FROM detected_spieces_legacy ds
+LEFT JOIN sensor_data sd ON sd.id=ds.sensor_data_id;
+
+DROP TABLE detected_spieces_legacy;
+DROP TABLE sensor_data_legacy;
+
+CREATE SEQUENCE sensor_data_id_seq OWNED BY sensor_data.id;
+SELECT setval('sensor_data_id_seq', COALESCE((SELECT MAX(id) FROM sensor_data), 0) + 1, false);
+ALTER TABLE sensor_data ALTER COLUMN id SET DEFAULT nextval('sensor_data_id_seq');
+
+CREATE SEQUENCE detected_spieces_id_seq OWNED BY detected_spieces.id;
+SELECT setval('detected_spieces_id_seq', COALESCE((SELECT MAX(id) FROM detected_spieces), 0) + 1, false);
+ALTER TABLE detected_spieces ALTER COLUMN id SET DEFAULT nextval('detected_spieces_id_seq');
+
+ALTER TABLE sensor_data ADD PRIMARY KEY (id, created_at);
+ALTER TABLE detected_spieces ADD PRIMARY KEY (id, created_at);
+
+CREATE INDEX sensor_data_sensor_id_created_at_idx ON sensor_data(sensor_id, created_at);
+CREATE INDEX sensor_data_created_at_idx ON sensor_data(created_at);
+CREATE INDEX detected_spieces_sensor_data_id_idx ON detected_spieces(sensor_data_id, created_at);
@@ -0,0 +1,2 @@
+-- No-op. The index is dropped with sensor_data by 4_partitioning.
+SELECT 1;
@@ -0,0 +1,3 @@
+-- No-op. The index on sensor_data(sensor_id, created_at) is created by 4_partitioning with the partitioned table,
+-- the version is kept, so databases which applied it earlier still know it.
+SELECT 1;
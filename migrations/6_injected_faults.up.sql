@@ -0,0 +1,22 @@
+-- Ground truth of faults injected by the generator. Window faults (outage, stuck, drift) have no reading,
+-- others reference the reading by id and created_at, as sensor_data is partitioned.
+CREATE TABLE IF NOT EXISTS injected_faults
+(
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    sensor_id INT NOT NULL,
+    sensor_data_id INT,
+    sensor_data_created_at TIMESTAMPTZ,
+    kind VARCHAR(32) NOT NULL,
+    profile VARCHAR(255) NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ DEFAULT (now() AT TIME ZONE 'utc-3'),
+    CONSTRAINT fk_sensor
+        FOREIGN KEY(sensor_id)
+        REFERENCES sensors(id),
+    CONSTRAINT chk_kind
+        CHECK (kind IN ('outage', 'stuck', 'drift', 'spike', 'duplicate', 'out_of_order', 'delay'))
+);
+
+CREATE INDEX IF NOT EXISTS injected_faults_sensor_id_started_at_idx ON injected_faults(sensor_id, started_at);
@@ -0,0 +1,8 @@
+ALTER TABLE spieces
+    DROP COLUMN IF EXISTS min_depth,
+    DROP COLUMN IF EXISTS max_depth,
+    DROP COLUMN IF EXISTS min_temperature,
+    DROP COLUMN IF EXISTS max_temperature,
+    DROP COLUMN IF EXISTS min_transparency,
+    DROP COLUMN IF EXISTS abundance,
+    DROP COLUMN IF EXISTS school_size;
@@ -0,0 +1,10 @@
+-- Habitat of a spiece drives which spieces the generator detects at a sensor.
+-- Defaults describe a spiece which lives everywhere, so existing catalogues keep being detected.
+ALTER TABLE spieces
+    ADD COLUMN IF NOT EXISTS min_depth DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS max_depth DOUBLE PRECISION NOT NULL DEFAULT 11000,
+    ADD COLUMN IF NOT EXISTS min_temperature REAL NOT NULL DEFAULT -2,
+    ADD COLUMN IF NOT EXISTS max_temperature REAL NOT NULL DEFAULT 40,
+    ADD COLUMN IF NOT EXISTS min_transparency SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS abundance REAL NOT NULL DEFAULT 0.5,
+    ADD COLUMN IF NOT EXISTS school_size INT NOT NULL DEFAULT 1;
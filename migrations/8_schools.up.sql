@@ -0,0 +1,19 @@
+-- Checkpoint of the population simulation, schools are saved periodically and resumed after a restart.
+-- Ids are assigned by the simulation.
+CREATE TABLE IF NOT EXISTS schools
+(
+    id INT PRIMARY KEY,
+    spiece_id INT NOT NULL,
+    size INT NOT NULL,
+    x DOUBLE PRECISION NOT NULL,
+    y DOUBLE PRECISION NOT NULL,
+    z DOUBLE PRECISION NOT NULL,
+    vx DOUBLE PRECISION NOT NULL,
+    vy DOUBLE PRECISION NOT NULL,
+    phase DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_spiece
+        FOREIGN KEY(spiece_id)
+        REFERENCES spieces(id)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,25 @@
+-- Environmental events created with the api, events of the config are not stored.
+-- Ramp up and decay are kept in seconds.
+CREATE TABLE IF NOT EXISTS events
+(
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    min_x DOUBLE PRECISION NOT NULL,
+    min_y DOUBLE PRECISION NOT NULL,
+    min_z DOUBLE PRECISION NOT NULL,
+    max_x DOUBLE PRECISION NOT NULL,
+    max_y DOUBLE PRECISION NOT NULL,
+    max_z DOUBLE PRECISION NOT NULL,
+    edge DOUBLE PRECISION NOT NULL DEFAULT 0,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    ramp_up INT NOT NULL DEFAULT 0,
+    decay INT NOT NULL DEFAULT 0,
+    shape VARCHAR(32) NOT NULL DEFAULT 'linear',
+    magnitude DOUBLE PRECISION NOT NULL DEFAULT 0,
+    spieces JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT chk_kind
+        CHECK (kind IN ('storm', 'upwelling', 'algal_bloom'))
+);
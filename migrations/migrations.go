@@ -0,0 +1,8 @@
+// Package migrations embeds sql migrations into the binary.
+// Files are named <version>_<name>.up.sql and <version>_<name>.down.sql.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,591 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.5.1-go
+// source: sensorgen/v1/common.proto
+
+package sensorgenv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Codename is "<group_name> <index>" of REST paths, e.g. "alpha 1".
+type Codename struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupName string `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Index     int32  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *Codename) Reset() {
+	*x = Codename{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_common_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Codename) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Codename) ProtoMessage() {}
+
+func (x *Codename) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_common_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Codename.ProtoReflect.Descriptor instead.
+func (*Codename) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_common_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Codename) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *Codename) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+// Coordinates of a sensor, z is depth.
+type Coordinates struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X float64 `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y float64 `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
+	Z float64 `protobuf:"fixed64,3,opt,name=z,proto3" json:"z,omitempty"`
+}
+
+func (x *Coordinates) Reset() {
+	*x = Coordinates{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_common_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Coordinates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinates) ProtoMessage() {}
+
+func (x *Coordinates) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_common_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinates.ProtoReflect.Descriptor instead.
+func (*Coordinates) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Coordinates) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Coordinates) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Coordinates) GetZ() float64 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+// TimeRange selects readings created in [from, till], unset bounds do not filter.
+type TimeRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Till *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=till,proto3" json:"till,omitempty"`
+}
+
+func (x *TimeRange) Reset() {
+	*x = TimeRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_common_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeRange) ProtoMessage() {}
+
+func (x *TimeRange) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_common_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeRange.ProtoReflect.Descriptor instead.
+func (*TimeRange) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_common_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TimeRange) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *TimeRange) GetTill() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Till
+	}
+	return nil
+}
+
+type Spiece struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *Spiece) Reset() {
+	*x = Spiece{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_common_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Spiece) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Spiece) ProtoMessage() {}
+
+func (x *Spiece) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_common_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Spiece.ProtoReflect.Descriptor instead.
+func (*Spiece) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_common_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Spiece) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Spiece) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type TemperatureResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Temperature float32 `protobuf:"fixed32,1,opt,name=temperature,proto3" json:"temperature,omitempty"`
+}
+
+func (x *TemperatureResponse) Reset() {
+	*x = TemperatureResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_common_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TemperatureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemperatureResponse) ProtoMessage() {}
+
+func (x *TemperatureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_common_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemperatureResponse.ProtoReflect.Descriptor instead.
+func (*TemperatureResponse) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_common_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TemperatureResponse) GetTemperature() float32 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+type TransparencyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transparency uint32 `protobuf:"varint,1,opt,name=transparency,proto3" json:"transparency,omitempty"`
+}
+
+func (x *TransparencyResponse) Reset() {
+	*x = TransparencyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_common_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransparencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransparencyResponse) ProtoMessage() {}
+
+func (x *TransparencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_common_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransparencyResponse.ProtoReflect.Descriptor instead.
+func (*TransparencyResponse) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_common_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TransparencyResponse) GetTransparency() uint32 {
+	if x != nil {
+		return x.Transparency
+	}
+	return 0
+}
+
+// MetricResponse is an aggregate of a measurement type in its unit, e.g. temperature or salinity.
+type MetricResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metric string  `protobuf:"bytes,1,opt,name=metric,proto3" json:"metric,omitempty"`
+	Value  float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *MetricResponse) Reset() {
+	*x = MetricResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_common_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MetricResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricResponse) ProtoMessage() {}
+
+func (x *MetricResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_common_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricResponse.ProtoReflect.Descriptor instead.
+func (*MetricResponse) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_common_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MetricResponse) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *MetricResponse) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+var File_sensorgen_v1_common_proto protoreflect.FileDescriptor
+
+var file_sensorgen_v1_common_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x73, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3f, 0x0a, 0x08, 0x43, 0x6f,
+	0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x37, 0x0a, 0x0b, 0x43,
+	0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x01, 0x7a, 0x22, 0x6b, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6c,
+	0x6c, 0x22, 0x2c, 0x0a, 0x06, 0x53, 0x70, 0x69, 0x65, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0x37, 0x0a, 0x13, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x74, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x3a, 0x0a, 0x14, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x22, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x22, 0x3e, 0x0a, 0x0e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x42, 0x48, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x2e, 0x73, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x50, 0x01, 0x5a, 0x32, 0x73, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x73, 0x2d, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2f,
+	0x76, 0x31, 0x3b, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x76, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_sensorgen_v1_common_proto_rawDescOnce sync.Once
+	file_sensorgen_v1_common_proto_rawDescData = file_sensorgen_v1_common_proto_rawDesc
+)
+
+func file_sensorgen_v1_common_proto_rawDescGZIP() []byte {
+	file_sensorgen_v1_common_proto_rawDescOnce.Do(func() {
+		file_sensorgen_v1_common_proto_rawDescData = protoimpl.X.CompressGZIP(file_sensorgen_v1_common_proto_rawDescData)
+	})
+	return file_sensorgen_v1_common_proto_rawDescData
+}
+
+var file_sensorgen_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_sensorgen_v1_common_proto_goTypes = []any{
+	(*Codename)(nil),              // 0: sensorgen.v1.Codename
+	(*Coordinates)(nil),           // 1: sensorgen.v1.Coordinates
+	(*TimeRange)(nil),             // 2: sensorgen.v1.TimeRange
+	(*Spiece)(nil),                // 3: sensorgen.v1.Spiece
+	(*TemperatureResponse)(nil),   // 4: sensorgen.v1.TemperatureResponse
+	(*TransparencyResponse)(nil),  // 5: sensorgen.v1.TransparencyResponse
+	(*MetricResponse)(nil),        // 6: sensorgen.v1.MetricResponse
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_sensorgen_v1_common_proto_depIdxs = []int32{
+	7, // 0: sensorgen.v1.TimeRange.from:type_name -> google.protobuf.Timestamp
+	7, // 1: sensorgen.v1.TimeRange.till:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_sensorgen_v1_common_proto_init() }
+func file_sensorgen_v1_common_proto_init() {
+	if File_sensorgen_v1_common_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_sensorgen_v1_common_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Codename); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_common_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Coordinates); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_common_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*TimeRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_common_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*Spiece); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_common_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*TemperatureResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_common_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*TransparencyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_common_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*MetricResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_sensorgen_v1_common_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_sensorgen_v1_common_proto_goTypes,
+		DependencyIndexes: file_sensorgen_v1_common_proto_depIdxs,
+		MessageInfos:      file_sensorgen_v1_common_proto_msgTypes,
+	}.Build()
+	File_sensorgen_v1_common_proto = out.File
+	file_sensorgen_v1_common_proto_rawDesc = nil
+	file_sensorgen_v1_common_proto_goTypes = nil
+	file_sensorgen_v1_common_proto_depIdxs = nil
+}
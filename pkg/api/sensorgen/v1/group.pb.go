@@ -0,0 +1,694 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.5.1-go
+// source: sensorgen/v1/group.proto
+
+package sensorgenv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Group struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *Group) Reset() {
+	*x = Group{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_group_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Group) ProtoMessage() {}
+
+func (x *Group) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_group_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_group_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Group) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Group) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Group) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListGroupsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListGroupsRequest) Reset() {
+	*x = ListGroupsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_group_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupsRequest) ProtoMessage() {}
+
+func (x *ListGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_group_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ListGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_group_proto_rawDescGZIP(), []int{1}
+}
+
+type ListGroupsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Groups []*Group `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *ListGroupsResponse) Reset() {
+	*x = ListGroupsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_group_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupsResponse) ProtoMessage() {}
+
+func (x *ListGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_group_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_group_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListGroupsResponse) GetGroups() []*Group {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type GroupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupName string `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+}
+
+func (x *GroupRequest) Reset() {
+	*x = GroupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_group_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupRequest) ProtoMessage() {}
+
+func (x *GroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_group_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupRequest.ProtoReflect.Descriptor instead.
+func (*GroupRequest) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_group_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GroupRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+type GroupMetricRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupName string `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	// Metric is a measurement type, e.g. temperature or salinity.
+	Metric string `protobuf:"bytes,2,opt,name=metric,proto3" json:"metric,omitempty"`
+}
+
+func (x *GroupMetricRequest) Reset() {
+	*x = GroupMetricRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_group_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupMetricRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMetricRequest) ProtoMessage() {}
+
+func (x *GroupMetricRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_group_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMetricRequest.ProtoReflect.Descriptor instead.
+func (*GroupMetricRequest) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_group_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GroupMetricRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *GroupMetricRequest) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+type SpiecesInGroupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupName string     `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Range     *TimeRange `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+	// Top limits the result to the most detected spieces, 0 returns all.
+	Top int32 `protobuf:"varint,3,opt,name=top,proto3" json:"top,omitempty"`
+}
+
+func (x *SpiecesInGroupRequest) Reset() {
+	*x = SpiecesInGroupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_group_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpiecesInGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpiecesInGroupRequest) ProtoMessage() {}
+
+func (x *SpiecesInGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_group_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpiecesInGroupRequest.ProtoReflect.Descriptor instead.
+func (*SpiecesInGroupRequest) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_group_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SpiecesInGroupRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *SpiecesInGroupRequest) GetRange() *TimeRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *SpiecesInGroupRequest) GetTop() int32 {
+	if x != nil {
+		return x.Top
+	}
+	return 0
+}
+
+type SpieceCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *SpieceCount) Reset() {
+	*x = SpieceCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_group_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpieceCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpieceCount) ProtoMessage() {}
+
+func (x *SpieceCount) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_group_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpieceCount.ProtoReflect.Descriptor instead.
+func (*SpieceCount) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_group_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SpieceCount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SpieceCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type SpiecesInGroupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Spieces []*SpieceCount `protobuf:"bytes,1,rep,name=spieces,proto3" json:"spieces,omitempty"`
+}
+
+func (x *SpiecesInGroupResponse) Reset() {
+	*x = SpiecesInGroupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_group_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpiecesInGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpiecesInGroupResponse) ProtoMessage() {}
+
+func (x *SpiecesInGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_group_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpiecesInGroupResponse.ProtoReflect.Descriptor instead.
+func (*SpiecesInGroupResponse) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_group_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SpiecesInGroupResponse) GetSpieces() []*SpieceCount {
+	if x != nil {
+		return x.Spieces
+	}
+	return nil
+}
+
+var File_sensorgen_v1_group_proto protoreflect.FileDescriptor
+
+var file_sensorgen_v1_group_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x73, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x73, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x67, 0x65, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x66, 0x0a, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x13, 0x0a, 0x11,
+	0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x41, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x73, 0x22, 0x2d, 0x0a, 0x0c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4e,
+	0x61, 0x6d, 0x65, 0x22, 0x4b, 0x0a, 0x12, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x22, 0x77, 0x0a, 0x15, 0x53, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x49, 0x6e, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x6f, 0x70, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x74, 0x6f, 0x70, 0x22, 0x37, 0x0a, 0x0b, 0x53, 0x70, 0x69,
+	0x65, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x4d, 0x0a, 0x16, 0x53, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x49, 0x6e, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x07,
+	0x73, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x69,
+	0x65, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x07, 0x73, 0x70, 0x69, 0x65, 0x63, 0x65,
+	0x73, 0x32, 0xc8, 0x03, 0x0a, 0x0c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73,
+	0x12, 0x1f, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x20, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x70, 0x69, 0x65, 0x63, 0x65,
+	0x73, 0x49, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x23, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x49,
+	0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e,
+	0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x69,
+	0x65, 0x63, 0x65, 0x73, 0x49, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x41, 0x76, 0x67, 0x49, 0x6e, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x12, 0x20, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67,
+	0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x41, 0x76, 0x67, 0x54, 0x65,
+	0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x49, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x12, 0x1a, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x73,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x6d, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x5b, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x41, 0x76, 0x67, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x49, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1a, 0x2e, 0x73,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x48, 0x0a, 0x10,
+	0x63, 0x6f, 0x6d, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31,
+	0x50, 0x01, 0x5a, 0x32, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x2d, 0x67, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2f, 0x76, 0x31, 0x3b, 0x73, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x67, 0x65, 0x6e, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_sensorgen_v1_group_proto_rawDescOnce sync.Once
+	file_sensorgen_v1_group_proto_rawDescData = file_sensorgen_v1_group_proto_rawDesc
+)
+
+func file_sensorgen_v1_group_proto_rawDescGZIP() []byte {
+	file_sensorgen_v1_group_proto_rawDescOnce.Do(func() {
+		file_sensorgen_v1_group_proto_rawDescData = protoimpl.X.CompressGZIP(file_sensorgen_v1_group_proto_rawDescData)
+	})
+	return file_sensorgen_v1_group_proto_rawDescData
+}
+
+var file_sensorgen_v1_group_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_sensorgen_v1_group_proto_goTypes = []any{
+	(*Group)(nil),                  // 0: sensorgen.v1.Group
+	(*ListGroupsRequest)(nil),      // 1: sensorgen.v1.ListGroupsRequest
+	(*ListGroupsResponse)(nil),     // 2: sensorgen.v1.ListGroupsResponse
+	(*GroupRequest)(nil),           // 3: sensorgen.v1.GroupRequest
+	(*GroupMetricRequest)(nil),     // 4: sensorgen.v1.GroupMetricRequest
+	(*SpiecesInGroupRequest)(nil),  // 5: sensorgen.v1.SpiecesInGroupRequest
+	(*SpieceCount)(nil),            // 6: sensorgen.v1.SpieceCount
+	(*SpiecesInGroupResponse)(nil), // 7: sensorgen.v1.SpiecesInGroupResponse
+	(*timestamppb.Timestamp)(nil),  // 8: google.protobuf.Timestamp
+	(*TimeRange)(nil),              // 9: sensorgen.v1.TimeRange
+	(*MetricResponse)(nil),         // 10: sensorgen.v1.MetricResponse
+	(*TemperatureResponse)(nil),    // 11: sensorgen.v1.TemperatureResponse
+	(*TransparencyResponse)(nil),   // 12: sensorgen.v1.TransparencyResponse
+}
+var file_sensorgen_v1_group_proto_depIdxs = []int32{
+	8,  // 0: sensorgen.v1.Group.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 1: sensorgen.v1.ListGroupsResponse.groups:type_name -> sensorgen.v1.Group
+	9,  // 2: sensorgen.v1.SpiecesInGroupRequest.range:type_name -> sensorgen.v1.TimeRange
+	6,  // 3: sensorgen.v1.SpiecesInGroupResponse.spieces:type_name -> sensorgen.v1.SpieceCount
+	1,  // 4: sensorgen.v1.GroupService.ListGroups:input_type -> sensorgen.v1.ListGroupsRequest
+	5,  // 5: sensorgen.v1.GroupService.GetSpiecesInGroup:input_type -> sensorgen.v1.SpiecesInGroupRequest
+	4,  // 6: sensorgen.v1.GroupService.GetAvgInGroup:input_type -> sensorgen.v1.GroupMetricRequest
+	3,  // 7: sensorgen.v1.GroupService.GetAvgTemperatureInGroup:input_type -> sensorgen.v1.GroupRequest
+	3,  // 8: sensorgen.v1.GroupService.GetAvgTransparencyInGroup:input_type -> sensorgen.v1.GroupRequest
+	2,  // 9: sensorgen.v1.GroupService.ListGroups:output_type -> sensorgen.v1.ListGroupsResponse
+	7,  // 10: sensorgen.v1.GroupService.GetSpiecesInGroup:output_type -> sensorgen.v1.SpiecesInGroupResponse
+	10, // 11: sensorgen.v1.GroupService.GetAvgInGroup:output_type -> sensorgen.v1.MetricResponse
+	11, // 12: sensorgen.v1.GroupService.GetAvgTemperatureInGroup:output_type -> sensorgen.v1.TemperatureResponse
+	12, // 13: sensorgen.v1.GroupService.GetAvgTransparencyInGroup:output_type -> sensorgen.v1.TransparencyResponse
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_sensorgen_v1_group_proto_init() }
+func file_sensorgen_v1_group_proto_init() {
+	if File_sensorgen_v1_group_proto != nil {
+		return
+	}
+	file_sensorgen_v1_common_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_sensorgen_v1_group_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Group); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_group_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ListGroupsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_group_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ListGroupsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_group_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*GroupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_group_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*GroupMetricRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_group_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*SpiecesInGroupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_group_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*SpieceCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_group_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*SpiecesInGroupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_sensorgen_v1_group_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sensorgen_v1_group_proto_goTypes,
+		DependencyIndexes: file_sensorgen_v1_group_proto_depIdxs,
+		MessageInfos:      file_sensorgen_v1_group_proto_msgTypes,
+	}.Build()
+	File_sensorgen_v1_group_proto = out.File
+	file_sensorgen_v1_group_proto_rawDesc = nil
+	file_sensorgen_v1_group_proto_goTypes = nil
+	file_sensorgen_v1_group_proto_depIdxs = nil
+}
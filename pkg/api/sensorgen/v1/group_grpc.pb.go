@@ -0,0 +1,265 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.5.1-go
+// source: sensorgen/v1/group.proto
+
+package sensorgenv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	GroupService_ListGroups_FullMethodName                = "/sensorgen.v1.GroupService/ListGroups"
+	GroupService_GetSpiecesInGroup_FullMethodName         = "/sensorgen.v1.GroupService/GetSpiecesInGroup"
+	GroupService_GetAvgInGroup_FullMethodName             = "/sensorgen.v1.GroupService/GetAvgInGroup"
+	GroupService_GetAvgTemperatureInGroup_FullMethodName  = "/sensorgen.v1.GroupService/GetAvgTemperatureInGroup"
+	GroupService_GetAvgTransparencyInGroup_FullMethodName = "/sensorgen.v1.GroupService/GetAvgTransparencyInGroup"
+)
+
+// GroupServiceClient is the client API for GroupService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GroupServiceClient interface {
+	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error)
+	// GetSpiecesInGroup is GET /api/v1/group/{groupName}/spieces, with top it is .../spieces/top/{N}.
+	GetSpiecesInGroup(ctx context.Context, in *SpiecesInGroupRequest, opts ...grpc.CallOption) (*SpiecesInGroupResponse, error)
+	// GetAvgInGroup is GET /api/v1/group/{groupName}/{metric}/average.
+	GetAvgInGroup(ctx context.Context, in *GroupMetricRequest, opts ...grpc.CallOption) (*MetricResponse, error)
+	// GetAvgTemperatureInGroup is GetAvgInGroup of temperature.
+	GetAvgTemperatureInGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*TemperatureResponse, error)
+	// GetAvgTransparencyInGroup is GetAvgInGroup of transparency.
+	GetAvgTransparencyInGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*TransparencyResponse, error)
+}
+
+type groupServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGroupServiceClient(cc grpc.ClientConnInterface) GroupServiceClient {
+	return &groupServiceClient{cc}
+}
+
+func (c *groupServiceClient) ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
+	out := new(ListGroupsResponse)
+	err := c.cc.Invoke(ctx, GroupService_ListGroups_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupServiceClient) GetSpiecesInGroup(ctx context.Context, in *SpiecesInGroupRequest, opts ...grpc.CallOption) (*SpiecesInGroupResponse, error) {
+	out := new(SpiecesInGroupResponse)
+	err := c.cc.Invoke(ctx, GroupService_GetSpiecesInGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupServiceClient) GetAvgInGroup(ctx context.Context, in *GroupMetricRequest, opts ...grpc.CallOption) (*MetricResponse, error) {
+	out := new(MetricResponse)
+	err := c.cc.Invoke(ctx, GroupService_GetAvgInGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupServiceClient) GetAvgTemperatureInGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*TemperatureResponse, error) {
+	out := new(TemperatureResponse)
+	err := c.cc.Invoke(ctx, GroupService_GetAvgTemperatureInGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupServiceClient) GetAvgTransparencyInGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*TransparencyResponse, error) {
+	out := new(TransparencyResponse)
+	err := c.cc.Invoke(ctx, GroupService_GetAvgTransparencyInGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GroupServiceServer is the server API for GroupService service.
+// All implementations must embed UnimplementedGroupServiceServer
+// for forward compatibility
+type GroupServiceServer interface {
+	ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error)
+	// GetSpiecesInGroup is GET /api/v1/group/{groupName}/spieces, with top it is .../spieces/top/{N}.
+	GetSpiecesInGroup(context.Context, *SpiecesInGroupRequest) (*SpiecesInGroupResponse, error)
+	// GetAvgInGroup is GET /api/v1/group/{groupName}/{metric}/average.
+	GetAvgInGroup(context.Context, *GroupMetricRequest) (*MetricResponse, error)
+	// GetAvgTemperatureInGroup is GetAvgInGroup of temperature.
+	GetAvgTemperatureInGroup(context.Context, *GroupRequest) (*TemperatureResponse, error)
+	// GetAvgTransparencyInGroup is GetAvgInGroup of transparency.
+	GetAvgTransparencyInGroup(context.Context, *GroupRequest) (*TransparencyResponse, error)
+	mustEmbedUnimplementedGroupServiceServer()
+}
+
+// UnimplementedGroupServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGroupServiceServer struct {
+}
+
+func (UnimplementedGroupServiceServer) ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroups not implemented")
+}
+func (UnimplementedGroupServiceServer) GetSpiecesInGroup(context.Context, *SpiecesInGroupRequest) (*SpiecesInGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSpiecesInGroup not implemented")
+}
+func (UnimplementedGroupServiceServer) GetAvgInGroup(context.Context, *GroupMetricRequest) (*MetricResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvgInGroup not implemented")
+}
+func (UnimplementedGroupServiceServer) GetAvgTemperatureInGroup(context.Context, *GroupRequest) (*TemperatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvgTemperatureInGroup not implemented")
+}
+func (UnimplementedGroupServiceServer) GetAvgTransparencyInGroup(context.Context, *GroupRequest) (*TransparencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvgTransparencyInGroup not implemented")
+}
+func (UnimplementedGroupServiceServer) mustEmbedUnimplementedGroupServiceServer() {}
+
+// UnsafeGroupServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GroupServiceServer will
+// result in compilation errors.
+type UnsafeGroupServiceServer interface {
+	mustEmbedUnimplementedGroupServiceServer()
+}
+
+func RegisterGroupServiceServer(s grpc.ServiceRegistrar, srv GroupServiceServer) {
+	s.RegisterService(&GroupService_ServiceDesc, srv)
+}
+
+func _GroupService_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServiceServer).ListGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupService_ListGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServiceServer).ListGroups(ctx, req.(*ListGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupService_GetSpiecesInGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpiecesInGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServiceServer).GetSpiecesInGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupService_GetSpiecesInGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServiceServer).GetSpiecesInGroup(ctx, req.(*SpiecesInGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupService_GetAvgInGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupMetricRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServiceServer).GetAvgInGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupService_GetAvgInGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServiceServer).GetAvgInGroup(ctx, req.(*GroupMetricRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupService_GetAvgTemperatureInGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServiceServer).GetAvgTemperatureInGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupService_GetAvgTemperatureInGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServiceServer).GetAvgTemperatureInGroup(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupService_GetAvgTransparencyInGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServiceServer).GetAvgTransparencyInGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupService_GetAvgTransparencyInGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServiceServer).GetAvgTransparencyInGroup(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GroupService_ServiceDesc is the grpc.ServiceDesc for GroupService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GroupService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sensorgen.v1.GroupService",
+	HandlerType: (*GroupServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListGroups",
+			Handler:    _GroupService_ListGroups_Handler,
+		},
+		{
+			MethodName: "GetSpiecesInGroup",
+			Handler:    _GroupService_GetSpiecesInGroup_Handler,
+		},
+		{
+			MethodName: "GetAvgInGroup",
+			Handler:    _GroupService_GetAvgInGroup_Handler,
+		},
+		{
+			MethodName: "GetAvgTemperatureInGroup",
+			Handler:    _GroupService_GetAvgTemperatureInGroup_Handler,
+		},
+		{
+			MethodName: "GetAvgTransparencyInGroup",
+			Handler:    _GroupService_GetAvgTransparencyInGroup_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sensorgen/v1/group.proto",
+}
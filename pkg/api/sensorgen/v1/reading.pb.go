@@ -0,0 +1,418 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.5.1-go
+// source: sensorgen/v1/reading.proto
+
+package sensorgenv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Reading struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Codename     *Codename              `protobuf:"bytes,2,opt,name=codename,proto3" json:"codename,omitempty"`
+	Coordinates  *Coordinates           `protobuf:"bytes,3,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	Temperature  float32                `protobuf:"fixed32,4,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	Transparency uint32                 `protobuf:"varint,5,opt,name=transparency,proto3" json:"transparency,omitempty"`
+	Spieces      []*Spiece              `protobuf:"bytes,6,rep,name=spieces,proto3" json:"spieces,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Readings dropped for this subscriber before this one.
+	Dropped int64 `protobuf:"varint,8,opt,name=dropped,proto3" json:"dropped,omitempty"`
+}
+
+func (x *Reading) Reset() {
+	*x = Reading{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_reading_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Reading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reading) ProtoMessage() {}
+
+func (x *Reading) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_reading_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reading.ProtoReflect.Descriptor instead.
+func (*Reading) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_reading_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Reading) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Reading) GetCodename() *Codename {
+	if x != nil {
+		return x.Codename
+	}
+	return nil
+}
+
+func (x *Reading) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *Reading) GetTemperature() float32 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *Reading) GetTransparency() uint32 {
+	if x != nil {
+		return x.Transparency
+	}
+	return 0
+}
+
+func (x *Reading) GetSpieces() []*Spiece {
+	if x != nil {
+		return x.Spieces
+	}
+	return nil
+}
+
+func (x *Reading) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Reading) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+type ExportReadingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Codename  *Codename  `protobuf:"bytes,1,opt,name=codename,proto3" json:"codename,omitempty"`
+	GroupName string     `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Range     *TimeRange `protobuf:"bytes,3,opt,name=range,proto3" json:"range,omitempty"`
+}
+
+func (x *ExportReadingsRequest) Reset() {
+	*x = ExportReadingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_reading_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportReadingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportReadingsRequest) ProtoMessage() {}
+
+func (x *ExportReadingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_reading_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportReadingsRequest.ProtoReflect.Descriptor instead.
+func (*ExportReadingsRequest) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_reading_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExportReadingsRequest) GetCodename() *Codename {
+	if x != nil {
+		return x.Codename
+	}
+	return nil
+}
+
+func (x *ExportReadingsRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *ExportReadingsRequest) GetRange() *TimeRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+// SubscribeReadingsRequest filters readings, empty filters subscribe to all sensors.
+type SubscribeReadingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Codename  *Codename `protobuf:"bytes,1,opt,name=codename,proto3" json:"codename,omitempty"`
+	GroupName string    `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+}
+
+func (x *SubscribeReadingsRequest) Reset() {
+	*x = SubscribeReadingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_reading_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeReadingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeReadingsRequest) ProtoMessage() {}
+
+func (x *SubscribeReadingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_reading_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeReadingsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeReadingsRequest) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_reading_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubscribeReadingsRequest) GetCodename() *Codename {
+	if x != nil {
+		return x.Codename
+	}
+	return nil
+}
+
+func (x *SubscribeReadingsRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+var File_sensorgen_v1_reading_proto protoreflect.FileDescriptor
+
+var file_sensorgen_v1_reading_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x72,
+	0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x73, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x73, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd5, 0x02, 0x0a, 0x07, 0x52, 0x65, 0x61, 0x64, 0x69,
+	0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x32, 0x0a, 0x08, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x08, 0x63, 0x6f,
+	0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69,
+	0x6e, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61,
+	0x74, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2e, 0x0a, 0x07, 0x73, 0x70, 0x69,
+	0x65, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x69, 0x65, 0x63, 0x65,
+	0x52, 0x07, 0x73, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22, 0x99,
+	0x01, 0x0a, 0x15, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x08, 0x63, 0x6f, 0x64, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x52, 0x08, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x6d, 0x0a, 0x18, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x08, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65,
+	0x52, 0x08, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x32, 0xb6, 0x01, 0x0a, 0x0e, 0x52, 0x65,
+	0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4e, 0x0a, 0x0e,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x23,
+	0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x30, 0x01, 0x12, 0x54, 0x0a, 0x11,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x12, 0x26, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x73, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67,
+	0x30, 0x01, 0x42, 0x48, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x50, 0x01, 0x5a, 0x32, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x73, 0x2d, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2f, 0x76, 0x31,
+	0x3b, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_sensorgen_v1_reading_proto_rawDescOnce sync.Once
+	file_sensorgen_v1_reading_proto_rawDescData = file_sensorgen_v1_reading_proto_rawDesc
+)
+
+func file_sensorgen_v1_reading_proto_rawDescGZIP() []byte {
+	file_sensorgen_v1_reading_proto_rawDescOnce.Do(func() {
+		file_sensorgen_v1_reading_proto_rawDescData = protoimpl.X.CompressGZIP(file_sensorgen_v1_reading_proto_rawDescData)
+	})
+	return file_sensorgen_v1_reading_proto_rawDescData
+}
+
+var file_sensorgen_v1_reading_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_sensorgen_v1_reading_proto_goTypes = []any{
+	(*Reading)(nil),                  // 0: sensorgen.v1.Reading
+	(*ExportReadingsRequest)(nil),    // 1: sensorgen.v1.ExportReadingsRequest
+	(*SubscribeReadingsRequest)(nil), // 2: sensorgen.v1.SubscribeReadingsRequest
+	(*Codename)(nil),                 // 3: sensorgen.v1.Codename
+	(*Coordinates)(nil),              // 4: sensorgen.v1.Coordinates
+	(*Spiece)(nil),                   // 5: sensorgen.v1.Spiece
+	(*timestamppb.Timestamp)(nil),    // 6: google.protobuf.Timestamp
+	(*TimeRange)(nil),                // 7: sensorgen.v1.TimeRange
+}
+var file_sensorgen_v1_reading_proto_depIdxs = []int32{
+	3, // 0: sensorgen.v1.Reading.codename:type_name -> sensorgen.v1.Codename
+	4, // 1: sensorgen.v1.Reading.coordinates:type_name -> sensorgen.v1.Coordinates
+	5, // 2: sensorgen.v1.Reading.spieces:type_name -> sensorgen.v1.Spiece
+	6, // 3: sensorgen.v1.Reading.created_at:type_name -> google.protobuf.Timestamp
+	3, // 4: sensorgen.v1.ExportReadingsRequest.codename:type_name -> sensorgen.v1.Codename
+	7, // 5: sensorgen.v1.ExportReadingsRequest.range:type_name -> sensorgen.v1.TimeRange
+	3, // 6: sensorgen.v1.SubscribeReadingsRequest.codename:type_name -> sensorgen.v1.Codename
+	1, // 7: sensorgen.v1.ReadingService.ExportReadings:input_type -> sensorgen.v1.ExportReadingsRequest
+	2, // 8: sensorgen.v1.ReadingService.SubscribeReadings:input_type -> sensorgen.v1.SubscribeReadingsRequest
+	0, // 9: sensorgen.v1.ReadingService.ExportReadings:output_type -> sensorgen.v1.Reading
+	0, // 10: sensorgen.v1.ReadingService.SubscribeReadings:output_type -> sensorgen.v1.Reading
+	9, // [9:11] is the sub-list for method output_type
+	7, // [7:9] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_sensorgen_v1_reading_proto_init() }
+func file_sensorgen_v1_reading_proto_init() {
+	if File_sensorgen_v1_reading_proto != nil {
+		return
+	}
+	file_sensorgen_v1_common_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_sensorgen_v1_reading_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Reading); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_reading_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ExportReadingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_reading_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*SubscribeReadingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_sensorgen_v1_reading_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sensorgen_v1_reading_proto_goTypes,
+		DependencyIndexes: file_sensorgen_v1_reading_proto_depIdxs,
+		MessageInfos:      file_sensorgen_v1_reading_proto_msgTypes,
+	}.Build()
+	File_sensorgen_v1_reading_proto = out.File
+	file_sensorgen_v1_reading_proto_rawDesc = nil
+	file_sensorgen_v1_reading_proto_goTypes = nil
+	file_sensorgen_v1_reading_proto_depIdxs = nil
+}
@@ -0,0 +1,206 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.5.1-go
+// source: sensorgen/v1/reading.proto
+
+package sensorgenv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ReadingService_ExportReadings_FullMethodName    = "/sensorgen.v1.ReadingService/ExportReadings"
+	ReadingService_SubscribeReadings_FullMethodName = "/sensorgen.v1.ReadingService/SubscribeReadings"
+)
+
+// ReadingServiceClient is the client API for ReadingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReadingServiceClient interface {
+	// ExportReadings is GET /api/v1/export/readings, readings are streamed ordered by created_at.
+	ExportReadings(ctx context.Context, in *ExportReadingsRequest, opts ...grpc.CallOption) (ReadingService_ExportReadingsClient, error)
+	// SubscribeReadings streams readings written by the generator from now on.
+	// Readings are dropped for a client which does not keep up, dropped counts them.
+	SubscribeReadings(ctx context.Context, in *SubscribeReadingsRequest, opts ...grpc.CallOption) (ReadingService_SubscribeReadingsClient, error)
+}
+
+type readingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReadingServiceClient(cc grpc.ClientConnInterface) ReadingServiceClient {
+	return &readingServiceClient{cc}
+}
+
+func (c *readingServiceClient) ExportReadings(ctx context.Context, in *ExportReadingsRequest, opts ...grpc.CallOption) (ReadingService_ExportReadingsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ReadingService_ServiceDesc.Streams[0], ReadingService_ExportReadings_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &readingServiceExportReadingsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReadingService_ExportReadingsClient interface {
+	Recv() (*Reading, error)
+	grpc.ClientStream
+}
+
+type readingServiceExportReadingsClient struct {
+	grpc.ClientStream
+}
+
+func (x *readingServiceExportReadingsClient) Recv() (*Reading, error) {
+	m := new(Reading)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *readingServiceClient) SubscribeReadings(ctx context.Context, in *SubscribeReadingsRequest, opts ...grpc.CallOption) (ReadingService_SubscribeReadingsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ReadingService_ServiceDesc.Streams[1], ReadingService_SubscribeReadings_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &readingServiceSubscribeReadingsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReadingService_SubscribeReadingsClient interface {
+	Recv() (*Reading, error)
+	grpc.ClientStream
+}
+
+type readingServiceSubscribeReadingsClient struct {
+	grpc.ClientStream
+}
+
+func (x *readingServiceSubscribeReadingsClient) Recv() (*Reading, error) {
+	m := new(Reading)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ReadingServiceServer is the server API for ReadingService service.
+// All implementations must embed UnimplementedReadingServiceServer
+// for forward compatibility
+type ReadingServiceServer interface {
+	// ExportReadings is GET /api/v1/export/readings, readings are streamed ordered by created_at.
+	ExportReadings(*ExportReadingsRequest, ReadingService_ExportReadingsServer) error
+	// SubscribeReadings streams readings written by the generator from now on.
+	// Readings are dropped for a client which does not keep up, dropped counts them.
+	SubscribeReadings(*SubscribeReadingsRequest, ReadingService_SubscribeReadingsServer) error
+	mustEmbedUnimplementedReadingServiceServer()
+}
+
+// UnimplementedReadingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedReadingServiceServer struct {
+}
+
+func (UnimplementedReadingServiceServer) ExportReadings(*ExportReadingsRequest, ReadingService_ExportReadingsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportReadings not implemented")
+}
+func (UnimplementedReadingServiceServer) SubscribeReadings(*SubscribeReadingsRequest, ReadingService_SubscribeReadingsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeReadings not implemented")
+}
+func (UnimplementedReadingServiceServer) mustEmbedUnimplementedReadingServiceServer() {}
+
+// UnsafeReadingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReadingServiceServer will
+// result in compilation errors.
+type UnsafeReadingServiceServer interface {
+	mustEmbedUnimplementedReadingServiceServer()
+}
+
+func RegisterReadingServiceServer(s grpc.ServiceRegistrar, srv ReadingServiceServer) {
+	s.RegisterService(&ReadingService_ServiceDesc, srv)
+}
+
+func _ReadingService_ExportReadings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportReadingsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReadingServiceServer).ExportReadings(m, &readingServiceExportReadingsServer{stream})
+}
+
+type ReadingService_ExportReadingsServer interface {
+	Send(*Reading) error
+	grpc.ServerStream
+}
+
+type readingServiceExportReadingsServer struct {
+	grpc.ServerStream
+}
+
+func (x *readingServiceExportReadingsServer) Send(m *Reading) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ReadingService_SubscribeReadings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeReadingsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReadingServiceServer).SubscribeReadings(m, &readingServiceSubscribeReadingsServer{stream})
+}
+
+type ReadingService_SubscribeReadingsServer interface {
+	Send(*Reading) error
+	grpc.ServerStream
+}
+
+type readingServiceSubscribeReadingsServer struct {
+	grpc.ServerStream
+}
+
+func (x *readingServiceSubscribeReadingsServer) Send(m *Reading) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ReadingService_ServiceDesc is the grpc.ServiceDesc for ReadingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReadingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sensorgen.v1.ReadingService",
+	HandlerType: (*ReadingServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportReadings",
+			Handler:       _ReadingService_ExportReadings_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeReadings",
+			Handler:       _ReadingService_SubscribeReadings_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "sensorgen/v1/reading.proto",
+}
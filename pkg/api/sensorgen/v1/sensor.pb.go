@@ -0,0 +1,815 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.5.1-go
+// source: sensorgen/v1/sensor.proto
+
+package sensorgenv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Sensor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Codename    *Codename    `protobuf:"bytes,1,opt,name=codename,proto3" json:"codename,omitempty"`
+	Coordinates *Coordinates `protobuf:"bytes,2,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	// Seconds between readings.
+	DataOutputRate int64                  `protobuf:"varint,3,opt,name=data_output_rate,json=dataOutputRate,proto3" json:"data_output_rate,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *Sensor) Reset() {
+	*x = Sensor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_sensor_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Sensor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sensor) ProtoMessage() {}
+
+func (x *Sensor) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_sensor_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sensor.ProtoReflect.Descriptor instead.
+func (*Sensor) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_sensor_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Sensor) GetCodename() *Codename {
+	if x != nil {
+		return x.Codename
+	}
+	return nil
+}
+
+func (x *Sensor) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *Sensor) GetDataOutputRate() int64 {
+	if x != nil {
+		return x.DataOutputRate
+	}
+	return 0
+}
+
+func (x *Sensor) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Sensor) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListSensorsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSensorsRequest) Reset() {
+	*x = ListSensorsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_sensor_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSensorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSensorsRequest) ProtoMessage() {}
+
+func (x *ListSensorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_sensor_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSensorsRequest.ProtoReflect.Descriptor instead.
+func (*ListSensorsRequest) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_sensor_proto_rawDescGZIP(), []int{1}
+}
+
+type ListSensorsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sensors []*Sensor `protobuf:"bytes,1,rep,name=sensors,proto3" json:"sensors,omitempty"`
+}
+
+func (x *ListSensorsResponse) Reset() {
+	*x = ListSensorsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_sensor_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSensorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSensorsResponse) ProtoMessage() {}
+
+func (x *ListSensorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_sensor_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSensorsResponse.ProtoReflect.Descriptor instead.
+func (*ListSensorsResponse) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_sensor_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListSensorsResponse) GetSensors() []*Sensor {
+	if x != nil {
+		return x.Sensors
+	}
+	return nil
+}
+
+type RegionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min *Coordinates `protobuf:"bytes,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max *Coordinates `protobuf:"bytes,2,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *RegionRequest) Reset() {
+	*x = RegionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_sensor_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionRequest) ProtoMessage() {}
+
+func (x *RegionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_sensor_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionRequest.ProtoReflect.Descriptor instead.
+func (*RegionRequest) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_sensor_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RegionRequest) GetMin() *Coordinates {
+	if x != nil {
+		return x.Min
+	}
+	return nil
+}
+
+func (x *RegionRequest) GetMax() *Coordinates {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+type SensorTemperatureRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Codename *Codename  `protobuf:"bytes,1,opt,name=codename,proto3" json:"codename,omitempty"`
+	Range    *TimeRange `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+}
+
+func (x *SensorTemperatureRequest) Reset() {
+	*x = SensorTemperatureRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_sensor_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SensorTemperatureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SensorTemperatureRequest) ProtoMessage() {}
+
+func (x *SensorTemperatureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_sensor_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SensorTemperatureRequest.ProtoReflect.Descriptor instead.
+func (*SensorTemperatureRequest) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_sensor_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SensorTemperatureRequest) GetCodename() *Codename {
+	if x != nil {
+		return x.Codename
+	}
+	return nil
+}
+
+func (x *SensorTemperatureRequest) GetRange() *TimeRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+// Metrics are measurement types, e.g. temperature or salinity.
+type RegionMetricRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min    *Coordinates `protobuf:"bytes,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max    *Coordinates `protobuf:"bytes,2,opt,name=max,proto3" json:"max,omitempty"`
+	Metric string       `protobuf:"bytes,3,opt,name=metric,proto3" json:"metric,omitempty"`
+}
+
+func (x *RegionMetricRequest) Reset() {
+	*x = RegionMetricRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_sensor_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegionMetricRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionMetricRequest) ProtoMessage() {}
+
+func (x *RegionMetricRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_sensor_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionMetricRequest.ProtoReflect.Descriptor instead.
+func (*RegionMetricRequest) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_sensor_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RegionMetricRequest) GetMin() *Coordinates {
+	if x != nil {
+		return x.Min
+	}
+	return nil
+}
+
+func (x *RegionMetricRequest) GetMax() *Coordinates {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+func (x *RegionMetricRequest) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+type SensorMetricRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Codename *Codename  `protobuf:"bytes,1,opt,name=codename,proto3" json:"codename,omitempty"`
+	Range    *TimeRange `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+	Metric   string     `protobuf:"bytes,3,opt,name=metric,proto3" json:"metric,omitempty"`
+}
+
+func (x *SensorMetricRequest) Reset() {
+	*x = SensorMetricRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_sensor_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SensorMetricRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SensorMetricRequest) ProtoMessage() {}
+
+func (x *SensorMetricRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_sensor_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SensorMetricRequest.ProtoReflect.Descriptor instead.
+func (*SensorMetricRequest) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_sensor_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SensorMetricRequest) GetCodename() *Codename {
+	if x != nil {
+		return x.Codename
+	}
+	return nil
+}
+
+func (x *SensorMetricRequest) GetRange() *TimeRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *SensorMetricRequest) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+// UpdateSensorRequest changes only the set fields.
+type UpdateSensorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Codename       *Codename    `protobuf:"bytes,1,opt,name=codename,proto3" json:"codename,omitempty"`
+	Coordinates    *Coordinates `protobuf:"bytes,2,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	DataOutputRate *int64       `protobuf:"varint,3,opt,name=data_output_rate,json=dataOutputRate,proto3,oneof" json:"data_output_rate,omitempty"`
+}
+
+func (x *UpdateSensorRequest) Reset() {
+	*x = UpdateSensorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_sensor_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSensorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSensorRequest) ProtoMessage() {}
+
+func (x *UpdateSensorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_sensor_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSensorRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSensorRequest) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_sensor_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateSensorRequest) GetCodename() *Codename {
+	if x != nil {
+		return x.Codename
+	}
+	return nil
+}
+
+func (x *UpdateSensorRequest) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *UpdateSensorRequest) GetDataOutputRate() int64 {
+	if x != nil && x.DataOutputRate != nil {
+		return *x.DataOutputRate
+	}
+	return 0
+}
+
+var File_sensorgen_v1_sensor_proto protoreflect.FileDescriptor
+
+var file_sensorgen_v1_sensor_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x73,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x73, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67,
+	0x65, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0x99, 0x02, 0x0a, 0x06, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x12, 0x32, 0x0a,
+	0x08, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x08, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67,
+	0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65,
+	0x73, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x12, 0x28,
+	0x0a, 0x10, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x72, 0x61,
+	0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x52, 0x61, 0x74, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x14,
+	0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x45, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x73,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x52, 0x07, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x22, 0x69, 0x0a, 0x0d, 0x52,
+	0x65, 0x67, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x03,
+	0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x61, 0x74, 0x65, 0x73, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x2b, 0x0a, 0x03, 0x6d, 0x61, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67,
+	0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65,
+	0x73, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x7d, 0x0a, 0x18, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x32, 0x0a, 0x08, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x08, 0x63, 0x6f,
+	0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x05,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x87, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a,
+	0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69,
+	0x6e, 0x61, 0x74, 0x65, 0x73, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x2b, 0x0a, 0x03, 0x6d, 0x61,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74,
+	0x65, 0x73, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x22,
+	0x90, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x08, 0x63, 0x6f, 0x64, 0x65, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x52, 0x08, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x22, 0xca, 0x01, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x08, 0x63, 0x6f,
+	0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x52, 0x08, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3b,
+	0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x52, 0x0b,
+	0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x10, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x52, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x32,
+	0xc4, 0x05, 0x0a, 0x0d, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x52, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73,
+	0x12, 0x20, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x6e, 0x49,
+	0x6e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x73, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4d,
+	0x61, 0x78, 0x49, 0x6e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x73, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0f, 0x47,
+	0x65, 0x74, 0x41, 0x76, 0x67, 0x46, 0x6f, 0x72, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x12, 0x21,
+	0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x59, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x6e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x54,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1b, 0x2e, 0x73, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x17, 0x47, 0x65,
+	0x74, 0x4d, 0x61, 0x78, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1b, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x41, 0x76, 0x67, 0x53,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x12, 0x26, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0c, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x12, 0x21, 0x2e, 0x73, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x48, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x2e, 0x73, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x50, 0x01, 0x5a, 0x32, 0x73, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x73, 0x2d, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65,
+	0x6e, 0x2f, 0x76, 0x31, 0x3b, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_sensorgen_v1_sensor_proto_rawDescOnce sync.Once
+	file_sensorgen_v1_sensor_proto_rawDescData = file_sensorgen_v1_sensor_proto_rawDesc
+)
+
+func file_sensorgen_v1_sensor_proto_rawDescGZIP() []byte {
+	file_sensorgen_v1_sensor_proto_rawDescOnce.Do(func() {
+		file_sensorgen_v1_sensor_proto_rawDescData = protoimpl.X.CompressGZIP(file_sensorgen_v1_sensor_proto_rawDescData)
+	})
+	return file_sensorgen_v1_sensor_proto_rawDescData
+}
+
+var file_sensorgen_v1_sensor_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_sensorgen_v1_sensor_proto_goTypes = []any{
+	(*Sensor)(nil),                   // 0: sensorgen.v1.Sensor
+	(*ListSensorsRequest)(nil),       // 1: sensorgen.v1.ListSensorsRequest
+	(*ListSensorsResponse)(nil),      // 2: sensorgen.v1.ListSensorsResponse
+	(*RegionRequest)(nil),            // 3: sensorgen.v1.RegionRequest
+	(*SensorTemperatureRequest)(nil), // 4: sensorgen.v1.SensorTemperatureRequest
+	(*RegionMetricRequest)(nil),      // 5: sensorgen.v1.RegionMetricRequest
+	(*SensorMetricRequest)(nil),      // 6: sensorgen.v1.SensorMetricRequest
+	(*UpdateSensorRequest)(nil),      // 7: sensorgen.v1.UpdateSensorRequest
+	(*Codename)(nil),                 // 8: sensorgen.v1.Codename
+	(*Coordinates)(nil),              // 9: sensorgen.v1.Coordinates
+	(*timestamppb.Timestamp)(nil),    // 10: google.protobuf.Timestamp
+	(*TimeRange)(nil),                // 11: sensorgen.v1.TimeRange
+	(*MetricResponse)(nil),           // 12: sensorgen.v1.MetricResponse
+	(*TemperatureResponse)(nil),      // 13: sensorgen.v1.TemperatureResponse
+	(*emptypb.Empty)(nil),            // 14: google.protobuf.Empty
+}
+var file_sensorgen_v1_sensor_proto_depIdxs = []int32{
+	8,  // 0: sensorgen.v1.Sensor.codename:type_name -> sensorgen.v1.Codename
+	9,  // 1: sensorgen.v1.Sensor.coordinates:type_name -> sensorgen.v1.Coordinates
+	10, // 2: sensorgen.v1.Sensor.created_at:type_name -> google.protobuf.Timestamp
+	10, // 3: sensorgen.v1.Sensor.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: sensorgen.v1.ListSensorsResponse.sensors:type_name -> sensorgen.v1.Sensor
+	9,  // 5: sensorgen.v1.RegionRequest.min:type_name -> sensorgen.v1.Coordinates
+	9,  // 6: sensorgen.v1.RegionRequest.max:type_name -> sensorgen.v1.Coordinates
+	8,  // 7: sensorgen.v1.SensorTemperatureRequest.codename:type_name -> sensorgen.v1.Codename
+	11, // 8: sensorgen.v1.SensorTemperatureRequest.range:type_name -> sensorgen.v1.TimeRange
+	9,  // 9: sensorgen.v1.RegionMetricRequest.min:type_name -> sensorgen.v1.Coordinates
+	9,  // 10: sensorgen.v1.RegionMetricRequest.max:type_name -> sensorgen.v1.Coordinates
+	8,  // 11: sensorgen.v1.SensorMetricRequest.codename:type_name -> sensorgen.v1.Codename
+	11, // 12: sensorgen.v1.SensorMetricRequest.range:type_name -> sensorgen.v1.TimeRange
+	8,  // 13: sensorgen.v1.UpdateSensorRequest.codename:type_name -> sensorgen.v1.Codename
+	9,  // 14: sensorgen.v1.UpdateSensorRequest.coordinates:type_name -> sensorgen.v1.Coordinates
+	1,  // 15: sensorgen.v1.SensorService.ListSensors:input_type -> sensorgen.v1.ListSensorsRequest
+	5,  // 16: sensorgen.v1.SensorService.GetMinInRegion:input_type -> sensorgen.v1.RegionMetricRequest
+	5,  // 17: sensorgen.v1.SensorService.GetMaxInRegion:input_type -> sensorgen.v1.RegionMetricRequest
+	6,  // 18: sensorgen.v1.SensorService.GetAvgForSensor:input_type -> sensorgen.v1.SensorMetricRequest
+	3,  // 19: sensorgen.v1.SensorService.GetMinRegionTemperature:input_type -> sensorgen.v1.RegionRequest
+	3,  // 20: sensorgen.v1.SensorService.GetMaxRegionTemperature:input_type -> sensorgen.v1.RegionRequest
+	4,  // 21: sensorgen.v1.SensorService.GetAvgSensorTemperature:input_type -> sensorgen.v1.SensorTemperatureRequest
+	7,  // 22: sensorgen.v1.SensorService.UpdateSensor:input_type -> sensorgen.v1.UpdateSensorRequest
+	2,  // 23: sensorgen.v1.SensorService.ListSensors:output_type -> sensorgen.v1.ListSensorsResponse
+	12, // 24: sensorgen.v1.SensorService.GetMinInRegion:output_type -> sensorgen.v1.MetricResponse
+	12, // 25: sensorgen.v1.SensorService.GetMaxInRegion:output_type -> sensorgen.v1.MetricResponse
+	12, // 26: sensorgen.v1.SensorService.GetAvgForSensor:output_type -> sensorgen.v1.MetricResponse
+	13, // 27: sensorgen.v1.SensorService.GetMinRegionTemperature:output_type -> sensorgen.v1.TemperatureResponse
+	13, // 28: sensorgen.v1.SensorService.GetMaxRegionTemperature:output_type -> sensorgen.v1.TemperatureResponse
+	13, // 29: sensorgen.v1.SensorService.GetAvgSensorTemperature:output_type -> sensorgen.v1.TemperatureResponse
+	14, // 30: sensorgen.v1.SensorService.UpdateSensor:output_type -> google.protobuf.Empty
+	23, // [23:31] is the sub-list for method output_type
+	15, // [15:23] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_sensorgen_v1_sensor_proto_init() }
+func file_sensorgen_v1_sensor_proto_init() {
+	if File_sensorgen_v1_sensor_proto != nil {
+		return
+	}
+	file_sensorgen_v1_common_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_sensorgen_v1_sensor_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Sensor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_sensor_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ListSensorsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_sensor_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ListSensorsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_sensor_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*RegionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_sensor_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*SensorTemperatureRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_sensor_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*RegionMetricRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_sensor_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*SensorMetricRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_sensor_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateSensorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_sensorgen_v1_sensor_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_sensorgen_v1_sensor_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sensorgen_v1_sensor_proto_goTypes,
+		DependencyIndexes: file_sensorgen_v1_sensor_proto_depIdxs,
+		MessageInfos:      file_sensorgen_v1_sensor_proto_msgTypes,
+	}.Build()
+	File_sensorgen_v1_sensor_proto = out.File
+	file_sensorgen_v1_sensor_proto_rawDesc = nil
+	file_sensorgen_v1_sensor_proto_goTypes = nil
+	file_sensorgen_v1_sensor_proto_depIdxs = nil
+}
@@ -0,0 +1,383 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.5.1-go
+// source: sensorgen/v1/sensor.proto
+
+package sensorgenv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	SensorService_ListSensors_FullMethodName             = "/sensorgen.v1.SensorService/ListSensors"
+	SensorService_GetMinInRegion_FullMethodName          = "/sensorgen.v1.SensorService/GetMinInRegion"
+	SensorService_GetMaxInRegion_FullMethodName          = "/sensorgen.v1.SensorService/GetMaxInRegion"
+	SensorService_GetAvgForSensor_FullMethodName         = "/sensorgen.v1.SensorService/GetAvgForSensor"
+	SensorService_GetMinRegionTemperature_FullMethodName = "/sensorgen.v1.SensorService/GetMinRegionTemperature"
+	SensorService_GetMaxRegionTemperature_FullMethodName = "/sensorgen.v1.SensorService/GetMaxRegionTemperature"
+	SensorService_GetAvgSensorTemperature_FullMethodName = "/sensorgen.v1.SensorService/GetAvgSensorTemperature"
+	SensorService_UpdateSensor_FullMethodName            = "/sensorgen.v1.SensorService/UpdateSensor"
+)
+
+// SensorServiceClient is the client API for SensorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SensorServiceClient interface {
+	ListSensors(ctx context.Context, in *ListSensorsRequest, opts ...grpc.CallOption) (*ListSensorsResponse, error)
+	// GetMinInRegion is GET /api/v1/region/{metric}/min.
+	GetMinInRegion(ctx context.Context, in *RegionMetricRequest, opts ...grpc.CallOption) (*MetricResponse, error)
+	// GetMaxInRegion is GET /api/v1/region/{metric}/max.
+	GetMaxInRegion(ctx context.Context, in *RegionMetricRequest, opts ...grpc.CallOption) (*MetricResponse, error)
+	// GetAvgForSensor is GET /api/v1/sensor/{codeName}/{metric}/average.
+	GetAvgForSensor(ctx context.Context, in *SensorMetricRequest, opts ...grpc.CallOption) (*MetricResponse, error)
+	// GetMinRegionTemperature is GetMinInRegion of temperature.
+	GetMinRegionTemperature(ctx context.Context, in *RegionRequest, opts ...grpc.CallOption) (*TemperatureResponse, error)
+	// GetMaxRegionTemperature is GetMaxInRegion of temperature.
+	GetMaxRegionTemperature(ctx context.Context, in *RegionRequest, opts ...grpc.CallOption) (*TemperatureResponse, error)
+	// GetAvgSensorTemperature is GetAvgForSensor of temperature.
+	GetAvgSensorTemperature(ctx context.Context, in *SensorTemperatureRequest, opts ...grpc.CallOption) (*TemperatureResponse, error)
+	// UpdateSensor is PATCH /api/v1/sensor/{codeName}, it needs the operator role.
+	UpdateSensor(ctx context.Context, in *UpdateSensorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type sensorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSensorServiceClient(cc grpc.ClientConnInterface) SensorServiceClient {
+	return &sensorServiceClient{cc}
+}
+
+func (c *sensorServiceClient) ListSensors(ctx context.Context, in *ListSensorsRequest, opts ...grpc.CallOption) (*ListSensorsResponse, error) {
+	out := new(ListSensorsResponse)
+	err := c.cc.Invoke(ctx, SensorService_ListSensors_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) GetMinInRegion(ctx context.Context, in *RegionMetricRequest, opts ...grpc.CallOption) (*MetricResponse, error) {
+	out := new(MetricResponse)
+	err := c.cc.Invoke(ctx, SensorService_GetMinInRegion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) GetMaxInRegion(ctx context.Context, in *RegionMetricRequest, opts ...grpc.CallOption) (*MetricResponse, error) {
+	out := new(MetricResponse)
+	err := c.cc.Invoke(ctx, SensorService_GetMaxInRegion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) GetAvgForSensor(ctx context.Context, in *SensorMetricRequest, opts ...grpc.CallOption) (*MetricResponse, error) {
+	out := new(MetricResponse)
+	err := c.cc.Invoke(ctx, SensorService_GetAvgForSensor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) GetMinRegionTemperature(ctx context.Context, in *RegionRequest, opts ...grpc.CallOption) (*TemperatureResponse, error) {
+	out := new(TemperatureResponse)
+	err := c.cc.Invoke(ctx, SensorService_GetMinRegionTemperature_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) GetMaxRegionTemperature(ctx context.Context, in *RegionRequest, opts ...grpc.CallOption) (*TemperatureResponse, error) {
+	out := new(TemperatureResponse)
+	err := c.cc.Invoke(ctx, SensorService_GetMaxRegionTemperature_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) GetAvgSensorTemperature(ctx context.Context, in *SensorTemperatureRequest, opts ...grpc.CallOption) (*TemperatureResponse, error) {
+	out := new(TemperatureResponse)
+	err := c.cc.Invoke(ctx, SensorService_GetAvgSensorTemperature_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) UpdateSensor(ctx context.Context, in *UpdateSensorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SensorService_UpdateSensor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SensorServiceServer is the server API for SensorService service.
+// All implementations must embed UnimplementedSensorServiceServer
+// for forward compatibility
+type SensorServiceServer interface {
+	ListSensors(context.Context, *ListSensorsRequest) (*ListSensorsResponse, error)
+	// GetMinInRegion is GET /api/v1/region/{metric}/min.
+	GetMinInRegion(context.Context, *RegionMetricRequest) (*MetricResponse, error)
+	// GetMaxInRegion is GET /api/v1/region/{metric}/max.
+	GetMaxInRegion(context.Context, *RegionMetricRequest) (*MetricResponse, error)
+	// GetAvgForSensor is GET /api/v1/sensor/{codeName}/{metric}/average.
+	GetAvgForSensor(context.Context, *SensorMetricRequest) (*MetricResponse, error)
+	// GetMinRegionTemperature is GetMinInRegion of temperature.
+	GetMinRegionTemperature(context.Context, *RegionRequest) (*TemperatureResponse, error)
+	// GetMaxRegionTemperature is GetMaxInRegion of temperature.
+	GetMaxRegionTemperature(context.Context, *RegionRequest) (*TemperatureResponse, error)
+	// GetAvgSensorTemperature is GetAvgForSensor of temperature.
+	GetAvgSensorTemperature(context.Context, *SensorTemperatureRequest) (*TemperatureResponse, error)
+	// UpdateSensor is PATCH /api/v1/sensor/{codeName}, it needs the operator role.
+	UpdateSensor(context.Context, *UpdateSensorRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedSensorServiceServer()
+}
+
+// UnimplementedSensorServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSensorServiceServer struct {
+}
+
+func (UnimplementedSensorServiceServer) ListSensors(context.Context, *ListSensorsRequest) (*ListSensorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSensors not implemented")
+}
+func (UnimplementedSensorServiceServer) GetMinInRegion(context.Context, *RegionMetricRequest) (*MetricResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMinInRegion not implemented")
+}
+func (UnimplementedSensorServiceServer) GetMaxInRegion(context.Context, *RegionMetricRequest) (*MetricResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMaxInRegion not implemented")
+}
+func (UnimplementedSensorServiceServer) GetAvgForSensor(context.Context, *SensorMetricRequest) (*MetricResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvgForSensor not implemented")
+}
+func (UnimplementedSensorServiceServer) GetMinRegionTemperature(context.Context, *RegionRequest) (*TemperatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMinRegionTemperature not implemented")
+}
+func (UnimplementedSensorServiceServer) GetMaxRegionTemperature(context.Context, *RegionRequest) (*TemperatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMaxRegionTemperature not implemented")
+}
+func (UnimplementedSensorServiceServer) GetAvgSensorTemperature(context.Context, *SensorTemperatureRequest) (*TemperatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvgSensorTemperature not implemented")
+}
+func (UnimplementedSensorServiceServer) UpdateSensor(context.Context, *UpdateSensorRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSensor not implemented")
+}
+func (UnimplementedSensorServiceServer) mustEmbedUnimplementedSensorServiceServer() {}
+
+// UnsafeSensorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SensorServiceServer will
+// result in compilation errors.
+type UnsafeSensorServiceServer interface {
+	mustEmbedUnimplementedSensorServiceServer()
+}
+
+func RegisterSensorServiceServer(s grpc.ServiceRegistrar, srv SensorServiceServer) {
+	s.RegisterService(&SensorService_ServiceDesc, srv)
+}
+
+func _SensorService_ListSensors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSensorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).ListSensors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_ListSensors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).ListSensors(ctx, req.(*ListSensorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_GetMinInRegion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegionMetricRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).GetMinInRegion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_GetMinInRegion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).GetMinInRegion(ctx, req.(*RegionMetricRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_GetMaxInRegion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegionMetricRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).GetMaxInRegion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_GetMaxInRegion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).GetMaxInRegion(ctx, req.(*RegionMetricRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_GetAvgForSensor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SensorMetricRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).GetAvgForSensor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_GetAvgForSensor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).GetAvgForSensor(ctx, req.(*SensorMetricRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_GetMinRegionTemperature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).GetMinRegionTemperature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_GetMinRegionTemperature_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).GetMinRegionTemperature(ctx, req.(*RegionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_GetMaxRegionTemperature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).GetMaxRegionTemperature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_GetMaxRegionTemperature_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).GetMaxRegionTemperature(ctx, req.(*RegionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_GetAvgSensorTemperature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SensorTemperatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).GetAvgSensorTemperature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_GetAvgSensorTemperature_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).GetAvgSensorTemperature(ctx, req.(*SensorTemperatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_UpdateSensor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSensorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).UpdateSensor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_UpdateSensor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).UpdateSensor(ctx, req.(*UpdateSensorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SensorService_ServiceDesc is the grpc.ServiceDesc for SensorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SensorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sensorgen.v1.SensorService",
+	HandlerType: (*SensorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSensors",
+			Handler:    _SensorService_ListSensors_Handler,
+		},
+		{
+			MethodName: "GetMinInRegion",
+			Handler:    _SensorService_GetMinInRegion_Handler,
+		},
+		{
+			MethodName: "GetMaxInRegion",
+			Handler:    _SensorService_GetMaxInRegion_Handler,
+		},
+		{
+			MethodName: "GetAvgForSensor",
+			Handler:    _SensorService_GetAvgForSensor_Handler,
+		},
+		{
+			MethodName: "GetMinRegionTemperature",
+			Handler:    _SensorService_GetMinRegionTemperature_Handler,
+		},
+		{
+			MethodName: "GetMaxRegionTemperature",
+			Handler:    _SensorService_GetMaxRegionTemperature_Handler,
+		},
+		{
+			MethodName: "GetAvgSensorTemperature",
+			Handler:    _SensorService_GetAvgSensorTemperature_Handler,
+		},
+		{
+			MethodName: "UpdateSensor",
+			Handler:    _SensorService_UpdateSensor_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sensorgen/v1/sensor.proto",
+}
@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.5.1-go
+// source: sensorgen/v1/spiece.proto
+
+package sensorgenv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListSpiecesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSpiecesRequest) Reset() {
+	*x = ListSpiecesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_spiece_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSpiecesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSpiecesRequest) ProtoMessage() {}
+
+func (x *ListSpiecesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_spiece_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSpiecesRequest.ProtoReflect.Descriptor instead.
+func (*ListSpiecesRequest) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_spiece_proto_rawDescGZIP(), []int{0}
+}
+
+type ListSpiecesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Spieces []*Spiece `protobuf:"bytes,1,rep,name=spieces,proto3" json:"spieces,omitempty"`
+}
+
+func (x *ListSpiecesResponse) Reset() {
+	*x = ListSpiecesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sensorgen_v1_spiece_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSpiecesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSpiecesResponse) ProtoMessage() {}
+
+func (x *ListSpiecesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sensorgen_v1_spiece_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSpiecesResponse.ProtoReflect.Descriptor instead.
+func (*ListSpiecesResponse) Descriptor() ([]byte, []int) {
+	return file_sensorgen_v1_spiece_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListSpiecesResponse) GetSpieces() []*Spiece {
+	if x != nil {
+		return x.Spieces
+	}
+	return nil
+}
+
+var File_sensorgen_v1_spiece_proto protoreflect.FileDescriptor
+
+var file_sensorgen_v1_spiece_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x73,
+	0x70, 0x69, 0x65, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x73, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x1a, 0x19, 0x73, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x67, 0x65, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x14, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x70, 0x69, 0x65,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x45, 0x0a, 0x13, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2e, 0x0a, 0x07, 0x73, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x70, 0x69, 0x65, 0x63, 0x65, 0x52, 0x07, 0x73, 0x70, 0x69, 0x65, 0x63, 0x65,
+	0x73, 0x32, 0x63, 0x0a, 0x0d, 0x53, 0x70, 0x69, 0x65, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x52, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x70, 0x69, 0x65, 0x63, 0x65,
+	0x73, 0x12, 0x20, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x70, 0x69, 0x65, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x48, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x2e, 0x73, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x50, 0x01, 0x5a, 0x32, 0x73, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x73, 0x2d, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65,
+	0x6e, 0x2f, 0x76, 0x31, 0x3b, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x67, 0x65, 0x6e, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_sensorgen_v1_spiece_proto_rawDescOnce sync.Once
+	file_sensorgen_v1_spiece_proto_rawDescData = file_sensorgen_v1_spiece_proto_rawDesc
+)
+
+func file_sensorgen_v1_spiece_proto_rawDescGZIP() []byte {
+	file_sensorgen_v1_spiece_proto_rawDescOnce.Do(func() {
+		file_sensorgen_v1_spiece_proto_rawDescData = protoimpl.X.CompressGZIP(file_sensorgen_v1_spiece_proto_rawDescData)
+	})
+	return file_sensorgen_v1_spiece_proto_rawDescData
+}
+
+var file_sensorgen_v1_spiece_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_sensorgen_v1_spiece_proto_goTypes = []any{
+	(*ListSpiecesRequest)(nil),  // 0: sensorgen.v1.ListSpiecesRequest
+	(*ListSpiecesResponse)(nil), // 1: sensorgen.v1.ListSpiecesResponse
+	(*Spiece)(nil),              // 2: sensorgen.v1.Spiece
+}
+var file_sensorgen_v1_spiece_proto_depIdxs = []int32{
+	2, // 0: sensorgen.v1.ListSpiecesResponse.spieces:type_name -> sensorgen.v1.Spiece
+	0, // 1: sensorgen.v1.SpieceService.ListSpieces:input_type -> sensorgen.v1.ListSpiecesRequest
+	1, // 2: sensorgen.v1.SpieceService.ListSpieces:output_type -> sensorgen.v1.ListSpiecesResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_sensorgen_v1_spiece_proto_init() }
+func file_sensorgen_v1_spiece_proto_init() {
+	if File_sensorgen_v1_spiece_proto != nil {
+		return
+	}
+	file_sensorgen_v1_common_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_sensorgen_v1_spiece_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ListSpiecesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sensorgen_v1_spiece_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ListSpiecesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_sensorgen_v1_spiece_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sensorgen_v1_spiece_proto_goTypes,
+		DependencyIndexes: file_sensorgen_v1_spiece_proto_depIdxs,
+		MessageInfos:      file_sensorgen_v1_spiece_proto_msgTypes,
+	}.Build()
+	File_sensorgen_v1_spiece_proto = out.File
+	file_sensorgen_v1_spiece_proto_rawDesc = nil
+	file_sensorgen_v1_spiece_proto_goTypes = nil
+	file_sensorgen_v1_spiece_proto_depIdxs = nil
+}
@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.5.1-go
+// source: sensorgen/v1/spiece.proto
+
+package sensorgenv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	SpieceService_ListSpieces_FullMethodName = "/sensorgen.v1.SpieceService/ListSpieces"
+)
+
+// SpieceServiceClient is the client API for SpieceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SpieceServiceClient interface {
+	ListSpieces(ctx context.Context, in *ListSpiecesRequest, opts ...grpc.CallOption) (*ListSpiecesResponse, error)
+}
+
+type spieceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSpieceServiceClient(cc grpc.ClientConnInterface) SpieceServiceClient {
+	return &spieceServiceClient{cc}
+}
+
+func (c *spieceServiceClient) ListSpieces(ctx context.Context, in *ListSpiecesRequest, opts ...grpc.CallOption) (*ListSpiecesResponse, error) {
+	out := new(ListSpiecesResponse)
+	err := c.cc.Invoke(ctx, SpieceService_ListSpieces_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SpieceServiceServer is the server API for SpieceService service.
+// All implementations must embed UnimplementedSpieceServiceServer
+// for forward compatibility
+type SpieceServiceServer interface {
+	ListSpieces(context.Context, *ListSpiecesRequest) (*ListSpiecesResponse, error)
+	mustEmbedUnimplementedSpieceServiceServer()
+}
+
+// UnimplementedSpieceServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSpieceServiceServer struct {
+}
+
+func (UnimplementedSpieceServiceServer) ListSpieces(context.Context, *ListSpiecesRequest) (*ListSpiecesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSpieces not implemented")
+}
+func (UnimplementedSpieceServiceServer) mustEmbedUnimplementedSpieceServiceServer() {}
+
+// UnsafeSpieceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SpieceServiceServer will
+// result in compilation errors.
+type UnsafeSpieceServiceServer interface {
+	mustEmbedUnimplementedSpieceServiceServer()
+}
+
+func RegisterSpieceServiceServer(s grpc.ServiceRegistrar, srv SpieceServiceServer) {
+	s.RegisterService(&SpieceService_ServiceDesc, srv)
+}
+
+func _SpieceService_ListSpieces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSpiecesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpieceServiceServer).ListSpieces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpieceService_ListSpieces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpieceServiceServer).ListSpieces(ctx, req.(*ListSpiecesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SpieceService_ServiceDesc is the grpc.ServiceDesc for SpieceService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SpieceService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sensorgen.v1.SpieceService",
+	HandlerType: (*SpieceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSpieces",
+			Handler:    _SpieceService_ListSpieces_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sensorgen/v1/spiece.proto",
+}
@@ -11,7 +11,7 @@ import (
 type PgConfig struct {
 	Username string `yaml:"username" env:"USERNAME" env-default:"vlad"`
 	Database string `yaml:"database" env:"DATABASE" env-default:"messenger"`
-	Password string `yaml:"password" env:"PASSWORD" env-default:""`
+	Password string `yaml:"password" env:"PASSWORD" env-default:"" secret:"true"`
 	Host     string `yaml:"host" env:"HOST" env-default:"localhost"`
 	Port     string `yaml:"port" env:"PORT" env-default:"5432"`
 	SSLMode  string `yaml:"ssl_mode" env:"SSL_MODE" env-default:"disable"`
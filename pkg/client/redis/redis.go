@@ -3,20 +3,22 @@ package redis
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
 const (
-	TTL = 10 * time.Second
+	// DefaultTTL makes Set use TTL of the cache, which can be changed on config reload.
+	DefaultTTL time.Duration = 0
 )
 
 type RedisConfig struct {
 	Network         string `yaml:"network" env:"NETWORK" env-default:"tcp"`
 	Host            string `yaml:"host" env:"HOST" env-default:"localhost"`
 	Port            string `yaml:"port" env:"PORT" env-default:"6379"`
-	Password        string `yaml:"password" env:"PASSWORD" env-default:""`
+	Password        string `yaml:"password" env:"PASSWORD" env-default:"" secret:"true"`
 	Database        int    `yaml:"database" env:"DATABASE" env-default:"0"`
 	MaxRetries      int    `yaml:"max_retries" env:"MAX_RETRIES" env-default:"3"`
 	DialTimeout     int    `yaml:"dial_timeout" env:"DIAL_TIMEOUT" env-default:"5"`
@@ -27,13 +29,31 @@ type RedisConfig struct {
 	MinIdleConns    int    `yaml:"min_idle_conns" env:"MIN_IDLE_CONNS" env-default:"0"`
 	MaxIdleConns    int    `yaml:"max_idle_conns" env:"MAX_IDLE_CONNS" env-default:"0"`
 	ConnMaxIdleTime int    `yaml:"conn_max_idle_time" env:"CONN_MAX_IDLE_TIME" env-default:"1800"`
+
+	TTL time.Duration `yaml:"ttl" env:"TTL" env-default:"10s" env-description:"expiration of cached values set with DefaultTTL"`
 }
 
 type RedisCache struct {
 	client *redis.Client
+	ttl    atomic.Int64
 }
 
 func NewRedisCache(cfg RedisConfig) *RedisCache {
+	c := &RedisCache{
+		client: NewClient(cfg),
+	}
+	c.SetTTL(cfg.TTL)
+
+	return c
+}
+
+// SetTTL changes expiration of values which are set later with DefaultTTL.
+func (r *RedisCache) SetTTL(ttl time.Duration) {
+	r.ttl.Store(int64(ttl))
+}
+
+// NewClient creates go-redis client, it is shared by the cache and other redis based stores.
+func NewClient(cfg RedisConfig) *redis.Client {
 	if cfg.PoolTimeout == 0 {
 		cfg.PoolTimeout = cfg.ReadTimeout + 1
 	}
@@ -52,12 +72,19 @@ func NewRedisCache(cfg RedisConfig) *RedisCache {
 		ConnMaxIdleTime: time.Duration(cfg.ConnMaxIdleTime) * time.Second,
 	})
 
-	return &RedisCache{
-		client: rdb,
-	}
+	return rdb
+}
+
+// Client returns underlying client, so other redis based stores can share its pool.
+func (r *RedisCache) Client() *redis.Client {
+	return r.client
 }
 
 func (r *RedisCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if expiration == DefaultTTL {
+		expiration = time.Duration(r.ttl.Load())
+	}
+
 	cmd := r.client.Set(ctx, key, value, expiration)
 
 	if _, err := cmd.Result(); err != nil {
@@ -0,0 +1,44 @@
+package clock
+
+import "time"
+
+// Accelerated starts at the given time and runs speed times faster than the wall clock.
+type Accelerated struct {
+	start time.Time
+	began time.Time
+	speed float64
+}
+
+func NewAccelerated(start time.Time, speed float64) *Accelerated {
+	return &Accelerated{start: start, began: time.Now(), speed: speed}
+}
+
+func (a *Accelerated) Now() time.Time {
+	return a.start.Add(time.Duration(float64(time.Since(a.began)) * a.speed))
+}
+
+// Speed is how many simulated seconds pass in a second.
+func (a *Accelerated) Speed() float64 {
+	return a.speed
+}
+
+func (a *Accelerated) NewTimer(d time.Duration) Timer {
+	t := &acceleratedTimer{c: make(chan time.Time, 1)}
+	t.timer = time.AfterFunc(time.Duration(float64(d)/a.speed), func() {
+		t.c <- a.Now()
+	})
+	return t
+}
+
+type acceleratedTimer struct {
+	c     chan time.Time
+	timer *time.Timer
+}
+
+func (t *acceleratedTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *acceleratedTimer) Stop() bool {
+	return t.timer.Stop()
+}
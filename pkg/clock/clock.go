@@ -0,0 +1,119 @@
+// Package clock lets the generator run in real, accelerated or stepped time.
+package clock
+
+import (
+	"fmt"
+	"time"
+)
+
+// Mode of the clock.
+type Mode string
+
+const (
+	// ModeReal follows the wall clock.
+	ModeReal Mode = "real"
+	// ModeAccelerated runs Speed times faster than the wall clock.
+	ModeAccelerated Mode = "accelerated"
+	// ModeStepped stands still till it is advanced.
+	ModeStepped Mode = "stepped"
+)
+
+// Config of the clock.
+type Config struct {
+	Mode Mode `yaml:"mode" env:"MODE" env-default:"real" env-description:"real, accelerated or stepped"`
+	// Speed is how many simulated seconds pass in a second of accelerated time.
+	Speed float64 `yaml:"speed" env:"SPEED" env-default:"60"`
+	// Start is the simulated time when the app starts, empty is now. Real time ignores it.
+	Start string `yaml:"start" env:"START" env-description:"RFC3339 time, empty is now"`
+}
+
+// Clock tells the time and fires timers in its time.
+type Clock interface {
+	Now() time.Time
+	// NewTimer fires when the clock reaches Now() + d, the channel receives the time of the clock.
+	NewTimer(d time.Duration) Timer
+}
+
+type Timer interface {
+	C() <-chan time.Time
+	// Stop reports whether the timer was stopped before it fired.
+	Stop() bool
+}
+
+// Advancer is a clock which is moved forward by hand.
+type Advancer interface {
+	Advance(d time.Duration) time.Time
+}
+
+// New returns the clock of the config.
+func New(cfg Config) (Clock, error) {
+	start := time.Now()
+	if cfg.Start != "" && cfg.Mode != ModeReal {
+		var err error
+		if start, err = time.Parse(time.RFC3339, cfg.Start); err != nil {
+			return nil, fmt.Errorf("start: %w", err)
+		}
+	}
+
+	switch cfg.Mode {
+	case ModeReal, "":
+		return Real{}, nil
+	case ModeAccelerated:
+		return NewAccelerated(start, cfg.Speed), nil
+	case ModeStepped:
+		return NewStepped(start), nil
+	}
+
+	return nil, fmt.Errorf("unknown clock mode %q", cfg.Mode)
+}
+
+// ModeOf returns the mode of the clock.
+func ModeOf(c Clock) Mode {
+	switch c.(type) {
+	case *Accelerated:
+		return ModeAccelerated
+	case *Stepped:
+		return ModeStepped
+	}
+	return ModeReal
+}
+
+// Validate returns problems of the config, config validation reports them.
+func (c Config) Validate() []string {
+	problems := make([]string, 0)
+
+	switch c.Mode {
+	case ModeReal, ModeAccelerated, ModeStepped:
+	default:
+		problems = append(problems, "mode should be real, accelerated or stepped")
+	}
+	if c.Mode == ModeAccelerated && c.Speed <= 0 {
+		problems = append(problems, "speed should be positive")
+	}
+	if c.Start != "" {
+		if _, err := time.Parse(time.RFC3339, c.Start); err != nil {
+			problems = append(problems, "start should be RFC3339 time")
+		}
+	}
+
+	return problems
+}
+
+// Real is the wall clock.
+type Real struct{}
+
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+func (Real) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
@@ -0,0 +1,95 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Stepped stands still till Advance moves it, timers which are due fire in the order of their deadlines.
+// Tests and demos advance it instead of sleeping.
+type Stepped struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*steppedTimer
+}
+
+func NewStepped(start time.Time) *Stepped {
+	return &Stepped{now: start}
+}
+
+func (s *Stepped) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.now
+}
+
+func (s *Stepped) NewTimer(d time.Duration) Timer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := &steppedTimer{clock: s, deadline: s.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- s.now
+		return t
+	}
+
+	s.timers = append(s.timers, t)
+	return t
+}
+
+// Advance moves the clock forward by d and fires timers which are due, it returns the new time.
+func (s *Stepped) Advance(d time.Duration) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.now = s.now.Add(d)
+
+	sort.SliceStable(s.timers, func(i, j int) bool {
+		return s.timers[i].deadline.Before(s.timers[j].deadline)
+	})
+
+	waiting := s.timers[:0]
+	for _, t := range s.timers {
+		if t.deadline.After(s.now) {
+			waiting = append(waiting, t)
+			continue
+		}
+		t.c <- t.deadline
+	}
+	s.timers = waiting
+
+	return s.now
+}
+
+// Waiting returns the number of timers which have not fired, tests wait for goroutines to set their timers.
+func (s *Stepped) Waiting() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.timers)
+}
+
+type steppedTimer struct {
+	clock    *Stepped
+	deadline time.Time
+	c        chan time.Time
+}
+
+func (t *steppedTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *steppedTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	for i, waiting := range t.clock.timers {
+		if waiting == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
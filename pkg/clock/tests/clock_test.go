@@ -0,0 +1,65 @@
+package clock
+
+import (
+	"sensors-generator/pkg/clock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+func Test_Stepped(t *testing.T) {
+	clk := clock.NewStepped(start)
+
+	late := clk.NewTimer(time.Hour)
+	early := clk.NewTimer(time.Minute)
+	stopped := clk.NewTimer(time.Minute)
+	assert.True(t, stopped.Stop())
+	assert.Equal(t, 2, clk.Waiting())
+
+	assert.Equal(t, start.Add(30*time.Minute), clk.Advance(30*time.Minute))
+	assert.Equal(t, start.Add(time.Minute), <-early.C())
+	assert.Equal(t, 1, clk.Waiting())
+	assert.Len(t, stopped.C(), 0)
+	assert.Len(t, late.C(), 0)
+
+	clk.Advance(time.Hour)
+	assert.Equal(t, start.Add(time.Hour), <-late.C())
+	assert.False(t, late.Stop())
+
+	// A timer which is already due fires at once.
+	assert.Equal(t, clk.Now(), <-clk.NewTimer(0).C())
+}
+
+func Test_Accelerated(t *testing.T) {
+	clk := clock.NewAccelerated(start, 3600)
+
+	timer := clk.NewTimer(time.Minute)
+	fired := <-timer.C()
+
+	// A simulated minute passes in about 17 milliseconds.
+	assert.False(t, fired.Before(start.Add(time.Minute)))
+	assert.True(t, clk.Now().After(start.Add(time.Minute)))
+	assert.True(t, clk.Now().Before(start.Add(time.Hour)))
+}
+
+func Test_New(t *testing.T) {
+	clk, err := clock.New(clock.Config{Mode: clock.ModeStepped, Start: "2023-07-01T00:00:00Z"})
+	assert.NoError(t, err)
+	assert.Equal(t, clock.ModeStepped, clock.ModeOf(clk))
+	assert.Equal(t, start, clk.Now())
+
+	clk, err = clock.New(clock.Config{Mode: clock.ModeReal, Start: "2023-07-01T00:00:00Z"})
+	assert.NoError(t, err)
+	assert.Equal(t, clock.ModeReal, clock.ModeOf(clk))
+	assert.WithinDuration(t, time.Now(), clk.Now(), time.Second)
+
+	_, err = clock.New(clock.Config{Mode: "fast"})
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{"mode should be real, accelerated or stepped", "start should be RFC3339 time"},
+		clock.Config{Mode: "fast", Start: "yesterday"}.Validate())
+	assert.Equal(t, []string{"speed should be positive"}, clock.Config{Mode: clock.ModeAccelerated}.Validate())
+}
@@ -0,0 +1,33 @@
+package environment
+
+import (
+	"math"
+	"time"
+)
+
+// Currents move drifting sensors. The water flows steadily with U along X and V along Y,
+// the tide adds a flow of Tidal which turns around once a TidalPeriod. Both weaken with depth.
+// Speeds are m/s.
+type Currents struct {
+	U           float64       `yaml:"u" env:"U" env-default:"0.05"`
+	V           float64       `yaml:"v" env:"V" env-default:"0.02"`
+	Tidal       float64       `yaml:"tidal" env:"TIDAL" env-default:"0.1"`
+	TidalPeriod time.Duration `yaml:"tidal_period" env:"TIDAL_PERIOD" env-default:"12h25m"`
+	// Depth is where currents are e times weaker than at the surface, meters.
+	Depth float64 `yaml:"depth" env:"DEPTH" env-default:"50"`
+}
+
+// Velocity of the water at the point and time, m/s. Z of the velocity is always 0,
+// currents do not move sensors up and down.
+func (c Currents) Velocity(p Point, at time.Time) Point {
+	weakening := math.Exp(-math.Max(p.Z, 0) / c.Depth)
+
+	u, v := c.U, c.V
+	if c.Tidal > 0 && c.TidalPeriod > 0 {
+		phase := 2 * math.Pi * float64(at.UnixNano()%int64(c.TidalPeriod)) / float64(c.TidalPeriod)
+		u += c.Tidal * math.Cos(phase)
+		v += c.Tidal * math.Sin(phase)
+	}
+
+	return Point{X: u * weakening, Y: v * weakening}
+}
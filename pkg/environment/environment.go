@@ -0,0 +1,162 @@
+package environment
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config of the shared environment. All sensors sample the same field, so neighbouring sensors
+// measure close values and aggregates of a region follow the same weather.
+type Config struct {
+	// Seed makes the field repeatable, 0 seeds with the current time.
+	Seed int64 `yaml:"seed" env:"SEED" env-default:"0"`
+
+	// SurfaceTemperature is the mean temperature at the surface over a year.
+	SurfaceTemperature float64 `yaml:"surface_temperature" env:"SURFACE_TEMPERATURE" env-default:"16"`
+	// SeasonalAmplitude is how much the surface is warmer in summer and colder in winter.
+	SeasonalAmplitude float64 `yaml:"seasonal_amplitude" env:"SEASONAL_AMPLITUDE" env-default:"6"`
+	// DiurnalAmplitude is how much the surface is warmer in the afternoon and colder at night.
+	DiurnalAmplitude float64 `yaml:"diurnal_amplitude" env:"DIURNAL_AMPLITUDE" env-default:"0.5"`
+	// DeepTemperature is the temperature below the thermocline.
+	DeepTemperature float64 `yaml:"deep_temperature" env:"DEEP_TEMPERATURE" env-default:"4"`
+	// ThermoclineDepth is the depth in meters where the temperature is between the surface and the deep one.
+	ThermoclineDepth float64 `yaml:"thermocline_depth" env:"THERMOCLINE_DEPTH" env-default:"30"`
+	// ThermoclineWidth is how many meters the temperature takes to drop at the thermocline.
+	ThermoclineWidth float64 `yaml:"thermocline_width" env:"THERMOCLINE_WIDTH" env-default:"10"`
+
+	// Transparency is the mean transparency, percents.
+	Transparency float64 `yaml:"transparency" env:"TRANSPARENCY" env-default:"70"`
+
+	// Salinity, Oxygen and PH are measured by sensors which carry them.
+	Salinity Salinity `yaml:"salinity" env-prefix:"SALINITY_"`
+	Oxygen   Oxygen   `yaml:"oxygen" env-prefix:"OXYGEN_"`
+	PH       PH       `yaml:"ph" env-prefix:"PH_"`
+
+	// Anomalies are warm and cold, clear and turbid patches which drift through the field.
+	Anomalies Anomalies `yaml:"anomalies" env-prefix:"ANOMALIES_"`
+	// Noise is measurement noise of sensors.
+	Noise Noise `yaml:"noise" env-prefix:"NOISE_"`
+
+	// Currents move drifting sensors.
+	Currents Currents `yaml:"currents" env-prefix:"CURRENTS_"`
+
+	// Events are scheduled storms, upwellings and algal blooms, more events can be created with the api.
+	Events []Event `yaml:"events"`
+}
+
+type Anomalies struct {
+	// Temperature and Transparency are standard deviations of anomalies.
+	Temperature  float64 `yaml:"temperature" env:"TEMPERATURE" env-default:"1.5"`
+	Transparency float64 `yaml:"transparency" env:"TRANSPARENCY" env-default:"15"`
+	// Scale is the size of a patch in meters, sensors closer than it measure similar anomalies.
+	Scale float64 `yaml:"scale" env:"SCALE" env-default:"50"`
+	// Period is how long a patch takes to change.
+	Period time.Duration `yaml:"period" env:"PERIOD" env-default:"6h"`
+}
+
+// Salinity changes from the surface to the deep one at the halocline, PSU.
+type Salinity struct {
+	Surface        float64 `yaml:"surface" env:"SURFACE" env-default:"33"`
+	Deep           float64 `yaml:"deep" env:"DEEP" env-default:"35"`
+	HaloclineDepth float64 `yaml:"halocline_depth" env:"HALOCLINE_DEPTH" env-default:"50"`
+	HaloclineWidth float64 `yaml:"halocline_width" env:"HALOCLINE_WIDTH" env-default:"15"`
+}
+
+// Oxygen is saturated at the surface, the saturation falls to DeepSaturation with the depth,
+// since oxygen is consumed and not mixed in. How much oxygen saturated water holds depends on its temperature.
+type Oxygen struct {
+	DeepSaturation float64 `yaml:"deep_saturation" env:"DEEP_SATURATION" env-default:"0.6"`
+	// Depth is where the saturation is e times closer to the deep one than at the surface, meters.
+	Depth float64 `yaml:"depth" env:"DEPTH" env-default:"150"`
+}
+
+// PH drops below Surface where oxygen is consumed, Drop is the drop of water without oxygen.
+type PH struct {
+	Surface float64 `yaml:"surface" env:"SURFACE" env-default:"8.1"`
+	Drop    float64 `yaml:"drop" env:"DROP" env-default:"0.5"`
+}
+
+// Noise of every sensor is a constant bias drawn once for the sensor and a random error of every reading,
+// both are standard deviations.
+type Noise struct {
+	TemperatureBias  float64 `yaml:"temperature_bias" env:"TEMPERATURE_BIAS" env-default:"0.1"`
+	Temperature      float64 `yaml:"temperature" env:"TEMPERATURE" env-default:"0.05"`
+	TransparencyBias float64 `yaml:"transparency_bias" env:"TRANSPARENCY_BIAS" env-default:"1"`
+	Transparency     float64 `yaml:"transparency" env:"TRANSPARENCY" env-default:"1"`
+	// Salinity, Pressure, Oxygen and PH are random errors only.
+	Salinity float64 `yaml:"salinity" env:"SALINITY" env-default:"0.02"`
+	Pressure float64 `yaml:"pressure" env:"PRESSURE" env-default:"0.1"`
+	Oxygen   float64 `yaml:"oxygen" env:"OXYGEN" env-default:"0.05"`
+	PH       float64 `yaml:"ph" env:"PH" env-default:"0.005"`
+}
+
+// Validate returns problems of the config, config validation reports them.
+func (c Config) Validate() []string {
+	problems := make([]string, 0)
+	notNegative := func(name string, value float64) {
+		if value < 0 {
+			problems = append(problems, fmt.Sprintf("%s should not be negative", name))
+		}
+	}
+
+	notNegative("seasonal_amplitude", c.SeasonalAmplitude)
+	notNegative("diurnal_amplitude", c.DiurnalAmplitude)
+	notNegative("thermocline_depth", c.ThermoclineDepth)
+	if c.ThermoclineWidth <= 0 {
+		problems = append(problems, "thermocline_width should be positive")
+	}
+	if c.Transparency < 0 || c.Transparency > 100 {
+		problems = append(problems, "transparency should be from 0 to 100")
+	}
+
+	notNegative("salinity.surface", c.Salinity.Surface)
+	notNegative("salinity.deep", c.Salinity.Deep)
+	notNegative("salinity.halocline_depth", c.Salinity.HaloclineDepth)
+	if c.Salinity.HaloclineWidth <= 0 {
+		problems = append(problems, "salinity.halocline_width should be positive")
+	}
+	if c.Oxygen.DeepSaturation < 0 || c.Oxygen.DeepSaturation > 1 {
+		problems = append(problems, "oxygen.deep_saturation should be from 0 to 1")
+	}
+	if c.Oxygen.Depth <= 0 {
+		problems = append(problems, "oxygen.depth should be positive")
+	}
+	if c.PH.Surface < 0 || c.PH.Surface > 14 {
+		problems = append(problems, "ph.surface should be from 0 to 14")
+	}
+	notNegative("ph.drop", c.PH.Drop)
+
+	notNegative("anomalies.temperature", c.Anomalies.Temperature)
+	notNegative("anomalies.transparency", c.Anomalies.Transparency)
+	if c.Anomalies.Scale <= 0 {
+		problems = append(problems, "anomalies.scale should be positive")
+	}
+	if c.Anomalies.Period <= 0 {
+		problems = append(problems, "anomalies.period should be positive")
+	}
+
+	notNegative("noise.temperature_bias", c.Noise.TemperatureBias)
+	notNegative("noise.temperature", c.Noise.Temperature)
+	notNegative("noise.transparency_bias", c.Noise.TransparencyBias)
+	notNegative("noise.transparency", c.Noise.Transparency)
+	notNegative("noise.salinity", c.Noise.Salinity)
+	notNegative("noise.pressure", c.Noise.Pressure)
+	notNegative("noise.oxygen", c.Noise.Oxygen)
+	notNegative("noise.ph", c.Noise.PH)
+
+	notNegative("currents.tidal", c.Currents.Tidal)
+	if c.Currents.TidalPeriod <= 0 {
+		problems = append(problems, "currents.tidal_period should be positive")
+	}
+	if c.Currents.Depth <= 0 {
+		problems = append(problems, "currents.depth should be positive")
+	}
+
+	for i, e := range c.Events {
+		for _, problem := range e.Validate() {
+			problems = append(problems, fmt.Sprintf("events[%d] %s: %s", i, e.Name, problem))
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,197 @@
+package environment
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// EventKind decides what an event changes.
+type EventKind string
+
+const (
+	// KindStorm lowers transparency.
+	KindStorm EventKind = "storm"
+	// KindUpwelling cools the water near the surface.
+	KindUpwelling EventKind = "upwelling"
+	// KindAlgalBloom lowers transparency and changes the mix of spieces.
+	KindAlgalBloom EventKind = "algal_bloom"
+)
+
+var EventKinds = []EventKind{KindStorm, KindUpwelling, KindAlgalBloom}
+
+// defaultMagnitudes are used when an event has no magnitude.
+var defaultMagnitudes = map[EventKind]float64{
+	KindStorm:      40,
+	KindUpwelling:  4,
+	KindAlgalBloom: 30,
+}
+
+// upwellingDepth is the depth in meters where cooling of an upwelling is e times weaker.
+const upwellingDepth = 20
+
+func NewEventKindFromString(kind string) (EventKind, error) {
+	for _, k := range EventKinds {
+		if string(k) == kind {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown event kind %q", kind)
+}
+
+// Shape of ramp up, decay and edges of an event.
+type Shape string
+
+const (
+	ShapeLinear Shape = "linear"
+	// ShapeSmooth starts and ends changes slowly, as a half of a cosine.
+	ShapeSmooth Shape = "smooth"
+)
+
+// Region is a box of the field.
+type Region struct {
+	Min Point `yaml:"min" json:"min"`
+	Max Point `yaml:"max" json:"max"`
+}
+
+// Event changes the field inside Region from StartsAt till EndsAt. It reaches full intensity
+// RampUp after the start and fades out during Decay before the end, outside the region
+// it fades out within Edge meters.
+type Event struct {
+	Name     string        `yaml:"name"`
+	Kind     EventKind     `yaml:"kind"`
+	Region   Region        `yaml:"region"`
+	Edge     float64       `yaml:"edge"`
+	StartsAt time.Time     `yaml:"starts_at"`
+	EndsAt   time.Time     `yaml:"ends_at"`
+	RampUp   time.Duration `yaml:"ramp_up"`
+	Decay    time.Duration `yaml:"decay"`
+	// Shape is linear when it is empty.
+	Shape Shape `yaml:"shape"`
+	// Magnitude at full intensity: storm and algal bloom lower transparency by it (percents),
+	// upwelling cools the surface by it (degrees). Zero uses the default of the kind.
+	Magnitude float64 `yaml:"magnitude"`
+	// Spieces multiply abundance of spieces by name at full intensity, e.g. 2 doubles detections of the spiece.
+	Spieces map[string]float64 `yaml:"spieces"`
+}
+
+// Effect of events at a point.
+type Effect struct {
+	Temperature  float64
+	Transparency float64
+	// Spieces multiply abundance of spieces by name, missing spieces are not changed.
+	Spieces map[string]float64
+}
+
+// Effects sums effects of events at the point and time.
+func Effects(events []Event, p Point, at time.Time) Effect {
+	effect := Effect{}
+
+	for _, e := range events {
+		intensity := e.Intensity(p, at)
+		if intensity <= 0 {
+			continue
+		}
+
+		magnitude := e.Magnitude
+		if magnitude == 0 {
+			magnitude = defaultMagnitudes[e.Kind]
+		}
+
+		switch e.Kind {
+		case KindStorm, KindAlgalBloom:
+			effect.Transparency -= intensity * magnitude
+		case KindUpwelling:
+			effect.Temperature -= intensity * magnitude * math.Exp(-p.Z/upwellingDepth)
+		}
+
+		for name, factor := range e.Spieces {
+			if effect.Spieces == nil {
+				effect.Spieces = make(map[string]float64)
+			}
+			if _, ok := effect.Spieces[name]; !ok {
+				effect.Spieces[name] = 1
+			}
+			effect.Spieces[name] *= 1 + intensity*(factor-1)
+		}
+	}
+
+	return effect
+}
+
+// Intensity of the event from 0 to 1 at the point and time.
+func (e Event) Intensity(p Point, at time.Time) float64 {
+	if at.Before(e.StartsAt) || !at.Before(e.EndsAt) {
+		return 0
+	}
+
+	intensity := 1.0
+	if since := at.Sub(e.StartsAt); since < e.RampUp {
+		intensity = float64(since) / float64(e.RampUp)
+	}
+	if till := e.EndsAt.Sub(at); till < e.Decay {
+		intensity = math.Min(intensity, float64(till)/float64(e.Decay))
+	}
+
+	if distance := e.Region.distance(p); distance > 0 {
+		if distance >= e.Edge {
+			return 0
+		}
+		intensity *= 1 - distance/e.Edge
+	}
+
+	if e.Shape == ShapeSmooth {
+		intensity = (1 - math.Cos(math.Pi*intensity)) / 2
+	}
+
+	return intensity
+}
+
+// Validate returns problems of the event.
+func (e Event) Validate() []string {
+	problems := make([]string, 0)
+
+	if e.Name == "" {
+		problems = append(problems, "name is required")
+	}
+	if _, err := NewEventKindFromString(string(e.Kind)); err != nil {
+		problems = append(problems, "kind should be storm, upwelling or algal_bloom")
+	}
+	if e.Shape != "" && e.Shape != ShapeLinear && e.Shape != ShapeSmooth {
+		problems = append(problems, "shape should be linear or smooth")
+	}
+	if e.Region.Min.X > e.Region.Max.X || e.Region.Min.Y > e.Region.Max.Y || e.Region.Min.Z > e.Region.Max.Z {
+		problems = append(problems, "region.min should not be greater than region.max")
+	}
+	if e.Edge < 0 {
+		problems = append(problems, "edge should not be negative")
+	}
+	if !e.StartsAt.Before(e.EndsAt) {
+		problems = append(problems, "starts_at should be before ends_at")
+	}
+	if e.RampUp < 0 || e.Decay < 0 {
+		problems = append(problems, "ramp_up and decay should not be negative")
+	} else if e.RampUp+e.Decay > e.EndsAt.Sub(e.StartsAt) {
+		problems = append(problems, "ramp_up and decay should fit between starts_at and ends_at")
+	}
+	if e.Magnitude < 0 {
+		problems = append(problems, "magnitude should not be negative")
+	}
+	for name, factor := range e.Spieces {
+		if factor < 0 {
+			problems = append(problems, fmt.Sprintf("spieces.%s should not be negative", name))
+		}
+	}
+
+	return problems
+}
+
+// distance from the point to the region, 0 inside it.
+func (r Region) distance(p Point) float64 {
+	outside := func(value, min, max float64) float64 {
+		return math.Max(0, math.Max(min-value, value-max))
+	}
+
+	dx, dy, dz := outside(p.X, r.Min.X, r.Max.X), outside(p.Y, r.Min.Y, r.Max.Y), outside(p.Z, r.Min.Z, r.Max.Z)
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
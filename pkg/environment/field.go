@@ -0,0 +1,124 @@
+package environment
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	// waves is the number of plane waves summed into anomalies, more waves look less regular.
+	waves = 12
+	// warmestDay is the day of the year when the surface is the warmest.
+	warmestDay = 220
+	// warmestHour is the hour (UTC) of the day when the surface is the warmest.
+	warmestHour = 15
+	// diurnalDepth is the depth in meters where the daily change of temperature is e times weaker.
+	diurnalDepth = 10
+
+	year = 365.25 * 24 * time.Hour
+	day  = 24 * time.Hour
+)
+
+// Point in the field, Z is the depth in meters.
+type Point struct {
+	X float64 `yaml:"x" json:"x"`
+	Y float64 `yaml:"y" json:"y"`
+	Z float64 `yaml:"z" json:"z"`
+}
+
+type wave struct {
+	kx, ky, kz float64
+	omega      float64
+	phase      float64
+}
+
+// Field is temperature and transparency of the water in space and time. Temperature is warm at the surface
+// and drops at the thermocline, the surface follows seasons and days. Anomalies are sums of plane waves
+// with random directions, so they are smooth in space, change slowly in time and repeat with the same seed.
+// Field is read only after it is created and safe for concurrent use.
+type Field struct {
+	cfg               Config
+	temperatureWaves  []wave
+	transparencyWaves []wave
+}
+
+func NewField(cfg Config) *Field {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	rnd := rand.New(rand.NewSource(seed))
+	return &Field{
+		cfg:               cfg,
+		temperatureWaves:  newWaves(rnd, cfg.Anomalies),
+		transparencyWaves: newWaves(rnd, cfg.Anomalies),
+	}
+}
+
+// Temperature at the point and time, degrees.
+func (f *Field) Temperature(p Point, at time.Time) float64 {
+	cfg := f.cfg
+
+	surface := cfg.SurfaceTemperature +
+		cfg.SeasonalAmplitude*math.Cos(2*math.Pi*(dayOfYear(at)-warmestDay)*float64(day)/float64(year)) +
+		cfg.DiurnalAmplitude*math.Cos(2*math.Pi*(hourOfDay(at)-warmestHour)/24)*math.Exp(-p.Z/diurnalDepth)
+	surface += cfg.Anomalies.Temperature * sum(f.temperatureWaves, p, at)
+
+	// Share of the surface water at the depth, it is close to 1 above the thermocline and to 0 below it.
+	mixed := 1 / (1 + math.Exp((p.Z-cfg.ThermoclineDepth)/cfg.ThermoclineWidth))
+
+	return cfg.DeepTemperature + (surface-cfg.DeepTemperature)*mixed
+}
+
+// Transparency at the point and time, percents from 0 to 100.
+func (f *Field) Transparency(p Point, at time.Time) float64 {
+	transparency := f.cfg.Transparency + f.cfg.Anomalies.Transparency*sum(f.transparencyWaves, p, at)
+	return clamp(transparency, 0, 100)
+}
+
+func newWaves(rnd *rand.Rand, anomalies Anomalies) []wave {
+	result := make([]wave, 0, waves)
+	for i := 0; i < waves; i++ {
+		k := 2 * math.Pi / anomalies.Scale * (0.5 + rnd.Float64())
+		direction := rnd.Float64() * 2 * math.Pi
+		omega := 2 * math.Pi / anomalies.Period.Seconds() * (0.5 + rnd.Float64())
+		if rnd.Intn(2) == 0 {
+			omega = -omega
+		}
+
+		result = append(result, wave{
+			kx:    k * math.Cos(direction),
+			ky:    k * math.Sin(direction),
+			kz:    k * (rnd.Float64() - 0.5),
+			omega: omega,
+			phase: rnd.Float64() * 2 * math.Pi,
+		})
+	}
+	return result
+}
+
+// sum of waves has zero mean and standard deviation 1.
+func sum(waves []wave, p Point, at time.Time) float64 {
+	if len(waves) == 0 {
+		return 0
+	}
+
+	t := float64(at.UnixNano()) / float64(time.Second)
+	var s float64
+	for _, w := range waves {
+		s += math.Sin(w.kx*p.X + w.ky*p.Y + w.kz*p.Z - w.omega*t + w.phase)
+	}
+	return s * math.Sqrt(2/float64(len(waves)))
+}
+
+func dayOfYear(at time.Time) float64 {
+	at = at.UTC()
+	return float64(at.YearDay()-1) + hourOfDay(at)/24
+}
+
+func hourOfDay(at time.Time) float64 {
+	at = at.UTC()
+	return float64(at.Hour()) + float64(at.Minute())/60 + float64(at.Second())/3600
+}
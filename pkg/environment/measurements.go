@@ -0,0 +1,66 @@
+package environment
+
+import (
+	"math"
+	"sensors-generator/pkg/measurement"
+)
+
+const (
+	// atmosphere is the pressure of the air at the surface, dbar. Pressure of the water grows
+	// by about a decibar per meter.
+	atmosphere = 10.1325
+	// dbarPerMeter is the pressure of a meter of sea water.
+	dbarPerMeter = 1.0
+)
+
+// Pressure at the point, dbar.
+func (f *Field) Pressure(p Point) float64 {
+	return atmosphere + dbarPerMeter*math.Max(p.Z, 0)
+}
+
+// Salinity at the point, PSU. It changes from the surface to the deep one at the halocline.
+func (f *Field) Salinity(p Point) float64 {
+	cfg := f.cfg.Salinity
+	mixed := 1 / (1 + math.Exp((p.Z-cfg.HaloclineDepth)/cfg.HaloclineWidth))
+	return cfg.Deep + (cfg.Surface-cfg.Deep)*mixed
+}
+
+// OxygenSaturation is the share of oxygen saturated water holds at the point, from 0 to 1.
+func (f *Field) OxygenSaturation(p Point) float64 {
+	cfg := f.cfg.Oxygen
+	return cfg.DeepSaturation + (1-cfg.DeepSaturation)*math.Exp(-math.Max(p.Z, 0)/cfg.Depth)
+}
+
+// Oxygen dissolved at the point, mg/L. Warm and salty water holds less oxygen,
+// so it follows the temperature the sensor measures.
+func (f *Field) Oxygen(p Point, temperature, salinity float64) float64 {
+	return oxygenSolubility(temperature, salinity) * f.OxygenSaturation(p)
+}
+
+// PH at the point, it drops where oxygen is consumed.
+func (f *Field) PH(p Point) float64 {
+	return f.cfg.PH.Surface - f.cfg.PH.Drop*(1-f.OxygenSaturation(p))
+}
+
+// oxygenSolubility is oxygen of saturated water, mg/L. It is a fit of fresh water solubility
+// lowered by salinity, close enough from 0 to 35 degrees.
+func oxygenSolubility(temperature, salinity float64) float64 {
+	t := temperature
+	fresh := 14.62 - 0.3898*t + 0.006969*t*t - 0.00005897*t*t*t
+	return fresh * (1 - 0.00535*salinity)
+}
+
+// measure returns the value of an additional measurement type without noise, temperature is the measured one.
+func (f *Field) measure(name string, p Point, temperature float64) (float64, bool) {
+	switch name {
+	case measurement.Pressure:
+		return f.Pressure(p), true
+	case measurement.Salinity:
+		return f.Salinity(p), true
+	case measurement.DissolvedOxygen:
+		return f.Oxygen(p, temperature, f.Salinity(p)), true
+	case measurement.PH:
+		return f.PH(p), true
+	}
+	return 0, false
+}
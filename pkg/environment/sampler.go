@@ -0,0 +1,151 @@
+package environment
+
+import (
+	"math/rand"
+	"sensors-generator/pkg/measurement"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Reading is what a sensor measures.
+type Reading struct {
+	Temperature  float64
+	Transparency float64
+}
+
+// sensorNoise is the bias of one sensor and the random source of its noise, readings of different sensors
+// are sampled without a shared lock.
+type sensorNoise struct {
+	temperature  float64
+	transparency float64
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// Sampler samples the field at sensors and adds measurement noise. Every sensor has its own bias,
+// it is drawn from the seed and the sensor id, so a sensor keeps its bias after a restart.
+// Sampler is safe for concurrent use.
+type Sampler struct {
+	field  *Field
+	noise  Noise
+	seed   int64
+	events atomic.Pointer[[]Event]
+
+	sensors sync.Map
+}
+
+func NewSampler(cfg Config) *Sampler {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	cfg.Seed = seed
+
+	s := &Sampler{
+		field: NewField(cfg),
+		noise: cfg.Noise,
+		seed:  seed,
+	}
+	s.SetEvents(cfg.Events)
+	return s
+}
+
+// SetEvents replaces events, events of the config and of the api are set together.
+func (s *Sampler) SetEvents(events []Event) {
+	events = append([]Event(nil), events...)
+	s.events.Store(&events)
+}
+
+// Effect of events at the point and time.
+func (s *Sampler) Effect(p Point, at time.Time) Effect {
+	return Effects(*s.events.Load(), p, at)
+}
+
+func (s *Sampler) Field() *Field {
+	return s.field
+}
+
+// Sample returns the reading of the sensor at the point with effects of events, transparency stays from 0 to 100.
+func (s *Sampler) Sample(sensorID int, p Point, at time.Time) Reading {
+	effect := s.Effect(p, at)
+	temperature := s.field.Temperature(p, at) + effect.Temperature
+	transparency := s.field.Transparency(p, at) + effect.Transparency
+
+	n := s.sensorNoise(sensorID)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	transparency += n.transparency + n.rand.NormFloat64()*s.noise.Transparency
+	return Reading{
+		Temperature:  temperature + n.temperature + n.rand.NormFloat64()*s.noise.Temperature,
+		Transparency: clamp(transparency, 0, 100),
+	}
+}
+
+// Measure returns additional measurements of the sensor at the point, names which are not additional types
+// (temperature, transparency) are skipped. Dissolved oxygen follows the measured temperature.
+// Values stay within ranges of their types.
+func (s *Sampler) Measure(sensorID int, p Point, temperature float64, names []string) map[string]float64 {
+	var values map[string]float64
+
+	n := s.sensorNoise(sensorID)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, name := range names {
+		value, ok := s.field.measure(name, p, temperature)
+		if !ok {
+			continue
+		}
+
+		t, _ := measurement.Lookup(name)
+		if values == nil {
+			values = make(map[string]float64, len(names))
+		}
+		values[name] = t.Clamp(value + n.rand.NormFloat64()*s.measurementNoise(name))
+	}
+
+	return values
+}
+
+func (s *Sampler) measurementNoise(name string) float64 {
+	switch name {
+	case measurement.Salinity:
+		return s.noise.Salinity
+	case measurement.Pressure:
+		return s.noise.Pressure
+	case measurement.DissolvedOxygen:
+		return s.noise.Oxygen
+	case measurement.PH:
+		return s.noise.PH
+	}
+	return 0
+}
+
+// sensorNoise returns noise of the sensor, the bias is drawn first, so it does not depend on readings.
+func (s *Sampler) sensorNoise(sensorID int) *sensorNoise {
+	if n, ok := s.sensors.Load(sensorID); ok {
+		return n.(*sensorNoise)
+	}
+
+	rnd := rand.New(rand.NewSource(s.seed + int64(sensorID)))
+	n := &sensorNoise{
+		temperature:  rnd.NormFloat64() * s.noise.TemperatureBias,
+		transparency: rnd.NormFloat64() * s.noise.TransparencyBias,
+		rand:         rnd,
+	}
+	actual, _ := s.sensors.LoadOrStore(sensorID, n)
+	return actual.(*sensorNoise)
+}
+
+func clamp(value, min, max float64) float64 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
@@ -0,0 +1,29 @@
+package environment
+
+import (
+	"math"
+	"sensors-generator/pkg/environment"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Currents_Velocity(t *testing.T) {
+	currents := environment.Currents{U: 0.05, V: 0.02, Tidal: 0.1, TidalPeriod: 12 * time.Hour, Depth: 50}
+	epoch := time.Unix(0, 0)
+
+	// The tide turns around once a period.
+	v := currents.Velocity(environment.Point{}, epoch)
+	assert.InDelta(t, 0.15, v.X, 1e-9)
+	assert.InDelta(t, 0.02, v.Y, 1e-9)
+
+	v = currents.Velocity(environment.Point{}, epoch.Add(3*time.Hour))
+	assert.InDelta(t, 0.05, v.X, 1e-9)
+	assert.InDelta(t, 0.12, v.Y, 1e-9)
+	assert.Zero(t, v.Z)
+
+	// Currents weaken with depth.
+	deep := currents.Velocity(environment.Point{Z: 50}, epoch)
+	assert.InDelta(t, 0.15/math.E, deep.X, 1e-9)
+}
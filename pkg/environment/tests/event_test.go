@@ -0,0 +1,90 @@
+package environment
+
+import (
+	"sensors-generator/pkg/environment"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var storm = environment.Event{
+	Name:     "storm",
+	Kind:     environment.KindStorm,
+	Region:   environment.Region{Min: environment.Point{X: 0, Y: 0, Z: 0}, Max: environment.Point{X: 100, Y: 100, Z: 50}},
+	Edge:     20,
+	StartsAt: summer,
+	EndsAt:   summer.Add(10 * time.Hour),
+	RampUp:   2 * time.Hour,
+	Decay:    4 * time.Hour,
+}
+
+func Test_Event_Intensity(t *testing.T) {
+	inside := environment.Point{X: 50, Y: 50, Z: 10}
+
+	assert.Equal(t, 0.0, storm.Intensity(inside, summer.Add(-time.Minute)))
+	assert.InDelta(t, 0.5, storm.Intensity(inside, summer.Add(time.Hour)), 1e-9)
+	assert.Equal(t, 1.0, storm.Intensity(inside, summer.Add(4*time.Hour)))
+	assert.InDelta(t, 0.25, storm.Intensity(inside, summer.Add(9*time.Hour)), 1e-9)
+	assert.Equal(t, 0.0, storm.Intensity(inside, summer.Add(10*time.Hour)))
+
+	// Outside the region the event fades out within the edge.
+	at := summer.Add(4 * time.Hour)
+	assert.InDelta(t, 0.5, storm.Intensity(environment.Point{X: 110, Y: 50, Z: 10}, at), 1e-9)
+	assert.Equal(t, 0.0, storm.Intensity(environment.Point{X: 130, Y: 50, Z: 10}, at))
+
+	smooth := storm
+	smooth.Shape = environment.ShapeSmooth
+	assert.InDelta(t, 0.5, smooth.Intensity(inside, summer.Add(time.Hour)), 1e-9)
+	assert.Less(t, smooth.Intensity(inside, summer.Add(30*time.Minute)), storm.Intensity(inside, summer.Add(30*time.Minute)))
+}
+
+func Test_Effects(t *testing.T) {
+	at := summer.Add(4 * time.Hour)
+	upwelling := storm
+	upwelling.Kind = environment.KindUpwelling
+	bloom := storm
+	bloom.Kind = environment.KindAlgalBloom
+	bloom.Magnitude = 10
+	bloom.Spieces = map[string]float64{"Cod": 0.5, "Herring": 3}
+
+	effect := environment.Effects([]environment.Event{storm, upwelling, bloom}, environment.Point{X: 50, Y: 50, Z: 0}, at)
+
+	// Default magnitudes of a storm and an upwelling, the bloom has its own.
+	assert.InDelta(t, -50, effect.Transparency, 1e-9)
+	assert.InDelta(t, -4, effect.Temperature, 1e-9)
+	assert.Equal(t, map[string]float64{"Cod": 0.5, "Herring": 3}, effect.Spieces)
+
+	// Upwelling cools the surface more than the deep water.
+	deep := environment.Effects([]environment.Event{upwelling}, environment.Point{X: 50, Y: 50, Z: 40}, at)
+	assert.Greater(t, deep.Temperature, effect.Temperature)
+
+	assert.Equal(t, environment.Effect{}, environment.Effects([]environment.Event{storm}, environment.Point{X: 500}, at))
+}
+
+func Test_Sampler_Events(t *testing.T) {
+	sampler := environment.NewSampler(cfg)
+	p := environment.Point{X: 50, Y: 50, Z: 10}
+	at := summer.Add(4 * time.Hour)
+
+	clear := sampler.Sample(1, p, at)
+	sampler.SetEvents([]environment.Event{storm})
+	stormy := sampler.Sample(1, p, at)
+
+	assert.Less(t, stormy.Transparency, clear.Transparency-20)
+}
+
+func Test_Event_Validate(t *testing.T) {
+	assert.Empty(t, storm.Validate())
+
+	invalid := storm
+	invalid.Kind = "tsunami"
+	invalid.EndsAt = storm.StartsAt.Add(time.Hour)
+	invalid.Spieces = map[string]float64{"Cod": -1}
+
+	assert.Equal(t, []string{
+		"kind should be storm, upwelling or algal_bloom",
+		"ramp_up and decay should fit between starts_at and ends_at",
+		"spieces.Cod should not be negative",
+	}, invalid.Validate())
+}
@@ -0,0 +1,115 @@
+package environment
+
+import (
+	"math"
+	"sensors-generator/pkg/environment"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	cfg = environment.Config{
+		Seed:               7,
+		SurfaceTemperature: 16,
+		SeasonalAmplitude:  6,
+		DiurnalAmplitude:   0.5,
+		DeepTemperature:    4,
+		ThermoclineDepth:   30,
+		ThermoclineWidth:   10,
+		Transparency:       70,
+		Salinity:           environment.Salinity{Surface: 33, Deep: 35, HaloclineDepth: 50, HaloclineWidth: 15},
+		Oxygen:             environment.Oxygen{DeepSaturation: 0.6, Depth: 150},
+		PH:                 environment.PH{Surface: 8.1, Drop: 0.5},
+		Anomalies:          environment.Anomalies{Temperature: 1.5, Transparency: 15, Scale: 50, Period: 6 * time.Hour},
+		Noise: environment.Noise{TemperatureBias: 0.1, Temperature: 0.05, TransparencyBias: 1, Transparency: 1,
+			Salinity: 0.02, Pressure: 0.1, Oxygen: 0.05, PH: 0.005},
+		Currents: environment.Currents{U: 0.05, V: 0.02, Tidal: 0.1, TidalPeriod: 12*time.Hour + 25*time.Minute, Depth: 50},
+	}
+	summer = time.Date(2023, time.August, 8, 15, 0, 0, 0, time.UTC)
+	winter = time.Date(2023, time.February, 8, 15, 0, 0, 0, time.UTC)
+)
+
+func Test_Field_Profile(t *testing.T) {
+	field := environment.NewField(cfg)
+	p := environment.Point{X: 100, Y: 50, Z: 2}
+
+	assert.Greater(t, field.Temperature(p, summer), field.Temperature(p, winter)+8)
+	assert.Greater(t, field.Temperature(p, summer), field.Temperature(p, summer.Add(-12*time.Hour)))
+	// Below the thermocline the water is cold all year.
+	deep := environment.Point{X: 100, Y: 50, Z: 200}
+	assert.InDelta(t, 4, field.Temperature(deep, summer), 0.1)
+	assert.InDelta(t, 4, field.Temperature(deep, winter), 0.1)
+
+	for x := 0.0; x < 500; x += 10 {
+		transparency := field.Transparency(environment.Point{X: x, Y: 50, Z: 5}, summer)
+		assert.True(t, transparency >= 0 && transparency <= 100)
+	}
+}
+
+func Test_Field_SpatiallyCorrelated(t *testing.T) {
+	field := environment.NewField(cfg)
+
+	// Differences of sensors a meter apart are much smaller than of sensors far apart.
+	var near, far float64
+	for i := 0; i < 200; i++ {
+		p := environment.Point{X: float64(i) * 37, Y: float64(i%13) * 29, Z: 5}
+		at := summer.Add(time.Duration(i) * 17 * time.Minute)
+
+		near += math.Abs(field.Temperature(p, at) - field.Temperature(environment.Point{X: p.X + 1, Y: p.Y, Z: 5}, at))
+		far += math.Abs(field.Temperature(p, at) - field.Temperature(environment.Point{X: p.X + 500, Y: p.Y + 300, Z: 5}, at))
+	}
+
+	assert.Less(t, near*10, far)
+}
+
+func Test_Field_ChangesSlowly(t *testing.T) {
+	field := environment.NewField(cfg)
+	p := environment.Point{X: 100, Y: 50, Z: 5}
+
+	assert.InDelta(t, field.Temperature(p, summer), field.Temperature(p, summer.Add(30*time.Second)), 0.01)
+	assert.InDelta(t, field.Transparency(p, summer), field.Transparency(p, summer.Add(30*time.Second)), 0.1)
+}
+
+func Test_Sampler_Noise(t *testing.T) {
+	sampler := environment.NewSampler(cfg)
+	p := environment.Point{X: 100, Y: 50, Z: 5}
+	truth := sampler.Field().Temperature(p, summer)
+
+	// Readings of a sensor scatter around its own bias.
+	var first, second float64
+	for i := 0; i < 500; i++ {
+		first += sampler.Sample(1, p, summer).Temperature - truth
+		second += sampler.Sample(2, p, summer).Temperature - truth
+	}
+	first, second = first/500, second/500
+
+	assert.Less(t, math.Abs(first), 0.5)
+	assert.NotEqual(t, math.Round(first*100), math.Round(second*100))
+
+	// The bias repeats with the same seed.
+	again := environment.NewSampler(cfg)
+	var repeated float64
+	for i := 0; i < 500; i++ {
+		repeated += again.Sample(1, p, summer).Temperature - truth
+	}
+	assert.InDelta(t, first, repeated/500, 0.02)
+}
+
+func Test_Config_Validate(t *testing.T) {
+	assert.Empty(t, cfg.Validate())
+
+	wrong := cfg
+	wrong.ThermoclineWidth = 0
+	wrong.Transparency = 120
+	wrong.Anomalies.Period = 0
+	wrong.Noise.Temperature = -1
+
+	assert.Equal(t, []string{
+		"thermocline_width should be positive",
+		"transparency should be from 0 to 100",
+		"anomalies.period should be positive",
+		"noise.temperature should not be negative",
+	}, wrong.Validate())
+}
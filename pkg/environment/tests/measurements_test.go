@@ -0,0 +1,70 @@
+package environment
+
+import (
+	"sensors-generator/pkg/environment"
+	"sensors-generator/pkg/measurement"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Field_Pressure(t *testing.T) {
+	field := environment.NewField(cfg)
+
+	assert.InDelta(t, 10.13, field.Pressure(environment.Point{Z: 0}), 0.01)
+	assert.InDelta(t, 110.13, field.Pressure(environment.Point{Z: 100}), 0.01)
+}
+
+func Test_Field_Salinity(t *testing.T) {
+	field := environment.NewField(cfg)
+
+	surface := field.Salinity(environment.Point{Z: 0})
+	halocline := field.Salinity(environment.Point{Z: 50})
+	deep := field.Salinity(environment.Point{Z: 300})
+
+	assert.InDelta(t, 33, surface, 0.1)
+	assert.InDelta(t, 34, halocline, 0.01)
+	assert.InDelta(t, 35, deep, 0.01)
+}
+
+func Test_Field_Oxygen(t *testing.T) {
+	field := environment.NewField(cfg)
+	surface := environment.Point{Z: 0}
+
+	// Saturated sea water holds about 8 mg/L at 15 degrees and less when it is warmer.
+	cold := field.Oxygen(surface, 5, 35)
+	warm := field.Oxygen(surface, 25, 35)
+	assert.InDelta(t, 10.6, cold, 0.3)
+	assert.InDelta(t, 6.9, warm, 0.3)
+
+	// Oxygen is consumed at depth.
+	assert.InDelta(t, 1, field.OxygenSaturation(surface), 1e-9)
+	assert.Less(t, field.Oxygen(environment.Point{Z: 500}, 5, 35), cold*0.7)
+}
+
+func Test_Field_PH(t *testing.T) {
+	field := environment.NewField(cfg)
+
+	assert.InDelta(t, 8.1, field.PH(environment.Point{Z: 0}), 1e-9)
+	assert.InDelta(t, 7.91, field.PH(environment.Point{Z: 500}), 0.01)
+}
+
+func Test_Sampler_Measure(t *testing.T) {
+	sampler := environment.NewSampler(cfg)
+	p := environment.Point{X: 10, Y: 20, Z: 80}
+	names := []string{measurement.Temperature, measurement.Salinity, measurement.Pressure,
+		measurement.DissolvedOxygen, measurement.PH}
+
+	values := sampler.Measure(1, p, 8, names)
+
+	// Temperature is not an additional measurement.
+	assert.Len(t, values, 4)
+	assert.InDelta(t, 90.13, values[measurement.Pressure], 0.5)
+	assert.InDelta(t, 34.9, values[measurement.Salinity], 0.2)
+	for name, value := range values {
+		mType, _ := measurement.Lookup(name)
+		assert.True(t, mType.Contains(value), "%s %v", name, value)
+	}
+
+	assert.Nil(t, sampler.Measure(1, p, 8, measurement.Default))
+}
@@ -0,0 +1,151 @@
+package fault
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kind of injected fault, it is stored in the ground truth table.
+type Kind string
+
+const (
+	KindOutage     Kind = "outage"
+	KindStuck      Kind = "stuck"
+	KindDrift      Kind = "drift"
+	KindSpike      Kind = "spike"
+	KindDuplicate  Kind = "duplicate"
+	KindOutOfOrder Kind = "out_of_order"
+	KindDelay      Kind = "delay"
+)
+
+var Kinds = []Kind{KindOutage, KindStuck, KindDrift, KindSpike, KindDuplicate, KindOutOfOrder, KindDelay}
+
+func NewKindFromString(kind string) (Kind, error) {
+	for _, k := range Kinds {
+		if string(k) == kind {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown fault kind %q", kind)
+}
+
+// Config of fault injection. Seed makes faults repeatable: every sensor gets its own
+// random source seeded with Seed plus sensor id, 0 seeds with the current time.
+type Config struct {
+	Enabled  bool      `yaml:"enabled" env:"ENABLED" env-default:"false"`
+	Seed     int64     `yaml:"seed" env:"SEED" env-default:"0"`
+	Profiles []Profile `yaml:"profiles"`
+}
+
+// Profile describes faults of sensors. It applies to Sensors (codenames, e.g. "alpha 1"),
+// then to sensors of Groups, a profile without both applies to all sensors.
+// Probabilities are checked on every reading, zero disables the fault.
+type Profile struct {
+	Name    string   `yaml:"name"`
+	Groups  []string `yaml:"groups"`
+	Sensors []string `yaml:"sensors"`
+
+	// Outage drops readings for the window.
+	Outage Window `yaml:"outage"`
+	// Stuck repeats the reading which started the window.
+	Stuck Window `yaml:"stuck"`
+	// Drift adds PerHour degrees for every hour since the window started.
+	Drift Drift `yaml:"drift"`
+	// Spike adds or subtracts Magnitude degrees.
+	Spike Spike `yaml:"spike"`
+	// Duplicate writes the reading twice.
+	Duplicate Chance `yaml:"duplicate"`
+	// OutOfOrder moves created_at of the reading back by up to Max, so it is older than previous readings.
+	OutOfOrder Shift `yaml:"out_of_order"`
+	// Delay writes the reading up to Max later, created_at is the time it was measured.
+	Delay Shift `yaml:"delay"`
+}
+
+type Chance struct {
+	Probability float64 `yaml:"probability"`
+}
+
+type Window struct {
+	Probability float64       `yaml:"probability"`
+	Duration    time.Duration `yaml:"duration"`
+}
+
+type Drift struct {
+	Window  `yaml:",inline"`
+	PerHour float64 `yaml:"per_hour"`
+}
+
+type Spike struct {
+	Probability float64 `yaml:"probability"`
+	Magnitude   float64 `yaml:"magnitude"`
+}
+
+type Shift struct {
+	Probability float64       `yaml:"probability"`
+	Max         time.Duration `yaml:"max"`
+}
+
+// ProfileFor returns the profile of the sensor, codename is group name and index, e.g. "alpha 1".
+func (c Config) ProfileFor(groupName, codename string) (Profile, bool) {
+	if !c.Enabled {
+		return Profile{}, false
+	}
+
+	for _, p := range c.Profiles {
+		for _, s := range p.Sensors {
+			if s == codename {
+				return p, true
+			}
+		}
+	}
+
+	for _, p := range c.Profiles {
+		for _, g := range p.Groups {
+			if g == groupName {
+				return p, true
+			}
+		}
+	}
+
+	for _, p := range c.Profiles {
+		if len(p.Sensors) == 0 && len(p.Groups) == 0 {
+			return p, true
+		}
+	}
+
+	return Profile{}, false
+}
+
+// Validate returns problems of profiles, config validation reports them with the profile name.
+func (p Profile) Validate() []string {
+	problems := make([]string, 0)
+	probability := func(name string, value float64) {
+		if value < 0 || value > 1 {
+			problems = append(problems, fmt.Sprintf("%s.probability should be from 0 to 1", name))
+		}
+	}
+	positive := func(name string, probability float64, value time.Duration) {
+		if probability > 0 && value <= 0 {
+			problems = append(problems, fmt.Sprintf("%s should be positive", name))
+		}
+	}
+
+	if p.Name == "" {
+		problems = append(problems, "name is required")
+	}
+
+	probability("outage", p.Outage.Probability)
+	positive("outage.duration", p.Outage.Probability, p.Outage.Duration)
+	probability("stuck", p.Stuck.Probability)
+	positive("stuck.duration", p.Stuck.Probability, p.Stuck.Duration)
+	probability("drift", p.Drift.Probability)
+	positive("drift.duration", p.Drift.Probability, p.Drift.Duration)
+	probability("spike", p.Spike.Probability)
+	probability("duplicate", p.Duplicate.Probability)
+	probability("out_of_order", p.OutOfOrder.Probability)
+	positive("out_of_order.max", p.OutOfOrder.Probability, p.OutOfOrder.Max)
+	probability("delay", p.Delay.Probability)
+	positive("delay.max", p.Delay.Probability, p.Delay.Max)
+
+	return problems
+}
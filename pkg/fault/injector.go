@@ -0,0 +1,153 @@
+package fault
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Reading is a clean reading before faults are injected.
+type Reading struct {
+	Temperature  float32
+	Transparency uint8
+	At           time.Time
+}
+
+// Details keep what is needed to restore the clean reading.
+type Details struct {
+	OriginalTemperature *float32      `json:"original_temperature,omitempty"`
+	InjectedTemperature *float32      `json:"injected_temperature,omitempty"`
+	DriftPerHour        float64       `json:"drift_per_hour,omitempty"`
+	MeasuredAt          *time.Time    `json:"measured_at,omitempty"`
+	Shift               time.Duration `json:"shift,omitempty"`
+}
+
+// Fault is a record of the ground truth. Window faults (outage, stuck, drift) are recorded
+// when they start and cover [StartedAt, EndedAt], other faults belong to one written reading.
+type Fault struct {
+	Kind      Kind
+	Profile   string
+	StartedAt time.Time
+	EndedAt   time.Time
+	Details   Details
+}
+
+// Decision tells the generator how to write the reading.
+type Decision struct {
+	// Skip is set during outages, nothing is written.
+	Skip    bool
+	Reading Reading
+	// Delay is how long to hold the reading before it is written.
+	Delay time.Duration
+	// Duplicate is set when the reading is written twice, the fault belongs to the copy.
+	Duplicate *Fault
+	// Started are window faults which started with this reading.
+	Started []Fault
+	// Faults belong to the written reading.
+	Faults []Fault
+}
+
+// Injector applies faults of a profile to readings of one sensor. It is not safe for concurrent use,
+// every sensor goroutine has its own injector.
+type Injector struct {
+	profile Profile
+	rand    *rand.Rand
+
+	outageUntil time.Time
+	stuckUntil  time.Time
+	stuck       Reading
+	driftFrom   time.Time
+	driftUntil  time.Time
+}
+
+func NewInjector(profile Profile, rand *rand.Rand) *Injector {
+	return &Injector{profile: profile, rand: rand}
+}
+
+func (i *Injector) Profile() Profile {
+	return i.profile
+}
+
+// Apply decides which faults the reading gets.
+func (i *Injector) Apply(r Reading) Decision {
+	d := Decision{Reading: r}
+	p := i.profile
+
+	if r.At.Before(i.outageUntil) {
+		d.Skip = true
+		return d
+	}
+
+	if i.chance(p.Outage.Probability) {
+		i.outageUntil = r.At.Add(p.Outage.Duration)
+		d.Started = append(d.Started, i.fault(KindOutage, r.At, i.outageUntil, Details{}))
+		d.Skip = true
+		return d
+	}
+
+	if !r.At.Before(i.driftUntil) && i.chance(p.Drift.Probability) {
+		i.driftFrom, i.driftUntil = r.At, r.At.Add(p.Drift.Duration)
+		d.Started = append(d.Started, i.fault(KindDrift, i.driftFrom, i.driftUntil,
+			Details{DriftPerHour: p.Drift.PerHour}))
+	}
+
+	if r.At.Before(i.driftUntil) {
+		d.Reading.Temperature += float32(p.Drift.PerHour * r.At.Sub(i.driftFrom).Hours())
+	}
+
+	if !r.At.Before(i.stuckUntil) && i.chance(p.Stuck.Probability) {
+		i.stuck, i.stuckUntil = d.Reading, r.At.Add(p.Stuck.Duration)
+		temperature := i.stuck.Temperature
+		d.Started = append(d.Started, i.fault(KindStuck, r.At, i.stuckUntil,
+			Details{InjectedTemperature: &temperature}))
+	}
+
+	if r.At.Before(i.stuckUntil) {
+		d.Reading.Temperature, d.Reading.Transparency = i.stuck.Temperature, i.stuck.Transparency
+	}
+
+	if i.chance(p.Spike.Probability) {
+		original := d.Reading.Temperature
+		magnitude := float32(p.Spike.Magnitude)
+		if i.rand.Intn(2) == 0 {
+			magnitude = -magnitude
+		}
+		d.Reading.Temperature += magnitude
+		injected := d.Reading.Temperature
+		d.Faults = append(d.Faults, i.fault(KindSpike, r.At, r.At,
+			Details{OriginalTemperature: &original, InjectedTemperature: &injected}))
+	}
+
+	if i.chance(p.OutOfOrder.Probability) {
+		shift := i.duration(p.OutOfOrder.Max)
+		measuredAt := r.At
+		d.Reading.At = r.At.Add(-shift)
+		d.Faults = append(d.Faults, i.fault(KindOutOfOrder, d.Reading.At, d.Reading.At,
+			Details{MeasuredAt: &measuredAt, Shift: shift}))
+	}
+
+	if i.chance(p.Delay.Probability) {
+		d.Delay = i.duration(p.Delay.Max)
+		d.Faults = append(d.Faults, i.fault(KindDelay, d.Reading.At, d.Reading.At.Add(d.Delay),
+			Details{Shift: d.Delay}))
+	}
+
+	if i.chance(p.Duplicate.Probability) {
+		duplicate := i.fault(KindDuplicate, d.Reading.At, d.Reading.At, Details{})
+		d.Duplicate = &duplicate
+	}
+
+	return d
+}
+
+func (i *Injector) chance(probability float64) bool {
+	return probability > 0 && i.rand.Float64() < probability
+}
+
+// duration returns random duration in (0, max].
+func (i *Injector) duration(max time.Duration) time.Duration {
+	return time.Duration(i.rand.Int63n(int64(max))) + 1
+}
+
+func (i *Injector) fault(kind Kind, startedAt, endedAt time.Time, details Details) Fault {
+	return Fault{Kind: kind, Profile: i.profile.Name, StartedAt: startedAt, EndedAt: endedAt, Details: details}
+}
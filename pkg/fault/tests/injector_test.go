@@ -0,0 +1,130 @@
+package fault
+
+import (
+	"math/rand"
+	"sensors-generator/pkg/fault"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Config_ProfileFor(t *testing.T) {
+	cfg := fault.Config{
+		Enabled: true,
+		Profiles: []fault.Profile{
+			{Name: "all"},
+			{Name: "group", Groups: []string{"alpha"}},
+			{Name: "sensor", Sensors: []string{"alpha 2"}},
+		},
+	}
+
+	profile, ok := cfg.ProfileFor("alpha", "alpha 2")
+	require.True(t, ok)
+	assert.Equal(t, "sensor", profile.Name)
+
+	profile, ok = cfg.ProfileFor("alpha", "alpha 1")
+	require.True(t, ok)
+	assert.Equal(t, "group", profile.Name)
+
+	profile, ok = cfg.ProfileFor("beta", "beta 1")
+	require.True(t, ok)
+	assert.Equal(t, "all", profile.Name)
+
+	cfg.Enabled = false
+	_, ok = cfg.ProfileFor("alpha", "alpha 2")
+	assert.False(t, ok)
+}
+
+func Test_Injector_Outage(t *testing.T) {
+	injector := fault.NewInjector(fault.Profile{
+		Name:   "outage",
+		Outage: fault.Window{Probability: 1, Duration: time.Minute},
+	}, rand.New(rand.NewSource(1)))
+
+	start := time.Unix(1700000000, 0)
+	decision := injector.Apply(fault.Reading{Temperature: 10, At: start})
+
+	assert.True(t, decision.Skip)
+	require.Len(t, decision.Started, 1)
+	assert.Equal(t, fault.KindOutage, decision.Started[0].Kind)
+	assert.Equal(t, start.Add(time.Minute), decision.Started[0].EndedAt)
+
+	// Readings inside the window are dropped without new records.
+	decision = injector.Apply(fault.Reading{Temperature: 10, At: start.Add(30 * time.Second)})
+	assert.True(t, decision.Skip)
+	assert.Empty(t, decision.Started)
+}
+
+func Test_Injector_StuckAndDrift(t *testing.T) {
+	injector := fault.NewInjector(fault.Profile{
+		Name:  "stuck",
+		Stuck: fault.Window{Probability: 1, Duration: time.Hour},
+	}, rand.New(rand.NewSource(1)))
+
+	start := time.Unix(1700000000, 0)
+	decision := injector.Apply(fault.Reading{Temperature: 10, Transparency: 50, At: start})
+	require.Len(t, decision.Started, 1)
+	assert.Equal(t, fault.KindStuck, decision.Started[0].Kind)
+
+	decision = injector.Apply(fault.Reading{Temperature: 12, Transparency: 60, At: start.Add(time.Minute)})
+	assert.Equal(t, fault.Reading{Temperature: 10, Transparency: 50, At: start.Add(time.Minute)}, decision.Reading)
+	assert.Empty(t, decision.Started)
+
+	injector = fault.NewInjector(fault.Profile{
+		Name:  "drift",
+		Drift: fault.Drift{Window: fault.Window{Probability: 1, Duration: 10 * time.Hour}, PerHour: 0.5},
+	}, rand.New(rand.NewSource(1)))
+
+	injector.Apply(fault.Reading{Temperature: 10, At: start})
+	decision = injector.Apply(fault.Reading{Temperature: 10, At: start.Add(2 * time.Hour)})
+	assert.Equal(t, float32(11), decision.Reading.Temperature)
+}
+
+func Test_Injector_ReadingFaults(t *testing.T) {
+	injector := fault.NewInjector(fault.Profile{
+		Name:       "all",
+		Spike:      fault.Spike{Probability: 1, Magnitude: 20},
+		Duplicate:  fault.Chance{Probability: 1},
+		OutOfOrder: fault.Shift{Probability: 1, Max: time.Minute},
+		Delay:      fault.Shift{Probability: 1, Max: time.Minute},
+	}, rand.New(rand.NewSource(1)))
+
+	at := time.Unix(1700000000, 0)
+	decision := injector.Apply(fault.Reading{Temperature: 10, At: at})
+
+	assert.False(t, decision.Skip)
+	assert.InDelta(t, 20, abs(decision.Reading.Temperature-10), 0.001)
+	assert.True(t, decision.Reading.At.Before(at))
+	assert.False(t, decision.Reading.At.Before(at.Add(-time.Minute)))
+	assert.Positive(t, decision.Delay)
+	require.NotNil(t, decision.Duplicate)
+
+	kinds := make([]fault.Kind, 0)
+	for _, f := range decision.Faults {
+		kinds = append(kinds, f.Kind)
+	}
+	assert.Equal(t, []fault.Kind{fault.KindSpike, fault.KindOutOfOrder, fault.KindDelay}, kinds)
+	assert.Equal(t, float32(10), *decision.Faults[0].Details.OriginalTemperature)
+	assert.Equal(t, at, *decision.Faults[1].Details.MeasuredAt)
+}
+
+func Test_Injector_SameSeedSameFaults(t *testing.T) {
+	profile := fault.Profile{Name: "spikes", Spike: fault.Spike{Probability: 0.3, Magnitude: 5}}
+	a := fault.NewInjector(profile, rand.New(rand.NewSource(42)))
+	b := fault.NewInjector(profile, rand.New(rand.NewSource(42)))
+
+	at := time.Unix(1700000000, 0)
+	for i := 0; i < 100; i++ {
+		reading := fault.Reading{Temperature: 10, At: at.Add(time.Duration(i) * time.Second)}
+		assert.Equal(t, a.Apply(reading), b.Apply(reading))
+	}
+}
+
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,49 @@
+package logging
+
+import "context"
+
+type ctxKey int
+
+const (
+	requestIDKey ctxKey = iota
+	routeKey
+)
+
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+func ContextWithRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeKey, route)
+}
+
+func RouteFromContext(ctx context.Context) string {
+	route, _ := ctx.Value(routeKey).(string)
+	return route
+}
+
+// LWithContext returns logger which adds request ID and route stored in ctx to every entry.
+func (l *Logger) LWithContext(ctx context.Context) *Logger {
+	if ctx == nil {
+		return l
+	}
+
+	fields := make(map[string]interface{}, 2)
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		fields["request_id"] = requestID
+	}
+	if route := RouteFromContext(ctx); route != "" {
+		fields["route"] = route
+	}
+
+	if len(fields) == 0 {
+		return l
+	}
+
+	return l.LWithFields(fields)
+}
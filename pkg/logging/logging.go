@@ -11,28 +11,22 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// writerHook is a hook that writes logs of specified LogLevels to specified Writer
-type writerHook struct {
-	Writer    []io.Writer
-	LogLevels []logrus.Level
-}
+const (
+	FormatText = "text"
+	FormatJSON = "json"
 
-// Fire will be called when some logging function is called with current hook
-// It will format log entry to string and write it to appropriate writer
-func (hook *writerHook) Fire(entry *logrus.Entry) error {
-	line, err := entry.String()
-	if err != nil {
-		return err
-	}
-	for _, w := range hook.Writer {
-		_, err = w.Write([]byte(line))
-	}
-	return err
-}
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
+)
 
-// Levels define on which log levels this hook would trigger
-func (hook *writerHook) Levels() []logrus.Level {
-	return hook.LogLevels
+// LogConfig describes how and where log entries are written.
+type LogConfig struct {
+	Format     string   `yaml:"format" env:"LOG_FORMAT" env-default:"text" env-description:"text or json"`
+	Outputs    []string `yaml:"outputs" env:"LOG_OUTPUTS" env-default:"stdout,file" env-description:"stdout, stderr or file"`
+	FilePath   string   `yaml:"file_path" env:"LOG_FILE_PATH" env-default:"logs/logs.log"`
+	MaxSize    int      `yaml:"max_size" env:"LOG_MAX_SIZE" env-default:"100" env-description:"megabytes, 0 disables rotation"`
+	MaxBackups int      `yaml:"max_backups" env:"LOG_MAX_BACKUPS" env-default:"5"`
 }
 
 var e *logrus.Entry
@@ -53,44 +47,111 @@ func (l *Logger) LWithFields(fields map[string]interface{}) *Logger {
 	return &Logger{l.WithFields(fields)}
 }
 
+// Init keeps the old behaviour: text logs to stdout and, outside of tests, to logs/logs.log.
 func Init(level string, test bool) {
+	cfg := LogConfig{
+		Format:   FormatText,
+		Outputs:  []string{OutputStdout, OutputFile},
+		FilePath: "logs/logs.log",
+	}
+
+	if test {
+		cfg.Outputs = []string{OutputStdout}
+	}
+
+	if err := InitWithConfig(level, cfg); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func InitWithConfig(level string, cfg LogConfig) error {
 	logrusLevel, err := logrus.ParseLevel(level)
 	if err != nil {
-		log.Fatalln(err)
+		return err
 	}
 
-	l := logrus.New()
-	l.SetReportCaller(true)
-	l.Formatter = &logrus.TextFormatter{
-		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
-			filename := path.Base(f.File)
-			return fmt.Sprintf("%s:%d", filename, f.Line), fmt.Sprintf("%s()", f.Function)
-		},
-		DisableColors: false,
-		FullTimestamp: true,
+	formatter, err := newFormatter(cfg.Format)
+	if err != nil {
+		return err
 	}
 
-	if !test {
-		if _, err := os.Stat("logs"); os.IsNotExist(err) {
-			if err := os.Mkdir("logs", 0777); err != nil {
-				panic(err)
+	l := logrus.New()
+	l.SetReportCaller(true)
+	l.Formatter = formatter
+
+	writers := make([]io.Writer, 0, len(cfg.Outputs))
+	for _, output := range cfg.Outputs {
+		switch output {
+		case OutputStdout:
+			writers = append(writers, os.Stdout)
+		case OutputStderr:
+			writers = append(writers, os.Stderr)
+		case OutputFile:
+			f, err := newRotatingFile(cfg.FilePath, cfg.MaxSize, cfg.MaxBackups)
+			if err != nil {
+				return err
 			}
+			writers = append(writers, f)
+		default:
+			return fmt.Errorf("unknown log output: %s", output)
 		}
-
-		f, err := os.OpenFile("logs/logs.log", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
-		if err != nil {
-			panic(err)
-		}
-
-		l.SetOutput(f) // Send all logs to nowhere by default
 	}
 
-	l.AddHook(&writerHook{
-		Writer:    []io.Writer{os.Stdout},
-		LogLevels: logrus.AllLevels,
-	})
+	if len(writers) == 0 {
+		l.SetOutput(io.Discard)
+	} else {
+		l.SetOutput(io.MultiWriter(writers...))
+	}
 
 	l.SetLevel(logrusLevel)
 
 	e = logrus.NewEntry(l)
+	return nil
+}
+
+// SetLevel changes level of the running logger, entries created before keep working.
+func SetLevel(level string) error {
+	logrusLevel, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	e.Logger.SetLevel(logrusLevel)
+	return nil
+}
+
+// SetFormat changes format of the running logger. Outputs can be changed only by InitWithConfig.
+func SetFormat(format string) error {
+	formatter, err := newFormatter(format)
+	if err != nil {
+		return err
+	}
+
+	e.Logger.SetFormatter(formatter)
+	return nil
+}
+
+func newFormatter(format string) (logrus.Formatter, error) {
+	callerPrettyfier := func(f *runtime.Frame) (string, string) {
+		filename := path.Base(f.File)
+		return fmt.Sprintf("%s()", f.Function), fmt.Sprintf("%s:%d", filename, f.Line)
+	}
+
+	switch format {
+	case FormatJSON:
+		return &logrus.JSONFormatter{
+			CallerPrettyfier: callerPrettyfier,
+		}, nil
+	case FormatText, "":
+		return &logrus.TextFormatter{
+			CallerPrettyfier: func(f *runtime.Frame) (string, string) {
+				function, file := callerPrettyfier(f)
+				return file, function
+			},
+			DisableColors: false,
+			FullTimestamp: true,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown log format: %s", format)
+	}
 }
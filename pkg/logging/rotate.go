@@ -0,0 +1,106 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const megabyte = 1024 * 1024
+
+// rotatingFile is a file writer which renames the file to <path>.1, <path>.2, ...
+// when it grows over maxSize megabytes. Only maxBackups old files are kept.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+	sync.Mutex
+}
+
+func newRotatingFile(path string, maxSize, maxBackups int) (*rotatingFile, error) {
+	if path == "" {
+		return nil, fmt.Errorf("log file path is empty")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return nil, err
+	}
+
+	rf := &rotatingFile{
+		path:       path,
+		maxSize:    int64(maxSize) * megabyte,
+		maxBackups: maxBackups,
+	}
+
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+
+	return rf, nil
+}
+
+func (rf *rotatingFile) open() error {
+	f, err := os.OpenFile(rf.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	rf.file = f
+	rf.size = info.Size()
+	return nil
+}
+
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	rf.Lock()
+	defer rf.Unlock()
+
+	if rf.maxSize > 0 && rf.size+int64(len(p)) > rf.maxSize && rf.size > 0 {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+func (rf *rotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return err
+	}
+
+	if rf.maxBackups <= 0 {
+		if err := os.Remove(rf.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return rf.open()
+	}
+
+	os.Remove(backupName(rf.path, rf.maxBackups))
+	for i := rf.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(backupName(rf.path, i), backupName(rf.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if err := os.Rename(rf.path, backupName(rf.path, 1)); err != nil {
+		return err
+	}
+
+	return rf.open()
+}
+
+func backupName(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"sensors-generator/pkg/logging"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const megabyte = 1024 * 1024
+
+// writeLogs writes size bytes of log entries of about 1KB each.
+func writeLogs(size int) {
+	message := strings.Repeat("x", 1000)
+	for written := 0; written < size; written += len(message) {
+		logging.GetLogger().Info(message)
+	}
+}
+
+func fileSize(t *testing.T, path string) int64 {
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	return info.Size()
+}
+
+func Test_RotatingFile_MaxSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "logs.log")
+	require.NoError(t, logging.InitWithConfig("info", logging.LogConfig{
+		Format:     logging.FormatJSON,
+		Outputs:    []string{logging.OutputFile},
+		FilePath:   path,
+		MaxSize:    1,
+		MaxBackups: 2,
+	}))
+	defer logging.Init("trace", true)
+
+	writeLogs(megabyte / 2)
+	assert.NoFileExists(t, path+".1")
+
+	writeLogs(megabyte)
+	assert.FileExists(t, path+".1")
+	assert.LessOrEqual(t, fileSize(t, path+".1"), int64(megabyte))
+	assert.LessOrEqual(t, fileSize(t, path), int64(megabyte))
+
+	// Only max_backups old files are kept.
+	writeLogs(3 * megabyte)
+	assert.FileExists(t, path+".2")
+	assert.NoFileExists(t, path+".3")
+	assert.LessOrEqual(t, fileSize(t, path+".2"), int64(megabyte))
+}
+
+func Test_RotatingFile_NoRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.log")
+	require.NoError(t, logging.InitWithConfig("info", logging.LogConfig{
+		Format:   logging.FormatText,
+		Outputs:  []string{logging.OutputFile},
+		FilePath: path,
+	}))
+	defer logging.Init("trace", true)
+
+	// max_size 0 disables rotation.
+	writeLogs(megabyte + megabyte/2)
+	assert.NoFileExists(t, path+".1")
+	assert.Greater(t, fileSize(t, path), int64(megabyte))
+}
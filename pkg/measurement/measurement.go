@@ -0,0 +1,110 @@
+// Package measurement lists what sensors measure. Every type has a unit and a range of valid values,
+// the generator keeps readings within it.
+package measurement
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Names of measurement types.
+const (
+	Temperature     = "temperature"
+	Transparency    = "transparency"
+	Salinity        = "salinity"
+	Pressure        = "pressure"
+	DissolvedOxygen = "dissolved_oxygen"
+	PH              = "ph"
+)
+
+// Type of a measurement.
+type Type struct {
+	Name string  `json:"name"`
+	Unit string  `json:"unit"`
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+}
+
+var types = []Type{
+	{Name: Temperature, Unit: "°C", Min: -5, Max: 40},
+	{Name: Transparency, Unit: "%", Min: 0, Max: 100},
+	{Name: Salinity, Unit: "PSU", Min: 0, Max: 45},
+	{Name: Pressure, Unit: "dbar", Min: 0, Max: 11000},
+	{Name: DissolvedOxygen, Unit: "mg/L", Min: 0, Max: 20},
+	{Name: PH, Unit: "pH", Min: 0, Max: 14},
+}
+
+// Default are measurements of a sensor which does not declare them. Temperature and transparency
+// are written with every reading, other types only by sensors which carry them.
+var Default = []string{Temperature, Transparency}
+
+// Types returns all measurement types.
+func Types() []Type {
+	return append([]Type(nil), types...)
+}
+
+// Names returns names of all measurement types.
+func Names() []string {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// Lookup returns the type by name.
+func Lookup(name string) (Type, bool) {
+	for _, t := range types {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Type{}, false
+}
+
+// IsBase reports whether the type is stored in its own column of readings, other types are stored together.
+func IsBase(name string) bool {
+	return name == Temperature || name == Transparency
+}
+
+// WithDefault returns names with Default types first, every sensor reports them.
+func WithDefault(names []string) []string {
+	result := append([]string(nil), Default...)
+	for _, name := range names {
+		if !IsBase(name) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// Contains reports whether the value is valid for the type.
+func (t Type) Contains(value float64) bool {
+	return value >= t.Min && value <= t.Max
+}
+
+// Clamp returns the closest valid value.
+func (t Type) Clamp(value float64) float64 {
+	if value < t.Min {
+		return t.Min
+	}
+	if value > t.Max {
+		return t.Max
+	}
+	return value
+}
+
+// Validate checks that names are known types without repeats.
+func Validate(names []string) error {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if _, ok := Lookup(name); !ok {
+			return fmt.Errorf("unknown measurement %q, known are %s", name, strings.Join(Names(), ", "))
+		}
+		if seen[name] {
+			return fmt.Errorf("measurement %q is repeated", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
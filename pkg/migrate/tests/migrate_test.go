@@ -0,0 +1,108 @@
+package migrate
+
+import (
+	"context"
+	"sensors-generator/pkg/logging"
+	"sensors-generator/pkg/migrate"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testFS = fstest.MapFS{
+	"1_init.up.sql":       {Data: []byte("CREATE TABLE a (id INT);")},
+	"1_init.down.sql":     {Data: []byte("DROP TABLE a;")},
+	"2_second.up.sql":     {Data: []byte("CREATE TABLE b (id INT);")},
+	"2_second.down.sql":   {Data: []byte("DROP TABLE b;")},
+	"migrations.go":       {Data: []byte("package migrations")},
+	"10_tenth.up.sql":     {Data: []byte("CREATE TABLE c (id INT);")},
+	"10_tenth.down.sql":   {Data: []byte("DROP TABLE c;")},
+	"not_a_migration.sql": {Data: []byte("SELECT 1;")},
+}
+
+func Test_Migrator_Latest(t *testing.T) {
+	logging.Init("trace", true)
+
+	migrator, err := migrate.New(nil, testFS, logging.GetLogger())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, migrator.Latest())
+}
+
+func Test_Migrator_New_MissingDown(t *testing.T) {
+	_, err := migrate.New(nil, fstest.MapFS{
+		"1_init.up.sql": {Data: []byte("CREATE TABLE a (id INT);")},
+	}, logging.GetLogger())
+
+	assert.Error(t, err)
+}
+
+func Test_Migrator_Up(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	migrator, err := migrate.New(db, testFS, logging.GetLogger())
+	assert.NoError(t, err)
+
+	mock.ExpectExec("SELECT pg_advisory_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()))
+
+	for _, migration := range []struct {
+		version int
+		name    string
+		script  string
+	}{{2, "second", "CREATE TABLE b"}, {10, "tenth", "CREATE TABLE c"}} {
+		mock.ExpectBegin()
+		mock.ExpectExec(migration.script).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO schema_migrations").
+			WithArgs(migration.version, migration.name, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+
+	mock.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, migrator.Up(context.Background()))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_Migrator_Down(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	migrator, err := migrate.New(db, testFS, logging.GetLogger())
+	assert.NoError(t, err)
+
+	mock.ExpectExec("SELECT pg_advisory_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).
+			AddRow(1, time.Now()).AddRow(2, time.Now()))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DROP TABLE b").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM schema_migrations").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, migrator.Down(context.Background(), 1))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+	limit    Limit
+}
+
+// MemoryLimiter keeps buckets in memory, so limits are applied per instance.
+type MemoryLimiter struct {
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+	sync.Mutex
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// NewMemoryLimiterWithClock is used by tests to control time.
+func NewMemoryLimiterWithClock(now func() time.Time) *MemoryLimiter {
+	limiter := NewMemoryLimiter()
+	limiter.now = now
+	limiter.lastSweep = now()
+	return limiter
+}
+
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	if limit.IsUnlimited() {
+		return true, 0, nil
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), lastSeen: now, limit: limit}
+		l.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+	b.lastSeen = now
+	b.limit = limit
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+
+	retryAfter := time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+	return false, retryAfter, nil
+}
+
+// sweep removes buckets which are full again, they are the same as new ones.
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, b := range l.buckets {
+		refill := time.Duration(float64(b.limit.Burst) / b.limit.Rate * float64(time.Second))
+		if now.Sub(b.lastSeen) > refill {
+			delete(l.buckets, key)
+		}
+	}
+
+	l.lastSweep = now
+}
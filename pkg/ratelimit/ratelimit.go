@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+const (
+	StoreMemory = "memory"
+	StoreRedis  = "redis"
+)
+
+// Limit describes token bucket: Rate tokens are added per second up to Burst tokens.
+type Limit struct {
+	Rate  float64 `yaml:"rate" env:"RATE"`
+	Burst int     `yaml:"burst" env:"BURST"`
+}
+
+type Limiter interface {
+	// Allow takes one token from the bucket of key. If the bucket is empty
+	// it returns false and the time after which the next token is available.
+	Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error)
+}
+
+func (l Limit) IsUnlimited() bool {
+	return l.Rate <= 0 || l.Burst <= 0
+}
+
+// Rule applies Limit to all routes which start with Prefix.
+type Rule struct {
+	Name   string `yaml:"name"`
+	Prefix string `yaml:"prefix"`
+	Limit  `yaml:",inline"`
+}
+
+type Rules struct {
+	Default Limit  `yaml:"default" env-prefix:"DEFAULT_"`
+	Groups  []Rule `yaml:"groups"`
+}
+
+// Match returns the name and limit of the first group which matches path.
+func (r Rules) Match(path string) (string, Limit) {
+	for _, rule := range r.Groups {
+		if strings.HasPrefix(path, rule.Prefix) {
+			return rule.Name, rule.Limit
+		}
+	}
+
+	return "default", r.Default
+}
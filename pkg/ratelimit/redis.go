@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "ratelimit:"
+
+// tokenBucketScript refills and takes a token atomically. Redis time is used,
+// so instances with different clocks share the same buckets.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local data = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(data[1])
+local ts = tonumber(data[2])
+if tokens == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + (now - ts) / 1000 * rate)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+
+return {allowed, retry}
+`)
+
+// RedisLimiter keeps buckets in Redis, so all instances share the limits.
+type RedisLimiter struct {
+	client *redis.Client
+}
+
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return &RedisLimiter{
+		client: client,
+	}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	if limit.IsUnlimited() {
+		return true, 0, nil
+	}
+
+	result, err := tokenBucketScript.Run(ctx, l.client, []string{keyPrefix + key},
+		limit.Rate, limit.Burst).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"sensors-generator/pkg/ratelimit"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemoryLimiter_Allow(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	limiter := ratelimit.NewMemoryLimiterWithClock(func() time.Time { return now })
+
+	ctx := context.Background()
+	limit := ratelimit.Limit{Rate: 1, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		allowed, _, err := limiter.Allow(ctx, "client", limit)
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	allowed, retryAfter, err := limiter.Allow(ctx, "client", limit)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, time.Second, retryAfter)
+
+	allowed, _, err = limiter.Allow(ctx, "another client", limit)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	now = now.Add(time.Second)
+
+	allowed, _, err = limiter.Allow(ctx, "client", limit)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func Test_Rules_Match(t *testing.T) {
+	rules := ratelimit.Rules{
+		Default: ratelimit.Limit{Rate: 10, Burst: 10},
+		Groups: []ratelimit.Rule{
+			{Name: "region", Prefix: "/api/v1/region", Limit: ratelimit.Limit{Rate: 1, Burst: 2}},
+		},
+	}
+
+	name, limit := rules.Match("/api/v1/region/temperature/max")
+	assert.Equal(t, "region", name)
+	assert.Equal(t, ratelimit.Limit{Rate: 1, Burst: 2}, limit)
+
+	name, limit = rules.Match("/api/v1/group/:groupName/spieces")
+	assert.Equal(t, "default", name)
+	assert.Equal(t, rules.Default, limit)
+}
@@ -0,0 +1,95 @@
+package trajectory
+
+import (
+	"math/rand"
+	"sensors-generator/pkg/environment"
+	"sensors-generator/pkg/trajectory"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+func Test_Trajectory_Validate(t *testing.T) {
+	assert.NoError(t, trajectory.Trajectory{Kind: trajectory.KindCurrent}.Validate())
+	assert.NoError(t, trajectory.Trajectory{Kind: trajectory.KindStatic}.Validate())
+	assert.Error(t, trajectory.Trajectory{Kind: "orbit"}.Validate())
+	assert.Error(t, trajectory.Trajectory{Kind: trajectory.KindWaypoints, Speed: 1}.Validate())
+	assert.Error(t, trajectory.Trajectory{Kind: trajectory.KindWaypoints,
+		Waypoints: []environment.Point{{X: 10}}}.Validate())
+	assert.Error(t, trajectory.Trajectory{Kind: trajectory.KindRandomWalk}.Validate())
+	assert.Error(t, trajectory.Trajectory{Kind: trajectory.KindCurrent, Radius: -1}.Validate())
+}
+
+func Test_Mover_Waypoints(t *testing.T) {
+	// The path is 10 meters to the waypoint, 10 meters down and 10*sqrt(2) meters back home.
+	mover := trajectory.NewMover(trajectory.Trajectory{
+		Kind:      trajectory.KindWaypoints,
+		Waypoints: []environment.Point{{X: 10, Z: 5}, {X: 10, Z: 15}},
+		Speed:     1,
+		StartsAt:  &start,
+	}, environment.Point{Z: 5}, environment.Currents{}, nil)
+
+	assert.Equal(t, environment.Point{Z: 5}, mover.Position(start.Add(-time.Hour)))
+	assert.Equal(t, environment.Point{X: 5, Z: 5}, mover.Position(start.Add(5*time.Second)))
+	assert.Equal(t, environment.Point{X: 10, Z: 10}, mover.Position(start.Add(15*time.Second)))
+
+	// Positions depend only on the time and repeat after the whole path.
+	length := 20 + 10*1.4142135623730951
+	lap := time.Duration(length * float64(time.Second))
+	back := mover.Position(start.Add(lap + 5*time.Second))
+	assert.InDelta(t, 5, back.X, 1e-6)
+	assert.InDelta(t, 5, back.Z, 1e-6)
+	assert.Equal(t, mover.Position(start.Add(15*time.Second)), environment.Point{X: 10, Z: 10})
+}
+
+func Test_Mover_RandomWalk(t *testing.T) {
+	home := environment.Point{X: 100, Y: 100, Z: 20}
+	walk := trajectory.Trajectory{Kind: trajectory.KindRandomWalk, Step: 50, Radius: 30}
+	mover := trajectory.NewMover(walk, home, environment.Currents{}, rand.New(rand.NewSource(7)))
+
+	assert.Equal(t, home, mover.Position(start))
+
+	var moved bool
+	for i := 1; i <= 100; i++ {
+		p := mover.Position(start.Add(time.Duration(i) * time.Minute))
+		assert.InDelta(t, home.X, p.X, 30)
+		assert.InDelta(t, home.Y, p.Y, 30)
+		assert.Equal(t, home.Z, p.Z)
+		moved = moved || p != home
+	}
+	assert.True(t, moved)
+
+	// Earlier times do not move the sensor back.
+	last := mover.Position(start.Add(100 * time.Minute))
+	assert.Equal(t, last, mover.Position(start))
+
+	// With the same seed the walk repeats.
+	again := trajectory.NewMover(walk, home, environment.Currents{}, rand.New(rand.NewSource(7)))
+	again.Position(start)
+	for i := 1; i <= 100; i++ {
+		again.Position(start.Add(time.Duration(i) * time.Minute))
+	}
+	assert.Equal(t, last, again.Position(start.Add(100*time.Minute)))
+}
+
+func Test_Mover_Current(t *testing.T) {
+	currents := environment.Currents{U: 0.1, V: -0.05, TidalPeriod: time.Hour, Depth: 50}
+	mover := trajectory.NewMover(trajectory.Trajectory{Kind: trajectory.KindCurrent, StartsAt: &start},
+		environment.Point{}, currents, nil)
+
+	p := mover.Position(start.Add(time.Hour))
+	assert.InDelta(t, 360, p.X, 1e-6)
+	assert.InDelta(t, -180, p.Y, 1e-6)
+
+	// A drifter at depth moves slower.
+	deep := trajectory.NewMover(trajectory.Trajectory{Kind: trajectory.KindCurrent, StartsAt: &start},
+		environment.Point{Z: 50}, currents, nil)
+	assert.InDelta(t, 360/2.718281828, deep.Position(start.Add(time.Hour)).X, 1e-3)
+
+	// A resumed drifter continues from the resumed position.
+	mover.Resume(environment.Point{X: 1000}, start.Add(2*time.Hour))
+	assert.InDelta(t, 1360, mover.Position(start.Add(3*time.Hour)).X, 1e-6)
+}
@@ -0,0 +1,225 @@
+package trajectory
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sensors-generator/pkg/environment"
+	"time"
+)
+
+// Kind of trajectory decides how a mobile sensor moves.
+type Kind string
+
+const (
+	// KindWaypoints goes from the home position through Waypoints and back home at Speed, over and over.
+	KindWaypoints Kind = "waypoints"
+	// KindRandomWalk wanders randomly, Step is the typical distance of an hour.
+	KindRandomWalk Kind = "random_walk"
+	// KindCurrent drifts with currents of the environment.
+	KindCurrent Kind = "current"
+	// KindStatic is not stored, a sensor updated with it stays at its home position again.
+	KindStatic Kind = "static"
+)
+
+var Kinds = []Kind{KindWaypoints, KindRandomWalk, KindCurrent, KindStatic}
+
+// maxStep is the longest step of integration of currents, longer gaps are split into steps.
+const maxStep = time.Minute
+
+// Trajectory of a mobile sensor. Every trajectory starts at the home position of the sensor,
+// random walks and drifters keep their depth.
+type Trajectory struct {
+	Kind Kind `json:"kind" example:"waypoints"`
+	// Waypoints of KindWaypoints, visited in order.
+	Waypoints []environment.Point `json:"waypoints,omitempty"`
+	// Speed along waypoints, m/s.
+	Speed float64 `json:"speed,omitempty" example:"0.3"`
+	// Step of KindRandomWalk is the standard deviation of the distance moved in an hour along X and Y, meters.
+	Step float64 `json:"step,omitempty" example:"20"`
+	// Radius keeps random walks and drifters within a box of Radius meters around the home position,
+	// 0 does not limit them.
+	Radius float64 `json:"radius,omitempty" example:"100"`
+	// StartsAt is when the sensor leaves home. Without it waypoints are followed since the epoch,
+	// so the position depends only on the time, and other kinds start from home at the first reading.
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+}
+
+// Validate returns the first problem of the trajectory.
+func (t Trajectory) Validate() error {
+	switch t.Kind {
+	case KindWaypoints:
+		if len(t.Waypoints) == 0 {
+			return errors.New("waypoints are required")
+		}
+		if !(t.Speed > 0) || math.IsInf(t.Speed, 0) {
+			return errors.New("speed should be positive")
+		}
+	case KindRandomWalk:
+		if !(t.Step > 0) || math.IsInf(t.Step, 0) {
+			return errors.New("step should be positive")
+		}
+	case KindCurrent, KindStatic:
+	default:
+		return fmt.Errorf("unknown trajectory kind %q, known are %v", t.Kind, Kinds)
+	}
+
+	if t.Radius < 0 || math.IsNaN(t.Radius) {
+		return errors.New("radius should not be negative")
+	}
+
+	return nil
+}
+
+// Stateful reports whether positions follow from the previous position, so they cannot be computed
+// from the time alone. Movers of such trajectories can be resumed from the last known position.
+func (t Trajectory) Stateful() bool {
+	return t.Kind == KindRandomWalk || t.Kind == KindCurrent
+}
+
+// Mover returns positions of one sensor along its trajectory. Random walks and drifters move step by step
+// from the previous position, so positions should be asked in time order, an earlier time returns
+// the last position. Mover is not safe for concurrent use.
+type Mover struct {
+	trajectory Trajectory
+	home       environment.Point
+	currents   environment.Currents
+	rand       *rand.Rand
+
+	position environment.Point
+	at       time.Time
+}
+
+func NewMover(t Trajectory, home environment.Point, currents environment.Currents, rnd *rand.Rand) *Mover {
+	m := &Mover{
+		trajectory: t,
+		home:       home,
+		currents:   currents,
+		rand:       rnd,
+		position:   home,
+	}
+	if t.StartsAt != nil {
+		m.at = *t.StartsAt
+	}
+	return m
+}
+
+// Resume continues a stateful trajectory from the position at the time, e.g. of the last written reading.
+// Positions of waypoints depend only on the time, so they are not resumed.
+func (m *Mover) Resume(p environment.Point, at time.Time) {
+	if !m.trajectory.Stateful() || at.Before(m.at) {
+		return
+	}
+	m.position, m.at = p, at
+}
+
+// Position of the sensor at the time.
+func (m *Mover) Position(at time.Time) environment.Point {
+	if m.trajectory.Kind == KindWaypoints {
+		return m.waypoint(at)
+	}
+
+	if !m.trajectory.Stateful() {
+		return m.home
+	}
+
+	if m.at.IsZero() {
+		m.at = at
+	}
+	if !at.After(m.at) {
+		return m.position
+	}
+
+	switch m.trajectory.Kind {
+	case KindRandomWalk:
+		sigma := m.trajectory.Step * math.Sqrt(at.Sub(m.at).Hours())
+		m.position.X += m.rand.NormFloat64() * sigma
+		m.position.Y += m.rand.NormFloat64() * sigma
+	case KindCurrent:
+		for t := m.at; t.Before(at); {
+			step := at.Sub(t)
+			if step > maxStep {
+				step = maxStep
+			}
+
+			velocity := m.currents.Velocity(m.position, t)
+			m.position.X += velocity.X * step.Seconds()
+			m.position.Y += velocity.Y * step.Seconds()
+			t = t.Add(step)
+		}
+	}
+
+	m.position = m.bounded(m.position)
+	m.at = at
+	return m.position
+}
+
+// waypoint goes along the closed path home, waypoints..., home, the distance is Speed times the time
+// since StartsAt.
+func (m *Mover) waypoint(at time.Time) environment.Point {
+	start := time.Unix(0, 0)
+	if m.trajectory.StartsAt != nil {
+		start = *m.trajectory.StartsAt
+	}
+	if !at.After(start) {
+		return m.home
+	}
+
+	path := make([]environment.Point, 0, len(m.trajectory.Waypoints)+2)
+	path = append(path, m.home)
+	path = append(path, m.trajectory.Waypoints...)
+	path = append(path, m.home)
+
+	var length float64
+	for i := 1; i < len(path); i++ {
+		length += distance(path[i-1], path[i])
+	}
+	if length == 0 {
+		return m.home
+	}
+
+	travelled := math.Mod(m.trajectory.Speed*at.Sub(start).Seconds(), length)
+	for i := 1; i < len(path); i++ {
+		segment := distance(path[i-1], path[i])
+		if travelled <= segment && segment > 0 {
+			share := travelled / segment
+			return environment.Point{
+				X: path[i-1].X + (path[i].X-path[i-1].X)*share,
+				Y: path[i-1].Y + (path[i].Y-path[i-1].Y)*share,
+				Z: path[i-1].Z + (path[i].Z-path[i-1].Z)*share,
+			}
+		}
+		travelled -= segment
+	}
+
+	return m.home
+}
+
+// bounded reflects the position from edges of the box of Radius around home.
+func (m *Mover) bounded(p environment.Point) environment.Point {
+	radius := m.trajectory.Radius
+	if radius <= 0 {
+		return p
+	}
+
+	p.X = reflect(p.X, m.home.X-radius, m.home.X+radius)
+	p.Y = reflect(p.Y, m.home.Y-radius, m.home.Y+radius)
+	return p
+}
+
+func reflect(value, min, max float64) float64 {
+	width := max - min
+	offset := math.Mod(value-min, 2*width)
+	if offset < 0 {
+		offset += 2 * width
+	}
+	if offset > width {
+		offset = 2*width - offset
+	}
+	return min + offset
+}
+
+func distance(a, b environment.Point) float64 {
+	return math.Sqrt((a.X-b.X)*(a.X-b.X) + (a.Y-b.Y)*(a.Y-b.Y) + (a.Z-b.Z)*(a.Z-b.Z))
+}